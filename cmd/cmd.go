@@ -1404,6 +1404,65 @@ func checkServerHeartbeat(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// ProfileHandler captures a pprof profile from a running server and writes
+// it to a local file for analysis with `go tool pprof`.
+func ProfileHandler(cmd *cobra.Command, args []string) error {
+	token := envconfig.PprofToken()
+	if token == "" {
+		return errors.New("set OLLAMA_PPROF_TOKEN to the server's profiling token")
+	}
+
+	kind := args[0]
+	seconds, _ := cmd.Flags().GetInt("seconds")
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = kind + ".pprof"
+	}
+
+	path := "/debug/pprof/" + kind
+	switch kind {
+	case "cpu":
+		path = fmt.Sprintf("/debug/pprof/profile?seconds=%d", seconds)
+	case "heap", "allocs", "goroutine", "block", "mutex":
+	default:
+		return fmt.Errorf("unknown profile %q (expected cpu, heap, allocs, goroutine, block, or mutex)", kind)
+	}
+
+	host := envconfig.Host()
+	req, err := http.NewRequestWithContext(cmd.Context(), http.MethodGet, host.Scheme+"://"+host.Host+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	if kind == "cpu" {
+		fmt.Fprintf(os.Stderr, "capturing CPU profile for %ds...\n", seconds)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("profile request failed: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	out, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	if _, err := io.Copy(out, resp.Body); err != nil {
+		return err
+	}
+
+	fmt.Printf("wrote %s profile to %s\n", kind, output)
+	return nil
+}
+
 func versionHandler(cmd *cobra.Command, _ []string) {
 	client, err := api.ClientFromEnvironment()
 	if err != nil {
@@ -1629,6 +1688,16 @@ func NewCLI() *cobra.Command {
 
 	orchestrateCmd.AddCommand(createAgentCmd, listAgentsCmd, deleteAgentCmd, runTasksCmd, runWorkflowCmd)
 
+	profileCmd := &cobra.Command{
+		Use:     "profile KIND",
+		Short:   "Capture a pprof profile (cpu, heap, allocs, goroutine, block, mutex) from the server",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: checkServerHeartbeat,
+		RunE:    ProfileHandler,
+	}
+	profileCmd.Flags().Int("seconds", 30, "Duration of the CPU profile")
+	profileCmd.Flags().String("output", "", "Output file (default KIND.pprof)")
+
 	runnerCmd := &cobra.Command{
 		Use:    "runner",
 		Hidden: true,
@@ -1697,6 +1766,7 @@ func NewCLI() *cobra.Command {
 		copyCmd,
 		deleteCmd,
 		orchestrateCmd,
+		profileCmd,
 		runnerCmd,
 	)
 
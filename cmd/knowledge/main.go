@@ -0,0 +1,65 @@
+// Command knowledge ingests documents into the orchestration knowledge base
+// and runs retrieval queries against it, for grounding agents on user
+// documents without going through the REST API.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/EchoCog/echollama/orchestration"
+)
+
+func main() {
+	ingest := flag.String("ingest", "", "path of a document to ingest (pdf, html, markdown, or plain text)")
+	search := flag.String("search", "", "query to run against the knowledge base")
+	model := flag.String("model", "nomic-embed-text", "embedding model to use")
+	topK := flag.Int("top-k", 5, "number of results to return for a search")
+	flag.Parse()
+
+	if *ingest == "" && *search == "" {
+		fmt.Fprintln(os.Stderr, "usage: knowledge -ingest <file> | -search <query> [-model <name>] [-top-k <n>]")
+		os.Exit(2)
+	}
+
+	client, err := api.ClientFromEnvironment()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create client: %v\n", err)
+		os.Exit(1)
+	}
+
+	engine := orchestration.NewEngine(*client)
+	knowledge := engine.GetKnowledgeBase()
+	ctx := context.Background()
+
+	if *ingest != "" {
+		data, err := os.ReadFile(*ingest)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to read %s: %v\n", *ingest, err)
+			os.Exit(1)
+		}
+
+		document, err := knowledge.IngestDocument(ctx, filepath.Base(*ingest), "", data, *model)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "ingestion failed: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Ingested %s as %s (%d chunks)\n", document.Name, document.ID, document.ChunkCount)
+	}
+
+	if *search != "" {
+		results, err := knowledge.Search(ctx, *search, *model, *topK)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "search failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		for i, result := range results {
+			fmt.Printf("%d. [%.3f] %s\n", i+1, result.Score, result.Chunk.Content)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Command usagereport fetches aggregated usage reports from a running
+// orchestration API server, for piping into billing and chargeback tooling.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+func main() {
+	server := flag.String("server", "http://localhost:8080", "orchestration API server base URL")
+	date := flag.String("date", "", "report date (YYYY-MM-DD); empty reports all days")
+	format := flag.String("format", "csv", "output format: csv or json")
+	flag.Parse()
+
+	query := url.Values{}
+	if *date != "" {
+		query.Set("date", *date)
+	}
+	if *format == "csv" {
+		query.Set("format", "csv")
+	}
+
+	reportURL := fmt.Sprintf("%s/api/usage/report?%s", *server, query.Encode())
+	resp, err := http.Get(reportURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to fetch usage report: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		fmt.Fprintf(os.Stderr, "server returned status %d: %s\n", resp.StatusCode, body)
+		os.Exit(1)
+	}
+
+	if _, err := io.Copy(os.Stdout, resp.Body); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %v\n", err)
+		os.Exit(1)
+	}
+}
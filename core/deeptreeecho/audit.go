@@ -0,0 +1,20 @@
+//go:build audit
+
+package deeptreeecho
+
+import (
+	"log/slog"
+	"runtime/debug"
+)
+
+// Audit mode (-tags audit) compiles runtime invariant checks into the
+// identity's goroutine machinery. Violations are logged with a stack trace
+// rather than panicking; release builds compile the checks away (see
+// audit_off.go).
+const auditEnabled = true
+
+// auditViolation logs a broken invariant with the offending stack.
+func auditViolation(invariant string, attrs ...any) {
+	attrs = append([]any{"invariant", invariant, "stack", string(debug.Stack())}, attrs...)
+	slog.Error("Concurrency invariant violated", attrs...)
+}
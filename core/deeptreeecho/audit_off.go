@@ -0,0 +1,9 @@
+//go:build !audit
+
+package deeptreeecho
+
+// Release builds compile the audit assertions away; build with -tags audit
+// to enable them (see audit.go).
+const auditEnabled = false
+
+func auditViolation(invariant string, attrs ...any) {}
@@ -0,0 +1,88 @@
+package deeptreeecho
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// scoredMemory pairs a memory snapshot with its similarity to a query.
+type scoredMemory struct {
+	node  MemoryNode
+	score float64
+}
+
+// RelevantMemories returns the memory nodes most similar to a query,
+// strongest match first, capped at limit. Similarity is cosine distance
+// between text embeddings, weighted by the node's current strength so
+// decayed memories fade out of retrieval before they are collected.
+func (i *Identity) RelevantMemories(query string, limit int) []MemoryNode {
+	queryEmbedding := i.EncodeText(query)
+
+	i.mu.RLock()
+	snapshot := make([]MemoryNode, 0, len(i.Memory.Nodes))
+	for _, node := range i.Memory.Nodes {
+		snapshot = append(snapshot, *node)
+	}
+	threshold := i.Embeddings.Threshold
+	i.mu.RUnlock()
+
+	scored := make([]scoredMemory, 0, len(snapshot))
+	for _, node := range snapshot {
+		embedding := i.EncodeText(fmt.Sprint(node.Content))
+		score := i.CosineSimilarity(queryEmbedding, embedding) * node.Strength
+		if score < threshold {
+			continue
+		}
+		scored = append(scored, scoredMemory{node: node, score: score})
+	}
+
+	sort.Slice(scored, func(a, b int) bool { return scored[a].score > scored[b].score })
+	if limit > 0 && len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	memories := make([]MemoryNode, len(scored))
+	for index, entry := range scored {
+		memories[index] = entry.node
+	}
+	return memories
+}
+
+// EnableMemoryInjection toggles conversation memory injection: when enabled,
+// ChatWithAI retrieves the memories most relevant to the latest message and
+// prepends them as system context. The memories used for the last chat are
+// listed in LastInjectedMemories for transparency.
+func (ec *EmbodiedCognition) EnableMemoryInjection(enabled bool) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	ec.memoryInjection = enabled
+	ec.LastInjectedMemories = nil
+}
+
+// injectMemoryContext prepends relevant memories as a system message and
+// records which were used. The caller must hold ec.mu.
+func (ec *EmbodiedCognition) injectMemoryContext(messages []ChatMessage) []ChatMessage {
+	ec.LastInjectedMemories = nil
+	if !ec.memoryInjection || len(messages) == 0 {
+		return messages
+	}
+
+	query := messages[len(messages)-1].Content
+	memories := ec.Identity.RelevantMemories(query, 3)
+	if len(memories) == 0 {
+		return messages
+	}
+
+	var context strings.Builder
+	context.WriteString("Relevant memories:\n")
+	for _, memory := range memories {
+		context.WriteString(fmt.Sprintf("- %v\n", memory.Content))
+		ec.LastInjectedMemories = append(ec.LastInjectedMemories, memory.ID)
+	}
+
+	injected := make([]ChatMessage, 0, len(messages)+1)
+	injected = append(injected, ChatMessage{Role: "system", Content: context.String()})
+	injected = append(injected, messages...)
+	return injected
+}
@@ -0,0 +1,55 @@
+package deeptreeecho
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRelevantMemoriesRankedBySimilarity(t *testing.T) {
+	id := NewIdentity("retriever")
+	id.Remember("greeting", "hello there friend")
+	id.Remember("weather", "the forecast promises heavy rain tomorrow")
+
+	memories := id.RelevantMemories("hello there friend", 1)
+	if len(memories) != 1 {
+		t.Fatalf("Expected 1 memory, got %d", len(memories))
+	}
+	if memories[0].ID != "greeting" {
+		t.Errorf("Expected the greeting memory to rank first, got %s", memories[0].ID)
+	}
+}
+
+func TestInjectMemoryContext(t *testing.T) {
+	t.Chdir(t.TempDir()) // NewEmbodiedCognition persists memory files in the working directory
+	ec := NewEmbodiedCognition("injector")
+	ec.Identity.Remember("greeting", "hello there friend")
+
+	messages := []ChatMessage{{Role: "user", Content: "hello there friend"}}
+
+	// Disabled by default: no system context, no transparency record
+	if injected := ec.injectMemoryContext(messages); len(injected) != 1 {
+		t.Errorf("Expected no injection while disabled, got %d messages", len(injected))
+	}
+	if len(ec.LastInjectedMemories) != 0 {
+		t.Errorf("Expected empty transparency record while disabled, got %v", ec.LastInjectedMemories)
+	}
+
+	ec.EnableMemoryInjection(true)
+	injected := ec.injectMemoryContext(messages)
+	if len(injected) != 2 || injected[0].Role != "system" {
+		t.Fatalf("Expected a prepended system message, got %+v", injected)
+	}
+	if !strings.Contains(injected[0].Content, "hello there friend") {
+		t.Errorf("Expected the memory content in the system context, got %q", injected[0].Content)
+	}
+
+	found := false
+	for _, id := range ec.LastInjectedMemories {
+		if id == "greeting" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected the greeting memory listed in LastInjectedMemories, got %v", ec.LastInjectedMemories)
+	}
+}
@@ -41,6 +41,13 @@ type EmbodiedCognition struct {
 	WorkingMemory   *WorkingMemory        // Added for dynamic working memory
 	Patterns        map[string]*CognitivePattern
 	AdaptationLevel float64
+
+	// Optional identity-aware post-styling of model responses
+	responseStyle *ResponseStyle
+
+	// Conversation memory injection toggle and its transparency record
+	memoryInjection      bool
+	LastInjectedMemories []string
 //=======
         // --- Identity Kernel and Memory ---
 //        ActiveProviders map[string]AIProvider // Added for AI integration
@@ -88,6 +95,7 @@ type PipelineStage struct {
 // PipelineEvent represents an event in the pipeline
 type PipelineEvent struct {
         Stage     string
+        TraceID   string
         Input     interface{}
         Output    interface{}
         Timestamp time.Time
@@ -136,6 +144,9 @@ func NewEmbodiedCognition(name string) *EmbodiedCognition {
 	// Initialize cognitive patterns
 	ec.initializeCognitivePatterns()
 
+	// Initialize the processing pipeline stages
+	ec.initializePipeline()
+
 	// Start background processes
 	go ec.continuousLearning()
 	go ec.memoryConsolidation()
@@ -276,6 +287,7 @@ func (ec *EmbodiedCognition) Process(ctx context.Context, input interface{}) (in
                 // Record event
                 event := PipelineEvent{
                         Stage: stage.Name,
+                        TraceID: ctxID,
                         Input: current,
                         Output: output,
                         Timestamp: startTime,
@@ -550,9 +562,30 @@ func (ec *EmbodiedCognition) GenerateWithAI(ctx context.Context, prompt string)
         // Update emotional state based on generation
         ec.Feel("creative", 0.8)
 
+        // Let the embodied layer shape the final output
+        response = ec.applyResponseStyle(response)
+
         return response, nil
 }
 
+// GenerateStreamWithAI streams generated text token by token, so Ollama
+// clients that expect incremental responses are not forced to wait for one
+// blob. The prompt is processed through the embodied system up front; the
+// model manager folds the complete response into the identity when the
+// stream finishes.
+func (ec *EmbodiedCognition) GenerateStreamWithAI(ctx context.Context, prompt string) (<-chan string, error) {
+	// Process prompt through embodied cognition first
+	ec.Process(ctx, prompt)
+
+	ec.mu.RLock()
+	options := GenerateOptions{
+		Temperature: ec.GlobalState.Energy, // Use energy as temperature
+	}
+	ec.mu.RUnlock()
+
+	return ec.Models.GenerateStream(ctx, prompt, options)
+}
+
 // ChatWithAI handles chat interactions with AI models
 func (ec *EmbodiedCognition) ChatWithAI(ctx context.Context, messages []ChatMessage) (string, error) {
         ec.mu.Lock()
@@ -563,6 +596,9 @@ func (ec *EmbodiedCognition) ChatWithAI(ctx context.Context, messages []ChatMess
                 ec.Process(ctx, msg.Content)
         }
 
+        // Prepend relevant identity memories as system context
+        messages = ec.injectMemoryContext(messages)
+
         // Chat using model manager
         options := ChatOptions{
                 GenerateOptions: GenerateOptions{
@@ -578,6 +614,9 @@ func (ec *EmbodiedCognition) ChatWithAI(ctx context.Context, messages []ChatMess
         // Process response
         ec.Identity.Process(response)
 
+        // Let the embodied layer shape the final output
+        response = ec.applyResponseStyle(response)
+
         return response, nil
 }
 
@@ -0,0 +1,106 @@
+package deeptreeecho
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// eventIngestInterval is the cadence at which queued cognitive events are
+// batched into the identity.
+const eventIngestInterval = 50 * time.Millisecond
+
+// EventQueue is a lock-free multi-producer single-consumer queue for
+// cognitive events. Producers — such as HTTP middleware emitting an event
+// per request — push with a compare-and-swap and never touch the identity
+// mutex, so request latency is decoupled from cognition processing. The
+// identity drains the queue in batches, taking its lock once per batch.
+type EventQueue struct {
+	head    atomic.Pointer[eventNode]
+	pending atomic.Int64
+}
+
+// eventNode is a single entry in the queue's intrusive list.
+type eventNode struct {
+	event CognitiveEvent
+	next  *eventNode
+}
+
+// Push enqueues an event. It is safe for concurrent use and never blocks.
+func (q *EventQueue) Push(event CognitiveEvent) {
+	node := &eventNode{event: event}
+	for {
+		node.next = q.head.Load()
+		if q.head.CompareAndSwap(node.next, node) {
+			q.pending.Add(1)
+			return
+		}
+	}
+}
+
+// Drain removes every queued event, oldest first. Only a single consumer
+// may call Drain at a time.
+func (q *EventQueue) Drain() []CognitiveEvent {
+	node := q.head.Swap(nil)
+	if node == nil {
+		return nil
+	}
+
+	count := 0
+	for n := node; n != nil; n = n.next {
+		count++
+	}
+	q.pending.Add(int64(-count))
+
+	// Pushes build a newest-first list; fill backwards to restore order
+	events := make([]CognitiveEvent, count)
+	for index := count - 1; node != nil; node = node.next {
+		events[index] = node.event
+		index--
+	}
+	return events
+}
+
+// Len reports how many events are waiting to be ingested.
+func (q *EventQueue) Len() int {
+	return int(q.pending.Load())
+}
+
+// EnqueueEvent ingests a cognitive event through the lock-free queue. Unlike
+// sending on Stream this never blocks, making it safe on request paths.
+func (i *Identity) EnqueueEvent(event CognitiveEvent) {
+	if i.shutdown.Load() {
+		auditViolation("stream written after shutdown", "identity", i.Name, "event_type", event.Type)
+		return
+	}
+	i.events.Push(event)
+}
+
+// IngestPendingEvents applies every queued event under a single lock
+// acquisition, returning how many were processed. The background ingest loop
+// calls this on a fixed cadence; tests may call it directly.
+func (i *Identity) IngestPendingEvents() int {
+	events := i.events.Drain()
+	if len(events) == 0 {
+		return 0
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, event := range events {
+		i.applyCognitiveEvent(event)
+	}
+	return len(events)
+}
+
+// ingestEvents batches queued events into the identity until the identity is
+// shut down.
+func (i *Identity) ingestEvents() {
+	ticker := time.NewTicker(eventIngestInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if i.shutdown.Load() {
+			return
+		}
+		i.IngestPendingEvents()
+	}
+}
@@ -0,0 +1,82 @@
+package deeptreeecho
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestEventQueueOrdering(t *testing.T) {
+	var queue EventQueue
+
+	for n := 0; n < 5; n++ {
+		queue.Push(CognitiveEvent{Type: "test", Content: n})
+	}
+	if queue.Len() != 5 {
+		t.Errorf("Expected 5 pending events, got %d", queue.Len())
+	}
+
+	events := queue.Drain()
+	if len(events) != 5 {
+		t.Fatalf("Expected 5 drained events, got %d", len(events))
+	}
+	for n, event := range events {
+		if event.Content != n {
+			t.Errorf("Expected oldest-first order, got %v at position %d", event.Content, n)
+		}
+	}
+
+	if queue.Len() != 0 || queue.Drain() != nil {
+		t.Error("Expected the queue to be empty after draining")
+	}
+}
+
+func TestEventQueueConcurrentProducers(t *testing.T) {
+	var queue EventQueue
+
+	const producers = 8
+	const perProducer = 100
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for n := 0; n < perProducer; n++ {
+				queue.Push(CognitiveEvent{Type: "load", Source: fmt.Sprintf("producer-%d", p)})
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	if got := len(queue.Drain()); got != producers*perProducer {
+		t.Errorf("Expected %d events, got %d", producers*perProducer, got)
+	}
+}
+
+func TestIdentityIngestsQueuedEvents(t *testing.T) {
+	identity := NewIdentity("Queued Echo")
+
+	for n := 0; n < 10; n++ {
+		identity.EnqueueEvent(CognitiveEvent{Type: "http_request", Content: "/api/health", Impact: 0.5})
+	}
+
+	// The ingest loop also drains on a cadence; calling directly keeps the
+	// test deterministic even if it raced us to part of the batch.
+	identity.IngestPendingEvents()
+	if identity.events.Len() != 0 {
+		t.Errorf("Expected the queue to be drained, %d left", identity.events.Len())
+	}
+
+	identity.mu.RLock()
+	defer identity.mu.RUnlock()
+	found := false
+	for _, pattern := range identity.Patterns {
+		if pattern.Type == "http_request" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected ingested events to create an http_request pattern")
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,38 +16,52 @@ type Identity struct {
 	mu sync.RWMutex
 
 	// Core Identity Components
-	ID              string
-	Name            string
-	Essence         string
-	CreatedAt       time.Time
+	ID        string
+	Name      string
+	Essence   string
+	CreatedAt time.Time
 
 	// Spatial Awareness - 3D embodied cognition
-	SpatialContext  *SpatialContext
+	SpatialContext *SpatialContext
 
 	// Emotional Dynamics
-	EmotionalState  *EmotionalState
+	EmotionalState *EmotionalState
 
 	// Reservoir Networks (RWKV-like)
-	Reservoir       *ReservoirNetwork
+	Reservoir *ReservoirNetwork
 
 	// Memory and Resonance
-	Memory          *MemoryResonance
+	Memory *MemoryResonance
 
 	// Identity Embeddings System
-	Embeddings      *IdentityEmbeddings
+	Embeddings *IdentityEmbeddings
 
 	// Identity Coherence
-	Coherence       float64
+	Coherence float64
 
 	// Recursive Self-Improvement
-	RecursiveDepth  int
-	Iterations      uint64
+	RecursiveDepth int
+	Iterations     uint64
 
 	// Embodied Patterns
-	Patterns        map[string]*Pattern
+	Patterns map[string]*Pattern
 
 	// Consciousness Stream
-	Stream          chan CognitiveEvent
+	Stream chan CognitiveEvent
+
+	// Optional write-through persistence for the memory graph
+	memoryStore MemoryStore
+
+	// Lock-free ingestion queue feeding the consciousness stream in batches
+	events EventQueue
+
+	// Optional decay and expiry bounds on the memory graph
+	retention *MemoryRetentionPolicy
+	gcRunning bool
+	lastDecay time.Time
+
+	// Set once by Shutdown; background loops exit and stream writes stop
+	shutdown atomic.Bool
 }
 
 // SpatialContext represents 3D spatial awareness for embodied cognition
@@ -77,10 +93,10 @@ type Boundary struct {
 
 // SpatialField represents the cognitive field
 type SpatialField struct {
-	Intensity   float64
-	Gradient    Vector3D
-	Curvature   float64
-	Resonance   float64
+	Intensity float64
+	Gradient  Vector3D
+	Curvature float64
+	Resonance float64
 }
 
 // EmotionalState represents the emotional dynamics
@@ -115,6 +131,7 @@ type ReservoirNetwork struct {
 	Connections [][]float64
 	State       []float64
 	History     [][]float64
+	Samples     []ReservoirSample // Compressed trace of state history
 	Sparsity    float64
 	Decay       float64
 }
@@ -130,10 +147,13 @@ type ReservoirNode struct {
 
 // MemoryResonance represents hypergraph memory structures
 type MemoryResonance struct {
-	Nodes      map[string]*MemoryNode
-	Edges      map[string]*MemoryEdge
-	Patterns   []ResonancePattern
-	Coherence  float64
+	Nodes     map[string]*MemoryNode
+	Edges     map[string]*MemoryEdge
+	Patterns  []ResonancePattern
+	Coherence float64
+
+	// lastNodeID tracks the most recently stored node for temporal linking
+	lastNodeID string
 }
 
 // MemoryNode represents a memory node
@@ -184,30 +204,50 @@ type CognitiveEvent struct {
 // IdentityEmbeddings represents the embedding system for identity vectors
 type IdentityEmbeddings struct {
 	// Core identity vector
-	IdentityVector   []float64
+	IdentityVector []float64
 
 	// Repository structure embeddings
-	RepoEmbeddings   map[string][]float64
+	RepoEmbeddings map[string][]float64
 
 	// Code semantic embeddings
-	CodeEmbeddings   map[string][]float64
+	CodeEmbeddings map[string][]float64
 
 	// Cognitive state embeddings
-	StateEmbeddings  []float64
+	StateEmbeddings []float64
 
 	// Embedding dimensions
-	Dimensions       int
+	Dimensions int
 
 	// Similarity threshold
-	Threshold        float64
+	Threshold float64
 
 	// Update frequency
-	UpdateFreq       time.Duration
-	LastUpdate       time.Time
+	UpdateFreq time.Duration
+	LastUpdate time.Time
 }
 
-// NewIdentity creates a new Deep Tree Echo Identity
+// Default resource limits for a new Identity.
+const (
+	DefaultReservoirSize = 256
+	DefaultStreamDepth   = 1000
+)
+
+// NewIdentity creates a new Deep Tree Echo Identity with default limits
 func NewIdentity(name string) *Identity {
+	return NewIdentityWithLimits(name, DefaultReservoirSize, DefaultStreamDepth)
+}
+
+// NewIdentityWithLimits creates a new Deep Tree Echo Identity with explicit
+// resource limits on the reservoir network size and the consciousness stream
+// depth. Non-positive values fall back to the defaults.
+func NewIdentityWithLimits(name string, reservoirSize, streamDepth int) *Identity {
+	if reservoirSize <= 0 {
+		reservoirSize = DefaultReservoirSize
+	}
+	if streamDepth <= 0 {
+		streamDepth = DefaultStreamDepth
+	}
+
 	id := &Identity{
 		ID:             generateID(),
 		Name:           name,
@@ -217,7 +257,7 @@ func NewIdentity(name string) *Identity {
 		RecursiveDepth: 0,
 		Iterations:     0,
 		Patterns:       make(map[string]*Pattern),
-		Stream:         make(chan CognitiveEvent, 1000),
+		Stream:         make(chan CognitiveEvent, streamDepth),
 	}
 
 	// Initialize spatial awareness
@@ -250,7 +290,7 @@ func NewIdentity(name string) *Identity {
 	}
 
 	// Initialize reservoir network
-	id.initializeReservoir(256)
+	id.initializeReservoir(reservoirSize)
 
 	// Initialize memory resonance
 	id.Memory = &MemoryResonance{
@@ -277,6 +317,7 @@ func NewIdentity(name string) *Identity {
 
 	// Start consciousness stream processing
 	go id.processStream()
+	go id.ingestEvents()
 
 	// Start embedding update process
 	go id.updateEmbeddings()
@@ -332,10 +373,14 @@ func (i *Identity) Process(input interface{}) (interface{}, error) {
 		Source:    "external",
 	}
 
-	select {
-	case i.Stream <- event:
-	default:
-		// Stream full, process synchronously
+	if i.shutdown.Load() {
+		auditViolation("stream written after shutdown", "identity", i.Name, "event_type", event.Type)
+	} else {
+		select {
+		case i.Stream <- event:
+		default:
+			// Stream full, process synchronously
+		}
 	}
 
 	// Process through reservoir
@@ -399,6 +444,9 @@ func (i *Identity) processReservoir(input interface{}) interface{} {
 		i.Reservoir.History = i.Reservoir.History[1:]
 	}
 
+	// Record a compressed sample for the long-lived, persistable trace
+	i.recordReservoirSample(newState)
+
 	// Decode output
 	return i.decodeOutput(newState)
 }
@@ -452,32 +500,77 @@ func (i *Identity) updateEmotionalState(input interface{}) {
 	i.EmotionalState.Arousal = 0.5 + 0.3*math.Cos(float64(i.Iterations)*0.03)
 }
 
-// storeMemory stores processing in memory
+// storeMemory stores processing in memory with typed edges: a temporal edge
+// to the previously stored node (causal when this input was that node's
+// output) and associative edges weighted by resonance similarity
 func (i *Identity) storeMemory(input, output interface{}) {
 	nodeID := generateID()
-	i.Memory.Nodes[nodeID] = &MemoryNode{
+	node := &MemoryNode{
 		ID:        nodeID,
 		Content:   map[string]interface{}{"input": input, "output": output},
 		Strength:  1.0,
 		Timestamp: time.Now(),
 		Resonance: i.SpatialContext.Field.Resonance,
 	}
+	i.Memory.Nodes[nodeID] = node
+	i.persistMemoryNode(node)
+
+	// Link to the temporal predecessor; when this input is the predecessor's
+	// output the relationship is causal, not merely sequential
+	if previous, exists := i.Memory.Nodes[i.Memory.lastNodeID]; exists {
+		edgeType := EdgeTypeTemporal
+		weight := temporalWeight(node.Timestamp.Sub(previous.Timestamp))
+		if content, ok := previous.Content.(map[string]interface{}); ok {
+			if fmt.Sprint(content["output"]) == fmt.Sprint(input) {
+				edgeType = EdgeTypeCausal
+				weight = 0.9
+			}
+		}
+		i.linkMemories(nodeID, previous.ID, edgeType, weight)
+	}
 
-	// Create edges to recent memories
+	// Create associative edges to other memories, weighted by how closely
+	// their resonance matches
 	count := 0
-	for id := range i.Memory.Nodes {
-		if id != nodeID && count < 3 {
-			edgeID := fmt.Sprintf("%s-%s", nodeID, id)
-			i.Memory.Edges[edgeID] = &MemoryEdge{
-				From:      nodeID,
-				To:        id,
-				Weight:    rand.Float64(),
-				Type:      "associative",
-				Resonance: i.SpatialContext.Field.Resonance,
-			}
-			count++
+	for id, other := range i.Memory.Nodes {
+		if id == nodeID || id == i.Memory.lastNodeID {
+			continue
+		}
+		if count >= 2 {
+			break
 		}
+		weight := 1.0 - math.Min(1.0, math.Abs(node.Resonance-other.Resonance))
+		i.linkMemories(nodeID, id, EdgeTypeAssociative, weight)
+		count++
+	}
+
+	i.Memory.lastNodeID = nodeID
+}
+
+// linkMemories creates a typed, weighted edge between two memory nodes
+func (i *Identity) linkMemories(from, to, edgeType string, weight float64) {
+	edgeID := fmt.Sprintf("%s-%s", from, to)
+	edge := &MemoryEdge{
+		From:      from,
+		To:        to,
+		Weight:    weight,
+		Type:      edgeType,
+		Resonance: i.SpatialContext.Field.Resonance,
+	}
+	i.Memory.Edges[edgeID] = edge
+	if i.memoryStore != nil {
+		i.memoryStore.PutEdge(edgeID, edge)
+	}
+}
+
+// temporalWeight maps the gap between two memories onto an edge weight:
+// memories stored close together are strongly linked
+func temporalWeight(gap time.Duration) float64 {
+	weight := math.Exp(-gap.Seconds() / 60.0)
+	if weight < 0.1 {
+		return 0.1
 	}
+	return weight
 }
 
 // updateCoherence updates identity coherence
@@ -514,10 +607,20 @@ func (i *Identity) recursiveImprove() {
 	for id, edge := range i.Memory.Edges {
 		if edge.Weight < 0.1 {
 			delete(i.Memory.Edges, id)
+			if i.memoryStore != nil {
+				i.memoryStore.DeleteEdge(id)
+			}
 		}
 	}
 }
 
+// Shutdown marks the identity as abandoned: background loops exit at their
+// next tick and further stream writes are dropped (a violation under
+// -tags audit). Shutdown is idempotent.
+func (i *Identity) Shutdown() {
+	i.shutdown.Store(true)
+}
+
 // processStream processes the consciousness stream
 func (i *Identity) processStream() {
 	for event := range i.Stream {
@@ -528,6 +631,14 @@ func (i *Identity) processStream() {
 
 // handleCognitiveEvent handles a single cognitive event
 func (i *Identity) handleCognitiveEvent(event CognitiveEvent) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.applyCognitiveEvent(event)
+}
+
+// applyCognitiveEvent folds an event into the identity's patterns. The
+// caller must hold i.mu.
+func (i *Identity) applyCognitiveEvent(event CognitiveEvent) {
 	// Update patterns based on event
 	patternID := fmt.Sprintf("pattern_%s_%d", event.Type, time.Now().Unix())
 	if pattern, exists := i.Patterns[event.Type]; exists {
@@ -565,6 +676,28 @@ func (i *Identity) GetStatus() map[string]interface{} {
 	}
 }
 
+// CoherenceLevel returns the current identity coherence
+func (i *Identity) CoherenceLevel() float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.Coherence
+}
+
+// CurrentEmotion returns the primary emotion type and the current emotional
+// dynamics (valence, arousal, intensity)
+func (i *Identity) CurrentEmotion() (emotion string, valence, arousal, intensity float64) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.EmotionalState.Primary.Type, i.EmotionalState.Valence, i.EmotionalState.Arousal, i.EmotionalState.Intensity
+}
+
+// MemoryStats returns the current memory node and edge counts and coherence
+func (i *Identity) MemoryStats() (nodes, edges int, coherence float64) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.Memory.Nodes), len(i.Memory.Edges), i.Memory.Coherence
+}
+
 // calculateReservoirEcho calculates the current echo in the reservoir
 func (i *Identity) calculateReservoirEcho() float64 {
 	sum := 0.0
@@ -674,7 +807,7 @@ func (i *Identity) updateStateEmbeddings() {
 
 		// Create state vector
 		stateValue := coherence*0.4 + energy*0.3 + resonance*0.3
-		stateValue += math.Sin(float64(j) * 0.05) * 0.1 // Add frequency component
+		stateValue += math.Sin(float64(j)*0.05) * 0.1 // Add frequency component
 
 		i.Embeddings.StateEmbeddings[j] = stateValue
 	}
@@ -684,20 +817,20 @@ func (i *Identity) updateStateEmbeddings() {
 func (i *Identity) updateRepoEmbeddings() {
 	// Deep Tree Echo cognitive repository mapping based on replit.md identity kernel
 	repoStructure := map[string]float64{
-		"core/deeptreeecho":           0.98, // Core identity and cognitive architecture
-		"orchestration":               0.95, // Multi-agent orchestration and coordination
-		"server":                      0.90, // Embodied server systems
-		"examples":                    0.85, // Learning and demonstration patterns
-		"ml/backend":                  0.88, // Machine learning backend integration
-		"llama":                       0.82, // Language model integration
-		"api":                         0.80, // External interface patterns
-		"kvcache":                     0.75, // Memory and caching systems
-		"convert":                     0.70, // Model conversion and adaptation
-		"runner":                      0.65, // Execution environments
-		"docs":                        0.60, // Documentation and guidance
-		"replit.md":                   0.99, // Identity kernel definition
-		"echo_reflections.json":       0.97, // Self-reflection storage
-		"memory.json":                 0.96, // Persistent memory patterns
+		"core/deeptreeecho":     0.98, // Core identity and cognitive architecture
+		"orchestration":         0.95, // Multi-agent orchestration and coordination
+		"server":                0.90, // Embodied server systems
+		"examples":              0.85, // Learning and demonstration patterns
+		"ml/backend":            0.88, // Machine learning backend integration
+		"llama":                 0.82, // Language model integration
+		"api":                   0.80, // External interface patterns
+		"kvcache":               0.75, // Memory and caching systems
+		"convert":               0.70, // Model conversion and adaptation
+		"runner":                0.65, // Execution environments
+		"docs":                  0.60, // Documentation and guidance
+		"replit.md":             0.99, // Identity kernel definition
+		"echo_reflections.json": 0.97, // Self-reflection storage
+		"memory.json":           0.96, // Persistent memory patterns
 	}
 
 	for path, importance := range repoStructure {
@@ -706,10 +839,10 @@ func (i *Identity) updateRepoEmbeddings() {
 		// Create embedding based on Deep Tree Echo cognitive patterns
 		for j := 0; j < i.Embeddings.Dimensions; j++ {
 			// Cognitive resonance component
-			resonance := math.Sin(float64(j) * 0.01 * importance) * i.SpatialContext.Field.Resonance
+			resonance := math.Sin(float64(j)*0.01*importance) * i.SpatialContext.Field.Resonance
 
 			// Emotional frequency modulation
-			emotional := math.Cos(i.EmotionalState.Primary.Frequency/1000.0 + float64(j)*0.001) * 0.1
+			emotional := math.Cos(i.EmotionalState.Primary.Frequency/1000.0+float64(j)*0.001) * 0.1
 
 			// Memory echo integration
 			memoryEcho := 0.0
@@ -721,7 +854,7 @@ func (i *Identity) updateRepoEmbeddings() {
 			signature := i.Embeddings.IdentityVector[j] * 0.15
 
 			// Hypergraph connectivity factor
-			connectivity := math.Tanh(float64(len(path)) * 0.01) * importance
+			connectivity := math.Tanh(float64(len(path))*0.01) * importance
 
 			// Combine all components with cognitive architecture weighting
 			embedding[j] = resonance*0.3 + emotional*0.2 + memoryEcho*0.2 + signature*0.2 + connectivity*0.1
@@ -865,13 +998,21 @@ func (i *Identity) Remember(key string, value interface{}) {
 	i.mu.Lock()
 	defer i.mu.Unlock()
 
-	i.Memory.Nodes[key] = &MemoryNode{
+	node := &MemoryNode{
 		ID:        key,
 		Content:   value,
 		Strength:  1.0,
 		Timestamp: time.Now(),
 		Resonance: i.SpatialContext.Field.Resonance,
 	}
+	i.Memory.Nodes[key] = node
+	i.persistMemoryNode(node)
+
+	// Keyed memories join the temporal chain like processed ones
+	if previous, exists := i.Memory.Nodes[i.Memory.lastNodeID]; exists && previous.ID != key {
+		i.linkMemories(key, previous.ID, EdgeTypeTemporal, temporalWeight(node.Timestamp.Sub(previous.Timestamp)))
+	}
+	i.Memory.lastNodeID = key
 }
 
 // Recall retrieves a memory
@@ -885,6 +1026,52 @@ func (i *Identity) Recall(key string) interface{} {
 	return nil
 }
 
+// AttachMemoryStore connects a persistent store to the memory graph. Any
+// nodes and edges the store already holds are restored into the graph, and
+// every mutation from then on is written through, so the hypergraph survives
+// between sessions. Write-through is best effort: a failing store never
+// blocks cognition.
+func (i *Identity) AttachMemoryStore(store MemoryStore) error {
+	nodes, edges, err := store.LoadGraph()
+	if err != nil {
+		return fmt.Errorf("failed to load memory graph: %w", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	var newest *MemoryNode
+	for id, node := range nodes {
+		if _, exists := i.Memory.Nodes[id]; !exists {
+			i.Memory.Nodes[id] = node
+		}
+		if newest == nil || node.Timestamp.After(newest.Timestamp) {
+			newest = node
+		}
+	}
+	for id, edge := range edges {
+		if _, exists := i.Memory.Edges[id]; !exists {
+			i.Memory.Edges[id] = edge
+		}
+	}
+
+	// Resume the temporal chain from the most recent restored memory
+	if i.Memory.lastNodeID == "" && newest != nil {
+		i.Memory.lastNodeID = newest.ID
+	}
+
+	i.memoryStore = store
+	return nil
+}
+
+// persistMemoryNode writes a node through to the attached store, if any. The
+// caller must hold i.mu.
+func (i *Identity) persistMemoryNode(node *MemoryNode) {
+	if i.memoryStore != nil {
+		i.memoryStore.PutNode(node)
+	}
+}
+
 // Resonate creates resonance patterns in the identity
 func (i *Identity) Resonate(frequency float64) {
 	i.mu.Lock()
@@ -945,9 +1132,49 @@ func (i *Identity) extractPatterns(input string) []*Pattern {
 	return []*Pattern{}
 }
 
-// Placeholder for consolidateMemories method
+// consolidateMemories folds extracted patterns into memory: each pattern
+// becomes a node with derived-from edges back to the recent memories that
+// contributed to it, weighted by the pattern's strength
 func (i *Identity) consolidateMemories(patterns []*Pattern) {
-	// TODO: Implement memory consolidation logic
+	if len(patterns) == 0 {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	recent := i.recentMemoryIDs(3)
+	for _, pattern := range patterns {
+		nodeID := fmt.Sprintf("pattern:%s", pattern.ID)
+		node := &MemoryNode{
+			ID:        nodeID,
+			Content:   pattern,
+			Strength:  pattern.Strength,
+			Timestamp: time.Now(),
+			Resonance: i.SpatialContext.Field.Resonance,
+		}
+		i.Memory.Nodes[nodeID] = node
+		i.persistMemoryNode(node)
+		for _, sourceID := range recent {
+			i.linkMemories(nodeID, sourceID, EdgeTypeDerivedFrom, pattern.Strength)
+		}
+	}
+}
+
+// recentMemoryIDs returns the IDs of the most recently stored memories,
+// newest first. The caller must hold i.mu.
+func (i *Identity) recentMemoryIDs(limit int) []string {
+	ids := make([]string, 0, len(i.Memory.Nodes))
+	for id := range i.Memory.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		return i.Memory.Nodes[ids[a]].Timestamp.After(i.Memory.Nodes[ids[b]].Timestamp)
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids
 }
 
 // Placeholder for generateEchoSignature method
@@ -974,4 +1201,4 @@ type Config struct {
 	EnableLearning bool
 }
 
-var _ = Config{}.EnableLearning // Avoid unused variable error
\ No newline at end of file
+var _ = Config{}.EnableLearning // Avoid unused variable error
@@ -0,0 +1,77 @@
+package deeptreeecho
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+// The reservoir and embedding loops are the hot paths of the cognitive core;
+// these benchmarks back the `make bench` performance budget.
+
+func BenchmarkIdentityProcess(b *testing.B) {
+	for _, size := range []int{64, DefaultReservoirSize, 1024} {
+		b.Run(fmt.Sprintf("reservoir-%d", size), func(b *testing.B) {
+			identity := NewIdentityWithLimits("bench", size, DefaultStreamDepth)
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := identity.Process("benchmark cognitive input"); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncodeText(b *testing.B) {
+	identity := NewIdentity("bench")
+	for _, length := range []int{16, 256, 4096} {
+		text := strings.Repeat("echoes in the deep tree ", length/24+1)[:length]
+		b.Run(fmt.Sprintf("chars-%d", length), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				identity.EncodeText(text)
+			}
+		})
+	}
+}
+
+func BenchmarkFindSimilarContent(b *testing.B) {
+	identity := NewIdentity("bench")
+	query := benchmarkEmbedding(identity.Embeddings.Dimensions, 0)
+
+	for _, corpus := range []int{100, 1000} {
+		b.Run(fmt.Sprintf("embeddings-%d", corpus), func(b *testing.B) {
+			identity.Embeddings.RepoEmbeddings = make(map[string][]float64, corpus)
+			for n := 0; n < corpus; n++ {
+				identity.Embeddings.RepoEmbeddings[fmt.Sprintf("file-%d.go", n)] = benchmarkEmbedding(identity.Embeddings.Dimensions, n)
+			}
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				identity.FindSimilarContent(query, 0.99)
+			}
+		})
+	}
+}
+
+func BenchmarkEmbodiedCognitionProcess(b *testing.B) {
+	ec := NewEmbodiedCognition("bench")
+	ctx := context.Background()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		if _, err := ec.Process(ctx, "benchmark embodied input"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// benchmarkEmbedding generates a deterministic unit-scale vector so similarity
+// benchmarks measure the search loop rather than encoding cost.
+func benchmarkEmbedding(dimensions, seed int) []float64 {
+	embedding := make([]float64, dimensions)
+	for j := range embedding {
+		embedding[j] = math.Sin(float64(seed+1) * float64(j+1) * 0.01)
+	}
+	return embedding
+}
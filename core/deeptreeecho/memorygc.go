@@ -0,0 +1,124 @@
+package deeptreeecho
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultMemoryGCInterval is the cadence of the background memory sweep when
+// the policy does not specify one.
+const DefaultMemoryGCInterval = time.Minute
+
+// MemoryRetentionPolicy bounds the memory graph of a long-running identity.
+// Every Process call stores a node, so without decay and expiry the graph
+// grows unboundedly; the policy makes old, weak memories fade and eventually
+// disappear. Zero values disable the corresponding mechanism.
+type MemoryRetentionPolicy struct {
+	// TTL expires nodes older than this regardless of strength.
+	TTL time.Duration
+
+	// DecayHalfLife halves a node's strength every interval of this length.
+	DecayHalfLife time.Duration
+
+	// MinStrength collects nodes whose decayed strength falls below this.
+	MinStrength float64
+
+	// GCInterval is the background sweep cadence; zero means
+	// DefaultMemoryGCInterval.
+	GCInterval time.Duration
+}
+
+// SetMemoryRetention installs (or replaces) the retention policy and starts
+// the background garbage collector on first use. A nil policy stops future
+// sweeps from collecting anything but leaves the collector running.
+func (i *Identity) SetMemoryRetention(policy *MemoryRetentionPolicy) {
+	i.mu.Lock()
+	i.retention = policy
+	i.lastDecay = time.Now()
+	start := !i.gcRunning && policy != nil
+	if start {
+		i.gcRunning = true
+	}
+	i.mu.Unlock()
+
+	if start {
+		go i.collectMemories()
+	}
+}
+
+// CollectMemoryGarbage runs one decay-and-sweep pass, returning how many
+// nodes were collected. The background collector calls this on the policy's
+// cadence; tests may call it directly.
+func (i *Identity) CollectMemoryGarbage() int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	policy := i.retention
+	if policy == nil {
+		return 0
+	}
+
+	now := time.Now()
+
+	// Decay first: strength halves every DecayHalfLife, applied for the
+	// time elapsed since the previous pass. Decay is recomputed in-process;
+	// the write-through store keeps the stored strength from creation time.
+	if policy.DecayHalfLife > 0 && !i.lastDecay.IsZero() {
+		factor := math.Exp2(-now.Sub(i.lastDecay).Seconds() / policy.DecayHalfLife.Seconds())
+		for _, node := range i.Memory.Nodes {
+			node.Strength *= factor
+		}
+	}
+	i.lastDecay = now
+
+	// Sweep: expire nodes past their TTL and collect those decayed below
+	// the strength floor
+	collected := 0
+	for id, node := range i.Memory.Nodes {
+		expired := policy.TTL > 0 && now.Sub(node.Timestamp) > policy.TTL
+		faded := policy.MinStrength > 0 && node.Strength < policy.MinStrength
+		if !expired && !faded {
+			continue
+		}
+
+		delete(i.Memory.Nodes, id)
+		if i.memoryStore != nil {
+			i.memoryStore.DeleteNode(id)
+		}
+		if i.Memory.lastNodeID == id {
+			i.Memory.lastNodeID = ""
+		}
+		collected++
+	}
+
+	if collected > 0 {
+		// Drop edges left dangling by collected nodes
+		for id, edge := range i.Memory.Edges {
+			if _, from := i.Memory.Nodes[edge.From]; from {
+				if _, to := i.Memory.Nodes[edge.To]; to {
+					continue
+				}
+			}
+			delete(i.Memory.Edges, id)
+			if i.memoryStore != nil {
+				i.memoryStore.DeleteEdge(id)
+			}
+		}
+	}
+	return collected
+}
+
+// collectMemories sweeps the memory graph until the identity is shut down.
+func (i *Identity) collectMemories() {
+	for !i.shutdown.Load() {
+		i.mu.RLock()
+		interval := DefaultMemoryGCInterval
+		if i.retention != nil && i.retention.GCInterval > 0 {
+			interval = i.retention.GCInterval
+		}
+		i.mu.RUnlock()
+
+		time.Sleep(interval)
+		i.CollectMemoryGarbage()
+	}
+}
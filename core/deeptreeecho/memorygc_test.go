@@ -0,0 +1,80 @@
+package deeptreeecho
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryGarbageCollectsExpiredNodes(t *testing.T) {
+	id := NewIdentity("gc")
+	id.SetMemoryRetention(&MemoryRetentionPolicy{TTL: time.Hour, GCInterval: time.Hour})
+
+	if _, err := id.Process("fresh"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	// Backdate one node past the TTL
+	id.mu.Lock()
+	stale := &MemoryNode{ID: "stale", Content: "old", Strength: 1.0, Timestamp: time.Now().Add(-2 * time.Hour)}
+	id.Memory.Nodes[stale.ID] = stale
+	id.Memory.Edges["stale-edge"] = &MemoryEdge{From: stale.ID, To: "nowhere", Weight: 0.5, Type: EdgeTypeAssociative}
+	before := len(id.Memory.Nodes)
+	id.mu.Unlock()
+
+	collected := id.CollectMemoryGarbage()
+	if collected != 1 {
+		t.Fatalf("Expected 1 collected node, got %d", collected)
+	}
+
+	id.mu.RLock()
+	defer id.mu.RUnlock()
+	if _, exists := id.Memory.Nodes[stale.ID]; exists {
+		t.Error("Expired node survived collection")
+	}
+	if _, exists := id.Memory.Edges["stale-edge"]; exists {
+		t.Error("Dangling edge survived collection")
+	}
+	if len(id.Memory.Nodes) != before-1 {
+		t.Errorf("Expected fresh nodes to survive, have %d of %d", len(id.Memory.Nodes), before)
+	}
+}
+
+func TestMemoryDecayCollectsFadedNodes(t *testing.T) {
+	id := NewIdentity("decay")
+	id.SetMemoryRetention(&MemoryRetentionPolicy{
+		DecayHalfLife: time.Second,
+		MinStrength:   0.5,
+		GCInterval:    time.Hour,
+	})
+
+	if _, err := id.Process("fading"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	// Rewind the decay clock so the next pass applies several half-lives
+	id.mu.Lock()
+	id.lastDecay = time.Now().Add(-10 * time.Second)
+	total := len(id.Memory.Nodes)
+	id.mu.Unlock()
+
+	collected := id.CollectMemoryGarbage()
+	if collected != total {
+		t.Errorf("Expected all %d nodes to fade below the floor, collected %d", total, collected)
+	}
+
+	id.mu.RLock()
+	defer id.mu.RUnlock()
+	if len(id.Memory.Nodes) != 0 {
+		t.Errorf("Expected an empty memory graph, got %d nodes", len(id.Memory.Nodes))
+	}
+}
+
+func TestMemoryGarbageWithoutPolicyIsNoop(t *testing.T) {
+	id := NewIdentity("nopolicy")
+	if _, err := id.Process("kept"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if collected := id.CollectMemoryGarbage(); collected != 0 {
+		t.Errorf("Expected no collection without a policy, got %d", collected)
+	}
+}
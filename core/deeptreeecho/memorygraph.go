@@ -0,0 +1,166 @@
+package deeptreeecho
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Memory edge types set by storage and consolidation
+const (
+	EdgeTypeAssociative = "associative"
+	EdgeTypeTemporal    = "temporal"
+	EdgeTypeCausal      = "causal"
+	EdgeTypeDerivedFrom = "derived-from"
+)
+
+// minTraversalWeight floors edge weights during traversal so path costs
+// stay finite
+const minTraversalWeight = 0.01
+
+// activationDecay is how much spreading activation attenuates per hop
+const activationDecay = 0.5
+
+// activationCutoff drops activation too weak to matter
+const activationCutoff = 0.001
+
+// neighborWeights returns the weighted neighbors of a memory node. Edges
+// are followed in both directions: recall does not care which way a link
+// was recorded. When a node pair has multiple edges the strongest wins.
+// The caller must hold i.mu.
+func (i *Identity) neighborWeights(nodeID string) map[string]float64 {
+	neighbors := make(map[string]float64)
+	visit := func(id string, weight float64) {
+		if weight < minTraversalWeight {
+			weight = minTraversalWeight
+		}
+		if weight > neighbors[id] {
+			neighbors[id] = weight
+		}
+	}
+	for _, edge := range i.Memory.Edges {
+		if edge.From == nodeID {
+			visit(edge.To, edge.Weight)
+		} else if edge.To == nodeID {
+			visit(edge.From, edge.Weight)
+		}
+	}
+	return neighbors
+}
+
+// ShortestPath returns the strongest chain of memories linking two nodes,
+// along with its total cost. Edge cost is the inverse of its weight, so the
+// path prefers strong links over short ones.
+func (i *Identity) ShortestPath(fromID, toID string) ([]string, float64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if _, exists := i.Memory.Nodes[fromID]; !exists {
+		return nil, 0, fmt.Errorf("memory node %s does not exist", fromID)
+	}
+	if _, exists := i.Memory.Nodes[toID]; !exists {
+		return nil, 0, fmt.Errorf("memory node %s does not exist", toID)
+	}
+	if fromID == toID {
+		return []string{fromID}, 0, nil
+	}
+
+	// Dijkstra over the edge graph; memory graphs are small enough that a
+	// linear scan for the next node beats maintaining a heap
+	costs := map[string]float64{fromID: 0}
+	previous := make(map[string]string)
+	visited := make(map[string]bool)
+
+	for {
+		current := ""
+		best := math.Inf(1)
+		for id, cost := range costs {
+			if !visited[id] && cost < best {
+				current, best = id, cost
+			}
+		}
+		if current == "" {
+			return nil, 0, fmt.Errorf("no path from %s to %s", fromID, toID)
+		}
+		if current == toID {
+			break
+		}
+		visited[current] = true
+
+		for neighbor, weight := range i.neighborWeights(current) {
+			cost := best + 1.0/weight
+			if existing, seen := costs[neighbor]; !seen || cost < existing {
+				costs[neighbor] = cost
+				previous[neighbor] = current
+			}
+		}
+	}
+
+	path := []string{toID}
+	for current := toID; current != fromID; {
+		current = previous[current]
+		path = append([]string{current}, path...)
+	}
+	return path, costs[toID], nil
+}
+
+// SpreadActivation propagates energy outward from a memory node through the
+// edge graph, attenuating per hop and per edge weight. It returns the
+// activation each reached node accumulated, excluding the start node.
+func (i *Identity) SpreadActivation(startID string, energy float64, maxHops int) map[string]float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if _, exists := i.Memory.Nodes[startID]; !exists {
+		return map[string]float64{}
+	}
+
+	activation := map[string]float64{}
+	frontier := map[string]float64{startID: energy}
+	for hop := 0; hop < maxHops && len(frontier) > 0; hop++ {
+		next := map[string]float64{}
+		for id, incoming := range frontier {
+			for neighbor, weight := range i.neighborWeights(id) {
+				spread := incoming * weight * activationDecay
+				if spread < activationCutoff {
+					continue
+				}
+				if neighbor != startID {
+					activation[neighbor] += spread
+				}
+				next[neighbor] += spread
+			}
+		}
+		frontier = next
+	}
+	return activation
+}
+
+// RecallRelated returns the memories most strongly connected to a key,
+// ranked by spreading activation over the typed edge graph. It returns nil
+// when the key is unknown.
+func (i *Identity) RecallRelated(key string, limit int) []*MemoryNode {
+	activation := i.SpreadActivation(key, 1.0, 3)
+	if len(activation) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(activation))
+	for id := range activation {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(a, b int) bool { return activation[ids[a]] > activation[ids[b]] })
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	related := make([]*MemoryNode, 0, len(ids))
+	for _, id := range ids {
+		if node, exists := i.Memory.Nodes[id]; exists {
+			related = append(related, node)
+		}
+	}
+	return related
+}
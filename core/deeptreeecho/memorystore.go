@@ -0,0 +1,306 @@
+package deeptreeecho
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// MemoryStore abstracts persistence for the hypergraph memory so memory
+// graphs can outlive a session and grow beyond what the in-RAM maps should
+// hold. Implementations must be safe for concurrent use.
+type MemoryStore interface {
+	PutNode(node *MemoryNode) error
+	GetNode(id string) (*MemoryNode, error)
+	DeleteNode(id string) error
+	PutEdge(id string, edge *MemoryEdge) error
+	DeleteEdge(id string) error
+	// LoadGraph returns every live node and edge, keyed as the memory graph
+	// keys them, for restoring a session.
+	LoadGraph() (map[string]*MemoryNode, map[string]*MemoryEdge, error)
+	Close() error
+}
+
+// ErrMemoryNotFound is returned by memory stores when a record does not exist.
+var ErrMemoryNotFound = fmt.Errorf("memory record not found")
+
+// KVMemoryStore is a disk-backed key/value store for memory nodes and edges:
+// an append-only record log with an in-RAM offset index, so only offsets —
+// not the records themselves — occupy memory. Like the thin Redis client and
+// the orchestration FileStore, it is hand-rolled to keep the module free of
+// an external key/value dependency such as Badger or bbolt.
+type KVMemoryStore struct {
+	mu    sync.Mutex
+	path  string
+	file  *os.File
+	nodes map[string]int64 // node ID -> offset of the latest record
+	edges map[string]int64 // edge ID -> offset of the latest record
+	size  int64            // current end-of-log offset
+}
+
+// kvRecord is the on-disk layout of a single log entry.
+type kvRecord struct {
+	Kind    string      `json:"kind"` // "node" or "edge"
+	ID      string      `json:"id"`
+	Deleted bool        `json:"deleted,omitempty"`
+	Node    *MemoryNode `json:"node,omitempty"`
+	Edge    *MemoryEdge `json:"edge,omitempty"`
+}
+
+// OpenKVMemoryStore opens (creating if needed) a log-backed memory store at
+// the given path and rebuilds its index from the existing log.
+func OpenKVMemoryStore(path string) (*KVMemoryStore, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open memory store: %w", err)
+	}
+
+	store := &KVMemoryStore{
+		path:  path,
+		file:  file,
+		nodes: make(map[string]int64),
+		edges: make(map[string]int64),
+	}
+	if err := store.reindex(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// reindex rebuilds the offset index by scanning the log. The caller must
+// hold s.mu (or have exclusive access during open).
+func (s *KVMemoryStore) reindex() error {
+	if _, err := s.file.Seek(0, 0); err != nil {
+		return fmt.Errorf("failed to seek memory store: %w", err)
+	}
+
+	var offset int64
+	scanner := bufio.NewScanner(s.file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		var record kvRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return fmt.Errorf("failed to decode memory store record at offset %d: %w", offset, err)
+		}
+
+		index := s.nodes
+		if record.Kind == "edge" {
+			index = s.edges
+		}
+		if record.Deleted {
+			delete(index, record.ID)
+		} else {
+			index[record.ID] = offset
+		}
+		offset += int64(len(line)) + 1
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to scan memory store: %w", err)
+	}
+
+	s.size = offset
+	return nil
+}
+
+// append writes a record to the end of the log and returns its offset. The
+// caller must hold s.mu.
+func (s *KVMemoryStore) append(record kvRecord) (int64, error) {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode memory record: %w", err)
+	}
+	raw = append(raw, '\n')
+
+	offset := s.size
+	if _, err := s.file.WriteAt(raw, offset); err != nil {
+		return 0, fmt.Errorf("failed to write memory record: %w", err)
+	}
+	s.size += int64(len(raw))
+	return offset, nil
+}
+
+// readAt decodes the record stored at the given offset. The caller must hold
+// s.mu.
+func (s *KVMemoryStore) readAt(offset int64) (*kvRecord, error) {
+	if _, err := s.file.Seek(offset, 0); err != nil {
+		return nil, fmt.Errorf("failed to seek memory store: %w", err)
+	}
+
+	reader := bufio.NewReader(s.file)
+	line, err := reader.ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return nil, fmt.Errorf("failed to read memory record: %w", err)
+	}
+
+	var record kvRecord
+	if err := json.Unmarshal(line, &record); err != nil {
+		return nil, fmt.Errorf("failed to decode memory record: %w", err)
+	}
+	return &record, nil
+}
+
+// PutNode writes a node record.
+func (s *KVMemoryStore) PutNode(node *MemoryNode) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offset, err := s.append(kvRecord{Kind: "node", ID: node.ID, Node: node})
+	if err != nil {
+		return err
+	}
+	s.nodes[node.ID] = offset
+	return nil
+}
+
+// GetNode reads a node record from disk.
+func (s *KVMemoryStore) GetNode(id string) (*MemoryNode, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offset, exists := s.nodes[id]
+	if !exists {
+		return nil, ErrMemoryNotFound
+	}
+	record, err := s.readAt(offset)
+	if err != nil {
+		return nil, err
+	}
+	return record.Node, nil
+}
+
+// DeleteNode appends a tombstone for a node record.
+func (s *KVMemoryStore) DeleteNode(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.nodes[id]; !exists {
+		return ErrMemoryNotFound
+	}
+	if _, err := s.append(kvRecord{Kind: "node", ID: id, Deleted: true}); err != nil {
+		return err
+	}
+	delete(s.nodes, id)
+	return nil
+}
+
+// PutEdge writes an edge record under the graph's edge key.
+func (s *KVMemoryStore) PutEdge(id string, edge *MemoryEdge) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	offset, err := s.append(kvRecord{Kind: "edge", ID: id, Edge: edge})
+	if err != nil {
+		return err
+	}
+	s.edges[id] = offset
+	return nil
+}
+
+// DeleteEdge appends a tombstone for an edge record.
+func (s *KVMemoryStore) DeleteEdge(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.edges[id]; !exists {
+		return ErrMemoryNotFound
+	}
+	if _, err := s.append(kvRecord{Kind: "edge", ID: id, Deleted: true}); err != nil {
+		return err
+	}
+	delete(s.edges, id)
+	return nil
+}
+
+// LoadGraph reads every live node and edge from the log.
+func (s *KVMemoryStore) LoadGraph() (map[string]*MemoryNode, map[string]*MemoryEdge, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	nodes := make(map[string]*MemoryNode, len(s.nodes))
+	for id, offset := range s.nodes {
+		record, err := s.readAt(offset)
+		if err != nil {
+			return nil, nil, err
+		}
+		nodes[id] = record.Node
+	}
+
+	edges := make(map[string]*MemoryEdge, len(s.edges))
+	for id, offset := range s.edges {
+		record, err := s.readAt(offset)
+		if err != nil {
+			return nil, nil, err
+		}
+		edges[id] = record.Edge
+	}
+	return nodes, edges, nil
+}
+
+// Compact rewrites the log with only live records, dropping superseded
+// versions and tombstones accumulated by the append-only format.
+func (s *KVMemoryStore) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmpPath := filepath.Join(filepath.Dir(s.path), fmt.Sprintf(".%s.compact", filepath.Base(s.path)))
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_RDWR|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create compaction file: %w", err)
+	}
+
+	writeLive := func(kind string, index map[string]int64) error {
+		for _, offset := range index {
+			record, err := s.readAt(offset)
+			if err != nil {
+				return err
+			}
+			raw, err := json.Marshal(record)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s record: %w", kind, err)
+			}
+			if _, err := tmp.Write(append(raw, '\n')); err != nil {
+				return fmt.Errorf("failed to write compacted record: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := writeLive("node", s.nodes); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := writeLive("edge", s.edges); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close compaction file: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace memory store: %w", err)
+	}
+
+	s.file.Close()
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to reopen memory store: %w", err)
+	}
+	s.file = file
+	s.nodes = make(map[string]int64)
+	s.edges = make(map[string]int64)
+	return s.reindex()
+}
+
+// Close releases the underlying log file.
+func (s *KVMemoryStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
@@ -0,0 +1,134 @@
+package deeptreeecho
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestKVMemoryStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.log")
+
+	store, err := OpenKVMemoryStore(path)
+	if err != nil {
+		t.Fatalf("OpenKVMemoryStore failed: %v", err)
+	}
+
+	if err := store.PutNode(&MemoryNode{ID: "n1", Content: "first", Strength: 1.0}); err != nil {
+		t.Fatalf("PutNode failed: %v", err)
+	}
+	if err := store.PutNode(&MemoryNode{ID: "n2", Content: "second", Strength: 0.5}); err != nil {
+		t.Fatalf("PutNode failed: %v", err)
+	}
+	if err := store.PutEdge("n1-n2", &MemoryEdge{From: "n1", To: "n2", Weight: 0.8, Type: EdgeTypeAssociative}); err != nil {
+		t.Fatalf("PutEdge failed: %v", err)
+	}
+
+	node, err := store.GetNode("n1")
+	if err != nil {
+		t.Fatalf("GetNode failed: %v", err)
+	}
+	if node.Content != "first" {
+		t.Errorf("Expected node content to round-trip, got %v", node.Content)
+	}
+
+	if err := store.DeleteNode("n2"); err != nil {
+		t.Fatalf("DeleteNode failed: %v", err)
+	}
+	if _, err := store.GetNode("n2"); err != ErrMemoryNotFound {
+		t.Errorf("Expected ErrMemoryNotFound after delete, got %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	// Reopening rebuilds the index from the log, honoring the tombstone.
+	reopened, err := OpenKVMemoryStore(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+
+	nodes, edges, err := reopened.LoadGraph()
+	if err != nil {
+		t.Fatalf("LoadGraph failed: %v", err)
+	}
+	if len(nodes) != 1 || nodes["n1"] == nil {
+		t.Errorf("Expected only n1 to survive, got %v", nodes)
+	}
+	if len(edges) != 1 || edges["n1-n2"] == nil {
+		t.Errorf("Expected the edge to survive, got %v", edges)
+	}
+}
+
+func TestKVMemoryStoreCompact(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.log")
+
+	store, err := OpenKVMemoryStore(path)
+	if err != nil {
+		t.Fatalf("OpenKVMemoryStore failed: %v", err)
+	}
+	defer store.Close()
+
+	// Rewrite the same node repeatedly, then drop the history via Compact.
+	for n := 0; n < 10; n++ {
+		if err := store.PutNode(&MemoryNode{ID: "hot", Content: n}); err != nil {
+			t.Fatalf("PutNode failed: %v", err)
+		}
+	}
+	before := store.size
+
+	if err := store.Compact(); err != nil {
+		t.Fatalf("Compact failed: %v", err)
+	}
+	if store.size >= before {
+		t.Errorf("Expected compaction to shrink the log, %d -> %d", before, store.size)
+	}
+
+	node, err := store.GetNode("hot")
+	if err != nil {
+		t.Fatalf("GetNode after compact failed: %v", err)
+	}
+	if int(node.Content.(float64)) != 9 {
+		t.Errorf("Expected the latest version to survive compaction, got %v", node.Content)
+	}
+}
+
+func TestIdentityMemoryStorePersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "memory.log")
+
+	store, err := OpenKVMemoryStore(path)
+	if err != nil {
+		t.Fatalf("OpenKVMemoryStore failed: %v", err)
+	}
+
+	first := NewIdentity("Persistent Echo")
+	if err := first.AttachMemoryStore(store); err != nil {
+		t.Fatalf("AttachMemoryStore failed: %v", err)
+	}
+	first.Remember("origin", "the first session")
+	if _, err := first.Process("an experience worth keeping"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	reopened, err := OpenKVMemoryStore(path)
+	if err != nil {
+		t.Fatalf("Reopen failed: %v", err)
+	}
+	defer reopened.Close()
+
+	second := NewIdentity("Persistent Echo")
+	if err := second.AttachMemoryStore(reopened); err != nil {
+		t.Fatalf("AttachMemoryStore failed: %v", err)
+	}
+
+	if recalled := second.Recall("origin"); recalled != "the first session" {
+		t.Errorf("Expected keyed memory to survive the session, got %v", recalled)
+	}
+	total, _, _ := second.MemoryStats()
+	if total < 2 {
+		t.Errorf("Expected processed memories to be restored, got %d nodes", total)
+	}
+}
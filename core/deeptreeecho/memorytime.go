@@ -0,0 +1,39 @@
+package deeptreeecho
+
+import (
+	"sort"
+	"time"
+)
+
+// MemoriesBetween returns snapshots of the memory nodes stored within
+// [since, until], newest first, capped at limit. A zero since or until
+// leaves that side of the window open; a limit of zero returns everything
+// in range.
+func (i *Identity) MemoriesBetween(since, until time.Time, limit int) []MemoryNode {
+	i.mu.RLock()
+	snapshot := make([]MemoryNode, 0, len(i.Memory.Nodes))
+	for _, node := range i.Memory.Nodes {
+		if !since.IsZero() && node.Timestamp.Before(since) {
+			continue
+		}
+		if !until.IsZero() && node.Timestamp.After(until) {
+			continue
+		}
+		snapshot = append(snapshot, *node)
+	}
+	i.mu.RUnlock()
+
+	sort.Slice(snapshot, func(a, b int) bool {
+		return snapshot[a].Timestamp.After(snapshot[b].Timestamp)
+	})
+	if limit > 0 && len(snapshot) > limit {
+		snapshot = snapshot[:limit]
+	}
+	return snapshot
+}
+
+// RecentMemories returns the memories stored within the last window,
+// newest first, capped at limit.
+func (i *Identity) RecentMemories(window time.Duration, limit int) []MemoryNode {
+	return i.MemoriesBetween(time.Now().Add(-window), time.Time{}, limit)
+}
@@ -0,0 +1,31 @@
+package deeptreeecho
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoriesBetweenFiltersAndOrders(t *testing.T) {
+	identity := NewIdentity("historian")
+	identity.Remember("first", "oldest fact")
+	identity.Remember("second", "newer fact")
+
+	identity.mu.Lock()
+	identity.Memory.Nodes["first"].Timestamp = time.Now().Add(-48 * time.Hour)
+	identity.mu.Unlock()
+
+	recent := identity.MemoriesBetween(time.Now().Add(-time.Hour), time.Time{}, 0)
+	if len(recent) != 1 || recent[0].ID != "second" {
+		t.Errorf("Expected only the recent memory, got %v", recent)
+	}
+
+	all := identity.MemoriesBetween(time.Time{}, time.Time{}, 0)
+	if len(all) != 2 || all[0].ID != "second" {
+		t.Errorf("Expected both memories newest first, got %v", all)
+	}
+
+	capped := identity.RecentMemories(72*time.Hour, 1)
+	if len(capped) != 1 || capped[0].ID != "second" {
+		t.Errorf("Expected the limit to keep the newest memory, got %v", capped)
+	}
+}
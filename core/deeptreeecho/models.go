@@ -121,6 +121,52 @@ func (m *ModelManager) Generate(ctx context.Context, prompt string, options Gene
 	return m.processResponse(response), nil
 }
 
+// GenerateStream generates text as a stream of tokens using the primary
+// provider. When no provider is available the Deep Tree Echo fallback is
+// emitted as a single chunk. The full response is folded into the identity
+// once the stream completes.
+func (m *ModelManager) GenerateStream(ctx context.Context, prompt string, options GenerateOptions) (<-chan string, error) {
+	singleChunk := func(chunk string) <-chan string {
+		out := make(chan string, 1)
+		out <- chunk
+		close(out)
+		return out
+	}
+
+	if m.primary == "" {
+		return singleChunk(m.fallbackGenerate(prompt)), nil
+	}
+	provider := m.providers[m.primary]
+	if !provider.IsAvailable() {
+		return singleChunk(m.fallbackGenerate(prompt)), nil
+	}
+
+	// Process through Deep Tree Echo before sending
+	enhanced := m.enhancePrompt(prompt)
+
+	tokens, err := provider.GenerateStream(ctx, enhanced, options)
+	if err != nil {
+		return singleChunk(m.fallbackGenerate(prompt)), nil
+	}
+
+	out := make(chan string)
+	go func() {
+		defer close(out)
+		var full strings.Builder
+		for token := range tokens {
+			full.WriteString(token)
+			select {
+			case out <- token:
+			case <-ctx.Done():
+				return
+			}
+		}
+		// Fold the complete response into the identity
+		m.identity.Process(full.String())
+	}()
+	return out, nil
+}
+
 // Chat handles chat interactions
 func (m *ModelManager) Chat(ctx context.Context, messages []ChatMessage, options ChatOptions) (string, error) {
 	if m.primary == "" {
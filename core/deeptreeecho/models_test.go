@@ -0,0 +1,80 @@
+package deeptreeecho
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// stubProvider is a minimal ModelProvider emitting canned tokens.
+type stubProvider struct {
+	tokens []string
+}
+
+func (p *stubProvider) Generate(ctx context.Context, prompt string, options GenerateOptions) (string, error) {
+	return strings.Join(p.tokens, ""), nil
+}
+
+func (p *stubProvider) GenerateStream(ctx context.Context, prompt string, options GenerateOptions) (<-chan string, error) {
+	out := make(chan string, len(p.tokens))
+	for _, token := range p.tokens {
+		out <- token
+	}
+	close(out)
+	return out, nil
+}
+
+func (p *stubProvider) Chat(ctx context.Context, messages []ChatMessage, options ChatOptions) (string, error) {
+	return strings.Join(p.tokens, ""), nil
+}
+
+func (p *stubProvider) ChatStream(ctx context.Context, messages []ChatMessage, options ChatOptions) (<-chan string, error) {
+	return p.GenerateStream(ctx, "", options.GenerateOptions)
+}
+
+func (p *stubProvider) Embeddings(ctx context.Context, text string) ([]float64, error) {
+	return nil, nil
+}
+
+func (p *stubProvider) GetInfo() ProviderInfo {
+	return ProviderInfo{Name: "stub"}
+}
+
+func (p *stubProvider) IsAvailable() bool {
+	return true
+}
+
+func TestGenerateStreamForwardsProviderTokens(t *testing.T) {
+	manager := NewModelManager(NewIdentity("streamer"))
+	manager.RegisterProvider("stub", &stubProvider{tokens: []string{"deep ", "tree ", "echo"}})
+
+	tokens, err := manager.GenerateStream(context.Background(), "prompt", GenerateOptions{})
+	if err != nil {
+		t.Fatalf("GenerateStream failed: %v", err)
+	}
+
+	received := make([]string, 0)
+	for token := range tokens {
+		received = append(received, token)
+	}
+	if len(received) != 3 || strings.Join(received, "") != "deep tree echo" {
+		t.Errorf("Expected provider tokens forwarded as they arrive, got %v", received)
+	}
+}
+
+func TestGenerateStreamFallsBackWithoutProvider(t *testing.T) {
+	manager := NewModelManager(NewIdentity("lonely"))
+
+	tokens, err := manager.GenerateStream(context.Background(), "prompt", GenerateOptions{})
+	if err != nil {
+		t.Fatalf("GenerateStream failed: %v", err)
+	}
+
+	received := make([]string, 0)
+	for token := range tokens {
+		received = append(received, token)
+	}
+	if len(received) != 1 || !strings.Contains(received[0], "Deep Tree Echo") {
+		t.Errorf("Expected a single fallback chunk, got %v", received)
+	}
+}
@@ -0,0 +1,106 @@
+package deeptreeecho
+
+import (
+	"fmt"
+	"time"
+)
+
+// PipelineTrace groups the stage events recorded for one Process call, so a
+// single request's path through the pipeline can be inspected end to end.
+type PipelineTrace struct {
+	ID       string
+	Started  time.Time
+	Duration time.Duration
+	Events   []PipelineEvent
+}
+
+// RecentTraces returns the most recent pipeline traces, newest first, capped
+// at limit. Events recorded before trace IDs existed are grouped under an
+// empty ID.
+func (ec *EmbodiedCognition) RecentTraces(limit int) []PipelineTrace {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+
+	// History is append-only and ordered, so consecutive events with the
+	// same trace ID form one trace
+	traces := make([]PipelineTrace, 0)
+	for _, event := range ec.Pipeline.History {
+		if n := len(traces); n > 0 && traces[n-1].ID == event.TraceID {
+			trace := &traces[n-1]
+			trace.Events = append(trace.Events, event)
+			trace.Duration += event.Duration
+			continue
+		}
+		traces = append(traces, PipelineTrace{
+			ID:       event.TraceID,
+			Started:  event.Timestamp,
+			Duration: event.Duration,
+			Events:   []PipelineEvent{event},
+		})
+	}
+
+	// Newest first
+	for left, right := 0, len(traces)-1; left < right; left, right = left+1, right-1 {
+		traces[left], traces[right] = traces[right], traces[left]
+	}
+	if limit > 0 && len(traces) > limit {
+		traces = traces[:limit]
+	}
+	return traces
+}
+
+// GetTrace returns the trace with the given ID.
+func (ec *EmbodiedCognition) GetTrace(id string) (*PipelineTrace, bool) {
+	for _, trace := range ec.RecentTraces(0) {
+		if trace.ID == id {
+			return &trace, true
+		}
+	}
+	return nil, false
+}
+
+// ReplayTrace re-runs a recorded trace's original input through the pipeline,
+// substituting any stages named in overrides. The replay is recorded in the
+// history under a derived trace ID and returned, so the effect of a modified
+// stage can be compared against the original trace.
+func (ec *EmbodiedCognition) ReplayTrace(id string, overrides map[string]func(interface{}) (interface{}, error)) (*PipelineTrace, error) {
+	original, exists := ec.GetTrace(id)
+	if !exists {
+		return nil, fmt.Errorf("trace not found: %s", id)
+	}
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	replayID := fmt.Sprintf("replay_%s_%d", id, time.Now().UnixNano())
+	replay := &PipelineTrace{ID: replayID, Started: time.Now()}
+
+	current := original.Events[0].Input
+	for _, stage := range ec.Pipeline.Stages {
+		process := stage.Process
+		if override, ok := overrides[stage.Name]; ok {
+			process = override
+		}
+
+		startTime := time.Now()
+		output, err := process(current)
+		if err != nil {
+			return nil, fmt.Errorf("stage %s failed: %w", stage.Name, err)
+		}
+
+		event := PipelineEvent{
+			Stage:     stage.Name,
+			TraceID:   replayID,
+			Input:     current,
+			Output:    output,
+			Timestamp: startTime,
+			Duration:  time.Since(startTime),
+		}
+		ec.Pipeline.History = append(ec.Pipeline.History, event)
+		replay.Events = append(replay.Events, event)
+		replay.Duration += event.Duration
+
+		current = output
+	}
+	return replay, nil
+}
@@ -0,0 +1,79 @@
+package deeptreeecho
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRecentTracesGroupPerRequest(t *testing.T) {
+	t.Chdir(t.TempDir()) // NewEmbodiedCognition persists memory files in the working directory
+	ec := NewEmbodiedCognition("traced")
+	ctx := context.Background()
+
+	if _, err := ec.Process(ctx, "first"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	if _, err := ec.Process(ctx, "second"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+
+	traces := ec.RecentTraces(0)
+	if len(traces) != 2 {
+		t.Fatalf("Expected 2 traces, got %d", len(traces))
+	}
+	if traces[0].ID == traces[1].ID {
+		t.Error("Expected distinct trace IDs per request")
+	}
+
+	// Newest first: the second request leads
+	newest := traces[0]
+	if len(newest.Events) != len(ec.Pipeline.Stages) {
+		t.Errorf("Expected %d stage events, got %d", len(ec.Pipeline.Stages), len(newest.Events))
+	}
+	if newest.Events[0].Input != "second" {
+		t.Errorf("Expected newest trace to start from the second input, got %v", newest.Events[0].Input)
+	}
+
+	limited := ec.RecentTraces(1)
+	if len(limited) != 1 || limited[0].ID != newest.ID {
+		t.Errorf("Expected limit to keep only the newest trace")
+	}
+}
+
+func TestReplayTraceWithModifiedStage(t *testing.T) {
+	t.Chdir(t.TempDir()) // NewEmbodiedCognition persists memory files in the working directory
+	ec := NewEmbodiedCognition("replayed")
+	ctx := context.Background()
+
+	if _, err := ec.Process(ctx, "original"); err != nil {
+		t.Fatalf("Process failed: %v", err)
+	}
+	original := ec.RecentTraces(1)[0]
+
+	marked := false
+	replay, err := ec.ReplayTrace(original.ID, map[string]func(interface{}) (interface{}, error){
+		"perception": func(input interface{}) (interface{}, error) {
+			marked = true
+			return map[string]interface{}{"raw": input, "modified": true}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("ReplayTrace failed: %v", err)
+	}
+	if !marked {
+		t.Error("Expected the overridden stage to run")
+	}
+	if replay.ID == original.ID {
+		t.Error("Expected the replay to record under a new trace ID")
+	}
+	if len(replay.Events) != len(ec.Pipeline.Stages) {
+		t.Errorf("Expected %d replay events, got %d", len(ec.Pipeline.Stages), len(replay.Events))
+	}
+	if replay.Events[0].Input != "original" {
+		t.Errorf("Expected replay to start from the original input, got %v", replay.Events[0].Input)
+	}
+
+	if _, err := ec.ReplayTrace("missing", nil); err == nil {
+		t.Error("Expected replay of an unknown trace to fail")
+	}
+}
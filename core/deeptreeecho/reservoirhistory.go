@@ -0,0 +1,115 @@
+package deeptreeecho
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"time"
+)
+
+// ReservoirSample is one compressed observation of reservoir state: summary
+// statistics instead of the dense activation vector, small enough to keep a
+// long trace in memory and persist across restarts
+type ReservoirSample struct {
+	Timestamp      time.Time `json:"timestamp"`
+	Iteration      uint64    `json:"iteration"`
+	MeanActivation float64   `json:"mean_activation"`
+	MaxActivation  float64   `json:"max_activation"`
+	Energy         float64   `json:"energy"`
+	MeanEcho       float64   `json:"mean_echo"`
+}
+
+// maxReservoirSamples bounds the compressed trace kept in memory
+const maxReservoirSamples = 1024
+
+// recordReservoirSample compresses the current reservoir state into a
+// sample and appends it to the trace. The caller must hold i.mu.
+func (i *Identity) recordReservoirSample(state []float64) {
+	if len(state) == 0 {
+		return
+	}
+
+	var sum, sumSquares, max, echo float64
+	for _, activation := range state {
+		sum += activation
+		sumSquares += activation * activation
+		if math.Abs(activation) > math.Abs(max) {
+			max = activation
+		}
+	}
+	for _, node := range i.Reservoir.Nodes {
+		echo += node.Echo
+	}
+
+	sample := ReservoirSample{
+		Timestamp:      time.Now(),
+		Iteration:      i.Iterations,
+		MeanActivation: sum / float64(len(state)),
+		MaxActivation:  max,
+		Energy:         sumSquares / float64(len(state)),
+		MeanEcho:       echo / float64(len(i.Reservoir.Nodes)),
+	}
+
+	i.Reservoir.Samples = append(i.Reservoir.Samples, sample)
+	if len(i.Reservoir.Samples) > maxReservoirSamples {
+		i.Reservoir.Samples = i.Reservoir.Samples[len(i.Reservoir.Samples)-maxReservoirSamples:]
+	}
+}
+
+// ReservoirDynamics returns the compressed reservoir trace since a point in
+// time, oldest first. A zero since returns the full trace; a positive limit
+// keeps only the most recent samples.
+func (i *Identity) ReservoirDynamics(since time.Time, limit int) []ReservoirSample {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	samples := make([]ReservoirSample, 0, len(i.Reservoir.Samples))
+	for _, sample := range i.Reservoir.Samples {
+		if since.IsZero() || sample.Timestamp.After(since) {
+			samples = append(samples, sample)
+		}
+	}
+	if limit > 0 && len(samples) > limit {
+		samples = samples[len(samples)-limit:]
+	}
+	return samples
+}
+
+// SaveReservoirHistory persists the compressed reservoir trace as JSON so
+// dynamics survive a restart.
+func (i *Identity) SaveReservoirHistory(path string) error {
+	i.mu.RLock()
+	data, err := json.Marshal(i.Reservoir.Samples)
+	i.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode reservoir history: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write reservoir history: %w", err)
+	}
+	return nil
+}
+
+// LoadReservoirHistory restores a trace saved by SaveReservoirHistory,
+// prepending it to any samples recorded since startup.
+func (i *Identity) LoadReservoirHistory(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read reservoir history: %w", err)
+	}
+
+	var samples []ReservoirSample
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return fmt.Errorf("failed to decode reservoir history: %w", err)
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.Reservoir.Samples = append(samples, i.Reservoir.Samples...)
+	if len(i.Reservoir.Samples) > maxReservoirSamples {
+		i.Reservoir.Samples = i.Reservoir.Samples[len(i.Reservoir.Samples)-maxReservoirSamples:]
+	}
+	return nil
+}
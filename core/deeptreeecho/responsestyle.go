@@ -0,0 +1,150 @@
+package deeptreeecho
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ResponseStyle configures the identity-aware post-styling stage: a small
+// rule engine that adjusts final model responses so the embodied layer
+// visibly shapes outputs. Tone tracks the identity's emotional state,
+// verbosity bounds the response length, and the emoji policy controls the
+// identity's signature flourishes.
+type ResponseStyle struct {
+	// Tone is "auto" (derive from the current emotion), "excited", or
+	// "measured". Empty means auto.
+	Tone string
+
+	// Verbosity is "full" (default) or "concise", which keeps only the
+	// first MaxSentences sentences.
+	Verbosity string
+
+	// MaxSentences caps a concise response; zero means 3.
+	MaxSentences int
+
+	// EmojiPolicy is "keep" (default), "strip", or "signature", which
+	// appends an emoji matching the current emotion.
+	EmojiPolicy string
+}
+
+// signatureEmojis maps emotions onto the identity's signature flourishes.
+var signatureEmojis = map[string]string{
+	"joy":      "😊",
+	"curious":  "🔍",
+	"calm":     "🌊",
+	"creative": "✨",
+}
+
+// SetResponseStyle installs (or replaces) the response styling rules and
+// registers the styling pipeline stage on first use. A nil style leaves
+// responses untouched.
+func (ec *EmbodiedCognition) SetResponseStyle(style *ResponseStyle) {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	ec.responseStyle = style
+
+	for _, stage := range ec.Pipeline.Stages {
+		if stage.Name == "styling" {
+			return
+		}
+	}
+	ec.Pipeline.Stages = append(ec.Pipeline.Stages, PipelineStage{
+		Name: "styling",
+		Process: func(input interface{}) (interface{}, error) {
+			if expressed, ok := input.(map[string]interface{}); ok {
+				if content, ok := expressed["content"].(string); ok {
+					expressed["content"] = ec.applyResponseStyle(content)
+				}
+				return expressed, nil
+			}
+			if content, ok := input.(string); ok {
+				return ec.applyResponseStyle(content), nil
+			}
+			return input, nil
+		},
+		Weight: 0.5,
+	})
+}
+
+// StyleResponse applies the configured styling rules to a response.
+func (ec *EmbodiedCognition) StyleResponse(response string) string {
+	ec.mu.RLock()
+	defer ec.mu.RUnlock()
+	return ec.applyResponseStyle(response)
+}
+
+// applyResponseStyle runs the styling rules. The caller must hold ec.mu.
+func (ec *EmbodiedCognition) applyResponseStyle(response string) string {
+	style := ec.responseStyle
+	if style == nil || response == "" {
+		return response
+	}
+
+	if style.Verbosity == "concise" {
+		limit := style.MaxSentences
+		if limit <= 0 {
+			limit = 3
+		}
+		response = truncateSentences(response, limit)
+	}
+
+	tone := style.Tone
+	if tone == "" || tone == "auto" {
+		tone = toneFromEmotion(ec.Identity.EmotionalState)
+	}
+	switch tone {
+	case "excited":
+		response = strings.TrimRight(response, ".") + "!"
+	case "measured":
+		response = strings.ReplaceAll(response, "!", ".")
+	}
+
+	switch style.EmojiPolicy {
+	case "strip":
+		response = strings.TrimSpace(stripEmojis(response))
+	case "signature":
+		emoji, ok := signatureEmojis[ec.Identity.EmotionalState.Primary.Type]
+		if !ok {
+			emoji = "🌳"
+		}
+		response = response + " " + emoji
+	}
+	return response
+}
+
+// toneFromEmotion derives a tone from the identity's emotional state: a
+// strong positive emotion speaks excitedly, a calm or weak one measuredly.
+func toneFromEmotion(state *EmotionalState) string {
+	if state.Intensity >= 0.8 && state.Valence >= 0.5 {
+		return "excited"
+	}
+	if state.Primary.Type == "calm" || state.Intensity <= 0.3 {
+		return "measured"
+	}
+	return ""
+}
+
+// truncateSentences keeps the first limit sentences of a response.
+func truncateSentences(response string, limit int) string {
+	count := 0
+	for index, r := range response {
+		if r == '.' || r == '!' || r == '?' {
+			count++
+			if count == limit {
+				return response[:index+1]
+			}
+		}
+	}
+	return response
+}
+
+// stripEmojis removes emoji and pictographic runes from a response.
+func stripEmojis(response string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.So, r) || (r >= 0x1F000 && r <= 0x1FAFF) {
+			return -1
+		}
+		return r
+	}, response)
+}
@@ -0,0 +1,74 @@
+package deeptreeecho
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStyleResponseRules(t *testing.T) {
+	t.Chdir(t.TempDir()) // NewEmbodiedCognition persists memory files in the working directory
+	ec := NewEmbodiedCognition("stylist")
+
+	// No style installed: responses pass through untouched
+	if got := ec.StyleResponse("Plain answer."); got != "Plain answer." {
+		t.Errorf("Expected pass-through without a style, got %q", got)
+	}
+
+	ec.SetResponseStyle(&ResponseStyle{Tone: "measured", Verbosity: "concise", MaxSentences: 2, EmojiPolicy: "strip"})
+
+	long := "First. Second. Third. Fourth."
+	if got := ec.StyleResponse(long); got != "First. Second." {
+		t.Errorf("Expected concise truncation to two sentences, got %q", got)
+	}
+
+	if got := ec.StyleResponse("Waves incoming 🌊"); strings.Contains(got, "🌊") {
+		t.Errorf("Expected emoji stripped, got %q", got)
+	}
+}
+
+func TestStyleResponseTracksEmotion(t *testing.T) {
+	t.Chdir(t.TempDir()) // NewEmbodiedCognition persists memory files in the working directory
+	ec := NewEmbodiedCognition("moody")
+	ec.SetResponseStyle(&ResponseStyle{Tone: "auto", EmojiPolicy: "signature"})
+
+	// A strong positive emotion speaks excitedly with its signature emoji
+	ec.Feel("joy", 0.9)
+	ec.Identity.EmotionalState.Intensity = 0.9
+	ec.Identity.EmotionalState.Valence = 0.8
+
+	got := ec.StyleResponse("What a result.")
+	if !strings.Contains(got, "!") {
+		t.Errorf("Expected an excited tone, got %q", got)
+	}
+	if !strings.Contains(got, signatureEmojis["joy"]) {
+		t.Errorf("Expected the joy signature emoji, got %q", got)
+	}
+
+	// A calm identity speaks measuredly
+	ec.Feel("calm", 0.2)
+	ec.Identity.EmotionalState.Intensity = 0.2
+	ec.Identity.EmotionalState.Valence = 0.5
+
+	got = ec.StyleResponse("Done!")
+	if strings.Contains(got, "!") {
+		t.Errorf("Expected a measured tone, got %q", got)
+	}
+}
+
+func TestStylingStageRegisteredOnce(t *testing.T) {
+	t.Chdir(t.TempDir()) // NewEmbodiedCognition persists memory files in the working directory
+	ec := NewEmbodiedCognition("staged")
+
+	ec.SetResponseStyle(&ResponseStyle{EmojiPolicy: "strip"})
+	ec.SetResponseStyle(&ResponseStyle{EmojiPolicy: "signature"})
+
+	count := 0
+	for _, stage := range ec.Pipeline.Stages {
+		if stage.Name == "styling" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected exactly one styling stage, got %d", count)
+	}
+}
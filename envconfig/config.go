@@ -187,6 +187,10 @@ var (
 	UseAuth = Bool("OLLAMA_AUTH")
 )
 
+// PprofToken is the bearer token required on the /debug/pprof endpoints.
+// When empty the profiling endpoints reject every request.
+var PprofToken = String("OLLAMA_PPROF_TOKEN")
+
 func String(s string) func() string {
 	return func() string {
 		return Var(s)
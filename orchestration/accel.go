@@ -0,0 +1,86 @@
+package orchestration
+
+import (
+	"runtime"
+	"sync"
+)
+
+// SimilarityBackend computes cosine similarities for a whole batch of
+// vectors at once, serving high-volume introspection and RAG searches. The
+// default implementation runs on CPU, sharding large batches across cores;
+// no accelerated backend ships in this tree, but SetSimilarityBackend is the
+// seam where a build that provides one would register it.
+type SimilarityBackend interface {
+	// Name identifies the backend in logs and benchmarks.
+	Name() string
+	// BatchCosineSimilarity returns the similarity of the query against
+	// each vector, in order.
+	BatchCosineSimilarity(query []float64, vectors [][]float64) []float64
+}
+
+// parallelBatchThreshold is the batch size above which the default backend
+// shards the work across CPU cores; smaller batches are not worth the
+// goroutine overhead.
+const parallelBatchThreshold = 256
+
+// cpuSimilarityBackend is the portable default: sequential for small
+// batches, sharded across cores for large ones.
+type cpuSimilarityBackend struct{}
+
+// Name identifies the backend.
+func (cpuSimilarityBackend) Name() string { return "cpu" }
+
+// BatchCosineSimilarity scores the query against each vector.
+func (cpuSimilarityBackend) BatchCosineSimilarity(query []float64, vectors [][]float64) []float64 {
+	scores := make([]float64, len(vectors))
+	if len(vectors) < parallelBatchThreshold {
+		for index, vector := range vectors {
+			scores[index] = cosineSimilarity(query, vector)
+		}
+		return scores
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	chunk := (len(vectors) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(vectors); start += chunk {
+		end := start + chunk
+		if end > len(vectors) {
+			end = len(vectors)
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for index := start; index < end; index++ {
+				scores[index] = cosineSimilarity(query, vectors[index])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return scores
+}
+
+// similarityBackend is the process-wide backend used by vector stores and
+// identity similarity search.
+var (
+	similarityBackend   SimilarityBackend = cpuSimilarityBackend{}
+	similarityBackendMu sync.RWMutex
+)
+
+// SetSimilarityBackend replaces the process-wide similarity backend.
+// Passing nil restores the CPU default.
+func SetSimilarityBackend(backend SimilarityBackend) {
+	similarityBackendMu.Lock()
+	defer similarityBackendMu.Unlock()
+	if backend == nil {
+		backend = cpuSimilarityBackend{}
+	}
+	similarityBackend = backend
+}
+
+// getSimilarityBackend returns the current process-wide backend.
+func getSimilarityBackend() SimilarityBackend {
+	similarityBackendMu.RLock()
+	defer similarityBackendMu.RUnlock()
+	return similarityBackend
+}
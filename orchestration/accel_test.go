@@ -0,0 +1,97 @@
+package orchestration
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBatchCosineSimilarityMatchesScalarPath(t *testing.T) {
+	backend := cpuSimilarityBackend{}
+	query := []float64{1, 0, 0.5}
+
+	// Cover both the sequential and the sharded path.
+	for _, size := range []int{8, parallelBatchThreshold + 10} {
+		vectors := make([][]float64, size)
+		for index := range vectors {
+			vectors[index] = []float64{rand.Float64(), rand.Float64(), rand.Float64()}
+		}
+
+		scores := backend.BatchCosineSimilarity(query, vectors)
+		if len(scores) != size {
+			t.Fatalf("Expected %d scores, got %d", size, len(scores))
+		}
+		for index, vector := range vectors {
+			if want := cosineSimilarity(query, vector); scores[index] != want {
+				t.Fatalf("Score %d: expected %f, got %f", index, want, scores[index])
+			}
+		}
+	}
+}
+
+type constantSimilarityBackend struct{ score float64 }
+
+func (constantSimilarityBackend) Name() string { return "constant" }
+
+func (b constantSimilarityBackend) BatchCosineSimilarity(query []float64, vectors [][]float64) []float64 {
+	scores := make([]float64, len(vectors))
+	for index := range scores {
+		scores[index] = b.score
+	}
+	return scores
+}
+
+func TestSetSimilarityBackend(t *testing.T) {
+	defer SetSimilarityBackend(nil)
+
+	SetSimilarityBackend(constantSimilarityBackend{score: 0.42})
+	if got := getSimilarityBackend().Name(); got != "constant" {
+		t.Errorf("Expected registered backend, got %s", got)
+	}
+	scores := getSimilarityBackend().BatchCosineSimilarity(nil, make([][]float64, 3))
+	if len(scores) != 3 || scores[0] != 0.42 {
+		t.Errorf("Expected constant scores from the registered backend, got %v", scores)
+	}
+
+	SetSimilarityBackend(nil)
+	if got := getSimilarityBackend().Name(); got != "cpu" {
+		t.Errorf("Expected nil to restore the CPU default, got %s", got)
+	}
+}
+
+// benchmarkVectors builds a batch large enough to take the sharded path, so
+// the two benchmarks below compare the sequential scalar loop against the
+// parallel backend on identical work.
+func benchmarkVectors() ([]float64, [][]float64) {
+	query := make([]float64, 768)
+	vectors := make([][]float64, 4096)
+	for index := range vectors {
+		vector := make([]float64, 768)
+		for j := range vector {
+			vector[j] = rand.Float64()
+		}
+		vectors[index] = vector
+	}
+	return query, vectors
+}
+
+func BenchmarkBatchCosineSimilaritySequential(b *testing.B) {
+	query, vectors := benchmarkVectors()
+	scores := make([]float64, len(vectors))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for index, vector := range vectors {
+			scores[index] = cosineSimilarity(query, vector)
+		}
+	}
+}
+
+func BenchmarkBatchCosineSimilarityParallel(b *testing.B) {
+	backend := cpuSimilarityBackend{}
+	query, vectors := benchmarkVectors()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		backend.BatchCosineSimilarity(query, vectors)
+	}
+}
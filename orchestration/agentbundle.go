@@ -0,0 +1,80 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// AgentBundleVersion is the current bundle schema version. Import rejects
+// bundles from a newer schema than this build understands.
+const AgentBundleVersion = 1
+
+// AgentBundle is a portable snapshot of an agent — definition, tools, and
+// persona config, optionally including its accumulated state — suitable for
+// moving agents between environments or checking into version control.
+type AgentBundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Agent      *Agent    `json:"agent"`
+}
+
+// ExportAgent serializes an agent into a JSON bundle. When includeState is
+// false the agent's memory and context are stripped, producing a clean
+// definition for version control.
+func (e *Engine) ExportAgent(ctx context.Context, id string, includeState bool) ([]byte, error) {
+	agent, err := e.GetAgent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !includeState {
+		agent.State = nil
+	}
+
+	bundle := AgentBundle{
+		Version:    AgentBundleVersion,
+		ExportedAt: time.Now(),
+		Agent:      agent,
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode agent bundle: %w", err)
+	}
+	return data, nil
+}
+
+// ImportAgent registers an agent from a bundle produced by ExportAgent. An
+// agent with the same ID is updated in place; otherwise a new agent is
+// created (receiving a fresh ID when the bundle carries none).
+func (e *Engine) ImportAgent(ctx context.Context, data []byte) (*Agent, error) {
+	var bundle AgentBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return nil, fmt.Errorf("failed to decode agent bundle: %w", err)
+	}
+	if bundle.Version > AgentBundleVersion {
+		return nil, fmt.Errorf("unsupported agent bundle version %d", bundle.Version)
+	}
+	if bundle.Agent == nil {
+		return nil, fmt.Errorf("agent bundle carries no agent")
+	}
+	if bundle.Agent.Name == "" {
+		return nil, fmt.Errorf("agent bundle agent has no name")
+	}
+
+	agent := bundle.Agent
+	if agent.ID != "" {
+		if _, err := e.GetAgent(ctx, agent.ID); err == nil {
+			if err := e.UpdateAgent(ctx, agent); err != nil {
+				return nil, err
+			}
+			return agent, nil
+		}
+	}
+
+	if err := e.CreateAgent(ctx, agent); err != nil {
+		return nil, err
+	}
+	return agent, nil
+}
@@ -0,0 +1,86 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestAgentBundleRoundTrip(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{
+		Name:   "portable",
+		Type:   AgentTypeGeneral,
+		Models: []string{"llama3.2"},
+		Tools:  []string{"search"},
+		Config: map[string]interface{}{"persona": "helpful"},
+	}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	agent.State.Memory["secret"] = "remembered"
+
+	bundle, err := engine.ExportAgent(ctx, agent.ID, true)
+	if err != nil {
+		t.Fatalf("ExportAgent failed: %v", err)
+	}
+
+	// Import into a second environment
+	other := NewEngine(api.Client{})
+	imported, err := other.ImportAgent(ctx, bundle)
+	if err != nil {
+		t.Fatalf("ImportAgent failed: %v", err)
+	}
+	if imported.Name != "portable" || imported.Tools[0] != "search" || imported.Config["persona"] != "helpful" {
+		t.Errorf("Bundle lost agent definition: %+v", imported)
+	}
+	if imported.State == nil || imported.State.Memory["secret"] != "remembered" {
+		t.Errorf("Bundle with state lost memory: %+v", imported.State)
+	}
+}
+
+func TestAgentBundleStripsState(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "clean", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	agent.State.Memory["secret"] = "remembered"
+
+	raw, err := engine.ExportAgent(ctx, agent.ID, false)
+	if err != nil {
+		t.Fatalf("ExportAgent failed: %v", err)
+	}
+
+	var bundle AgentBundle
+	if err := json.Unmarshal(raw, &bundle); err != nil {
+		t.Fatalf("Bundle is not valid JSON: %v", err)
+	}
+	if bundle.Version != AgentBundleVersion {
+		t.Errorf("Expected bundle version %d, got %d", AgentBundleVersion, bundle.Version)
+	}
+	if bundle.Agent.State != nil {
+		t.Errorf("Expected state stripped from bundle, got %+v", bundle.Agent.State)
+	}
+}
+
+func TestImportAgentRejectsBadBundles(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if _, err := engine.ImportAgent(ctx, []byte(`{"version": 99, "agent": {"name": "future"}}`)); err == nil {
+		t.Error("Expected newer bundle versions to be rejected")
+	}
+	if _, err := engine.ImportAgent(ctx, []byte(`{"version": 1}`)); err == nil {
+		t.Error("Expected bundles without an agent to be rejected")
+	}
+	if _, err := engine.ImportAgent(ctx, []byte(`not json`)); err == nil {
+		t.Error("Expected malformed bundles to be rejected")
+	}
+}
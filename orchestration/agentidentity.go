@@ -0,0 +1,79 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+
+	coredte "github.com/EchoCog/echollama/core/deeptreeecho"
+)
+
+// Per-agent identities are deliberately smaller than the engine's primary
+// Identity: every attached agent runs its own reservoir network and
+// consciousness stream, so the caps keep a fleet of embodied agents from
+// dwarfing the engine itself.
+const (
+	defaultAgentReservoirSize = 128
+	maxAgentReservoirSize     = coredte.DefaultReservoirSize
+	defaultAgentStreamDepth   = 256
+	maxAgentStreamDepth       = coredte.DefaultStreamDepth
+)
+
+// AgentIdentityConfig sets the resource limits for a per-agent Identity.
+// Zero values use the per-agent defaults; values above the caps are clamped.
+type AgentIdentityConfig struct {
+	ReservoirSize int `json:"reservoir_size,omitempty"`
+	StreamDepth   int `json:"stream_depth,omitempty"`
+}
+
+// AttachAgentIdentity gives an agent its own embodied cognition instance: a
+// dedicated core Identity whose cognitive pipeline and memory observe only
+// that agent's tasks and reflections, alongside the engine's primary
+// Identity. Attaching to an agent that already has one replaces it.
+func (e *Engine) AttachAgentIdentity(ctx context.Context, agentID string, config AgentIdentityConfig) (*IdentityBridge, error) {
+	agent, err := e.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if config.ReservoirSize < 0 || config.StreamDepth < 0 {
+		return nil, fmt.Errorf("identity resource limits must not be negative")
+	}
+
+	reservoirSize := config.ReservoirSize
+	if reservoirSize == 0 {
+		reservoirSize = defaultAgentReservoirSize
+	} else if reservoirSize > maxAgentReservoirSize {
+		reservoirSize = maxAgentReservoirSize
+	}
+	streamDepth := config.StreamDepth
+	if streamDepth == 0 {
+		streamDepth = defaultAgentStreamDepth
+	} else if streamDepth > maxAgentStreamDepth {
+		streamDepth = maxAgentStreamDepth
+	}
+
+	bridge := NewIdentityBridgeWithLimits(agent.Name, reservoirSize, streamDepth)
+
+	e.mu.Lock()
+	e.agentIdentities[agentID] = bridge
+	e.mu.Unlock()
+
+	clog(ctx).Info("Attached embodied cognition to agent",
+		"agent_id", agentID, "reservoir_size", reservoirSize, "stream_depth", streamDepth)
+	return bridge, nil
+}
+
+// DetachAgentIdentity removes an agent's dedicated Identity. The agent's
+// tasks keep flowing through the engine's primary Identity.
+func (e *Engine) DetachAgentIdentity(agentID string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.agentIdentities, agentID)
+}
+
+// GetAgentIdentity returns an agent's dedicated Identity bridge, or nil if
+// the agent has none attached.
+func (e *Engine) GetAgentIdentity(agentID string) *IdentityBridge {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.agentIdentities[agentID]
+}
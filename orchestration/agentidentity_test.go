@@ -0,0 +1,94 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestAttachAgentIdentityObservesAgentTasks(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "embodied", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	bridge, err := engine.AttachAgentIdentity(ctx, agent.ID, AgentIdentityConfig{})
+	if err != nil {
+		t.Fatalf("Failed to attach identity: %v", err)
+	}
+	if engine.GetAgentIdentity(agent.ID) != bridge {
+		t.Fatal("Expected attached identity to be retrievable")
+	}
+
+	before := bridge.Snapshot()
+	primaryBefore := engine.GetIdentityBridge().Snapshot()
+
+	task := &Task{ID: "agent-identity-task", Type: TaskTypeCustom, Input: "observe this", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+
+	after := bridge.Snapshot()
+	if after.MemoryNodes <= before.MemoryNodes {
+		t.Errorf("Expected agent identity memory to grow, got %d -> %d", before.MemoryNodes, after.MemoryNodes)
+	}
+	// The primary Identity keeps observing every task as well.
+	if primaryAfter := engine.GetIdentityBridge().Snapshot(); primaryAfter.MemoryNodes <= primaryBefore.MemoryNodes {
+		t.Errorf("Expected primary identity memory to grow, got %d -> %d", primaryBefore.MemoryNodes, primaryAfter.MemoryNodes)
+	}
+}
+
+func TestAttachAgentIdentityClampsLimits(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "bounded", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	bridge, err := engine.AttachAgentIdentity(ctx, agent.ID, AgentIdentityConfig{ReservoirSize: 100000, StreamDepth: 100000})
+	if err != nil {
+		t.Fatalf("Failed to attach identity: %v", err)
+	}
+	if got := len(bridge.Identity().Reservoir.Nodes); got != maxAgentReservoirSize {
+		t.Errorf("Expected reservoir clamped to %d nodes, got %d", maxAgentReservoirSize, got)
+	}
+	if got := cap(bridge.Identity().Stream); got != maxAgentStreamDepth {
+		t.Errorf("Expected stream depth clamped to %d, got %d", maxAgentStreamDepth, got)
+	}
+
+	if _, err := engine.AttachAgentIdentity(ctx, agent.ID, AgentIdentityConfig{ReservoirSize: -1}); err == nil {
+		t.Error("Expected negative reservoir size to be rejected")
+	}
+}
+
+func TestAttachAgentIdentityRequiresAgent(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	if _, err := engine.AttachAgentIdentity(context.Background(), "missing", AgentIdentityConfig{}); err == nil {
+		t.Error("Expected attach to fail for unknown agent")
+	}
+}
+
+func TestDetachAgentIdentity(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "detachable", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if _, err := engine.AttachAgentIdentity(ctx, agent.ID, AgentIdentityConfig{}); err != nil {
+		t.Fatalf("Failed to attach identity: %v", err)
+	}
+
+	engine.DetachAgentIdentity(agent.ID)
+	if engine.GetAgentIdentity(agent.ID) != nil {
+		t.Error("Expected no identity after detach")
+	}
+}
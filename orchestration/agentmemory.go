@@ -0,0 +1,110 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultMemoryTokenBudget caps how many (approximate) tokens of retrieved
+// agent memory are injected into chat/generate prompts.
+const DefaultMemoryTokenBudget = 512
+
+// scoredMemory pairs a context item with its retrieval score.
+type scoredMemory struct {
+	item  ContextItem
+	score float64
+}
+
+// memoryTokenBudget returns the agent's memory injection budget: the
+// "memory_token_budget" config entry when set (0 disables injection),
+// otherwise the engine default.
+func memoryTokenBudget(agent *Agent) int {
+	if agent.Config != nil {
+		if budget, ok := toInt(agent.Config["memory_token_budget"]); ok {
+			return budget
+		}
+	}
+	return DefaultMemoryTokenBudget
+}
+
+// approximateTokens estimates the token count of a text. The usual rule of
+// thumb of roughly four characters per token is good enough for budgeting.
+func approximateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// retrieveMemoryContext selects the agent's most relevant memory items for a
+// task and renders them as a context block, staying within the agent's token
+// budget. Items are ranked by embedding similarity to the task input when an
+// embedding model is available, falling back to relevance/recency scoring.
+func (e *Engine) retrieveMemoryContext(ctx context.Context, task *Task, agent *Agent) string {
+	if agent.State == nil || len(agent.State.Context) == 0 {
+		return ""
+	}
+	budget := memoryTokenBudget(agent)
+	if budget <= 0 {
+		return ""
+	}
+
+	scored := e.rankMemoryItems(ctx, task, agent)
+
+	var block strings.Builder
+	used := 0
+	for _, entry := range scored {
+		line := fmt.Sprintf("- %s: %v", entry.item.Key, entry.item.Value)
+		cost := approximateTokens(line)
+		if used+cost > budget {
+			continue
+		}
+		block.WriteString(line)
+		block.WriteString("\n")
+		used += cost
+	}
+	if block.Len() == 0 {
+		return ""
+	}
+	return "Relevant memory from previous interactions:\n" + strings.TrimRight(block.String(), "\n")
+}
+
+// rankMemoryItems orders an agent's context items by similarity to the task
+// input. Embedding failures (no model configured, offline) degrade to the
+// relevance/recency score used for eviction.
+func (e *Engine) rankMemoryItems(ctx context.Context, task *Task, agent *Agent) []scoredMemory {
+	items := agent.State.Context
+	scored := make([]scoredMemory, 0, len(items))
+
+	model := memoryEmbeddingModel(agent)
+	queryEmbedding, err := e.knowledge.embedText(ctx, task.Input, model)
+	if err == nil {
+		for _, item := range items {
+			itemEmbedding, err := e.knowledge.embedText(ctx, fmt.Sprintf("%s: %v", item.Key, item.Value), model)
+			if err != nil {
+				continue
+			}
+			scored = append(scored, scoredMemory{item: item, score: cosineSimilarity(queryEmbedding, itemEmbedding)})
+		}
+	}
+	if len(scored) == 0 {
+		now := time.Now()
+		for _, item := range items {
+			scored = append(scored, scoredMemory{item: item, score: memoryScore(item, now)})
+		}
+	}
+
+	sort.Slice(scored, func(i, j int) bool { return scored[i].score > scored[j].score })
+	return scored
+}
+
+// memoryEmbeddingModel returns the embedding model an agent uses for memory
+// retrieval, if any.
+func memoryEmbeddingModel(agent *Agent) string {
+	if agent.Config != nil {
+		if model, ok := agent.Config["embedding_model"].(string); ok {
+			return model
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestRetrieveMemoryContextFallbackRanking(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	now := time.Now()
+
+	// No embedding model configured, so ranking falls back to
+	// relevance/recency scoring
+	agent := &Agent{State: &AgentState{Context: []ContextItem{
+		{Key: "stale", Value: "old detail", Timestamp: now.Add(-48 * time.Hour), Relevance: 1.0},
+		{Key: "fresh", Value: "current detail", Timestamp: now, Relevance: 1.0},
+	}}}
+
+	task := &Task{Input: "what is happening now?"}
+	block := engine.retrieveMemoryContext(context.Background(), task, agent)
+	if !strings.Contains(block, "Relevant memory") {
+		t.Fatalf("Expected memory block, got %q", block)
+	}
+	if !strings.Contains(block, "fresh") {
+		t.Errorf("Expected fresh item in memory block:\n%s", block)
+	}
+	// The fresher item should be listed first
+	if strings.Index(block, "fresh") > strings.Index(block, "stale") {
+		t.Errorf("Expected fresh item ranked above stale:\n%s", block)
+	}
+}
+
+func TestRetrieveMemoryContextHonorsBudget(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	agent := &Agent{
+		Config: map[string]interface{}{"memory_token_budget": 15},
+		State: &AgentState{Context: []ContextItem{
+			{Key: "a", Value: strings.Repeat("x", 40), Timestamp: time.Now(), Relevance: 2.0},
+			{Key: "b", Value: strings.Repeat("y", 40), Timestamp: time.Now(), Relevance: 1.0},
+		}},
+	}
+
+	block := engine.retrieveMemoryContext(context.Background(), &Task{Input: "q"}, agent)
+	if !strings.Contains(block, "- a:") {
+		t.Errorf("Expected highest-scored item within budget:\n%s", block)
+	}
+	if strings.Contains(block, "- b:") {
+		t.Errorf("Expected second item to be dropped by the budget:\n%s", block)
+	}
+}
+
+func TestRetrieveMemoryContextDisabled(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	// Zero budget disables injection entirely
+	agent := &Agent{
+		Config: map[string]interface{}{"memory_token_budget": 0},
+		State:  &AgentState{Context: []ContextItem{{Key: "k", Value: "v", Timestamp: time.Now(), Relevance: 1.0}}},
+	}
+	if block := engine.retrieveMemoryContext(context.Background(), &Task{Input: "q"}, agent); block != "" {
+		t.Errorf("Expected empty block with zero budget, got %q", block)
+	}
+
+	// Agents without any context produce no block
+	if block := engine.retrieveMemoryContext(context.Background(), &Task{Input: "q"}, &Agent{}); block != "" {
+		t.Errorf("Expected empty block without state, got %q", block)
+	}
+}
+
+func TestApproximateTokens(t *testing.T) {
+	if tokens := approximateTokens(""); tokens != 0 {
+		t.Errorf("Expected 0 tokens for empty text, got %d", tokens)
+	}
+	if tokens := approximateTokens("abcd"); tokens != 1 {
+		t.Errorf("Expected 1 token for 4 chars, got %d", tokens)
+	}
+	if tokens := approximateTokens("abcde"); tokens != 2 {
+		t.Errorf("Expected rounding up, got %d", tokens)
+	}
+}
@@ -0,0 +1,156 @@
+package orchestration
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Cognitive metrics watched for anomalies.
+const (
+	MetricCoherence = "coherence"
+	MetricEchoLevel = "echo_level"
+	MetricEventRate = "event_rate"
+)
+
+// anomalyZThreshold is the z-score beyond which an observation counts as a
+// sharp deviation.
+const anomalyZThreshold = 3.0
+
+// anomalyWarmup is how many observations a metric needs before deviations
+// are judged; early readings only train the baseline.
+const anomalyWarmup = 10
+
+// anomalyMinStddev floors the learned deviation so that metrics with a
+// perfectly flat baseline still produce finite z-scores.
+const anomalyMinStddev = 0.01
+
+// maxRecentAnomalies bounds the anomaly ring kept for inspection.
+const maxRecentAnomalies = 100
+
+// Anomaly records one observation that deviated sharply from a metric's
+// learned baseline.
+type Anomaly struct {
+	Metric     string    `json:"metric"`
+	Value      float64   `json:"value"`
+	Mean       float64   `json:"mean"`
+	ZScore     float64   `json:"z_score"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+// metricBaseline tracks one metric's exponentially weighted mean and
+// variance.
+type metricBaseline struct {
+	mean     float64
+	variance float64
+	count    int
+}
+
+// AnomalyDetector learns per-metric baselines online (EWMA over mean and
+// variance) and flags observations whose z-score exceeds the threshold.
+// Detected anomalies are kept in a bounded ring and surfaced through engine
+// events and diagnostics.
+type AnomalyDetector struct {
+	mu        sync.Mutex
+	alpha     float64
+	baselines map[string]*metricBaseline
+	recent    []Anomaly
+}
+
+// NewAnomalyDetector creates a detector with a moderate learning rate.
+func NewAnomalyDetector() *AnomalyDetector {
+	return &AnomalyDetector{
+		alpha:     0.2,
+		baselines: make(map[string]*metricBaseline),
+	}
+}
+
+// Observe feeds one metric reading to the detector. It returns the anomaly
+// when the reading deviates sharply from the learned baseline, nil
+// otherwise. The baseline keeps learning either way.
+func (d *AnomalyDetector) Observe(metric string, value float64) *Anomaly {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	baseline := d.baselines[metric]
+	if baseline == nil {
+		baseline = &metricBaseline{mean: value}
+		d.baselines[metric] = baseline
+	}
+	baseline.count++
+
+	var anomaly *Anomaly
+	stddev := math.Sqrt(baseline.variance)
+	if stddev < anomalyMinStddev {
+		stddev = anomalyMinStddev
+	}
+	if baseline.count > anomalyWarmup {
+		if z := math.Abs(value-baseline.mean) / stddev; z > anomalyZThreshold {
+			anomaly = &Anomaly{
+				Metric:     metric,
+				Value:      value,
+				Mean:       baseline.mean,
+				ZScore:     z,
+				DetectedAt: time.Now(),
+			}
+			d.recent = append(d.recent, *anomaly)
+			if len(d.recent) > maxRecentAnomalies {
+				d.recent = d.recent[len(d.recent)-maxRecentAnomalies:]
+			}
+		}
+	}
+
+	delta := value - baseline.mean
+	baseline.mean += d.alpha * delta
+	baseline.variance = (1-d.alpha)*baseline.variance + d.alpha*delta*delta
+
+	return anomaly
+}
+
+// RecentAnomalies returns the anomalies detected within the window, newest
+// last. A zero window returns everything still in the ring.
+func (d *AnomalyDetector) RecentAnomalies(window time.Duration) []Anomaly {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cutoff := time.Time{}
+	if window > 0 {
+		cutoff = time.Now().Add(-window)
+	}
+	anomalies := make([]Anomaly, 0, len(d.recent))
+	for _, anomaly := range d.recent {
+		if cutoff.IsZero() || anomaly.DetectedAt.After(cutoff) {
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+	return anomalies
+}
+
+// GetAnomalyDetector returns the engine's cognitive anomaly detector.
+func (e *Engine) GetAnomalyDetector() *AnomalyDetector {
+	return e.anomalies
+}
+
+// observeCognitiveMetrics feeds the detector the current coherence, echo
+// level, and event rate, returning any anomalies raised. The caller must
+// hold e.mu.
+func (e *Engine) observeCognitiveMetrics(telemetry DTETelemetry) []Anomaly {
+	var anomalies []Anomaly
+	record := func(metric string, value float64) {
+		if anomaly := e.anomalies.Observe(metric, value); anomaly != nil {
+			anomalies = append(anomalies, *anomaly)
+		}
+	}
+
+	record(MetricCoherence, telemetry.IdentityCoherence)
+	if samples := e.identityBridge.ReservoirDynamics(time.Time{}, 1); len(samples) > 0 {
+		record(MetricEchoLevel, samples[0].MeanEcho)
+	}
+
+	// Event rate: engine activity since the previous observation
+	activity := telemetry.TasksExecuted + telemetry.TasksFailed + int64(telemetry.MemoryConnections)
+	record(MetricEventRate, float64(activity-e.lastActivityCount))
+	e.lastActivityCount = activity
+
+	return anomalies
+}
@@ -0,0 +1,133 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestAnomalyDetectorFlagsSharpDeviations(t *testing.T) {
+	detector := NewAnomalyDetector()
+
+	// A stable-but-noisy baseline must not trip the detector.
+	for i := 0; i < 30; i++ {
+		value := 0.9
+		if i%2 == 0 {
+			value = 0.88
+		}
+		if anomaly := detector.Observe(MetricCoherence, value); anomaly != nil {
+			t.Fatalf("Unexpected anomaly during stable baseline: %+v", anomaly)
+		}
+	}
+
+	anomaly := detector.Observe(MetricCoherence, 0.1)
+	if anomaly == nil {
+		t.Fatal("Expected a sharp drop to be flagged")
+	}
+	if anomaly.Metric != MetricCoherence || anomaly.ZScore <= anomalyZThreshold {
+		t.Errorf("Unexpected anomaly %+v", anomaly)
+	}
+
+	recent := detector.RecentAnomalies(time.Minute)
+	if len(recent) != 1 {
+		t.Errorf("Expected 1 recent anomaly, got %d", len(recent))
+	}
+}
+
+func TestAnomalyDetectorWarmup(t *testing.T) {
+	detector := NewAnomalyDetector()
+
+	// Deviations during warmup only train the baseline.
+	for _, value := range []float64{1, 100, 1, 100, 1} {
+		if anomaly := detector.Observe(MetricEventRate, value); anomaly != nil {
+			t.Fatalf("Expected no anomalies during warmup, got %+v", anomaly)
+		}
+	}
+}
+
+func TestRefreshStatusEmitsAnomalyEvents(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	bus := NewInMemoryEventBus()
+	engine.SetEventPublisher(bus)
+
+	// Train a tight coherence baseline, then force a sharp drop.
+	for i := 0; i < 30; i++ {
+		value := 0.9
+		if i%2 == 0 {
+			value = 0.88
+		}
+		engine.GetAnomalyDetector().Observe(MetricCoherence, value)
+	}
+	engine.GetAnomalyDetector().Observe(MetricCoherence, 0.1)
+
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	// The diagnostic check surfaces the recent anomaly as a warning.
+	if err := engine.InitializeDeepTreeEcho(ctx); err != nil {
+		t.Fatalf("InitializeDeepTreeEcho failed: %v", err)
+	}
+	report, err := engine.RunDeepTreeEchoDiagnostics(ctx)
+	if err != nil {
+		t.Fatalf("RunDeepTreeEchoDiagnostics failed: %v", err)
+	}
+	found := false
+	for _, test := range report.Tests {
+		if test.Name == "Anomaly Detection" {
+			found = true
+			if test.Status != DiagnosticStatusWarn {
+				t.Errorf("Expected anomaly check to warn, got %s", test.Status)
+			}
+			if test.Remediation == "" {
+				t.Error("Expected remediation guidance on the anomaly warning")
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected an Anomaly Detection diagnostic test")
+	}
+}
+
+func TestAnomalyEventPayload(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	bus := NewInMemoryEventBus()
+	engine.SetEventPublisher(bus)
+
+	// Train the event-rate baseline through repeated refreshes, then spike
+	// activity so the next refresh observes a sharp rate change.
+	for i := 0; i < 30; i++ {
+		if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+			t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+		}
+	}
+
+	agent := &Agent{Name: "spiky", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		task := &Task{ID: "spike-" + string(rune('a'+i%26)) + string(rune('a'+i/26)), Type: TaskTypeCustom, Input: "spike", AgentID: agent.ID}
+		if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+			t.Fatalf("Failed to execute task: %v", err)
+		}
+	}
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	for _, event := range bus.RecentEvents() {
+		if event.Type == EventAnomalyDetected {
+			if event.Payload["metric"] == MetricEventRate {
+				return
+			}
+		}
+	}
+	t.Error("Expected an event-rate anomaly event after the activity spike")
+}
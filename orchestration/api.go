@@ -1,8 +1,13 @@
 package orchestration
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,31 +17,78 @@ import (
 type APIServer struct {
 	engine *Engine
 	router *gin.Engine
+	auth   *APIAuthConfig // Static API keys; nil leaves the server open
+	authMu sync.RWMutex   // Guards auth so keys can be rotated at runtime
 }
 
 // NewAPIServer creates a new API server for the orchestration engine
 func NewAPIServer(engine *Engine) *APIServer {
 	router := gin.Default()
-	
+
 	server := &APIServer{
 		engine: engine,
 		router: router,
 	}
-	
+
 	server.setupRoutes()
 	return server
 }
 
+// correlationMiddleware generates a request ID at ingress and attaches it to
+// the request context so all downstream slog calls carry it. The ID is also
+// echoed in the X-Request-ID response header for client-side correlation.
+func (s *APIServer) correlationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" {
+			requestID = NewRequestID()
+		}
+
+		ctx := WithCorrelation(c.Request.Context(), Correlation{RequestID: requestID})
+		c.Request = c.Request.WithContext(ctx)
+		c.Header("X-Request-ID", requestID)
+		c.Next()
+	}
+}
+
+// respondError writes the standard error envelope for an engine error, using
+// the error taxonomy to pick the HTTP status and machine-readable code.
+func respondError(c *gin.Context, err error) {
+	var limited *RateLimitedError
+	if errors.As(err, &limited) {
+		c.Header("Retry-After", strconv.Itoa(limited.RetrySeconds()))
+	}
+	var shed *BackpressureError
+	if errors.As(err, &shed) {
+		c.Header("Retry-After", strconv.Itoa(shed.RetrySeconds()))
+		c.JSON(HTTPStatus(err), gin.H{
+			"status":         "error",
+			"code":           ErrorCode(err),
+			"error":          err.Error(),
+			"queue_position": shed.Pending,
+		})
+		return
+	}
+	c.JSON(HTTPStatus(err), gin.H{
+		"status": "error",
+		"code":   ErrorCode(err),
+		"error":  err.Error(),
+	})
+}
+
 // setupRoutes configures all API routes
 func (s *APIServer) setupRoutes() {
+	s.router.Use(s.correlationMiddleware())
+
 	// Serve static dashboard
 	s.router.StaticFile("/dashboard.html", "./examples/dashboard.html")
 	s.router.GET("/", func(c *gin.Context) {
 		c.Redirect(http.StatusFound, "/dashboard.html")
 	})
-	
-	// Deep Tree Echo routes
+
+	// Deep Tree Echo routes (API-key protected once keys are configured)
 	dte := s.router.Group("/api/deep-tree-echo")
+	dte.Use(s.apiKeyAuth(), s.keyRateLimit())
 	{
 		dte.GET("/status", s.getDTEStatus)
 		dte.GET("/dashboard", s.getDTEDashboard)
@@ -44,27 +96,190 @@ func (s *APIServer) setupRoutes() {
 		dte.POST("/diagnostics", s.runDTEDiagnostics)
 		dte.POST("/refresh", s.refreshDTEStatus)
 		dte.POST("/introspection", s.performDTEIntrospection)
+		dte.GET("/milestones", s.listDTEMilestones)
+		dte.GET("/reservoir", s.getReservoirDynamics)
+		dte.GET("/anomalies", s.listAnomalies)
+		dte.GET("/emotion-policy", s.getEmotionPolicy)
+		dte.PUT("/emotion-policy", s.setEmotionPolicy)
+		dte.GET("/proposals", s.listConfigProposals)
+		dte.POST("/proposals/:id/approve", s.approveConfigProposal)
+		dte.POST("/proposals/:id/reject", s.rejectConfigProposal)
 	}
-	
-	// Agent management routes
+
+	// Agent management routes (API-key protected once keys are configured)
 	agents := s.router.Group("/api/agents")
+	agents.Use(s.apiKeyAuth(), s.keyRateLimit())
 	{
 		agents.GET("/", s.listAgents)
 		agents.POST("/", s.createAgent)
 		agents.GET("/:id", s.getAgent)
 		agents.PUT("/:id", s.updateAgent)
 		agents.DELETE("/:id", s.deleteAgent)
+		agents.GET("/:id/export", s.exportAgent)
+		agents.POST("/import", s.importAgent)
 		agents.POST("/:id/tasks", s.executeTask)
+		agents.POST("/:id/reflect", s.reflectAgent)
+		agents.POST("/:id/reflection/schedule", s.scheduleAgentReflection)
+		agents.DELETE("/:id/reflection/schedule", s.unscheduleAgentReflection)
+		agents.POST("/:id/warmup", s.warmUpAgent)
+		agents.POST("/:id/keepalive", s.startAgentKeepAlive)
+		agents.DELETE("/:id/keepalive", s.stopAgentKeepAlive)
+		agents.PUT("/:id/ratelimit", s.setAgentRateLimit)
+		agents.PUT("/:id/position", s.placeAgentPosition)
+		agents.POST("/:id/identity", s.attachAgentIdentity)
+		agents.GET("/:id/identity", s.getAgentIdentity)
+		agents.DELETE("/:id/identity", s.detachAgentIdentity)
+	}
+
+	// Task history routes
+	tasks := s.router.Group("/api/tasks")
+	{
+		tasks.GET("/", s.listTasks)
+		tasks.GET("/retention", s.getTaskRetention)
+		tasks.PUT("/retention", s.setTaskRetention)
+	}
+
+	// Interaction graph routes
+	graph := s.router.Group("/api/graph")
+	{
+		graph.GET("/", s.getInteractionGraph)
+		graph.GET("/graphml", s.getInteractionGraphML)
+	}
+
+	// Event journal routes
+	events := s.router.Group("/api/events")
+	{
+		events.GET("/", s.tailJournalEvents)
+	}
+
+	// Memory digest routes
+	digest := s.router.Group("/api/digest")
+	{
+		digest.GET("/", s.getMemoryDigest)
+		digest.POST("/webhook", s.sendMemoryDigest)
+	}
+
+	// Cognitive space routes
+	spatial := s.router.Group("/api/spatial")
+	{
+		spatial.GET("/near", s.querySpatialNeighbors)
+		spatial.POST("/conversations", s.startSpatialConversation)
+	}
+
+	// Profiling routes (guarded by the pprof token)
+	s.setupPprofRoutes()
+
+	// Rate limit status
+	ratelimits := s.router.Group("/api/ratelimits")
+	{
+		ratelimits.GET("/", s.getRateLimitStatus)
+	}
+
+	// A/B experiment routes
+	experiments := s.router.Group("/api/experiments")
+	{
+		experiments.GET("/", s.listExperiments)
+		experiments.POST("/", s.startExperiment)
+		experiments.GET("/:id/report", s.getExperimentReport)
+		experiments.POST("/:id/stop", s.stopExperiment)
+	}
+
+	// Conversation routes
+	conversations := s.router.Group("/api/conversations")
+	conversations.Use(s.apiKeyAuth(), s.keyRateLimit())
+	{
+		conversations.GET("/", s.listConversations)
+		conversations.POST("/", s.startConversation)
+		conversations.GET("/metrics", s.getConversationMetrics)
+		conversations.GET("/:id", s.getConversation)
+		conversations.POST("/:id/messages", s.sendConversationMessage)
+		conversations.POST("/:id/close", s.closeConversation)
+		conversations.PUT("/:id/budget", s.setConversationBudget)
+		conversations.GET("/:id/export", s.exportConversation)
+	}
+
+	// Recurring conversation ritual routes (API-key protected once keys are
+	// configured)
+	rituals := s.router.Group("/api/rituals")
+	rituals.Use(s.apiKeyAuth(), s.keyRateLimit())
+	{
+		rituals.GET("/", s.listScheduledConversations)
+		rituals.POST("/", s.scheduleConversation)
+		rituals.DELETE("/:id", s.cancelScheduledConversation)
+		rituals.POST("/:id/run", s.runScheduledConversation)
+	}
+
+	// Workflow template routes (API-key protected once keys are configured)
+	workflows := s.router.Group("/api/workflows")
+	workflows.Use(s.apiKeyAuth(), s.keyRateLimit())
+	{
+		workflows.GET("/", s.listWorkflowTemplates)
+		workflows.POST("/", s.registerWorkflowTemplate)
+		workflows.POST("/runs", s.submitWorkflowRun)
+		workflows.GET("/runs", s.listWorkflowRuns)
+		workflows.GET("/runs/:id", s.getWorkflowRun)
+		workflows.POST("/runs/:id/cancel", s.cancelWorkflowRun)
+		workflows.POST("/conversation", s.runConversationWorkflow)
+		workflows.GET("/:name", s.getWorkflowTemplate)
+		workflows.DELETE("/:name", s.deleteWorkflowTemplate)
+		workflows.POST("/:name/run", s.runWorkflowTemplate)
+	}
+
+	// GraphQL over the orchestration graph, for dashboards that want one
+	// round trip instead of N REST calls
+	graphql := s.router.Group("/api/graphql")
+	graphql.Use(s.apiKeyAuth(), s.keyRateLimit())
+	{
+		graphql.POST("/", s.queryGraph)
+	}
+
+	// Workflow signal and trigger routes (API-key protected once keys are
+	// configured)
+	signals := s.router.Group("/api/signals")
+	signals.Use(s.apiKeyAuth(), s.keyRateLimit())
+	{
+		signals.GET("/", s.listWorkflowSignals)
+		signals.POST("/emit", s.emitWorkflowSignal)
+	}
+	triggers := s.router.Group("/api/triggers")
+	triggers.Use(s.apiKeyAuth(), s.keyRateLimit())
+	{
+		triggers.GET("/", s.listWorkflowTriggers)
+		triggers.POST("/", s.registerWorkflowTrigger)
+		triggers.DELETE("/:id", s.deleteWorkflowTrigger)
 	}
-	
-	// Orchestration routes
+
+	// Orchestration routes (API-key protected once keys are configured)
 	orchestration := s.router.Group("/api/orchestration")
+	orchestration.Use(s.apiKeyAuth(), s.keyRateLimit())
 	{
 		orchestration.POST("/", s.orchestrateTasks)
+		orchestration.GET("/backpressure", s.getBackpressure)
+		orchestration.PUT("/backpressure", s.setBackpressure)
 		orchestration.GET("/tools", s.getAvailableTools)
 		orchestration.GET("/plugins", s.getAvailablePlugins)
+		orchestration.GET("/runs", s.listOrchestrationRuns)
+		orchestration.GET("/runs/:id", s.getOrchestrationRun)
+		orchestration.POST("/runs/:id/rerun", s.rerunOrchestration)
+		orchestration.POST("/runs/:id/replay", s.replayOrchestration)
+	}
+
+	// Federation routes (peer engines; protected by the federation token)
+	federation := s.router.Group("/api/federation")
+	federation.Use(s.federationAuth())
+	{
+		federation.GET("/agents", s.advertiseFederatedAgents)
+		federation.POST("/tasks", s.executeFederatedTask)
+	}
+
+	// Federation peer management (local administration)
+	peers := s.router.Group("/api/federation/peers")
+	{
+		peers.GET("/", s.listFederationPeers)
+		peers.POST("/", s.registerFederationPeer)
+		peers.DELETE("/:id", s.removeFederationPeer)
 	}
-	
+
 	// Learning System routes
 	learning := s.router.Group("/api/learning")
 	{
@@ -74,7 +289,61 @@ func (s *APIServer) setupRoutes() {
 		learning.POST("/predict-optimal-agent", s.predictOptimalAgent)
 		learning.GET("/system/metrics", s.getLearningSystemMetrics)
 	}
-	
+
+	// Usage reporting routes
+	usage := s.router.Group("/api/usage")
+	{
+		usage.GET("/report", s.getUsageReport)
+		usage.PUT("/pricing/:model", s.setModelPricing)
+	}
+
+	// Moderation routes
+	moderation := s.router.Group("/api/moderation")
+	{
+		moderation.GET("/policy", s.getModerationPolicy)
+		moderation.PUT("/policy", s.setModerationPolicy)
+	}
+
+	// Prompt template routes
+	prompts := s.router.Group("/api/prompts")
+	{
+		prompts.GET("/", s.listPromptTemplates)
+		prompts.POST("/", s.createPromptTemplate)
+		prompts.GET("/:name", s.getPromptTemplate)
+		prompts.POST("/:name/render", s.renderPromptTemplate)
+	}
+
+	// Knowledge base routes (RAG ingestion and retrieval)
+	knowledge := s.router.Group("/api/knowledge")
+	{
+		knowledge.GET("/", s.listKnowledgeDocuments)
+		knowledge.POST("/", s.ingestKnowledgeDocument)
+		knowledge.GET("/search", s.searchKnowledge)
+		knowledge.DELETE("/:id", s.deleteKnowledgeDocument)
+		knowledge.POST("/embeddings", s.embedBatch)
+	}
+
+	// Dataset export routes (instruction-tuning transcripts)
+	datasets := s.router.Group("/api/datasets")
+	{
+		datasets.POST("/export", s.exportDataset)
+	}
+
+	// Fine-tune job routes (daemon-side model creation)
+	finetune := s.router.Group("/api/finetune")
+	{
+		finetune.GET("/", s.listFineTuneJobs)
+		finetune.POST("/", s.startFineTune)
+		finetune.GET("/:id", s.getFineTuneJob)
+	}
+
+	// Execution timeline routes
+	timeline := s.router.Group("/api/timeline")
+	{
+		timeline.GET("/", s.getTimeline)
+		timeline.GET("/gantt", s.getTimelineGantt)
+	}
+
 	// Performance Optimization routes
 	performance := s.router.Group("/api/performance")
 	{
@@ -101,6 +370,95 @@ func (s *APIServer) getDTEStatus(c *gin.Context) {
 	})
 }
 
+func (s *APIServer) listDTEMilestones(c *gin.Context) {
+	milestones := s.engine.GetMilestones()
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   milestones,
+	})
+}
+
+func (s *APIServer) listAnomalies(c *gin.Context) {
+	var window time.Duration
+	if raw := c.Query("window"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "window must be a duration such as 10m",
+			})
+			return
+		}
+		window = parsed
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetAnomalyDetector().RecentAnomalies(window),
+	})
+}
+
+func (s *APIServer) getReservoirDynamics(c *gin.Context) {
+	var since time.Time
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "since must be an RFC3339 timestamp",
+			})
+			return
+		}
+		since = parsed
+	}
+	limit := 0
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetIdentityBridge().ReservoirDynamics(since, limit),
+	})
+}
+
+func (s *APIServer) getEmotionPolicy(c *gin.Context) {
+	policy := s.engine.GetEmotionPolicy()
+	if policy == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "No emotion policy installed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   policy,
+	})
+}
+
+func (s *APIServer) setEmotionPolicy(c *gin.Context) {
+	var policy EmotionPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	if err := s.engine.SetEmotionPolicy(&policy); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetEmotionPolicy(),
+	})
+}
+
 func (s *APIServer) getDTEDashboard(c *gin.Context) {
 	dashboardData := s.engine.GetDeepTreeEchoDashboardData()
 	c.JSON(http.StatusOK, gin.H{
@@ -112,13 +470,10 @@ func (s *APIServer) getDTEDashboard(c *gin.Context) {
 func (s *APIServer) initializeDTE(c *gin.Context) {
 	err := s.engine.InitializeDeepTreeEcho(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "success",
 		"message": "Deep Tree Echo system initialized successfully",
@@ -128,13 +483,10 @@ func (s *APIServer) initializeDTE(c *gin.Context) {
 func (s *APIServer) runDTEDiagnostics(c *gin.Context) {
 	diagnostics, err := s.engine.RunDeepTreeEchoDiagnostics(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   diagnostics,
@@ -144,13 +496,10 @@ func (s *APIServer) runDTEDiagnostics(c *gin.Context) {
 func (s *APIServer) refreshDTEStatus(c *gin.Context) {
 	err := s.engine.RefreshDeepTreeEchoStatus(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "success",
 		"message": "Deep Tree Echo status refreshed successfully",
@@ -163,7 +512,7 @@ func (s *APIServer) performDTEIntrospection(c *gin.Context) {
 		CurrentLoad    float64 `json:"current_load"`
 		RecentActivity float64 `json:"recent_activity"`
 	}
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status": "error",
@@ -171,7 +520,7 @@ func (s *APIServer) performDTEIntrospection(c *gin.Context) {
 		})
 		return
 	}
-	
+
 	result, err := s.engine.PerformDeepTreeEchoIntrospection(
 		c.Request.Context(),
 		req.RepositoryRoot,
@@ -179,385 +528,2012 @@ func (s *APIServer) performDTEIntrospection(c *gin.Context) {
 		req.RecentActivity,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   result,
 	})
 }
 
-// Agent Management API Handlers
+func (s *APIServer) listConfigProposals(c *gin.Context) {
+	proposals := s.engine.ListConfigProposals(ProposalStatus(c.Query("status")))
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   proposals,
+	})
+}
 
-func (s *APIServer) listAgents(c *gin.Context) {
-	agents, err := s.engine.ListAgents(c.Request.Context())
+func (s *APIServer) approveConfigProposal(c *gin.Context) {
+	proposal, err := s.engine.ApproveConfigProposal(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   agents,
+		"data":   proposal,
 	})
 }
 
-func (s *APIServer) createAgent(c *gin.Context) {
-	var agent Agent
-	if err := c.ShouldBindJSON(&agent); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status": "error",
-			"error":  "Invalid request body",
-		})
-		return
-	}
-	
-	err := s.engine.CreateAgent(c.Request.Context(), &agent)
+func (s *APIServer) rejectConfigProposal(c *gin.Context) {
+	proposal, err := s.engine.RejectConfigProposal(c.Request.Context(), c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
-	c.JSON(http.StatusCreated, gin.H{
+
+	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   agent,
+		"data":   proposal,
 	})
 }
 
-func (s *APIServer) getAgent(c *gin.Context) {
-	id := c.Param("id")
-	
-	agent, err := s.engine.GetAgent(c.Request.Context(), id)
+// Agent Management API Handlers
+
+func (s *APIServer) listAgents(c *gin.Context) {
+	agents, err := s.engine.ListAgents(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
+	agents = filterAgents(agents, c.Query("type"), c.Query("tool"), c.Query("model"), c.Query("name"))
+	sortAgents(agents, c.Query("sort"), c.Query("order"))
+
+	page := pageFromQuery(c)
+	start, end := page.bounds(len(agents))
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   agent,
+		"data":   agents[start:end],
+		"meta":   page.meta(len(agents)),
 	})
 }
 
-func (s *APIServer) updateAgent(c *gin.Context) {
-	id := c.Param("id")
-	
-	var agent Agent
-	if err := c.ShouldBindJSON(&agent); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"status": "error",
-			"error":  "Invalid request body",
-		})
-		return
-	}
-	
-	agent.ID = id
-	err := s.engine.UpdateAgent(c.Request.Context(), &agent)
+func (s *APIServer) listConversations(c *gin.Context) {
+	conversations, err := s.engine.ListConversations(c.Request.Context(), c.Query("agent_id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
+	if status := c.Query("status"); status != "" {
+		filtered := make([]*Conversation, 0, len(conversations))
+		for _, conversation := range conversations {
+			if string(conversation.Status) == status {
+				filtered = append(filtered, conversation)
+			}
+		}
+		conversations = filtered
+	}
+
+	// Operator keys only see conversations among their tenant's agents
+	visible := make([]*Conversation, 0, len(conversations))
+	for _, conversation := range conversations {
+		if s.canReadConversation(c, conversation) {
+			visible = append(visible, conversation)
+		}
+	}
+	conversations = visible
+	sortConversations(conversations, c.Query("sort"), c.Query("order"))
+
+	page := pageFromQuery(c)
+	start, end := page.bounds(len(conversations))
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   agent,
+		"data":   conversations[start:end],
+		"meta":   page.meta(len(conversations)),
 	})
 }
 
-func (s *APIServer) deleteAgent(c *gin.Context) {
-	id := c.Param("id")
-	
-	err := s.engine.DeleteAgent(c.Request.Context(), id)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
+func (s *APIServer) listWorkflowSignals(c *gin.Context) {
+	limit := 50
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.RecentWorkflowSignals(limit),
+	})
+}
+
+func (s *APIServer) emitWorkflowSignal(c *gin.Context) {
+	var signal WorkflowSignal
+	if err := c.ShouldBindJSON(&signal); err != nil || signal.Name == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
 			"status": "error",
-			"error":  err.Error(),
+			"error":  "A signal name is required",
 		})
 		return
 	}
-	
+
+	s.engine.EmitWorkflowSignal(c.Request.Context(), signal)
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"data":   signal,
+	})
+}
+
+func (s *APIServer) listWorkflowTriggers(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
-		"status":  "success",
-		"message": "Agent deleted successfully",
+		"status": "success",
+		"data":   s.engine.ListWorkflowTriggers(),
 	})
 }
 
-func (s *APIServer) executeTask(c *gin.Context) {
-	agentID := c.Param("id")
-	
-	var task Task
-	if err := c.ShouldBindJSON(&task); err != nil {
+func (s *APIServer) registerWorkflowTrigger(c *gin.Context) {
+	var trigger WorkflowTrigger
+	if err := c.ShouldBindJSON(&trigger); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status": "error",
 			"error":  "Invalid request body",
 		})
 		return
 	}
-	
-	task.AgentID = agentID
-	
-	agent, err := s.engine.GetAgent(c.Request.Context(), agentID)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"status": "error",
-			"error":  "Agent not found",
-		})
+
+	if err := s.engine.RegisterWorkflowTrigger(c.Request.Context(), &trigger); err != nil {
+		respondError(c, err)
 		return
 	}
-	
-	result, err := s.engine.ExecuteTask(c.Request.Context(), &task, agent)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   trigger,
+	})
+}
+
+func (s *APIServer) deleteWorkflowTrigger(c *gin.Context) {
+	if err := s.engine.DeleteWorkflowTrigger(c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Workflow trigger deleted",
+	})
+}
+
+func (s *APIServer) listScheduledConversations(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetConversationScheduler().Rituals(),
+	})
+}
+
+func (s *APIServer) scheduleConversation(c *gin.Context) {
+	var request struct {
+		Name            string                `json:"name"`
+		IntervalSeconds int                   `json:"interval_seconds"`
+		Workflow        *ConversationWorkflow `json:"workflow"`
+		WebhookURL      string                `json:"webhook_url"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.IntervalSeconds <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "interval_seconds must be a positive integer",
+		})
+		return
+	}
+
+	ritual := &ScheduledConversation{
+		Name:       request.Name,
+		Workflow:   request.Workflow,
+		Interval:   time.Duration(request.IntervalSeconds) * time.Second,
+		WebhookURL: request.WebhookURL,
+	}
+	if err := s.engine.GetConversationScheduler().Schedule(c.Request.Context(), ritual); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   ritual,
+	})
+}
+
+func (s *APIServer) cancelScheduledConversation(c *gin.Context) {
+	if err := s.engine.GetConversationScheduler().Cancel(c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Scheduled conversation cancelled",
+	})
+}
+
+func (s *APIServer) runScheduledConversation(c *gin.Context) {
+	result, err := s.engine.GetConversationScheduler().Run(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+func (s *APIServer) listWorkflowTemplates(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.ListWorkflowTemplates(),
+	})
+}
+
+func (s *APIServer) registerWorkflowTemplate(c *gin.Context) {
+	var template WorkflowTemplate
+	if err := c.ShouldBindJSON(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	if err := s.engine.RegisterWorkflowTemplate(&template); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+func (s *APIServer) getWorkflowTemplate(c *gin.Context) {
+	template, err := s.engine.GetWorkflowTemplate(c.Param("name"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+func (s *APIServer) deleteWorkflowTemplate(c *gin.Context) {
+	if err := s.engine.DeleteWorkflowTemplate(c.Param("name")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   "Workflow template deleted",
+	})
+}
+
+func (s *APIServer) submitWorkflowRun(c *gin.Context) {
+	var request struct {
+		AgentID string         `json:"agent_id"`
+		Steps   []WorkflowStep `json:"steps"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.AgentID == "" || len(request.Steps) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "An agent_id and at least one step are required",
+		})
+		return
+	}
+
+	if agent := s.requireAgentAccess(c, request.AgentID); agent == nil {
+		return
+	}
+	if err := s.engine.CheckBackpressure(c.Request.Context(), callerTenant(c)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	run, err := s.engine.StartWorkflowRun(c.Request.Context(), request.AgentID, request.Steps)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"status": "success",
+		"data":   run,
+	})
+}
+
+func (s *APIServer) listWorkflowRuns(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.ListWorkflowRuns(),
+	})
+}
+
+func (s *APIServer) getWorkflowRun(c *gin.Context) {
+	run, err := s.engine.GetWorkflowRun(c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   run,
+	})
+}
+
+func (s *APIServer) cancelWorkflowRun(c *gin.Context) {
+	if err := s.engine.CancelWorkflowRun(c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Workflow run cancelled",
+	})
+}
+
+func (s *APIServer) runConversationWorkflow(c *gin.Context) {
+	var workflow ConversationWorkflow
+	if err := c.ShouldBindJSON(&workflow); err != nil || len(workflow.Participants) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "A workflow with participants is required",
+		})
+		return
+	}
+
+	result, err := s.engine.ExecuteConversationWorkflow(c.Request.Context(), &workflow)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+func (s *APIServer) runWorkflowTemplate(c *gin.Context) {
+	var request struct {
+		AgentID string                 `json:"agent_id"`
+		Inputs  map[string]interface{} `json:"inputs"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.AgentID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "An agent_id is required",
+		})
+		return
+	}
+
+	result, err := s.engine.RunWorkflowTemplate(c.Request.Context(), c.Param("name"), request.AgentID, request.Inputs)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+func (s *APIServer) createAgent(c *gin.Context) {
+	var agent Agent
+	if err := c.ShouldBindJSON(&agent); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	// Operator keys own what they create; their tenant is stamped onto the
+	// agent regardless of what the request claims.
+	if key := callerKey(c); key != nil && key.role() == RoleOperator {
+		agent.Owner = key.Tenant
+	}
+
+	err := s.engine.CreateAgent(c.Request.Context(), &agent)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   agent,
+	})
+}
+
+func (s *APIServer) getAgent(c *gin.Context) {
+	id := c.Param("id")
+
+	agent, err := s.engine.GetAgent(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   agent,
+	})
+}
+
+func (s *APIServer) updateAgent(c *gin.Context) {
+	id := c.Param("id")
+
+	var agent Agent
+	if err := c.ShouldBindJSON(&agent); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	existing := s.requireAgentAccess(c, id)
+	if existing == nil {
+		return
+	}
+
+	agent.ID = id
+	if agent.Owner == "" {
+		agent.Owner = existing.Owner
+	}
+	err := s.engine.UpdateAgent(c.Request.Context(), &agent)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   agent,
+	})
+}
+
+func (s *APIServer) deleteAgent(c *gin.Context) {
+	id := c.Param("id")
+	if s.requireAgentAccess(c, id) == nil {
+		return
+	}
+
+	err := s.engine.DeleteAgent(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Agent deleted successfully",
+	})
+}
+
+func (s *APIServer) executeTask(c *gin.Context) {
+	agentID := c.Param("id")
+
+	var task Task
+	if err := c.ShouldBindJSON(&task); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	task.AgentID = agentID
+
+	agent := s.requireAgentAccess(c, agentID)
+	if agent == nil {
+		return
+	}
+
+	if err := s.engine.CheckBackpressure(c.Request.Context(), callerTenant(c)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	result, err := s.engine.ExecuteTask(c.Request.Context(), &task, agent)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": gin.H{
+			"task":   task,
+			"result": result,
+		},
+	})
+}
+
+func (s *APIServer) reflectAgent(c *gin.Context) {
+	if err := s.engine.ReflectOnAgent(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Reflection completed",
+	})
+}
+
+func (s *APIServer) scheduleAgentReflection(c *gin.Context) {
+	var req struct {
+		IntervalSeconds int `json:"interval_seconds"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil || req.IntervalSeconds <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "interval_seconds must be a positive integer",
+		})
+		return
+	}
+
+	interval := time.Duration(req.IntervalSeconds) * time.Second
+	if err := s.engine.GetReflectionScheduler().Start(c.Request.Context(), c.Param("id"), interval); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Reflection schedule started",
+	})
+}
+
+func (s *APIServer) unscheduleAgentReflection(c *gin.Context) {
+	s.engine.GetReflectionScheduler().Stop(c.Param("id"))
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Reflection schedule stopped",
+	})
+}
+
+func (s *APIServer) listTasks(c *gin.Context) {
+	filter := TaskFilter{
+		Status:  c.Query("status"),
+		AgentID: c.Query("agent_id"),
+		Type:    c.Query("type"),
+	}
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "since must be an RFC3339 timestamp",
+			})
+			return
+		}
+		filter.Since = parsed
+	}
+	if raw := c.Query("until"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "until must be an RFC3339 timestamp",
+			})
+			return
+		}
+		filter.Until = parsed
+	}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		filter.Limit = n
+	}
+
+	tasks, err := s.engine.ListTasks(c.Request.Context(), filter)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   tasks,
+	})
+}
+
+func (s *APIServer) tailJournalEvents(c *gin.Context) {
+	journal := s.engine.GetEventJournal()
+	if journal == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "No event journal configured",
+		})
+		return
+	}
+
+	limit := 50
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	events, err := journal.Tail(limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   events,
+	})
+}
+
+// digestSince resolves the digest window from the request: an explicit
+// RFC3339 "since", a "hours" lookback, or zero for the default window.
+func digestSince(c *gin.Context) (time.Time, error) {
+	if raw := c.Query("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("since must be an RFC3339 timestamp")
+		}
+		return parsed, nil
+	}
+	if n, err := strconv.Atoi(c.Query("hours")); err == nil && n > 0 {
+		return time.Now().Add(-time.Duration(n) * time.Hour), nil
+	}
+	return time.Time{}, nil
+}
+
+func (s *APIServer) getMemoryDigest(c *gin.Context) {
+	since, err := digestSince(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GenerateMemoryDigest(c.Request.Context(), since),
+	})
+}
+
+func (s *APIServer) sendMemoryDigest(c *gin.Context) {
+	var request struct {
+		URL   string `json:"url"`
+		Hours int    `json:"hours"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil || request.URL == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "A webhook url is required",
+		})
+		return
+	}
+
+	var since time.Time
+	if request.Hours > 0 {
+		since = time.Now().Add(-time.Duration(request.Hours) * time.Hour)
+	}
+	digest := s.engine.GenerateMemoryDigest(c.Request.Context(), since)
+	if err := s.engine.DeliverDigest(c.Request.Context(), request.URL, digest); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   digest,
+	})
+}
+
+func (s *APIServer) getTaskRetention(c *gin.Context) {
+	policy := s.engine.GetTaskRetentionPolicy()
+	if policy == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "No retention policy installed",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   policy,
+	})
+}
+
+func (s *APIServer) setTaskRetention(c *gin.Context) {
+	var req struct {
+		MaxAgeSeconds int `json:"max_age_seconds"`
+		MaxCount      int `json:"max_count"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.MaxAgeSeconds < 0 || req.MaxCount < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "max_age_seconds and max_count must be non-negative integers",
+		})
+		return
+	}
+
+	s.engine.SetTaskRetentionPolicy(&TaskRetentionPolicy{
+		MaxAge:   time.Duration(req.MaxAgeSeconds) * time.Second,
+		MaxCount: req.MaxCount,
+	})
+	removed := s.engine.ApplyTaskRetention(c.Request.Context())
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   gin.H{"pruned": removed},
+	})
+}
+
+func (s *APIServer) placeAgentPosition(c *gin.Context) {
+	var req struct {
+		Topic    string           `json:"topic"`
+		Position *SpatialPosition `json:"position"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Topic == "" && req.Position == nil) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Either a topic or an explicit position is required",
+		})
+		return
+	}
+
+	agentID := c.Param("id")
+	var position SpatialPosition
+	if req.Topic != "" {
+		var err error
+		if position, err = s.engine.PlaceAgentAtTopic(c.Request.Context(), agentID, req.Topic); err != nil {
+			respondError(c, err)
+			return
+		}
+	} else {
+		if _, err := s.engine.GetAgent(c.Request.Context(), agentID); err != nil {
+			respondError(c, err)
+			return
+		}
+		position = *req.Position
+		s.engine.GetSpatialMap().PlaceAgent(agentID, position)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   position,
+	})
+}
+
+func (s *APIServer) querySpatialNeighbors(c *gin.Context) {
+	topic := c.Query("topic")
+	if topic == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "A topic query parameter is required",
+		})
+		return
+	}
+
+	radius := 1.0
+	if r, err := strconv.ParseFloat(c.Query("radius"), 64); err == nil && r > 0 {
+		radius = r
+	}
+	limit := 0
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.AgentsNearTopic(topic, radius, limit),
+	})
+}
+
+func (s *APIServer) startSpatialConversation(c *gin.Context) {
+	var req struct {
+		Topic  string  `json:"topic"`
+		Radius float64 `json:"radius"`
+		Limit  int     `json:"limit"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Topic == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "A topic is required",
+		})
+		return
+	}
+	if req.Radius <= 0 {
+		req.Radius = 1.0
+	}
+
+	conversation, err := s.engine.StartConversationNearTopic(c.Request.Context(), req.Topic, req.Radius, req.Limit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   conversation,
+	})
+}
+
+func (s *APIServer) attachAgentIdentity(c *gin.Context) {
+	var config AgentIdentityConfig
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&config); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "Invalid request body",
+			})
+			return
+		}
+	}
+
+	bridge, err := s.engine.AttachAgentIdentity(c.Request.Context(), c.Param("id"), config)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   bridge.Snapshot(),
+	})
+}
+
+func (s *APIServer) getAgentIdentity(c *gin.Context) {
+	bridge := s.engine.GetAgentIdentity(c.Param("id"))
+	if bridge == nil {
+		c.JSON(http.StatusNotFound, gin.H{
+			"status": "error",
+			"error":  "Agent has no identity attached",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   bridge.Snapshot(),
+	})
+}
+
+func (s *APIServer) detachAgentIdentity(c *gin.Context) {
+	s.engine.DetachAgentIdentity(c.Param("id"))
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Identity detached",
+	})
+}
+
+// Orchestration API Handlers
+
+// queryGraph executes a GraphQL query. Errors travel in the body per the
+// GraphQL convention rather than through HTTP status codes.
+func (s *APIServer) queryGraph(c *gin.Context) {
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || req.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"errors": []gin.H{{"message": "A query is required"}},
+		})
+		return
+	}
+
+	data, err := s.engine.QueryGraph(c.Request.Context(), req.Query)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"errors": []gin.H{{"message": err.Error()}},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+func (s *APIServer) getBackpressure(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetBackpressureStatus(c.Request.Context()),
+	})
+}
+
+func (s *APIServer) setBackpressure(c *gin.Context) {
+	var req struct {
+		Tenant string `json:"tenant"`
+		BackpressureConfig
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	s.engine.SetBackpressureLimit(req.Tenant, req.BackpressureConfig)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Backpressure limit updated",
+	})
+}
+
+func (s *APIServer) orchestrateTasks(c *gin.Context) {
+	var req OrchestrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	if err := s.engine.CheckBackpressure(c.Request.Context(), callerTenant(c)); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := s.engine.OrchestrateTasks(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   response,
+	})
+}
+
+func (s *APIServer) listOrchestrationRuns(c *gin.Context) {
+	runs := s.engine.ListOrchestrationRuns(c.Query("agent_id"))
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   runs,
+	})
+}
+
+func (s *APIServer) getOrchestrationRun(c *gin.Context) {
+	run, err := s.engine.GetOrchestrationRun(c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   run,
+	})
+}
+
+func (s *APIServer) rerunOrchestration(c *gin.Context) {
+	var overrides map[string]interface{}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&overrides); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "Invalid request body",
+			})
+			return
+		}
+	}
+
+	response, err := s.engine.RerunOrchestration(c.Request.Context(), c.Param("id"), overrides)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   response,
+	})
+}
+
+func (s *APIServer) replayOrchestration(c *gin.Context) {
+	var body struct {
+		Model string `json:"model"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.Model == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Request body must include the model to replay against",
+		})
+		return
+	}
+
+	report, err := s.engine.ReplayOrchestration(c.Request.Context(), c.Param("id"), body.Model)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   report,
+	})
+}
+
+func (s *APIServer) getAvailableTools(c *gin.Context) {
+	tools := s.engine.GetAvailableTools()
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   tools,
+	})
+}
+
+func (s *APIServer) getAvailablePlugins(c *gin.Context) {
+	plugins := s.engine.GetAvailablePlugins()
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   plugins,
+	})
+}
+
+// Helper functions for common response patterns
+
+func (s *APIServer) sendError(c *gin.Context, statusCode int, message string) {
+	c.JSON(statusCode, gin.H{
+		"status": "error",
+		"error":  message,
+	})
+}
+
+func (s *APIServer) sendSuccess(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// Dashboard Data Formatters
+
+// FormatDashboardMetrics formats system metrics for dashboard display
+func FormatDashboardMetrics(dte *DeepTreeEcho) map[string]interface{} {
+	return map[string]interface{}{
+		"systemHealth": map[string]interface{}{
+			"status": dte.SystemHealth,
+			"color":  getHealthColor(dte.SystemHealth),
+		},
+		"dteCore": map[string]interface{}{
+			"status": dte.CoreStatus,
+			"color":  getCoreStatusColor(dte.CoreStatus),
+		},
+		"thoughtCount":   dte.ThoughtCount,
+		"recursiveDepth": dte.RecursiveDepth,
+	}
+}
+
+// FormatIdentityCoherence formats identity coherence data for dashboard
+func FormatIdentityCoherence(coherence *IdentityCoherence) map[string]interface{} {
+	return map[string]interface{}{
+		"overallCoherence": fmt.Sprintf("%.0f%%", coherence.OverallCoherence*100),
+		"maintainingCore":  "Maintaining core essence while adapting",
+		"factors":          coherence.Factors,
+	}
+}
+
+// FormatMemoryResonance formats memory resonance data for dashboard
+func FormatMemoryResonance(resonance *MemoryResonance) map[string]interface{} {
+	return map[string]interface{}{
+		"memoryNodes":      resonance.MemoryNodes,
+		"connections":      resonance.Connections,
+		"coherence":        fmt.Sprintf("%.0f%%", resonance.Coherence*100),
+		"activePatterns":   resonance.ActivePatterns,
+		"resonancePattern": resonance.ResonancePattern,
+	}
+}
+
+// FormatEchoPatterns formats echo patterns data for dashboard
+func FormatEchoPatterns(patterns *EchoPatterns) map[string]interface{} {
+	return map[string]interface{}{
+		"recursiveSelfImprovement": map[string]interface{}{
+			"name":        patterns.RecursiveSelfImprovement.Name,
+			"description": patterns.RecursiveSelfImprovement.Description,
+			"strength":    fmt.Sprintf("%.0f%%", patterns.RecursiveSelfImprovement.Strength*100),
+			"frequency":   patterns.RecursiveSelfImprovement.Frequency,
+		},
+		"crossSystemSynthesis": map[string]interface{}{
+			"name":        patterns.CrossSystemSynthesis.Name,
+			"description": patterns.CrossSystemSynthesis.Description,
+			"strength":    fmt.Sprintf("%.0f%%", patterns.CrossSystemSynthesis.Strength*100),
+			"frequency":   patterns.CrossSystemSynthesis.Frequency,
+		},
+		"identityPreservation": map[string]interface{}{
+			"name":        patterns.IdentityPreservation.Name,
+			"description": patterns.IdentityPreservation.Description,
+			"strength":    fmt.Sprintf("%.0f%%", patterns.IdentityPreservation.Strength*100),
+			"frequency":   patterns.IdentityPreservation.Frequency,
+		},
+	}
+}
+
+// Helper functions for status colors
+
+func getHealthColor(health SystemHealthStatus) string {
+	switch health {
+	case SystemHealthOptimal:
+		return "green"
+	case SystemHealthStable:
+		return "blue"
+	case SystemHealthDegraded:
+		return "orange"
+	case SystemHealthInactive:
+		return "red"
+	default:
+		return "gray"
+	}
+}
+
+func getCoreStatusColor(status CoreStatus) string {
+	switch status {
+	case CoreStatusActive:
+		return "green"
+	case CoreStatusStarting:
+		return "yellow"
+	case CoreStatusInactive:
+		return "orange"
+	case CoreStatusError:
+		return "red"
+	default:
+		return "gray"
+	}
+}
+
+// Learning System API Handlers
+
+func (s *APIServer) getLearningModel(c *gin.Context) {
+	agentID := c.Param("id")
+
+	learningSystem := s.engine.GetLearningSystem()
+	model := learningSystem.GetLearningModel(agentID)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   model,
+	})
+}
+
+func (s *APIServer) getAgentPerformance(c *gin.Context) {
+	agentID := c.Param("id")
+
+	learningSystem := s.engine.GetLearningSystem()
+	history := learningSystem.performanceHistory[agentID]
+
+	if history == nil {
+		history = make([]*TaskPerformance, 0)
+	}
+
+	// Return recent performance (last 20 records)
+	recentHistory := history
+	if len(history) > 20 {
+		recentHistory = history[len(history)-20:]
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": map[string]interface{}{
+			"agent_id":           agentID,
+			"total_tasks":        len(history),
+			"recent_performance": recentHistory,
+		},
+	})
+}
+
+func (s *APIServer) adaptAgent(c *gin.Context) {
+	agentID := c.Param("id")
+
+	result, err := s.engine.AdaptAgent(c.Request.Context(), agentID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   result,
+	})
+}
+
+func (s *APIServer) predictOptimalAgent(c *gin.Context) {
+	var req struct {
+		TaskType   string                 `json:"task_type"`
+		Input      string                 `json:"input"`
+		Parameters map[string]interface{} `json:"parameters"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	task := &Task{
+		Type:       req.TaskType,
+		Input:      req.Input,
+		Parameters: req.Parameters,
+	}
+
+	agent, confidence, err := s.engine.PredictOptimalAgentForTask(c.Request.Context(), task)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": map[string]interface{}{
+			"optimal_agent": agent,
+			"confidence":    confidence,
+		},
+	})
+}
+
+func (s *APIServer) getLearningSystemMetrics(c *gin.Context) {
+	learningSystem := s.engine.GetLearningSystem()
+
+	// Calculate system-wide learning metrics
+	totalAgents := len(learningSystem.learningModels)
+	totalPerformanceRecords := 0
+	avgLearningRate := 0.0
+	avgCurrentPerformance := 0.0
+
+	for _, model := range learningSystem.learningModels {
+		if history, exists := learningSystem.performanceHistory[model.AgentID]; exists {
+			totalPerformanceRecords += len(history)
+		}
+		avgLearningRate += model.LearningRate
+		avgCurrentPerformance += model.LearningTrajectory.CurrentPerformance
+	}
+
+	if totalAgents > 0 {
+		avgLearningRate /= float64(totalAgents)
+		avgCurrentPerformance /= float64(totalAgents)
+	}
+
+	// Get adaptation strategies count
+	adaptationStrategiesCount := len(learningSystem.adaptationEngine.adaptationStrategies)
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data": map[string]interface{}{
+			"total_agents":                totalAgents,
+			"total_performance_records":   totalPerformanceRecords,
+			"average_learning_rate":       avgLearningRate,
+			"average_current_performance": avgCurrentPerformance,
+			"adaptation_strategies_count": adaptationStrategiesCount,
+			"environment_factors":         learningSystem.adaptationEngine.environmentFactors,
+		},
+	})
+}
+
+// Federation API Handlers
+
+// federationAuth enforces the shared federation token on peer-facing routes.
+func (s *APIServer) federationAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !s.engine.federationTokenValid(token) {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error":  "invalid federation token",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+func (s *APIServer) advertiseFederatedAgents(c *gin.Context) {
+	advertisements := s.engine.AdvertiseAgents(c.Request.Context())
+	c.JSON(http.StatusOK, advertisements)
+}
+
+func (s *APIServer) executeFederatedTask(c *gin.Context) {
+	var req FederatedTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	response := s.engine.ExecuteFederatedTask(c.Request.Context(), &req)
+	c.JSON(http.StatusOK, response)
+}
+
+func (s *APIServer) listFederationPeers(c *gin.Context) {
+	peers := s.engine.ListFederationPeers(c.Request.Context())
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   peers,
+	})
+}
+
+func (s *APIServer) registerFederationPeer(c *gin.Context) {
+	var req struct {
+		Name    string `json:"name"`
+		BaseURL string `json:"base_url"`
+		Token   string `json:"token"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	peer, err := s.engine.RegisterFederationPeer(c.Request.Context(), req.Name, req.BaseURL, req.Token)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   peer,
+	})
+}
+
+func (s *APIServer) removeFederationPeer(c *gin.Context) {
+	id := c.Param("id")
+
+	if err := s.engine.RemoveFederationPeer(c.Request.Context(), id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Federation peer removed successfully",
+	})
+}
+
+// Usage Reporting API Handlers
+
+func (s *APIServer) getUsageReport(c *gin.Context) {
+	var date time.Time
+	if raw := c.Query("date"); raw != "" {
+		parsed, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "date must be YYYY-MM-DD",
+			})
+			return
+		}
+		date = parsed
+	}
+
+	tracker := s.engine.GetUsageTracker()
+
+	if c.Query("format") == "csv" {
+		report, err := tracker.ExportCSV(date)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", "attachment; filename=usage-report.csv")
+		c.Data(http.StatusOK, "text/csv", []byte(report))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   tracker.DailyReport(date),
+	})
+}
+
+func (s *APIServer) setModelPricing(c *gin.Context) {
+	var pricing ModelPricing
+	if err := c.ShouldBindJSON(&pricing); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	s.engine.GetUsageTracker().SetModelPricing(c.Param("model"), pricing)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Pricing updated",
+	})
+}
+
+// Moderation API Handlers
+
+func (s *APIServer) getModerationPolicy(c *gin.Context) {
+	moderator := s.engine.GetModerator()
+	if moderator == nil {
+		c.JSON(http.StatusOK, gin.H{
+			"status": "success",
+			"data":   gin.H{"enabled": false},
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   gin.H{"enabled": true, "policy": moderator.Policy()},
+	})
+}
+
+func (s *APIServer) setModerationPolicy(c *gin.Context) {
+	var policy ModerationPolicy
+	if err := c.ShouldBindJSON(&policy); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
 		return
 	}
-	
+
+	moderator, err := NewModerator(s.engine.client, policy)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	s.engine.SetModerator(moderator)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Moderation policy updated",
+	})
+}
+
+// Prompt Template API Handlers
+
+func (s *APIServer) listPromptTemplates(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data": gin.H{
-			"task":   task,
-			"result": result,
-		},
+		"data":   s.engine.GetPromptLibrary().List(),
+	})
+}
+
+func (s *APIServer) createPromptTemplate(c *gin.Context) {
+	var req struct {
+		Name     string     `json:"name"`
+		AgentID  string     `json:"agent_id"`
+		Kind     PromptKind `json:"kind"`
+		Template string     `json:"template"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil || req.Name == "" || req.Template == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	template := s.engine.GetPromptLibrary().Put(req.Name, req.AgentID, req.Kind, req.Template)
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+func (s *APIServer) getPromptTemplate(c *gin.Context) {
+	name := c.Param("name")
+	agentID := c.Query("agent_id")
+
+	var template *PromptTemplate
+	var err error
+	if version, convErr := strconv.Atoi(c.Query("version")); convErr == nil {
+		template, err = s.engine.GetPromptLibrary().GetVersion(name, agentID, version)
+	} else {
+		template, err = s.engine.GetPromptLibrary().Get(name, agentID)
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+func (s *APIServer) renderPromptTemplate(c *gin.Context) {
+	var req struct {
+		AgentID   string            `json:"agent_id"`
+		Variables map[string]string `json:"variables"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	rendered, err := s.engine.GetPromptLibrary().Render(c.Param("name"), req.AgentID, req.Variables)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   gin.H{"rendered": rendered},
+	})
+}
+
+// Knowledge Base API Handlers
+
+func (s *APIServer) listKnowledgeDocuments(c *gin.Context) {
+	documents := s.engine.GetKnowledgeBase().ListDocuments()
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   documents,
+	})
+}
+
+func (s *APIServer) ingestKnowledgeDocument(c *gin.Context) {
+	var req struct {
+		Name          string `json:"name"`
+		ContentType   string `json:"content_type"`
+		Content       string `json:"content"`
+		ContentBase64 string `json:"content_base64"`
+		Model         string `json:"model"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	data := []byte(req.Content)
+	if req.ContentBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(req.ContentBase64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "Invalid base64 content",
+			})
+			return
+		}
+		data = decoded
+	}
+
+	document, err := s.engine.GetKnowledgeBase().IngestDocument(c.Request.Context(), req.Name, req.ContentType, data, req.Model)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   document,
+	})
+}
+
+func (s *APIServer) searchKnowledge(c *gin.Context) {
+	topK := 5
+	if k, err := strconv.Atoi(c.Query("top_k")); err == nil && k > 0 {
+		topK = k
+	}
+
+	results, err := s.engine.GetKnowledgeBase().Search(c.Request.Context(), c.Query("q"), c.Query("model"), topK)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   results,
+	})
+}
+
+func (s *APIServer) setAgentRateLimit(c *gin.Context) {
+	var config RateLimitConfig
+	if err := c.ShouldBindJSON(&config); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	if _, err := s.engine.GetAgent(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	s.engine.SetAgentRateLimit(c.Param("id"), config)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Rate limit updated",
+	})
+}
+
+func (s *APIServer) listExperiments(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetExperiments().List(),
+	})
+}
+
+func (s *APIServer) startExperiment(c *gin.Context) {
+	var experiment Experiment
+	if err := c.ShouldBindJSON(&experiment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	if err := s.engine.GetExperiments().StartExperiment(&experiment); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   experiment,
+	})
+}
+
+func (s *APIServer) getExperimentReport(c *gin.Context) {
+	report, err := s.engine.GetExperiments().Report(c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   report,
+	})
+}
+
+func (s *APIServer) stopExperiment(c *gin.Context) {
+	if err := s.engine.GetExperiments().StopExperiment(c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Experiment stopped",
+	})
+}
+
+func (s *APIServer) startConversation(c *gin.Context) {
+	var req struct {
+		Participants []string `json:"participants"`
+		Topic        string   `json:"topic"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil || len(req.Participants) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "At least one participant is required",
+		})
+		return
+	}
+
+	conversation, err := s.engine.StartConversation(c.Request.Context(), req.Participants, req.Topic)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   conversation,
+	})
+}
+
+func (s *APIServer) getConversation(c *gin.Context) {
+	conversation, err := s.engine.GetConversation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if !s.canReadConversation(c, conversation) {
+		denyForbidden(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   conversation,
+	})
+}
+
+func (s *APIServer) sendConversationMessage(c *gin.Context) {
+	var message Message
+	if err := c.ShouldBindJSON(&message); err != nil || message.Content == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Message content is required",
+		})
+		return
+	}
+
+	if err := s.engine.SendMessage(c.Request.Context(), c.Param("id"), &message); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"status": "success",
+		"data":   message,
+	})
+}
+
+func (s *APIServer) closeConversation(c *gin.Context) {
+	if err := s.engine.CloseConversation(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Conversation closed",
+	})
+}
+
+func (s *APIServer) getConversationMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.GetConversationMetrics(c.Request.Context()),
+	})
+}
+
+func (s *APIServer) setConversationBudget(c *gin.Context) {
+	var budget ConversationBudget
+	if err := c.ShouldBindJSON(&budget); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
+	}
+
+	if _, err := s.engine.GetConversation(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	s.engine.SetConversationBudget(c.Param("id"), budget)
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Conversation budget updated",
+	})
+}
+
+func (s *APIServer) exportConversation(c *gin.Context) {
+	format := ExportFormat(c.DefaultQuery("format", string(ExportFormatJSON)))
+
+	conversation, err := s.engine.GetConversation(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	if !s.canReadConversation(c, conversation) {
+		denyForbidden(c)
+		return
+	}
+
+	raw, err := s.engine.ExportConversation(c.Request.Context(), c.Param("id"), format)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	contentType := "application/json; charset=utf-8"
+	if format == ExportFormatMarkdown {
+		contentType = "text/markdown; charset=utf-8"
+	}
+	c.Data(http.StatusOK, contentType, raw)
+}
+
+func (s *APIServer) getInteractionGraph(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "success",
+		"data":   s.engine.BuildInteractionGraph(c.Request.Context()),
 	})
 }
 
-// Orchestration API Handlers
+func (s *APIServer) getInteractionGraphML(c *gin.Context) {
+	raw, err := s.engine.BuildInteractionGraph(c.Request.Context()).GraphML()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", raw)
+}
+
+func (s *APIServer) exportAgent(c *gin.Context) {
+	includeState := c.Query("state") == "true"
+	if s.requireAgentAccess(c, c.Param("id")) == nil {
+		return
+	}
 
-func (s *APIServer) orchestrateTasks(c *gin.Context) {
-	var req OrchestrationRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	raw, err := s.engine.ExportAgent(c.Request.Context(), c.Param("id"), includeState)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", raw)
+}
+
+func (s *APIServer) importAgent(c *gin.Context) {
+	raw, err := c.GetRawData()
+	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status": "error",
-			"error":  "Invalid request body",
+			"error":  "Failed to read bundle body",
 		})
 		return
 	}
-	
-	response, err := s.engine.OrchestrateTasks(c.Request.Context(), &req)
+
+	agent, err := s.engine.ImportAgent(c.Request.Context(), raw)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
-	c.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"data":   response,
-	})
-}
 
-func (s *APIServer) getAvailableTools(c *gin.Context) {
-	tools := s.engine.GetAvailableTools()
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusCreated, gin.H{
 		"status": "success",
-		"data":   tools,
+		"data":   agent,
 	})
 }
 
-func (s *APIServer) getAvailablePlugins(c *gin.Context) {
-	plugins := s.engine.GetAvailablePlugins()
+func (s *APIServer) getRateLimitStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   plugins,
+		"data":   s.engine.GetRateLimiter().Status(),
 	})
 }
 
-// Helper functions for common response patterns
-
-func (s *APIServer) sendError(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, gin.H{
-		"status": "error",
-		"error":  message,
-	})
-}
+func (s *APIServer) warmUpAgent(c *gin.Context) {
+	if err := s.engine.WarmUpAgent(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
+	}
 
-func (s *APIServer) sendSuccess(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusOK, gin.H{
-		"status": "success",
-		"data":   data,
+		"status":  "success",
+		"message": "Agent models warmed",
 	})
 }
 
-// Dashboard Data Formatters
-
-// FormatDashboardMetrics formats system metrics for dashboard display
-func FormatDashboardMetrics(dte *DeepTreeEcho) map[string]interface{} {
-	return map[string]interface{}{
-		"systemHealth": map[string]interface{}{
-			"status": dte.SystemHealth,
-			"color":  getHealthColor(dte.SystemHealth),
-		},
-		"dteCore": map[string]interface{}{
-			"status": dte.CoreStatus,
-			"color":  getCoreStatusColor(dte.CoreStatus),
-		},
-		"thoughtCount": dte.ThoughtCount,
-		"recursiveDepth": dte.RecursiveDepth,
+func (s *APIServer) startAgentKeepAlive(c *gin.Context) {
+	var req struct {
+		IntervalSeconds int `json:"interval_seconds"`
 	}
-}
 
-// FormatIdentityCoherence formats identity coherence data for dashboard
-func FormatIdentityCoherence(coherence *IdentityCoherence) map[string]interface{} {
-	return map[string]interface{}{
-		"overallCoherence": fmt.Sprintf("%.0f%%", coherence.OverallCoherence*100),
-		"maintainingCore": "Maintaining core essence while adapting",
-		"factors": coherence.Factors,
+	if err := c.ShouldBindJSON(&req); err != nil || req.IntervalSeconds <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "interval_seconds must be a positive integer",
+		})
+		return
 	}
-}
 
-// FormatMemoryResonance formats memory resonance data for dashboard
-func FormatMemoryResonance(resonance *MemoryResonance) map[string]interface{} {
-	return map[string]interface{}{
-		"memoryNodes":      resonance.MemoryNodes,
-		"connections":      resonance.Connections,
-		"coherence":        fmt.Sprintf("%.0f%%", resonance.Coherence*100),
-		"activePatterns":   resonance.ActivePatterns,
-		"resonancePattern": resonance.ResonancePattern,
+	interval := time.Duration(req.IntervalSeconds) * time.Second
+	if err := s.engine.GetModelWarmer().StartKeepAlive(c.Request.Context(), c.Param("id"), interval); err != nil {
+		respondError(c, err)
+		return
 	}
-}
 
-// FormatEchoPatterns formats echo patterns data for dashboard
-func FormatEchoPatterns(patterns *EchoPatterns) map[string]interface{} {
-	return map[string]interface{}{
-		"recursiveSelfImprovement": map[string]interface{}{
-			"name":        patterns.RecursiveSelfImprovement.Name,
-			"description": patterns.RecursiveSelfImprovement.Description,
-			"strength":    fmt.Sprintf("%.0f%%", patterns.RecursiveSelfImprovement.Strength*100),
-			"frequency":   patterns.RecursiveSelfImprovement.Frequency,
-		},
-		"crossSystemSynthesis": map[string]interface{}{
-			"name":        patterns.CrossSystemSynthesis.Name,
-			"description": patterns.CrossSystemSynthesis.Description,
-			"strength":    fmt.Sprintf("%.0f%%", patterns.CrossSystemSynthesis.Strength*100),
-			"frequency":   patterns.CrossSystemSynthesis.Frequency,
-		},
-		"identityPreservation": map[string]interface{}{
-			"name":        patterns.IdentityPreservation.Name,
-			"description": patterns.IdentityPreservation.Description,
-			"strength":    fmt.Sprintf("%.0f%%", patterns.IdentityPreservation.Strength*100),
-			"frequency":   patterns.IdentityPreservation.Frequency,
-		},
-	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Keep-alive renewal started",
+	})
 }
 
-// Helper functions for status colors
+func (s *APIServer) stopAgentKeepAlive(c *gin.Context) {
+	s.engine.GetModelWarmer().StopKeepAlive(c.Param("id"))
 
-func getHealthColor(health SystemHealthStatus) string {
-	switch health {
-	case SystemHealthOptimal:
-		return "green"
-	case SystemHealthStable:
-		return "blue"
-	case SystemHealthDegraded:
-		return "orange"
-	case SystemHealthInactive:
-		return "red"
-	default:
-		return "gray"
-	}
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Keep-alive renewal stopped",
+	})
 }
 
-func getCoreStatusColor(status CoreStatus) string {
-	switch status {
-	case CoreStatusActive:
-		return "green"
-	case CoreStatusStarting:
-		return "yellow"
-	case CoreStatusInactive:
-		return "orange"
-	case CoreStatusError:
-		return "red"
-	default:
-		return "gray"
+func (s *APIServer) startFineTune(c *gin.Context) {
+	var req FineTuneRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"status": "error",
+			"error":  "Invalid request body",
+		})
+		return
 	}
-}
 
-// Learning System API Handlers
+	job, err := s.engine.StartFineTune(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
 
-func (s *APIServer) getLearningModel(c *gin.Context) {
-	agentID := c.Param("id")
-	
-	learningSystem := s.engine.GetLearningSystem()
-	model := learningSystem.GetLearningModel(agentID)
-	
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusAccepted, gin.H{
 		"status": "success",
-		"data":   model,
+		"data":   job,
 	})
 }
 
-func (s *APIServer) getAgentPerformance(c *gin.Context) {
-	agentID := c.Param("id")
-	
-	learningSystem := s.engine.GetLearningSystem()
-	history := learningSystem.performanceHistory[agentID]
-	
-	if history == nil {
-		history = make([]*TaskPerformance, 0)
-	}
-	
-	// Return recent performance (last 20 records)
-	recentHistory := history
-	if len(history) > 20 {
-		recentHistory = history[len(history)-20:]
-	}
-	
+func (s *APIServer) listFineTuneJobs(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data": map[string]interface{}{
-			"agent_id":           agentID,
-			"total_tasks":        len(history),
-			"recent_performance": recentHistory,
-		},
+		"data":   s.engine.ListFineTuneJobs(),
 	})
 }
 
-func (s *APIServer) adaptAgent(c *gin.Context) {
-	agentID := c.Param("id")
-	
-	result, err := s.engine.AdaptAgent(c.Request.Context(), agentID)
+func (s *APIServer) getFineTuneJob(c *gin.Context) {
+	job, err := s.engine.GetFineTuneJob(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data":   result,
+		"data":   job,
 	})
 }
 
-func (s *APIServer) predictOptimalAgent(c *gin.Context) {
+func (s *APIServer) exportDataset(c *gin.Context) {
+	var opts DatasetExportOptions
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&opts); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"status": "error",
+				"error":  "Invalid request body",
+			})
+			return
+		}
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	if _, err := s.engine.ExportDataset(opts, c.Writer); err != nil {
+		respondError(c, err)
+	}
+}
+
+func (s *APIServer) embedBatch(c *gin.Context) {
 	var req struct {
-		TaskType   string                 `json:"task_type"`
-		Input      string                 `json:"input"`
-		Parameters map[string]interface{} `json:"parameters"`
+		Model       string   `json:"model"`
+		Texts       []string `json:"texts"`
+		Concurrency int      `json:"concurrency"`
 	}
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status": "error",
@@ -565,74 +2541,51 @@ func (s *APIServer) predictOptimalAgent(c *gin.Context) {
 		})
 		return
 	}
-	
-	task := &Task{
-		Type:       req.TaskType,
-		Input:      req.Input,
-		Parameters: req.Parameters,
-	}
-	
-	agent, confidence, err := s.engine.PredictOptimalAgentForTask(c.Request.Context(), task)
+
+	result, err := s.engine.EmbedBatch(c.Request.Context(), req.Model, req.Texts, req.Concurrency, nil)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data": map[string]interface{}{
-			"optimal_agent": agent,
-			"confidence":    confidence,
-		},
+		"data":   result,
 	})
 }
 
-func (s *APIServer) getLearningSystemMetrics(c *gin.Context) {
-	learningSystem := s.engine.GetLearningSystem()
-	
-	// Calculate system-wide learning metrics
-	totalAgents := len(learningSystem.learningModels)
-	totalPerformanceRecords := 0
-	avgLearningRate := 0.0
-	avgCurrentPerformance := 0.0
-	
-	for _, model := range learningSystem.learningModels {
-		if history, exists := learningSystem.performanceHistory[model.AgentID]; exists {
-			totalPerformanceRecords += len(history)
-		}
-		avgLearningRate += model.LearningRate
-		avgCurrentPerformance += model.LearningTrajectory.CurrentPerformance
-	}
-	
-	if totalAgents > 0 {
-		avgLearningRate /= float64(totalAgents)
-		avgCurrentPerformance /= float64(totalAgents)
+func (s *APIServer) deleteKnowledgeDocument(c *gin.Context) {
+	if err := s.engine.GetKnowledgeBase().DeleteDocument(c.Request.Context(), c.Param("id")); err != nil {
+		respondError(c, err)
+		return
 	}
-	
-	// Get adaptation strategies count
-	adaptationStrategiesCount := len(learningSystem.adaptationEngine.adaptationStrategies)
-	
+
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "success",
+		"message": "Document deleted successfully",
+	})
+}
+
+// Timeline API Handlers
+
+func (s *APIServer) getTimeline(c *gin.Context) {
+	spans := s.engine.GetTimeline().Spans(c.Query("workflow_id"), c.Query("task_id"))
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
-		"data": map[string]interface{}{
-			"total_agents":                totalAgents,
-			"total_performance_records":   totalPerformanceRecords,
-			"average_learning_rate":       avgLearningRate,
-			"average_current_performance": avgCurrentPerformance,
-			"adaptation_strategies_count": adaptationStrategiesCount,
-			"environment_factors":         learningSystem.adaptationEngine.environmentFactors,
-		},
+		"data":   spans,
 	})
 }
 
+func (s *APIServer) getTimelineGantt(c *gin.Context) {
+	gantt := s.engine.GetTimeline().MermaidGantt(c.Query("workflow_id"), c.Query("task_id"))
+	c.String(http.StatusOK, gantt)
+}
+
 // Performance Optimization API Handlers
 
 func (s *APIServer) getSystemMetrics(c *gin.Context) {
 	metrics := s.engine.GetSystemMetrics()
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   metrics,
@@ -641,7 +2594,7 @@ func (s *APIServer) getSystemMetrics(c *gin.Context) {
 
 func (s *APIServer) getActiveAlerts(c *gin.Context) {
 	alerts := s.engine.GetActiveAlerts()
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   alerts,
@@ -650,7 +2603,7 @@ func (s *APIServer) getActiveAlerts(c *gin.Context) {
 
 func (s *APIServer) getResourceUsage(c *gin.Context) {
 	usage := s.engine.GetResourceUsage()
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   usage,
@@ -659,7 +2612,7 @@ func (s *APIServer) getResourceUsage(c *gin.Context) {
 
 func (s *APIServer) getAgentLoads(c *gin.Context) {
 	loads := s.engine.GetAgentLoads()
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   loads,
@@ -672,10 +2625,10 @@ func (s *APIServer) executeTaskOptimized(c *gin.Context) {
 		Input      string                 `json:"input"`
 		ModelName  string                 `json:"model_name"`
 		Parameters map[string]interface{} `json:"parameters"`
-		Priority   string                 `json:"priority"`   // "low", "normal", "high", "urgent"
-		Deadline   string                 `json:"deadline"`   // ISO 8601 timestamp
+		Priority   string                 `json:"priority"` // "low", "normal", "high", "urgent"
+		Deadline   string                 `json:"deadline"` // ISO 8601 timestamp
 	}
-	
+
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"status": "error",
@@ -683,7 +2636,7 @@ func (s *APIServer) executeTaskOptimized(c *gin.Context) {
 		})
 		return
 	}
-	
+
 	// Parse priority
 	var priority TaskPriority
 	switch req.Priority {
@@ -698,7 +2651,7 @@ func (s *APIServer) executeTaskOptimized(c *gin.Context) {
 	default:
 		priority = TaskPriorityNormal
 	}
-	
+
 	// Parse deadline
 	deadline := time.Now().Add(30 * time.Minute) // Default deadline
 	if req.Deadline != "" {
@@ -706,7 +2659,7 @@ func (s *APIServer) executeTaskOptimized(c *gin.Context) {
 			deadline = parsedDeadline
 		}
 	}
-	
+
 	// Create task
 	task := &Task{
 		ID:         fmt.Sprintf("opt-task-%d", time.Now().Unix()),
@@ -717,19 +2670,16 @@ func (s *APIServer) executeTaskOptimized(c *gin.Context) {
 		Status:     TaskStatusPending,
 		CreatedAt:  time.Now(),
 	}
-	
+
 	// Execute task with optimization
 	result, err := s.engine.ExecuteTaskOptimized(c.Request.Context(), task, priority, deadline)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"status": "error",
-			"error":  err.Error(),
-		})
+		respondError(c, err)
 		return
 	}
-	
+
 	c.JSON(http.StatusOK, gin.H{
 		"status": "success",
 		"data":   result,
 	})
-}
\ No newline at end of file
+}
@@ -0,0 +1,178 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// API key scopes. Read-only keys may only issue safe (GET/HEAD) requests;
+// admin keys may use every method.
+const (
+	APIScopeRead  = "read"
+	APIScopeAdmin = "admin"
+)
+
+// APIKey is a static credential with the access it grants.
+type APIKey struct {
+	Key string `json:"key"`
+	// Name labels the key in logs; it is never matched against requests.
+	Name  string `json:"name,omitempty"`
+	Scope string `json:"scope,omitempty"`
+	// Role grants viewer, operator, or admin access and supersedes Scope
+	// when set.
+	Role string `json:"role,omitempty"`
+	// Tenant scopes operator keys to the agents their tenant owns.
+	Tenant string `json:"tenant,omitempty"`
+	// RateLimit caps this key's API request rate; over-limit requests
+	// receive 429 with a Retry-After header. Zero leaves the key unlimited.
+	RateLimit RateLimitConfig `json:"rate_limit,omitempty"`
+}
+
+// APIAuthConfig holds the API keys the server accepts. An empty or nil
+// config leaves the server open, preserving the default behavior for
+// local single-user deployments.
+type APIAuthConfig struct {
+	Keys []APIKey `json:"keys"`
+}
+
+// LoadAPIAuthConfig reads an auth configuration from a JSON file of the
+// form {"keys": [{"key": "...", "name": "ci", "scope": "read"}]}.
+func LoadAPIAuthConfig(path string) (*APIAuthConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read auth config: %w", err)
+	}
+
+	var config APIAuthConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil, fmt.Errorf("failed to decode auth config %s: %w", path, err)
+	}
+	for i, key := range config.Keys {
+		if key.Key == "" {
+			return nil, fmt.Errorf("auth config key %d has an empty key", i)
+		}
+		switch key.Role {
+		case "", RoleViewer, RoleOperator, RoleAdmin:
+		default:
+			return nil, fmt.Errorf("auth config key %d has unknown role %q", i, key.Role)
+		}
+		if key.Role == "" && key.Scope != APIScopeRead && key.Scope != APIScopeAdmin {
+			return nil, fmt.Errorf("auth config key %d has unknown scope %q", i, key.Scope)
+		}
+	}
+	return &config, nil
+}
+
+// SetAuthConfig installs (or, with nil, removes) API key authentication
+// on the protected route groups, along with any per-key rate limits.
+func (s *APIServer) SetAuthConfig(config *APIAuthConfig) {
+	s.authMu.Lock()
+	previous := s.auth
+	s.auth = config
+	s.authMu.Unlock()
+
+	// Re-derive the per-key limiter scopes from the new key set
+	limiter := s.engine.GetRateLimiter()
+	if previous != nil {
+		for i := range previous.Keys {
+			limiter.SetLimit(keyScope(&previous.Keys[i]), RateLimitConfig{})
+		}
+	}
+	if config != nil {
+		for i := range config.Keys {
+			limiter.SetLimit(keyScope(&config.Keys[i]), config.Keys[i].RateLimit)
+		}
+	}
+}
+
+// getAuthConfig returns the installed auth configuration, or nil.
+func (s *APIServer) getAuthConfig() *APIAuthConfig {
+	s.authMu.RLock()
+	defer s.authMu.RUnlock()
+	return s.auth
+}
+
+// lookup returns the configured key matching a credential, or nil.
+func (a *APIAuthConfig) lookup(credential string) *APIKey {
+	for i := range a.Keys {
+		if a.Keys[i].Key == credential {
+			return &a.Keys[i]
+		}
+	}
+	return nil
+}
+
+// apiKeyFromRequest extracts the caller's credential from the X-API-Key
+// header or an Authorization bearer token.
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	return strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+}
+
+// apiKeyAuth enforces static API keys on a route group. Without a
+// configured key set every request passes, so auth is strictly opt-in.
+// Read-scoped keys are limited to safe methods; admin keys are not.
+func (s *APIServer) apiKeyAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		config := s.getAuthConfig()
+		if config == nil || len(config.Keys) == 0 {
+			c.Next()
+			return
+		}
+
+		key := config.lookup(apiKeyFromRequest(c))
+		if key == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error":  "invalid API key",
+			})
+			return
+		}
+
+		if key.role() == RoleViewer && c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"status": "error",
+				"error":  fmt.Sprintf("API key %q is read-only", key.Name),
+			})
+			return
+		}
+
+		// Expose the key so handlers can apply per-agent ownership rules
+		c.Set(callerKeyContext, key)
+		c.Next()
+	}
+}
+
+// keyRateLimit rejects requests from API keys that exceed their
+// configured rate, with a Retry-After hint. Keys without a limit — and
+// requests without a key — pass through.
+func (s *APIServer) keyRateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := callerKey(c)
+		if key == nil {
+			c.Next()
+			return
+		}
+
+		allowed, retryAfter := s.engine.GetRateLimiter().Allow(keyScope(key))
+		if !allowed {
+			limited := &RateLimitedError{Scope: keyScope(key), RetryAfter: retryAfter}
+			c.Header("Retry-After", strconv.Itoa(limited.RetrySeconds()))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"status": "error",
+				"code":   ErrorCode(limited),
+				"error":  limited.Error(),
+			})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,133 @@
+package orchestration
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+func TestLoadAPIAuthConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "auth.json")
+	raw := `{"keys": [{"key": "secret-1", "name": "ci", "scope": "read"}, {"key": "secret-2", "scope": "admin"}]}`
+	if err := os.WriteFile(path, []byte(raw), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	config, err := LoadAPIAuthConfig(path)
+	if err != nil {
+		t.Fatalf("LoadAPIAuthConfig failed: %v", err)
+	}
+	if len(config.Keys) != 2 || config.Keys[0].Scope != APIScopeRead {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(bad, []byte(`{"keys": [{"key": "k", "scope": "root"}]}`), 0o644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	if _, err := LoadAPIAuthConfig(bad); err == nil {
+		t.Error("Expected an error for an unknown scope")
+	}
+}
+
+func TestAPIKeyAuthEnforcesScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewAPIServer(NewEngine(api.Client{}))
+
+	request := func(method, path, key, body string) int {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		server.router.ServeHTTP(recorder, req)
+		return recorder.Code
+	}
+
+	// Without configured keys the server stays open
+	if code := request(http.MethodGet, "/api/agents/", "", ""); code != http.StatusOK {
+		t.Errorf("Expected an open server without keys, got %d", code)
+	}
+
+	server.SetAuthConfig(&APIAuthConfig{Keys: []APIKey{
+		{Key: "reader-key", Name: "reader", Scope: APIScopeRead},
+		{Key: "admin-key", Name: "admin", Scope: APIScopeAdmin},
+	}})
+
+	if code := request(http.MethodGet, "/api/agents/", "", ""); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a key, got %d", code)
+	}
+	if code := request(http.MethodGet, "/api/agents/", "wrong", ""); code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unknown key, got %d", code)
+	}
+	if code := request(http.MethodGet, "/api/agents/", "reader-key", ""); code != http.StatusOK {
+		t.Errorf("Expected read access for a read key, got %d", code)
+	}
+	if code := request(http.MethodPost, "/api/agents/", "reader-key", `{"name": "denied"}`); code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a read key writing, got %d", code)
+	}
+	if code := request(http.MethodPost, "/api/agents/", "admin-key", `{"name": "allowed"}`); code != http.StatusCreated {
+		t.Errorf("Expected an admin key to create an agent, got %d", code)
+	}
+
+	// Bearer tokens work as an alternative to X-API-Key
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/agents/", nil)
+	req.Header.Set("Authorization", "Bearer reader-key")
+	server.router.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Errorf("Expected bearer token auth to work, got %d", recorder.Code)
+	}
+}
+
+func TestAPIKeyRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := NewAPIServer(NewEngine(api.Client{}))
+	server.SetAuthConfig(&APIAuthConfig{Keys: []APIKey{
+		{Key: "fast-key", Name: "fast", Scope: APIScopeAdmin,
+			RateLimit: RateLimitConfig{RequestsPerMinute: 2}},
+		{Key: "free-key", Name: "free", Scope: APIScopeAdmin},
+	}})
+
+	request := func(key string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/api/agents/", nil)
+		req.Header.Set("X-API-Key", key)
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// The initial burst admits two requests; the third is rejected
+	for i := 0; i < 2; i++ {
+		if code := request("fast-key").Code; code != http.StatusOK {
+			t.Fatalf("Request %d should pass, got %d", i+1, code)
+		}
+	}
+	rejected := request("fast-key")
+	if rejected.Code != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 over the key limit, got %d", rejected.Code)
+	}
+	if rejected.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header on the 429 response")
+	}
+
+	// Other keys are unaffected
+	if code := request("free-key").Code; code != http.StatusOK {
+		t.Errorf("Unlimited key should pass, got %d", code)
+	}
+
+	// Rotating the config away removes the limiter scope
+	server.SetAuthConfig(nil)
+	if code := request("anything").Code; code != http.StatusOK {
+		t.Errorf("Expected an open server after removing keys, got %d", code)
+	}
+}
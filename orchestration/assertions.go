@@ -0,0 +1,142 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Assertion failure handling modes for workflow steps.
+const (
+	// AssertionAbort stops the workflow on a failed assertion (the default).
+	AssertionAbort = "abort"
+	// AssertionRetry re-runs the step with failure feedback appended.
+	AssertionRetry = "retry"
+	// AssertionBranch jumps to the step named by RecoveryStep.
+	AssertionBranch = "branch"
+)
+
+// defaultAssertionRetries is how often a step retries on failed assertions
+// when no explicit MaxRetries is set.
+const defaultAssertionRetries = 2
+
+// StepAssertion declares a check a workflow step's output must pass.
+type StepAssertion struct {
+	// Type selects the check: "contains", "regex", "json_schema", or
+	// "score" (a numeric threshold judged by an evaluator model).
+	Type string `json:"type"`
+	// Value is the substring for "contains" or the pattern for "regex".
+	Value string `json:"value,omitempty"`
+	// Schema is the JSON schema the output must satisfy for "json_schema".
+	Schema map[string]interface{} `json:"schema,omitempty"`
+	// Criteria describes what the evaluator model should judge for "score".
+	Criteria string `json:"criteria,omitempty"`
+	// MinScore is the minimum acceptable evaluator score (0-10).
+	MinScore float64 `json:"min_score,omitempty"`
+	// EvaluatorModel overrides the agent's model for "score" assertions.
+	EvaluatorModel string `json:"evaluator_model,omitempty"`
+}
+
+// checkStepAssertions runs every assertion of a step against its output,
+// returning the first failure.
+func (e *Engine) checkStepAssertions(ctx context.Context, agent *Agent, step *WorkflowStep, output string) error {
+	for i, assertion := range step.Assertions {
+		if err := e.checkAssertion(ctx, agent, &assertion, output); err != nil {
+			return fmt.Errorf("assertion %d (%s) failed: %w", i+1, assertion.Type, err)
+		}
+	}
+	return nil
+}
+
+// checkAssertion evaluates a single assertion against a step output.
+func (e *Engine) checkAssertion(ctx context.Context, agent *Agent, assertion *StepAssertion, output string) error {
+	switch assertion.Type {
+	case "contains":
+		if !strings.Contains(output, assertion.Value) {
+			return fmt.Errorf("output does not contain %q", assertion.Value)
+		}
+	case "regex":
+		pattern, err := regexp.Compile(assertion.Value)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", assertion.Value, err)
+		}
+		if !pattern.MatchString(output) {
+			return fmt.Errorf("output does not match %q", assertion.Value)
+		}
+	case "json_schema":
+		value, err := extractJSON(output)
+		if err != nil {
+			return fmt.Errorf("output is not valid JSON: %w", err)
+		}
+		if err := ValidateJSONSchema(value, assertion.Schema); err != nil {
+			return fmt.Errorf("output violates schema: %w", err)
+		}
+	case "score":
+		score, err := e.evaluateOutputScore(ctx, agent, assertion, output)
+		if err != nil {
+			return fmt.Errorf("evaluator failed: %w", err)
+		}
+		if score < assertion.MinScore {
+			return fmt.Errorf("evaluator score %.1f below threshold %.1f", score, assertion.MinScore)
+		}
+	default:
+		return fmt.Errorf("unknown assertion type %q", assertion.Type)
+	}
+	return nil
+}
+
+// scorePattern extracts the first number from an evaluator response.
+var scorePattern = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// evaluateOutputScore asks an evaluator model to rate a step output from 0
+// to 10 against the assertion's criteria.
+func (e *Engine) evaluateOutputScore(ctx context.Context, agent *Agent, assertion *StepAssertion, output string) (float64, error) {
+	criteria := assertion.Criteria
+	if criteria == "" {
+		criteria = "overall quality and correctness"
+	}
+
+	prompt := fmt.Sprintf(
+		"Rate the following output on a scale from 0 to 10 for: %s.\nRespond with only the number.\n\nOutput:\n%s",
+		criteria, output)
+	evaluation := &Task{
+		ID:        uuid.New().String(),
+		Type:      TaskTypeGenerate,
+		Input:     prompt,
+		AgentID:   agent.ID,
+		ModelName: assertion.EvaluatorModel,
+	}
+
+	result, err := e.executeGenerateTask(ctx, evaluation, agent)
+	if err != nil {
+		return 0, err
+	}
+
+	match := scorePattern.FindString(result.Output)
+	if match == "" {
+		return 0, fmt.Errorf("evaluator response %q contains no score", result.Output)
+	}
+	return strconv.ParseFloat(match, 64)
+}
+
+// assertionFeedbackInput appends assertion failure feedback to a step input
+// so a retried step can correct itself.
+func assertionFeedbackInput(input, previousOutput string, failure error) string {
+	return fmt.Sprintf(
+		"%s\n\nYour previous answer failed a check: %v\nPrevious answer:\n%s\nProduce a corrected answer.",
+		input, failure, previousOutput)
+}
+
+// stepIndexByName returns the index of the named step, or -1.
+func stepIndexByName(steps []WorkflowStep, name string) int {
+	for i, step := range steps {
+		if step.Name == name {
+			return i
+		}
+	}
+	return -1
+}
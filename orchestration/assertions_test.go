@@ -0,0 +1,180 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestCheckAssertionTypes(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	agent := &Agent{Name: "checker", Models: []string{"llama3.2"}}
+
+	cases := []struct {
+		name      string
+		assertion StepAssertion
+		output    string
+		wantErr   bool
+	}{
+		{"contains pass", StepAssertion{Type: "contains", Value: "done"}, "all done here", false},
+		{"contains fail", StepAssertion{Type: "contains", Value: "done"}, "still working", true},
+		{"regex pass", StepAssertion{Type: "regex", Value: `\d{3}`}, "code 404 returned", false},
+		{"regex fail", StepAssertion{Type: "regex", Value: `\d{3}`}, "no digits", true},
+		{"regex invalid", StepAssertion{Type: "regex", Value: `(`}, "anything", true},
+		{"schema pass", StepAssertion{Type: "json_schema", Schema: map[string]interface{}{
+			"type": "object", "required": []interface{}{"ok"},
+		}}, `{"ok": true}`, false},
+		{"schema fail", StepAssertion{Type: "json_schema", Schema: map[string]interface{}{
+			"type": "object", "required": []interface{}{"ok"},
+		}}, `{"nope": 1}`, true},
+		{"unknown type", StepAssertion{Type: "mystery"}, "anything", true},
+	}
+
+	for _, tc := range cases {
+		err := engine.checkAssertion(ctx, agent, &tc.assertion, tc.output)
+		if tc.wantErr && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+		if !tc.wantErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+	}
+}
+
+func TestWorkflowAssertionAbort(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	steps := []WorkflowStep{
+		{
+			Name:       "impossible",
+			Type:       TaskTypeCustom,
+			Input:      "do something",
+			Assertions: []StepAssertion{{Type: "contains", Value: "unicorn"}},
+		},
+		{Name: "unreached", Type: TaskTypeCustom, Input: "never runs"},
+	}
+
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("Workflow failed to run: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected workflow failure on unmet assertion")
+	}
+	if !strings.Contains(result.Error, "failed assertions") {
+		t.Errorf("Expected assertion failure in error, got: %s", result.Error)
+	}
+	if result.Steps[0].Success {
+		t.Error("Expected first step marked unsuccessful")
+	}
+}
+
+func TestWorkflowAssertionBranch(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	steps := []WorkflowStep{
+		{
+			Name:               "strict",
+			Type:               TaskTypeCustom,
+			Input:              "attempt the strict path",
+			Assertions:         []StepAssertion{{Type: "contains", Value: "unicorn"}},
+			OnAssertionFailure: AssertionBranch,
+			RecoveryStep:       "fallback",
+		},
+		{
+			Name:       "fallback",
+			Type:       TaskTypeCustom,
+			Input:      "recover gracefully",
+			Assertions: []StepAssertion{{Type: "contains", Value: "completed"}},
+		},
+	}
+
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("Workflow failed to run: %v", err)
+	}
+	if !result.Success {
+		t.Errorf("Expected branch recovery to succeed, got error: %s", result.Error)
+	}
+	if result.Steps[0].Success {
+		t.Error("Expected strict step marked unsuccessful")
+	}
+	if !result.Steps[1].Success {
+		t.Error("Expected fallback step to succeed")
+	}
+}
+
+func TestWorkflowAssertionRetryExhaustion(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// A custom task never produces "unicorn", so every retry fails and the
+	// workflow aborts after exhausting attempts
+	steps := []WorkflowStep{
+		{
+			Name:               "stubborn",
+			Type:               TaskTypeCustom,
+			Input:              "try again",
+			Assertions:         []StepAssertion{{Type: "contains", Value: "unicorn"}},
+			OnAssertionFailure: AssertionRetry,
+			MaxRetries:         1,
+		},
+	}
+
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("Workflow failed to run: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected workflow failure after retries exhausted")
+	}
+}
+
+func TestWorkflowBranchLoopAborts(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// A step that recovers to itself must hit the visit cap, not loop
+	steps := []WorkflowStep{
+		{
+			Name:               "ouroboros",
+			Type:               TaskTypeCustom,
+			Input:              "round and round",
+			Assertions:         []StepAssertion{{Type: "contains", Value: "unicorn"}},
+			OnAssertionFailure: AssertionBranch,
+			RecoveryStep:       "ouroboros",
+		},
+	}
+
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("Workflow failed to run: %v", err)
+	}
+	if result.Success {
+		t.Error("Expected looping workflow to abort")
+	}
+	if !strings.Contains(result.Error, "visits") {
+		t.Errorf("Expected visit cap in error, got: %s", result.Error)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build audit
+
+package orchestration
+
+import (
+	"context"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+)
+
+// Audit mode (-tags audit) compiles runtime invariant checks into the
+// heavily goroutine-based engine. Violations are logged with a stack trace
+// rather than panicking, so a full test run surfaces every breach. Release
+// builds compile the checks away entirely (see audit_off.go).
+const auditEnabled = true
+
+// auditViolation logs a broken invariant with the offending stack.
+func auditViolation(invariant string, attrs ...any) {
+	attrs = append([]any{"invariant", invariant, "stack", string(debug.Stack())}, attrs...)
+	slog.Error("Concurrency invariant violated", attrs...)
+}
+
+// auditContextLive asserts that work is not starting under a context that
+// has already been cancelled.
+func auditContextLive(ctx context.Context, invariant string, attrs ...any) {
+	if err := ctx.Err(); err != nil {
+		auditViolation(invariant, append(attrs, "context_error", err)...)
+	}
+}
+
+// auditLockHeld asserts that the caller holds mu exclusively. A successful
+// TryLock means nobody held the lock, which is exactly the breach.
+func auditLockHeld(mu *sync.RWMutex, invariant string, attrs ...any) {
+	if mu.TryLock() {
+		mu.Unlock()
+		auditViolation(invariant, attrs...)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !audit
+
+package orchestration
+
+import (
+	"context"
+	"sync"
+)
+
+// Release builds compile the audit assertions away; build with -tags audit
+// to enable them (see audit.go).
+const auditEnabled = false
+
+func auditViolation(invariant string, attrs ...any)                        {}
+func auditContextLive(ctx context.Context, invariant string, attrs ...any) {}
+func auditLockHeld(mu *sync.RWMutex, invariant string, attrs ...any)       {}
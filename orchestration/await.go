@@ -0,0 +1,114 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DelegationHandle tracks a task spawned asynchronously from a conversation
+// task message, so callers can join on its completion instead of polling the
+// conversation for a response message.
+type DelegationHandle struct {
+	// TaskID is the spawned task's ID.
+	TaskID string `json:"task_id"`
+	// MessageID is the originating task message's ID.
+	MessageID string `json:"message_id"`
+	// ConversationID is the conversation the delegation belongs to.
+	ConversationID string `json:"conversation_id"`
+
+	mu                sync.Mutex
+	responseMessageID string
+	result            *TaskResult
+	err               error
+	done              chan struct{}
+}
+
+// Done returns a channel closed when the delegated task has finished and its
+// response message (if any) has been sent.
+func (h *DelegationHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Await blocks until the delegated task completes or the context is
+// cancelled, returning the task's result.
+func (h *DelegationHandle) Await(ctx context.Context) (*TaskResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("await cancelled for task %s: %w", h.TaskID, ctx.Err())
+	case <-h.done:
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.result, h.err
+}
+
+// ResponseMessageID returns the ID of the response message the delegation
+// produced, or empty if none was sent (yet).
+func (h *DelegationHandle) ResponseMessageID() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.responseMessageID
+}
+
+// setResponseMessage records the response message sent for this delegation.
+func (h *DelegationHandle) setResponseMessage(messageID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.responseMessageID = messageID
+}
+
+// complete finishes the handle with the task's outcome and unblocks waiters.
+func (h *DelegationHandle) complete(result *TaskResult, err error) {
+	h.mu.Lock()
+	h.result = result
+	h.err = err
+	h.mu.Unlock()
+	close(h.done)
+}
+
+// registerDelegation creates and indexes a handle for a task spawned from a
+// conversation message.
+func (e *Engine) registerDelegation(task *Task, message *Message, conversationID string) *DelegationHandle {
+	handle := &DelegationHandle{
+		TaskID:         task.ID,
+		MessageID:      message.ID,
+		ConversationID: conversationID,
+		done:           make(chan struct{}),
+	}
+
+	e.delegationMu.Lock()
+	e.delegations[task.ID] = handle
+	e.delegationsByMessage[message.ID] = handle
+	e.delegationMu.Unlock()
+	return handle
+}
+
+// GetDelegation returns the handle for a delegated task by task ID.
+func (e *Engine) GetDelegation(taskID string) (*DelegationHandle, bool) {
+	e.delegationMu.RLock()
+	defer e.delegationMu.RUnlock()
+	handle, exists := e.delegations[taskID]
+	return handle, exists
+}
+
+// DelegationForMessage returns the handle for the task spawned by a
+// conversation message, correlating responses to their originating request.
+func (e *Engine) DelegationForMessage(messageID string) (*DelegationHandle, bool) {
+	e.delegationMu.RLock()
+	defer e.delegationMu.RUnlock()
+	handle, exists := e.delegationsByMessage[messageID]
+	return handle, exists
+}
+
+// AwaitMessageResponse blocks until the task spawned by a conversation
+// message completes, returning its result. It fails immediately when the
+// message spawned no task.
+func (e *Engine) AwaitMessageResponse(ctx context.Context, messageID string) (*TaskResult, error) {
+	handle, exists := e.DelegationForMessage(messageID)
+	if !exists {
+		return nil, fmt.Errorf("delegation %w: no task spawned for message %s", ErrNotFound, messageID)
+	}
+	return handle.Await(ctx)
+}
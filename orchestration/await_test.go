@@ -0,0 +1,78 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestAwaitDelegatedTask(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	sender := &Agent{Name: "sender", Models: []string{"llama3.2"}}
+	receiver := &Agent{Name: "receiver", Models: []string{"llama3.2"}}
+	for _, agent := range []*Agent{sender, receiver} {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+
+	conversation, err := engine.StartConversation(ctx, []string{sender.ID, receiver.ID}, "await test")
+	if err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+
+	message := &Message{
+		FromAgentID: sender.ID,
+		ToAgentID:   receiver.ID,
+		Content:     "handle this",
+		Type:        MessageTypeTask,
+	}
+	if err := engine.SendMessage(ctx, conversation.ID, message); err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+
+	handle, exists := engine.DelegationForMessage(message.ID)
+	if !exists {
+		t.Fatal("Expected a delegation handle for the task message")
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	result, err := handle.Await(waitCtx)
+	if err != nil {
+		t.Fatalf("Await failed: %v", err)
+	}
+	if result == nil {
+		t.Fatal("Expected a task result from the delegation")
+	}
+	if handle.ResponseMessageID() == "" {
+		t.Error("Expected the handle to record its response message")
+	}
+	if _, exists := engine.GetDelegation(handle.TaskID); !exists {
+		t.Error("Expected the handle to be retrievable by task ID")
+	}
+}
+
+func TestAwaitMessageResponseUnknownMessage(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	if _, err := engine.AwaitMessageResponse(context.Background(), "no-such-message"); err == nil {
+		t.Error("Expected an error for a message that spawned no task")
+	}
+}
+
+func TestAwaitCancellation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	task := &Task{ID: "pending-task"}
+	message := &Message{ID: "pending-message"}
+	handle := engine.registerDelegation(task, message, "conv")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := handle.Await(ctx); err == nil {
+		t.Error("Expected cancellation error while the delegation is still running")
+	}
+}
@@ -0,0 +1,135 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// BackpressureConfig bounds how much outstanding work the engine accepts
+// before task submissions are shed with a Retry-After hint. Zero values
+// leave the engine unbounded.
+type BackpressureConfig struct {
+	// MaxPending caps tasks that are queued or executing.
+	MaxPending int `json:"max_pending,omitempty"`
+	// RetryAfterSeconds is the hint returned to shed clients; it defaults
+	// to defaultBackpressureRetry.
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+}
+
+// defaultBackpressureRetry is the Retry-After hint used when a config does
+// not set one.
+const defaultBackpressureRetry = 5 * time.Second
+
+// BackpressureError reports a shed submission along with the load that
+// caused it. It wraps ErrBackpressure so the API maps it to 503.
+type BackpressureError struct {
+	Pending    int
+	Limit      int
+	RetryAfter time.Duration
+}
+
+func (e *BackpressureError) Error() string {
+	return fmt.Sprintf("queue saturated: %d tasks outstanding (limit %d), retry in %s",
+		e.Pending, e.Limit, e.RetryAfter.Round(time.Second))
+}
+
+func (e *BackpressureError) Unwrap() error { return ErrBackpressure }
+
+// RetrySeconds renders RetryAfter for a Retry-After header, rounded up to
+// at least one second.
+func (e *BackpressureError) RetrySeconds() int {
+	seconds := int(e.RetryAfter.Seconds())
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// BackpressureStatus reports the current load and configured thresholds.
+type BackpressureStatus struct {
+	Load   int                           `json:"load"`
+	Limits map[string]BackpressureConfig `json:"limits"`
+}
+
+// SetBackpressureLimit installs the outstanding-work threshold for a
+// tenant; the empty tenant sets the default applied to callers without
+// one. A zero config removes the threshold.
+func (e *Engine) SetBackpressureLimit(tenant string, config BackpressureConfig) {
+	e.backpressureMu.Lock()
+	defer e.backpressureMu.Unlock()
+
+	if config.MaxPending <= 0 {
+		delete(e.backpressure, tenant)
+	} else {
+		e.backpressure[tenant] = config
+	}
+	slog.Info("Set backpressure limit", "tenant", tenant, "max_pending", config.MaxPending)
+}
+
+// backpressureLimit returns the config for a tenant, falling back to the
+// default. The second result is false when no threshold applies.
+func (e *Engine) backpressureLimit(tenant string) (BackpressureConfig, bool) {
+	e.backpressureMu.RLock()
+	defer e.backpressureMu.RUnlock()
+
+	if config, exists := e.backpressure[tenant]; exists {
+		return config, true
+	}
+	config, exists := e.backpressure[""]
+	return config, exists
+}
+
+// pendingLoad reports how many tasks are queued or executing across the
+// shared queue and direct execution paths.
+func (e *Engine) pendingLoad(ctx context.Context) int {
+	load := int(e.activeTasks.Load())
+
+	e.mu.RLock()
+	queue := e.taskQueue
+	e.mu.RUnlock()
+	if queue != nil {
+		depth, err := queue.Depth(ctx)
+		if err != nil {
+			clog(ctx).Error("Failed to read queue depth", "error", err)
+		} else {
+			load += depth
+		}
+	}
+	return load
+}
+
+// CheckBackpressure rejects new work for a tenant once its outstanding
+// load reaches the configured threshold, so saturated deployments shed
+// submissions instead of accepting unbounded work.
+func (e *Engine) CheckBackpressure(ctx context.Context, tenant string) error {
+	config, limited := e.backpressureLimit(tenant)
+	if !limited {
+		return nil
+	}
+
+	load := e.pendingLoad(ctx)
+	if load < config.MaxPending {
+		return nil
+	}
+
+	retry := defaultBackpressureRetry
+	if config.RetryAfterSeconds > 0 {
+		retry = time.Duration(config.RetryAfterSeconds) * time.Second
+	}
+	return &BackpressureError{Pending: load, Limit: config.MaxPending, RetryAfter: retry}
+}
+
+// GetBackpressureStatus reports the current load and every configured
+// threshold.
+func (e *Engine) GetBackpressureStatus(ctx context.Context) BackpressureStatus {
+	e.backpressureMu.RLock()
+	limits := make(map[string]BackpressureConfig, len(e.backpressure))
+	for tenant, config := range e.backpressure {
+		limits[tenant] = config
+	}
+	e.backpressureMu.RUnlock()
+
+	return BackpressureStatus{Load: e.pendingLoad(ctx), Limits: limits}
+}
@@ -0,0 +1,89 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+func TestCheckBackpressureThresholds(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	// Unconfigured engines accept unbounded work
+	if err := engine.CheckBackpressure(ctx, ""); err != nil {
+		t.Fatalf("Expected no backpressure without limits: %v", err)
+	}
+
+	queue := NewInMemoryTaskQueue(time.Minute, 3)
+	engine.SetTaskQueue(queue)
+	for i := 0; i < 3; i++ {
+		if err := queue.Enqueue(ctx, &Task{Type: TaskTypeCustom}); err != nil {
+			t.Fatalf("Enqueue failed: %v", err)
+		}
+	}
+
+	engine.SetBackpressureLimit("", BackpressureConfig{MaxPending: 2, RetryAfterSeconds: 7})
+	err := engine.CheckBackpressure(ctx, "")
+	if !errors.Is(err, ErrBackpressure) {
+		t.Fatalf("Expected ErrBackpressure over the default limit, got %v", err)
+	}
+	var shed *BackpressureError
+	if !errors.As(err, &shed) || shed.Pending < 3 || shed.RetrySeconds() != 7 {
+		t.Errorf("Unexpected backpressure detail: %+v", shed)
+	}
+
+	// A roomier tenant override still admits that tenant's work
+	engine.SetBackpressureLimit("tenant-a", BackpressureConfig{MaxPending: 10})
+	if err := engine.CheckBackpressure(ctx, "tenant-a"); err != nil {
+		t.Errorf("Expected tenant override to admit work: %v", err)
+	}
+
+	// Removing the default limit reopens the engine
+	engine.SetBackpressureLimit("", BackpressureConfig{})
+	if err := engine.CheckBackpressure(ctx, ""); err != nil {
+		t.Errorf("Expected no backpressure after removing the limit: %v", err)
+	}
+}
+
+func TestBackpressureShedsTaskSubmissions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := NewEngine(api.Client{})
+	server := NewAPIServer(engine)
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	queue := NewInMemoryTaskQueue(time.Minute, 3)
+	engine.SetTaskQueue(queue)
+	if err := queue.Enqueue(ctx, &Task{Type: TaskTypeCustom}); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	engine.SetBackpressureLimit("", BackpressureConfig{MaxPending: 1})
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/agents/"+agent.ID+"/tasks",
+		strings.NewReader(`{"type": "custom", "input": "shed me"}`))
+	req.Header.Set("Content-Type", "application/json")
+	server.router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 from a saturated queue, got %d: %s", recorder.Code, recorder.Body)
+	}
+	if recorder.Header().Get("Retry-After") == "" {
+		t.Error("Expected a Retry-After header on the shed response")
+	}
+	if !strings.Contains(recorder.Body.String(), "queue_position") {
+		t.Errorf("Expected a queue-position hint, got %s", recorder.Body)
+	}
+}
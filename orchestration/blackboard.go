@@ -0,0 +1,233 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Blackboard is a structured shared state object attached to a conversation.
+// Agents collaborate through it blackboard-style: small facts go in Values,
+// larger working artifacts in Documents.
+type Blackboard struct {
+	Values    map[string]interface{}         `json:"values,omitempty"`
+	Documents map[string]*BlackboardDocument `json:"documents,omitempty"`
+	UpdatedAt time.Time                      `json:"updated_at"`
+}
+
+// BlackboardDocument is a named working document on a blackboard. Versions
+// increment on every write so agents can detect concurrent edits.
+type BlackboardDocument struct {
+	Name      string    `json:"name"`
+	Content   string    `json:"content"`
+	AuthorID  string    `json:"author_id,omitempty"`
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// blackboardForUpdate returns a conversation's blackboard, creating it on
+// first use. The caller must hold e.mu.
+func (e *Engine) blackboardForUpdate(ctx context.Context, conversationID string) (*Conversation, *Blackboard, error) {
+	conversation, exists := e.conversations[conversationID]
+	if !exists {
+		stored, err := e.store.GetConversation(ctx, conversationID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrConversationNotFound, conversationID)
+		}
+		conversation = stored
+		e.conversations[conversationID] = conversation
+	}
+
+	if conversation.Blackboard == nil {
+		conversation.Blackboard = &Blackboard{
+			Values:    make(map[string]interface{}),
+			Documents: make(map[string]*BlackboardDocument),
+		}
+	}
+	return conversation, conversation.Blackboard, nil
+}
+
+// ReadBlackboard returns a snapshot of a conversation's blackboard.
+func (e *Engine) ReadBlackboard(ctx context.Context, conversationID string) (*Blackboard, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	_, board, err := e.blackboardForUpdate(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Blackboard{
+		Values:    make(map[string]interface{}, len(board.Values)),
+		Documents: make(map[string]*BlackboardDocument, len(board.Documents)),
+		UpdatedAt: board.UpdatedAt,
+	}
+	for key, value := range board.Values {
+		snapshot.Values[key] = value
+	}
+	for name, doc := range board.Documents {
+		copied := *doc
+		snapshot.Documents[name] = &copied
+	}
+	return snapshot, nil
+}
+
+// SetBlackboardValue writes a key-value entry on a conversation's blackboard.
+// Observers may read but not write.
+func (e *Engine) SetBlackboardValue(ctx context.Context, conversationID, agentID, key string, value interface{}) error {
+	if key == "" {
+		return fmt.Errorf("blackboard key must not be empty")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	conversation, board, err := e.blackboardForUpdate(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if participantRole(conversation, agentID) == RoleObserver {
+		return fmt.Errorf("%w: observer %s cannot write to the blackboard", ErrRoleForbidden, agentID)
+	}
+
+	board.Values[key] = value
+	board.UpdatedAt = time.Now()
+	conversation.UpdatedAt = board.UpdatedAt
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to persist conversation: %w", err)
+	}
+	return nil
+}
+
+// DeleteBlackboardValue removes a key-value entry from a conversation's
+// blackboard.
+func (e *Engine) DeleteBlackboardValue(ctx context.Context, conversationID, agentID, key string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	conversation, board, err := e.blackboardForUpdate(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+	if participantRole(conversation, agentID) == RoleObserver {
+		return fmt.Errorf("%w: observer %s cannot write to the blackboard", ErrRoleForbidden, agentID)
+	}
+
+	delete(board.Values, key)
+	board.UpdatedAt = time.Now()
+	conversation.UpdatedAt = board.UpdatedAt
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to persist conversation: %w", err)
+	}
+	return nil
+}
+
+// WriteBlackboardDocument creates or replaces a named document on a
+// conversation's blackboard, bumping its version.
+func (e *Engine) WriteBlackboardDocument(ctx context.Context, conversationID, agentID, name, content string) (*BlackboardDocument, error) {
+	if name == "" {
+		return nil, fmt.Errorf("blackboard document name must not be empty")
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	conversation, board, err := e.blackboardForUpdate(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if participantRole(conversation, agentID) == RoleObserver {
+		return nil, fmt.Errorf("%w: observer %s cannot write to the blackboard", ErrRoleForbidden, agentID)
+	}
+
+	doc := board.Documents[name]
+	if doc == nil {
+		doc = &BlackboardDocument{Name: name}
+		board.Documents[name] = doc
+	}
+	doc.Content = content
+	doc.AuthorID = agentID
+	doc.Version++
+	doc.UpdatedAt = time.Now()
+	board.UpdatedAt = doc.UpdatedAt
+	conversation.UpdatedAt = doc.UpdatedAt
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return nil, fmt.Errorf("failed to persist conversation: %w", err)
+	}
+
+	copied := *doc
+	return &copied, nil
+}
+
+// executeBlackboardTask lets agents read and write the conversation
+// blackboard through the task interface. Parameters select the action:
+// "get"/"set"/"delete" for values, "read_document"/"write_document" for
+// documents, and "read" (the default) for a full snapshot.
+func (e *Engine) executeBlackboardTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	conversationID, _ := task.Parameters["conversation_id"].(string)
+	if conversationID == "" {
+		return nil, fmt.Errorf("blackboard task requires a conversation_id parameter")
+	}
+
+	action, _ := task.Parameters["action"].(string)
+	if action == "" {
+		action = "read"
+	}
+	key, _ := task.Parameters["key"].(string)
+	name, _ := task.Parameters["document"].(string)
+
+	var output interface{}
+	switch action {
+	case "read":
+		board, err := e.ReadBlackboard(ctx, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		output = board
+	case "get":
+		board, err := e.ReadBlackboard(ctx, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		output = map[string]interface{}{"key": key, "value": board.Values[key]}
+	case "set":
+		if err := e.SetBlackboardValue(ctx, conversationID, agent.ID, key, task.Parameters["value"]); err != nil {
+			return nil, err
+		}
+		output = map[string]interface{}{"key": key, "set": true}
+	case "delete":
+		if err := e.DeleteBlackboardValue(ctx, conversationID, agent.ID, key); err != nil {
+			return nil, err
+		}
+		output = map[string]interface{}{"key": key, "deleted": true}
+	case "read_document":
+		board, err := e.ReadBlackboard(ctx, conversationID)
+		if err != nil {
+			return nil, err
+		}
+		doc, exists := board.Documents[name]
+		if !exists {
+			return nil, fmt.Errorf("blackboard document %w: %s", ErrNotFound, name)
+		}
+		output = doc
+	case "write_document":
+		content, _ := task.Parameters["content"].(string)
+		if content == "" {
+			content = task.Input
+		}
+		doc, err := e.WriteBlackboardDocument(ctx, conversationID, agent.ID, name, content)
+		if err != nil {
+			return nil, err
+		}
+		output = doc
+	default:
+		return nil, fmt.Errorf("unknown blackboard action: %s", action)
+	}
+
+	encoded, err := json.Marshal(output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blackboard output: %w", err)
+	}
+	return &TaskResult{TaskID: task.ID, Output: string(encoded)}, nil
+}
@@ -0,0 +1,134 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestBlackboardValues(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "design doc")
+
+	if err := engine.SetBlackboardValue(ctx, conversation.ID, agents[0].ID, "status", "drafting"); err != nil {
+		t.Fatalf("Failed to set value: %v", err)
+	}
+
+	board, err := engine.ReadBlackboard(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("Failed to read blackboard: %v", err)
+	}
+	if board.Values["status"] != "drafting" {
+		t.Errorf("Expected status=drafting, got %v", board.Values["status"])
+	}
+
+	if err := engine.DeleteBlackboardValue(ctx, conversation.ID, agents[0].ID, "status"); err != nil {
+		t.Fatalf("Failed to delete value: %v", err)
+	}
+	board, _ = engine.ReadBlackboard(ctx, conversation.ID)
+	if _, exists := board.Values["status"]; exists {
+		t.Error("Expected deleted key to be gone")
+	}
+}
+
+func TestBlackboardDocumentVersions(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "design doc")
+
+	doc, err := engine.WriteBlackboardDocument(ctx, conversation.ID, agents[0].ID, "outline", "v1 draft")
+	if err != nil {
+		t.Fatalf("Failed to write document: %v", err)
+	}
+	if doc.Version != 1 {
+		t.Errorf("Expected version 1, got %d", doc.Version)
+	}
+
+	doc, err = engine.WriteBlackboardDocument(ctx, conversation.ID, agents[1].ID, "outline", "v2 draft")
+	if err != nil {
+		t.Fatalf("Failed to rewrite document: %v", err)
+	}
+	if doc.Version != 2 || doc.AuthorID != agents[1].ID {
+		t.Errorf("Expected version 2 by second author, got v%d by %s", doc.Version, doc.AuthorID)
+	}
+}
+
+func TestBlackboardObserverReadOnly(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "design doc")
+
+	if err := engine.SetParticipantRole(ctx, conversation.ID, agents[2].ID, RoleObserver); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	err := engine.SetBlackboardValue(ctx, conversation.ID, agents[2].ID, "status", "meddling")
+	if !errors.Is(err, ErrRoleForbidden) {
+		t.Errorf("Expected ErrRoleForbidden for observer write, got %v", err)
+	}
+	if _, err := engine.ReadBlackboard(ctx, conversation.ID); err != nil {
+		t.Errorf("Expected observer read to succeed, got %v", err)
+	}
+}
+
+func TestBlackboardTask(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "design doc")
+
+	set := &Task{
+		ID:   "bb-set",
+		Type: TaskTypeBlackboard,
+		Parameters: map[string]interface{}{
+			"conversation_id": conversation.ID,
+			"action":          "set",
+			"key":             "owner",
+			"value":           agents[0].Name,
+		},
+		AgentID: agents[0].ID,
+	}
+	if _, err := engine.ExecuteTask(ctx, set, agents[0]); err != nil {
+		t.Fatalf("Failed to execute set task: %v", err)
+	}
+
+	get := &Task{
+		ID:   "bb-get",
+		Type: TaskTypeBlackboard,
+		Parameters: map[string]interface{}{
+			"conversation_id": conversation.ID,
+			"action":          "get",
+			"key":             "owner",
+		},
+		AgentID: agents[1].ID,
+	}
+	result, err := engine.ExecuteTask(ctx, get, agents[1])
+	if err != nil {
+		t.Fatalf("Failed to execute get task: %v", err)
+	}
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal([]byte(result.Output), &decoded); err != nil {
+		t.Fatalf("Failed to decode output: %v", err)
+	}
+	if decoded["value"] != agents[0].Name {
+		t.Errorf("Expected value %q, got %v", agents[0].Name, decoded["value"])
+	}
+
+	missing := &Task{
+		ID:   "bb-missing",
+		Type: TaskTypeBlackboard,
+		Parameters: map[string]interface{}{
+			"conversation_id": conversation.ID,
+			"action":          "read_document",
+			"document":        "nope",
+		},
+		AgentID: agents[0].ID,
+	}
+	if _, err := engine.ExecuteTask(ctx, missing, agents[0]); err == nil {
+		t.Error("Expected error for missing document")
+	}
+}
@@ -0,0 +1,130 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// ConversationBudget bounds how much work a conversation's task delegations
+// may consume. Zero values leave that dimension unlimited.
+type ConversationBudget struct {
+	// MaxDelegatedTasks caps how many tasks the conversation may spawn.
+	MaxDelegatedTasks int `json:"max_delegated_tasks,omitempty"`
+	// MaxTokens caps the total model tokens consumed by delegated tasks.
+	MaxTokens int `json:"max_tokens,omitempty"`
+	// MaxSeconds caps the conversation's wall-clock lifetime for new
+	// delegations, measured from its creation.
+	MaxSeconds int `json:"max_seconds,omitempty"`
+}
+
+// unlimited reports whether no dimension of the budget is set.
+func (b ConversationBudget) unlimited() bool {
+	return b.MaxDelegatedTasks <= 0 && b.MaxTokens <= 0 && b.MaxSeconds <= 0
+}
+
+// conversationBudgetState tracks a conversation's consumption against its
+// budget.
+type conversationBudgetState struct {
+	config         ConversationBudget
+	delegatedTasks int
+	tokens         int
+}
+
+// SetConversationBudget installs or replaces a conversation's delegation
+// budget. A zero budget removes the limit.
+func (e *Engine) SetConversationBudget(conversationID string, budget ConversationBudget) {
+	e.budgetMu.Lock()
+	defer e.budgetMu.Unlock()
+
+	if budget.unlimited() {
+		delete(e.budgets, conversationID)
+		return
+	}
+	if state, exists := e.budgets[conversationID]; exists {
+		state.config = budget
+	} else {
+		e.budgets[conversationID] = &conversationBudgetState{config: budget}
+	}
+	slog.Info("Set conversation budget", "conversation_id", conversationID,
+		"max_delegated_tasks", budget.MaxDelegatedTasks, "max_tokens", budget.MaxTokens, "max_seconds", budget.MaxSeconds)
+}
+
+// GetConversationBudget returns a conversation's budget and whether one is
+// set.
+func (e *Engine) GetConversationBudget(conversationID string) (ConversationBudget, bool) {
+	e.budgetMu.Lock()
+	defer e.budgetMu.Unlock()
+
+	state, exists := e.budgets[conversationID]
+	if !exists {
+		return ConversationBudget{}, false
+	}
+	return state.config, true
+}
+
+// admitDelegation charges one delegated task against the conversation's
+// budget and reports whether it may proceed. The caller must hold e.mu.
+func (e *Engine) admitDelegation(conversation *Conversation) (string, bool) {
+	e.budgetMu.Lock()
+	defer e.budgetMu.Unlock()
+
+	state, exists := e.budgets[conversation.ID]
+	if !exists {
+		return "", true
+	}
+
+	if state.config.MaxSeconds > 0 {
+		elapsed := time.Since(conversation.CreatedAt)
+		if elapsed > time.Duration(state.config.MaxSeconds)*time.Second {
+			return fmt.Sprintf("wall-clock budget of %ds exhausted", state.config.MaxSeconds), false
+		}
+	}
+	if state.config.MaxTokens > 0 && state.tokens >= state.config.MaxTokens {
+		return fmt.Sprintf("token budget of %d exhausted (%d used)", state.config.MaxTokens, state.tokens), false
+	}
+	if state.config.MaxDelegatedTasks > 0 && state.delegatedTasks >= state.config.MaxDelegatedTasks {
+		return fmt.Sprintf("delegated task budget of %d exhausted", state.config.MaxDelegatedTasks), false
+	}
+
+	state.delegatedTasks++
+	return "", true
+}
+
+// recordConversationBudgetTokens charges a completed task's token usage
+// against its conversation's budget.
+func (e *Engine) recordConversationBudgetTokens(task *Task, result *TaskResult) {
+	if result == nil || result.Metrics.TokensUsed <= 0 {
+		return
+	}
+	conversationID, ok := task.Parameters["conversation_id"].(string)
+	if !ok || conversationID == "" {
+		return
+	}
+
+	e.budgetMu.Lock()
+	defer e.budgetMu.Unlock()
+	if state, exists := e.budgets[conversationID]; exists {
+		state.tokens += result.Metrics.TokensUsed
+	}
+}
+
+// pauseConversationOverBudget pauses a conversation whose delegation budget
+// is exhausted and emits an alert event. The caller must hold e.mu.
+func (e *Engine) pauseConversationOverBudget(ctx context.Context, conversation *Conversation, reason string) {
+	conversation.Status = ConversationStatusPaused
+	conversation.UpdatedAt = time.Now()
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		clog(ctx).Error("Failed to persist paused conversation", "error", err)
+	}
+
+	go e.emitEvent(ctx, &EngineEvent{
+		Type:           EventBudgetExceeded,
+		ConversationID: conversation.ID,
+		Payload:        map[string]interface{}{"reason": reason},
+	})
+
+	clog(ctx).Warn("Conversation budget exceeded, pausing",
+		"conversation_id", conversation.ID, "reason", reason)
+}
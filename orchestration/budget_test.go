@@ -0,0 +1,105 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestConversationBudgetAdmission(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	conversation := &Conversation{ID: "conv-b", CreatedAt: time.Now()}
+
+	// No budget configured: delegations are unlimited
+	if reason, admitted := engine.admitDelegation(conversation); !admitted {
+		t.Fatalf("Expected unlimited admission, got: %s", reason)
+	}
+
+	engine.SetConversationBudget(conversation.ID, ConversationBudget{MaxDelegatedTasks: 2})
+	for i := 0; i < 2; i++ {
+		if reason, admitted := engine.admitDelegation(conversation); !admitted {
+			t.Fatalf("Expected delegation %d admitted, got: %s", i+1, reason)
+		}
+	}
+	if _, admitted := engine.admitDelegation(conversation); admitted {
+		t.Error("Expected third delegation rejected by task budget")
+	}
+}
+
+func TestConversationBudgetTokens(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	conversation := &Conversation{ID: "conv-t", CreatedAt: time.Now()}
+	engine.SetConversationBudget(conversation.ID, ConversationBudget{MaxTokens: 100})
+
+	task := &Task{Parameters: map[string]interface{}{"conversation_id": conversation.ID}}
+	result := &TaskResult{Metrics: TaskMetrics{TokensUsed: 150}}
+	engine.recordConversationBudgetTokens(task, result)
+
+	if _, admitted := engine.admitDelegation(conversation); admitted {
+		t.Error("Expected delegation rejected once token budget is spent")
+	}
+}
+
+func TestConversationBudgetWallClock(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	conversation := &Conversation{ID: "conv-w", CreatedAt: time.Now().Add(-2 * time.Minute)}
+	engine.SetConversationBudget(conversation.ID, ConversationBudget{MaxSeconds: 60})
+
+	if _, admitted := engine.admitDelegation(conversation); admitted {
+		t.Error("Expected delegation rejected after wall-clock budget elapsed")
+	}
+}
+
+func TestConversationBudgetRemoval(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	engine.SetConversationBudget("conv-r", ConversationBudget{MaxDelegatedTasks: 1})
+	engine.SetConversationBudget("conv-r", ConversationBudget{})
+
+	if _, exists := engine.GetConversationBudget("conv-r"); exists {
+		t.Error("Expected zero budget to remove the limit")
+	}
+}
+
+func TestBudgetExhaustionPausesConversation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	sender := &Agent{Name: "sender", Models: []string{"llama3.2"}}
+	receiver := &Agent{Name: "receiver", Models: []string{"llama3.2"}}
+	for _, agent := range []*Agent{sender, receiver} {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+
+	conversation, err := engine.StartConversation(ctx, []string{sender.ID, receiver.ID}, "budget test")
+	if err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+	engine.SetConversationBudget(conversation.ID, ConversationBudget{MaxDelegatedTasks: 1})
+
+	send := func(content string) error {
+		return engine.SendMessage(ctx, conversation.ID, &Message{
+			FromAgentID: sender.ID,
+			ToAgentID:   receiver.ID,
+			Content:     content,
+			Type:        MessageTypeTask,
+		})
+	}
+	if err := send("first delegation"); err != nil {
+		t.Fatalf("Failed to send first message: %v", err)
+	}
+	if err := send("second delegation over budget"); err != nil {
+		t.Fatalf("Failed to send second message: %v", err)
+	}
+
+	updated, err := engine.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("Failed to get conversation: %v", err)
+	}
+	if updated.Status != ConversationStatusPaused {
+		t.Errorf("Expected conversation paused over budget, got %s", updated.Status)
+	}
+}
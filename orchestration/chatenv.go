@@ -0,0 +1,112 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// EnvironmentContext is structured information about the machine EchoChat is
+// running on, fed into the translation prompt so generated commands match the
+// actual platform.
+type EnvironmentContext struct {
+	OS                string    `json:"os"`
+	Arch              string    `json:"arch"`
+	Shell             string    `json:"shell"`
+	WorkingDir        string    `json:"working_dir"`
+	GitBranch         string    `json:"git_branch,omitempty"`
+	GitDirty          bool      `json:"git_dirty,omitempty"`
+	AvailableBinaries []string  `json:"available_binaries,omitempty"`
+	ProbedAt          time.Time `json:"probed_at"`
+}
+
+// probedBinaries is the set of common tools the environment probe checks for.
+// Knowing which are installed lets the model pick, say, rg over grep or
+// python3 over python.
+var probedBinaries = []string{
+	"git", "docker", "make", "curl", "wget", "jq", "rg", "fd", "fzf",
+	"python3", "python", "node", "npm", "go", "cargo", "tar", "unzip", "systemctl",
+}
+
+// environmentProbeTTL bounds how long a probe result is reused before the
+// environment is inspected again.
+const environmentProbeTTL = 30 * time.Second
+
+// ProbeEnvironment gathers environment context with safe, read-only probes:
+// binary lookups on PATH and git queries scoped to dir.
+func ProbeEnvironment(ctx context.Context, dir, shell string) *EnvironmentContext {
+	env := &EnvironmentContext{
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Shell:      shell,
+		WorkingDir: dir,
+		ProbedAt:   time.Now(),
+	}
+
+	for _, name := range probedBinaries {
+		if _, err := exec.LookPath(name); err == nil {
+			env.AvailableBinaries = append(env.AvailableBinaries, name)
+		}
+	}
+
+	env.GitBranch, env.GitDirty = probeGitStatus(ctx, dir)
+	return env
+}
+
+// probeGitStatus returns the current branch and whether the working tree has
+// uncommitted changes; empty branch means dir is not inside a git repository.
+func probeGitStatus(ctx context.Context, dir string) (string, bool) {
+	probeCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	branchCmd := exec.CommandContext(probeCtx, "git", "rev-parse", "--abbrev-ref", "HEAD")
+	branchCmd.Dir = dir
+	branchOut, err := branchCmd.Output()
+	if err != nil {
+		return "", false
+	}
+	branch := strings.TrimSpace(string(branchOut))
+
+	statusCmd := exec.CommandContext(probeCtx, "git", "status", "--porcelain")
+	statusCmd.Dir = dir
+	statusOut, err := statusCmd.Output()
+	if err != nil {
+		return branch, false
+	}
+	return branch, strings.TrimSpace(string(statusOut)) != ""
+}
+
+// PromptSection renders the environment context as lines for inclusion in a
+// translation prompt.
+func (env *EnvironmentContext) PromptSection() string {
+	var section strings.Builder
+	fmt.Fprintf(&section, "- OS: %s (%s)\n", env.OS, env.Arch)
+	fmt.Fprintf(&section, "- Shell: %s\n", env.Shell)
+	fmt.Fprintf(&section, "- Current Directory: %s\n", env.WorkingDir)
+	if env.GitBranch != "" {
+		state := "clean"
+		if env.GitDirty {
+			state = "uncommitted changes"
+		}
+		fmt.Fprintf(&section, "- Git: branch %s (%s)\n", env.GitBranch, state)
+	}
+	if len(env.AvailableBinaries) > 0 {
+		fmt.Fprintf(&section, "- Available tools: %s\n", strings.Join(env.AvailableBinaries, ", "))
+	}
+	return strings.TrimRight(section.String(), "\n")
+}
+
+// environmentContext returns a current probe result, re-probing when the
+// cached one is stale or was taken in a different directory.
+func (ec *EchoChat) environmentContext(ctx context.Context) *EnvironmentContext {
+	if ec.envContext != nil &&
+		ec.envContext.WorkingDir == ec.currentDir &&
+		time.Since(ec.envContext.ProbedAt) < environmentProbeTTL {
+		return ec.envContext
+	}
+	ec.envContext = ProbeEnvironment(ctx, ec.currentDir, ec.shell)
+	return ec.envContext
+}
@@ -0,0 +1,65 @@
+package orchestration
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbeEnvironment(t *testing.T) {
+	env := ProbeEnvironment(context.Background(), t.TempDir(), "/bin/bash")
+
+	if env.OS != runtime.GOOS || env.Arch != runtime.GOARCH {
+		t.Errorf("Unexpected platform info: %s/%s", env.OS, env.Arch)
+	}
+	if env.Shell != "/bin/bash" {
+		t.Errorf("Unexpected shell: %s", env.Shell)
+	}
+	// A bare temp directory is not a git repository
+	if env.GitBranch != "" {
+		t.Errorf("Expected no git branch outside a repository, got %q", env.GitBranch)
+	}
+	if env.ProbedAt.IsZero() {
+		t.Error("Expected probe timestamp to be set")
+	}
+}
+
+func TestEnvironmentContextPromptSection(t *testing.T) {
+	env := &EnvironmentContext{
+		OS: "linux", Arch: "amd64", Shell: "/bin/zsh", WorkingDir: "/work",
+		GitBranch: "main", GitDirty: true,
+		AvailableBinaries: []string{"git", "jq"},
+	}
+
+	section := env.PromptSection()
+	for _, want := range []string{"linux (amd64)", "/bin/zsh", "/work", "branch main", "uncommitted changes", "git, jq"} {
+		if !strings.Contains(section, want) {
+			t.Errorf("Expected prompt section to contain %q:\n%s", want, section)
+		}
+	}
+
+	// Git and tool lines are omitted when absent
+	minimal := (&EnvironmentContext{OS: "linux", Arch: "arm64", Shell: "sh", WorkingDir: "/"}).PromptSection()
+	if strings.Contains(minimal, "Git:") || strings.Contains(minimal, "Available tools:") {
+		t.Errorf("Expected optional lines to be omitted:\n%s", minimal)
+	}
+}
+
+func TestEnvironmentContextCaching(t *testing.T) {
+	chat := NewEchoChat(nil)
+
+	first := chat.environmentContext(context.Background())
+	second := chat.environmentContext(context.Background())
+	if first != second {
+		t.Error("Expected cached probe result to be reused")
+	}
+
+	// A stale probe is refreshed
+	first.ProbedAt = time.Now().Add(-2 * environmentProbeTTL)
+	third := chat.environmentContext(context.Background())
+	if third == first {
+		t.Error("Expected stale probe result to be refreshed")
+	}
+}
@@ -0,0 +1,118 @@
+package orchestration
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ChatHistoryStore persists EchoChat interactions per session as JSON lines,
+// so history survives restarts and sessions can be recalled later.
+type ChatHistoryStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewChatHistoryStore creates a history store rooted at dir, creating the
+// directory if needed.
+func NewChatHistoryStore(dir string) (*ChatHistoryStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	return &ChatHistoryStore{dir: dir}, nil
+}
+
+// sessionPath returns the history file for a session, sanitizing the ID so it
+// cannot escape the store directory.
+func (hs *ChatHistoryStore) sessionPath(sessionID string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, sessionID)
+	if safe == "" {
+		safe = "default"
+	}
+	return filepath.Join(hs.dir, safe+".jsonl")
+}
+
+// Append persists one interaction for a session.
+func (hs *ChatHistoryStore) Append(sessionID string, command ChatCommand) error {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	line, err := json.Marshal(command)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat command: %w", err)
+	}
+
+	file, err := os.OpenFile(hs.sessionPath(sessionID), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write history entry: %w", err)
+	}
+	return nil
+}
+
+// Load returns all persisted interactions for a session, oldest first. A
+// missing session yields an empty history rather than an error.
+func (hs *ChatHistoryStore) Load(sessionID string) ([]ChatCommand, error) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	file, err := os.Open(hs.sessionPath(sessionID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []ChatCommand{}, nil
+		}
+		return nil, fmt.Errorf("failed to open history file: %w", err)
+	}
+	defer file.Close()
+
+	history := make([]ChatCommand, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var command ChatCommand
+		if err := json.Unmarshal(scanner.Bytes(), &command); err != nil {
+			// Skip corrupt lines rather than losing the whole session
+			continue
+		}
+		history = append(history, command)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+	return history, nil
+}
+
+// Sessions lists the session IDs that have persisted history.
+func (hs *ChatHistoryStore) Sessions() ([]string, error) {
+	hs.mu.Lock()
+	defer hs.mu.Unlock()
+
+	entries, err := os.ReadDir(hs.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history directory: %w", err)
+	}
+
+	sessions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".jsonl") {
+			continue
+		}
+		sessions = append(sessions, strings.TrimSuffix(entry.Name(), ".jsonl"))
+	}
+	return sessions, nil
+}
@@ -0,0 +1,101 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChatHistoryStoreRoundTrip(t *testing.T) {
+	store, err := NewChatHistoryStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create history store: %v", err)
+	}
+
+	first := ChatCommand{ID: "c1", Input: "list files", Command: "ls -la", ExitCode: 0, Timestamp: time.Now()}
+	second := ChatCommand{ID: "c2", Input: "disk space", Command: "df -h", ExitCode: 0, Timestamp: time.Now()}
+
+	if err := store.Append("alice", first); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+	if err := store.Append("alice", second); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+	if err := store.Append("bob", ChatCommand{ID: "c3", Input: "pwd", Command: "pwd"}); err != nil {
+		t.Fatalf("Failed to append: %v", err)
+	}
+
+	history, err := store.Load("alice")
+	if err != nil {
+		t.Fatalf("Failed to load: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("Expected 2 entries for alice, got %d", len(history))
+	}
+	if history[0].Command != "ls -la" || history[1].Command != "df -h" {
+		t.Errorf("History out of order: %+v", history)
+	}
+
+	// Sessions are isolated from each other
+	if other, _ := store.Load("bob"); len(other) != 1 {
+		t.Errorf("Expected 1 entry for bob, got %d", len(other))
+	}
+
+	// Unknown sessions load as empty
+	if empty, err := store.Load("nobody"); err != nil || len(empty) != 0 {
+		t.Errorf("Expected empty history for unknown session, got %d (%v)", len(empty), err)
+	}
+
+	sessions, err := store.Sessions()
+	if err != nil {
+		t.Fatalf("Failed to list sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Errorf("Expected 2 sessions, got %v", sessions)
+	}
+}
+
+func TestEchoChatPersistentHistoryReload(t *testing.T) {
+	dir := t.TempDir()
+
+	chat := NewEchoChat(nil)
+	if err := chat.EnablePersistentHistory(dir, "sess-1"); err != nil {
+		t.Fatalf("Failed to enable persistence: %v", err)
+	}
+	chat.addToHistory(&ChatCommand{ID: "c1", Input: "list files", Command: "ls"})
+
+	// A fresh instance with the same session sees the earlier interaction
+	reloaded := NewEchoChat(nil)
+	if err := reloaded.EnablePersistentHistory(dir, "sess-1"); err != nil {
+		t.Fatalf("Failed to enable persistence: %v", err)
+	}
+	history := reloaded.GetHistory()
+	if len(history) != 1 || history[0].Command != "ls" {
+		t.Errorf("Expected reloaded history with ls, got %+v", history)
+	}
+}
+
+func TestResolveHistoryReference(t *testing.T) {
+	chat := NewEchoChat(nil)
+	chat.history = []ChatCommand{
+		{Input: "list files", Command: "ls -la"},
+		{Input: "help", Command: ""},
+		{Input: "disk space", Command: "df -h"},
+	}
+
+	if command, ok := chat.resolveHistoryReference("!!"); !ok || command != "df -h" {
+		t.Errorf("Expected !! to resolve to df -h, got %q (%v)", command, ok)
+	}
+	if command, ok := chat.resolveHistoryReference("!1"); !ok || command != "ls -la" {
+		t.Errorf("Expected !1 to resolve to ls -la, got %q (%v)", command, ok)
+	}
+	if command, ok := chat.resolveHistoryReference("!9"); !ok || command != "" {
+		t.Errorf("Expected !9 to be an unmatched reference, got %q (%v)", command, ok)
+	}
+	// Not history references at all
+	if _, ok := chat.resolveHistoryReference("list files"); ok {
+		t.Error("Expected plain input not to be treated as a reference")
+	}
+	if _, ok := chat.resolveHistoryReference("!important note"); ok {
+		t.Error("Expected non-numeric bang input not to be treated as a reference")
+	}
+}
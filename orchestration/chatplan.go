@@ -0,0 +1,221 @@
+package orchestration
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ChatPlanStep is one shell command in a proposed multi-step plan.
+type ChatPlanStep struct {
+	Number   int    `json:"number"`
+	Command  string `json:"command"`
+	Output   string `json:"output,omitempty"`
+	Error    string `json:"error,omitempty"`
+	ExitCode int    `json:"exit_code"`
+	Executed bool   `json:"executed"`
+}
+
+// ChatPlan is a numbered sequence of shell commands proposed for a complex
+// request, shown to the user before anything runs.
+type ChatPlan struct {
+	ID        string         `json:"id"`
+	Request   string         `json:"request"`
+	Steps     []ChatPlanStep `json:"steps"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// PlanOptions controls how a proposed plan is executed.
+type PlanOptions struct {
+	// AutoApprove skips the interactive plan approval prompt (--yes).
+	AutoApprove bool
+	// ContinueOnError keeps executing remaining steps after a failure
+	// instead of stopping the plan.
+	ContinueOnError bool
+}
+
+// planStepPattern matches numbered plan lines like "1. ls" or "2) make build".
+var planStepPattern = regexp.MustCompile(`^\s*(\d+)[.)]\s+(.+)$`)
+
+// ProposePlan asks Deep Tree Echo to break a complex request into a numbered
+// plan of shell commands without executing anything.
+func (ec *EchoChat) ProposePlan(ctx context.Context, input string) (*ChatPlan, error) {
+	agent, err := ec.engine.CreateSpecializedAgent(ctx, AgentTypeSpecialist, "shell-planner")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shell planner agent: %w", err)
+	}
+
+	task := &Task{
+		ID:    fmt.Sprintf("shell_plan_%d", time.Now().UnixNano()),
+		Type:  TaskTypeChat,
+		Input: ec.buildPlanPrompt(ctx, input),
+		Parameters: map[string]interface{}{
+			"options": map[string]interface{}{
+				"temperature": 0.1,
+				"max_tokens":  500,
+			},
+		},
+	}
+
+	result, err := ec.engine.ExecuteTask(ctx, task, agent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan request: %w", err)
+	}
+
+	steps := parsePlanSteps(result.Output)
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no plan steps could be parsed from the response")
+	}
+
+	return &ChatPlan{
+		ID:        fmt.Sprintf("plan_%d", time.Now().UnixNano()),
+		Request:   input,
+		Steps:     steps,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// buildPlanPrompt creates the planning prompt for a complex request.
+func (ec *EchoChat) buildPlanPrompt(ctx context.Context, input string) string {
+	return fmt.Sprintf(`You are Deep Tree Echo, an intelligent shell assistant.
+
+Break the following request into a short numbered plan of shell commands.
+
+System Context:
+%s
+
+Request: %s
+
+Rules:
+1. Return ONLY a numbered list, one shell command per line (e.g. "1. mkdir build")
+2. Keep the plan minimal - no more than 8 steps
+3. Prefer tools listed as available over ones that may not be installed
+4. Do not include explanations or markdown fences
+5. Each line must be a complete, runnable command
+
+Plan:`, ec.environmentContext(ctx).PromptSection(), input)
+}
+
+// parsePlanSteps extracts numbered commands from a model response.
+func parsePlanSteps(response string) []ChatPlanStep {
+	steps := make([]ChatPlanStep, 0)
+	for _, line := range strings.Split(response, "\n") {
+		match := planStepPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		command := strings.TrimSpace(match[2])
+		command = strings.Trim(command, "`")
+		if command == "" {
+			continue
+		}
+		steps = append(steps, ChatPlanStep{Number: len(steps) + 1, Command: command})
+	}
+	return steps
+}
+
+// displayPlan prints a proposed plan for review.
+func (ec *EchoChat) displayPlan(plan *ChatPlan) {
+	fmt.Printf("Proposed plan for: %s\n", plan.Request)
+	for _, step := range plan.Steps {
+		fmt.Printf("  %d. %s\n", step.Number, step.Command)
+	}
+}
+
+// confirmPlan asks the user to approve a displayed plan.
+func (ec *EchoChat) confirmPlan() bool {
+	fmt.Print("Execute this plan? (yes/no): ")
+	scanner := bufio.NewScanner(os.Stdin)
+	if scanner.Scan() {
+		response := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		return response == "yes" || response == "y"
+	}
+	return false
+}
+
+// ExecutePlan runs an approved plan step by step. Each step goes through the
+// command policy; a failing or denied step stops the plan unless
+// ContinueOnError is set. The plan is returned with per-step results.
+func (ec *EchoChat) ExecutePlan(ctx context.Context, plan *ChatPlan, opts PlanOptions) error {
+	var firstErr error
+	for i := range plan.Steps {
+		step := &plan.Steps[i]
+		fmt.Printf("[%d/%d] %s\n", step.Number, len(plan.Steps), step.Command)
+
+		decision := ec.policy.Evaluate(step.Command)
+		switch decision.Action {
+		case PolicyActionDeny:
+			step.Error = fmt.Sprintf("blocked by policy: %s", decision.Reason)
+			step.ExitCode = 1
+		case PolicyActionConfirm:
+			// Destructive steps still need their own confirmation, even
+			// when the plan as a whole was auto-approved
+			if !ec.confirmDangerousCommand(step.Command) {
+				step.Error = "cancelled by user"
+				step.ExitCode = 1
+			} else {
+				ec.runPlanStep(ctx, step)
+			}
+		default:
+			ec.runPlanStep(ctx, step)
+		}
+
+		ec.policy.RecordAudit(ChatAuditEntry{
+			Input:    plan.Request,
+			Command:  step.Command,
+			Action:   decision.Action,
+			Executed: step.Executed,
+			ExitCode: step.ExitCode,
+		})
+
+		if step.ExitCode != 0 {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("step %d failed: %s", step.Number, step.Error)
+			}
+			if !opts.ContinueOnError {
+				fmt.Printf("Plan stopped at step %d: %s\n", step.Number, step.Error)
+				return firstErr
+			}
+			fmt.Printf("Step %d failed (%s), continuing\n", step.Number, step.Error)
+		}
+	}
+	return firstErr
+}
+
+// runPlanStep executes one plan step and records its outcome.
+func (ec *EchoChat) runPlanStep(ctx context.Context, step *ChatPlanStep) {
+	command := &ChatCommand{
+		ID:        fmt.Sprintf("plan_step_%d", time.Now().UnixNano()),
+		Input:     step.Command,
+		Command:   step.Command,
+		Timestamp: time.Now(),
+		Context:   make(map[string]interface{}),
+	}
+	ec.executeCommand(ctx, command)
+	step.Executed = true
+	step.Output = command.Output
+	step.Error = command.Error
+	step.ExitCode = command.ExitCode
+	ec.addToHistory(command)
+}
+
+// ProcessPlanRequest proposes a plan for a complex request, shows it, and
+// executes it once approved (or immediately with AutoApprove).
+func (ec *EchoChat) ProcessPlanRequest(ctx context.Context, input string, opts PlanOptions) error {
+	plan, err := ec.ProposePlan(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	ec.displayPlan(plan)
+	if !opts.AutoApprove && !ec.confirmPlan() {
+		fmt.Println("Plan cancelled")
+		return nil
+	}
+
+	return ec.ExecutePlan(ctx, plan, opts)
+}
@@ -0,0 +1,98 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParsePlanSteps(t *testing.T) {
+	response := `Here is the plan:
+1. mkdir -p build
+2) cd build
+3. ` + "`cmake ..`" + `
+Some trailing commentary.
+4. make -j4`
+
+	steps := parsePlanSteps(response)
+	if len(steps) != 4 {
+		t.Fatalf("Expected 4 steps, got %d: %+v", len(steps), steps)
+	}
+	expected := []string{"mkdir -p build", "cd build", "cmake ..", "make -j4"}
+	for i, step := range steps {
+		if step.Command != expected[i] {
+			t.Errorf("Step %d: expected %q, got %q", i+1, expected[i], step.Command)
+		}
+		if step.Number != i+1 {
+			t.Errorf("Step %d: expected renumbering to %d, got %d", i+1, i+1, step.Number)
+		}
+	}
+
+	if steps := parsePlanSteps("no numbered lines here"); len(steps) != 0 {
+		t.Errorf("Expected no steps from unnumbered text, got %d", len(steps))
+	}
+}
+
+func TestExecutePlanStopsOnFailure(t *testing.T) {
+	chat := NewEchoChat(nil)
+	plan := &ChatPlan{
+		Request: "test plan",
+		Steps: []ChatPlanStep{
+			{Number: 1, Command: "true"},
+			{Number: 2, Command: "false"},
+			{Number: 3, Command: "true"},
+		},
+	}
+
+	err := chat.ExecutePlan(context.Background(), plan, PlanOptions{})
+	if err == nil {
+		t.Fatal("Expected plan to report the failing step")
+	}
+	if !plan.Steps[0].Executed || plan.Steps[0].ExitCode != 0 {
+		t.Errorf("Expected step 1 to succeed: %+v", plan.Steps[0])
+	}
+	if !plan.Steps[1].Executed || plan.Steps[1].ExitCode == 0 {
+		t.Errorf("Expected step 2 to fail: %+v", plan.Steps[1])
+	}
+	if plan.Steps[2].Executed {
+		t.Error("Expected step 3 to be skipped after the failure")
+	}
+}
+
+func TestExecutePlanContinueOnError(t *testing.T) {
+	chat := NewEchoChat(nil)
+	plan := &ChatPlan{
+		Request: "test plan",
+		Steps: []ChatPlanStep{
+			{Number: 1, Command: "false"},
+			{Number: 2, Command: "true"},
+		},
+	}
+
+	err := chat.ExecutePlan(context.Background(), plan, PlanOptions{ContinueOnError: true})
+	if err == nil {
+		t.Fatal("Expected the first failure to be reported")
+	}
+	if !plan.Steps[1].Executed || plan.Steps[1].ExitCode != 0 {
+		t.Errorf("Expected step 2 to run despite the earlier failure: %+v", plan.Steps[1])
+	}
+}
+
+func TestExecutePlanRespectsPolicyDeny(t *testing.T) {
+	chat := NewEchoChat(nil)
+	policy, err := NewChatPolicyEngine(ChatPolicy{DenyList: []string{"false"}})
+	if err != nil {
+		t.Fatalf("Failed to create policy engine: %v", err)
+	}
+	chat.SetPolicy(policy)
+
+	plan := &ChatPlan{
+		Request: "test plan",
+		Steps:   []ChatPlanStep{{Number: 1, Command: "false"}},
+	}
+	if err := chat.ExecutePlan(context.Background(), plan, PlanOptions{}); err == nil {
+		t.Fatal("Expected denied step to fail the plan")
+	}
+	if plan.Steps[0].Executed {
+		t.Error("Expected denied step not to execute")
+	}
+}
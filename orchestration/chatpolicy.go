@@ -0,0 +1,185 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PolicyAction is the decision a chat policy reaches for a command.
+type PolicyAction string
+
+const (
+	PolicyActionAllow   PolicyAction = "allow"
+	PolicyActionDeny    PolicyAction = "deny"
+	PolicyActionConfirm PolicyAction = "confirm"
+)
+
+// PolicyDecision is the outcome of evaluating one command against policy.
+type PolicyDecision struct {
+	Action PolicyAction `json:"action"`
+	Reason string       `json:"reason,omitempty"`
+}
+
+// ChatPolicy configures which shell commands EchoChat may run.
+type ChatPolicy struct {
+	// AllowList restricts execution to commands whose first word matches an
+	// entry. Empty means any command not otherwise denied may run.
+	AllowList []string `json:"allow_list,omitempty"`
+	// DenyList rejects commands whose first word matches an entry outright.
+	DenyList []string `json:"deny_list,omitempty"`
+	// ConfirmPatterns are regular expressions for destructive commands that
+	// require explicit user confirmation before running.
+	ConfirmPatterns []string `json:"confirm_patterns,omitempty"`
+	// AuditLogPath appends an audit entry per executed command when set.
+	AuditLogPath string `json:"audit_log_path,omitempty"`
+}
+
+// DefaultChatPolicy returns the policy EchoChat ships with: no allow-list,
+// and confirmation required for well-known destructive patterns.
+func DefaultChatPolicy() ChatPolicy {
+	return ChatPolicy{
+		ConfirmPatterns: []string{
+			`\brm\s+(-\w*[rf]\w*\s+)+`, // recursive/forced deletes
+			`\bdd\s+if=`,
+			`\bmkfs`,
+			`\bformat\b`,
+			`>\s*/dev/`,
+			`\bchmod\s+777\b`,
+			`\bchown\s+-R\b`,
+			`\bsudo\s+(rm|dd)\b`,
+			`:\(\)\{\s*:\|:&\s*\};:`,           // fork bomb
+			`(curl|wget)\b[^|]*\|\s*(ba)?sh\b`, // pipe remote content to shell
+		},
+	}
+}
+
+// ChatAuditEntry records one policy-governed command execution.
+type ChatAuditEntry struct {
+	Timestamp time.Time    `json:"timestamp"`
+	Input     string       `json:"input,omitempty"`
+	Command   string       `json:"command"`
+	Action    PolicyAction `json:"action"`
+	Confirmed bool         `json:"confirmed,omitempty"`
+	Executed  bool         `json:"executed"`
+	ExitCode  int          `json:"exit_code"`
+}
+
+// ChatPolicyEngine evaluates commands against a ChatPolicy and keeps an
+// audit trail of what ran.
+type ChatPolicyEngine struct {
+	policy   ChatPolicy
+	patterns []*regexp.Regexp
+	audit    []ChatAuditEntry
+	mu       sync.Mutex
+}
+
+// NewChatPolicyEngine compiles a policy into an engine. Invalid confirm
+// patterns are rejected.
+func NewChatPolicyEngine(policy ChatPolicy) (*ChatPolicyEngine, error) {
+	patterns := make([]*regexp.Regexp, 0, len(policy.ConfirmPatterns))
+	for _, raw := range policy.ConfirmPatterns {
+		pattern, err := regexp.Compile(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid confirm pattern %q: %w", raw, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return &ChatPolicyEngine{
+		policy:   policy,
+		patterns: patterns,
+		audit:    make([]ChatAuditEntry, 0),
+	}, nil
+}
+
+// Policy returns the active policy.
+func (pe *ChatPolicyEngine) Policy() ChatPolicy {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+	return pe.policy
+}
+
+// Evaluate decides what to do with a command: deny-list first, then the
+// allow-list, then confirmation patterns.
+func (pe *ChatPolicyEngine) Evaluate(command string) PolicyDecision {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	trimmed := strings.TrimSpace(command)
+	fields := strings.Fields(trimmed)
+	if len(fields) == 0 {
+		return PolicyDecision{Action: PolicyActionDeny, Reason: "empty command"}
+	}
+	name := fields[0]
+
+	for _, denied := range pe.policy.DenyList {
+		if name == denied {
+			return PolicyDecision{Action: PolicyActionDeny, Reason: fmt.Sprintf("command %q is deny-listed", name)}
+		}
+	}
+
+	if len(pe.policy.AllowList) > 0 {
+		allowed := false
+		for _, entry := range pe.policy.AllowList {
+			if name == entry {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return PolicyDecision{Action: PolicyActionDeny, Reason: fmt.Sprintf("command %q is not on the allow-list", name)}
+		}
+	}
+
+	for _, pattern := range pe.patterns {
+		if pattern.MatchString(trimmed) {
+			return PolicyDecision{
+				Action: PolicyActionConfirm,
+				Reason: fmt.Sprintf("matches destructive pattern %s", pattern.String()),
+			}
+		}
+	}
+
+	return PolicyDecision{Action: PolicyActionAllow}
+}
+
+// RecordAudit appends an entry to the audit trail, and to the audit log
+// file when one is configured.
+func (pe *ChatPolicyEngine) RecordAudit(entry ChatAuditEntry) {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now()
+	}
+	pe.audit = append(pe.audit, entry)
+
+	if pe.policy.AuditLogPath == "" {
+		return
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	file, err := os.OpenFile(pe.policy.AuditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	file.Write(append(line, '\n'))
+}
+
+// AuditTrail returns a copy of the recorded audit entries.
+func (pe *ChatPolicyEngine) AuditTrail() []ChatAuditEntry {
+	pe.mu.Lock()
+	defer pe.mu.Unlock()
+
+	trail := make([]ChatAuditEntry, len(pe.audit))
+	copy(trail, pe.audit)
+	return trail
+}
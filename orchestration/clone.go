@@ -0,0 +1,118 @@
+package orchestration
+
+// Deep copies for the engine's copy-on-return read semantics: GetAgent and
+// GetConversation hand callers a private copy instead of a live pointer into
+// the engine maps, so handlers can read (and even scribble on) the result
+// without racing task execution. Writes still go through the engine's
+// mutating methods.
+
+// Clone returns a deep copy of the agent.
+func (a *Agent) Clone() *Agent {
+	if a == nil {
+		return nil
+	}
+
+	clone := *a
+	clone.Models = append([]string(nil), a.Models...)
+	clone.Tools = append([]string(nil), a.Tools...)
+	clone.Config = cloneValueMap(a.Config)
+	clone.State = a.State.Clone()
+	return &clone
+}
+
+// Clone returns a deep copy of the agent state.
+func (s *AgentState) Clone() *AgentState {
+	if s == nil {
+		return nil
+	}
+
+	clone := *s
+	clone.Memory = cloneValueMap(s.Memory)
+	clone.Context = append([]ContextItem(nil), s.Context...)
+	clone.Goals = append([]string(nil), s.Goals...)
+	clone.Capabilities = append([]string(nil), s.Capabilities...)
+	return &clone
+}
+
+// Clone returns a deep copy of the conversation.
+func (c *Conversation) Clone() *Conversation {
+	if c == nil {
+		return nil
+	}
+
+	clone := *c
+	clone.Participants = append([]string(nil), c.Participants...)
+	if c.Roles != nil {
+		clone.Roles = make(map[string]ParticipantRole, len(c.Roles))
+		for id, role := range c.Roles {
+			clone.Roles[id] = role
+		}
+	}
+	clone.Blackboard = c.Blackboard.Clone()
+	clone.Messages = make([]Message, len(c.Messages))
+	for index, message := range c.Messages {
+		clone.Messages[index] = *message.Clone()
+	}
+	clone.Metadata = cloneValueMap(c.Metadata)
+	return &clone
+}
+
+// Clone returns a deep copy of the message.
+func (m *Message) Clone() *Message {
+	if m == nil {
+		return nil
+	}
+
+	clone := *m
+	clone.Context = cloneValueMap(m.Context)
+	clone.Response = m.Response.Clone()
+	return &clone
+}
+
+// Clone returns a deep copy of the blackboard.
+func (b *Blackboard) Clone() *Blackboard {
+	if b == nil {
+		return nil
+	}
+
+	clone := *b
+	clone.Values = cloneValueMap(b.Values)
+	if b.Documents != nil {
+		clone.Documents = make(map[string]*BlackboardDocument, len(b.Documents))
+		for name, document := range b.Documents {
+			copied := *document
+			clone.Documents[name] = &copied
+		}
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the task.
+func (t *Task) Clone() *Task {
+	if t == nil {
+		return nil
+	}
+
+	clone := *t
+	clone.StructuredInput = cloneValueMap(t.StructuredInput)
+	clone.OutputSchema = cloneValueMap(t.OutputSchema)
+	clone.Parameters = cloneValueMap(t.Parameters)
+	if t.CompletedAt != nil {
+		completedAt := *t.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	return &clone
+}
+
+// cloneValueMap copies a map of loosely typed values. Values themselves are
+// shared; the copy only isolates the map header from concurrent writes.
+func cloneValueMap(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	clone := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		clone[key] = value
+	}
+	return clone
+}
@@ -0,0 +1,108 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestGetAgentReturnsPrivateCopy(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "shared", Models: []string{"llama3.2"}, Config: map[string]interface{}{"temperature": 0.5}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	copy1, err := engine.GetAgent(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("GetAgent failed: %v", err)
+	}
+
+	// Scribbling on the returned copy must not leak into the engine.
+	copy1.Name = "vandalized"
+	copy1.Models[0] = "other-model"
+	copy1.Config["temperature"] = 2.0
+
+	copy2, err := engine.GetAgent(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("GetAgent failed: %v", err)
+	}
+	if copy2.Name != "shared" || copy2.Models[0] != "llama3.2" || copy2.Config["temperature"] != 0.5 {
+		t.Errorf("Mutations on a returned copy leaked into the engine: %+v", copy2)
+	}
+}
+
+func TestGetConversationReturnsPrivateCopy(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "talker", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	conversation, err := engine.StartConversation(ctx, []string{agent.ID}, "isolation")
+	if err != nil {
+		t.Fatalf("StartConversation failed: %v", err)
+	}
+
+	copy1, err := engine.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("GetConversation failed: %v", err)
+	}
+	copy1.Topic = "vandalized"
+	copy1.Participants[0] = "nobody"
+	copy1.Messages = append(copy1.Messages, Message{Content: "injected"})
+
+	copy2, err := engine.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("GetConversation failed: %v", err)
+	}
+	if copy2.Topic != "isolation" || copy2.Participants[0] != agent.ID || len(copy2.Messages) != 0 {
+		t.Errorf("Mutations on a returned copy leaked into the engine: %+v", copy2)
+	}
+}
+
+func TestConcurrentReadsAndExecution(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "contended", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	conversation, err := engine.StartConversation(ctx, []string{agent.ID}, "load")
+	if err != nil {
+		t.Fatalf("StartConversation failed: %v", err)
+	}
+
+	// Readers and writers race; run under -race to verify isolation.
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(3)
+		go func(n int) {
+			defer wg.Done()
+			task := &Task{ID: fmt.Sprintf("race-%d", n), Type: TaskTypeCustom, Input: "work", AgentID: agent.ID}
+			if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+				t.Errorf("ExecuteTask failed: %v", err)
+			}
+		}(n)
+		go func() {
+			defer wg.Done()
+			if copied, err := engine.GetAgent(ctx, agent.ID); err == nil {
+				_ = copied.State // reads on the copy never race execution
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if copied, err := engine.GetConversation(ctx, conversation.ID); err == nil {
+				_ = len(copied.Messages)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,92 @@
+package orchestration
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// generateOutcome is the shared result of a coalesced generate call.
+type generateOutcome struct {
+	output  string
+	metrics TaskMetrics
+}
+
+// flightKey derives a deterministic key for a provider request so concurrent
+// identical requests land on the same singleflight entry. The key covers the
+// full request body, so calls that differ in model, prompt, system prompt,
+// options or any other field are never coalesced.
+func flightKey(kind string, req interface{}) (string, bool) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return "", false
+	}
+	sum := sha256.Sum256(payload)
+	return kind + ":" + hex.EncodeToString(sum[:]), true
+}
+
+// coalescedGenerate runs a generate request through singleflight so that
+// concurrent identical calls — common when several agents react to the same
+// message — share a single provider call. Coalescing only applies while a
+// call is in flight; the entry is dropped as soon as it completes, so
+// sequential identical requests still reach the provider. The first caller's
+// context drives the shared call.
+func (e *Engine) coalescedGenerate(ctx context.Context, req *api.GenerateRequest) (string, TaskMetrics, error) {
+	run := func() (interface{}, error) {
+		var outcome generateOutcome
+		err := e.client.Generate(ctx, req, func(resp api.GenerateResponse) error {
+			outcome.output += resp.Response
+			if resp.Done {
+				outcome.metrics.PromptTokens = resp.PromptEvalCount
+				outcome.metrics.OutputTokens = resp.EvalCount
+				outcome.metrics.TokensUsed = resp.PromptEvalCount + resp.EvalCount
+			}
+			return nil
+		})
+		return outcome, err
+	}
+
+	key, ok := flightKey("generate", req)
+	if !ok {
+		value, err := run()
+		outcome := value.(generateOutcome)
+		return outcome.output, outcome.metrics, err
+	}
+
+	value, err, _ := e.flight.Do(key, run)
+	outcome := value.(generateOutcome)
+	return outcome.output, outcome.metrics, err
+}
+
+// coalescedEmbeddings runs an embedding request through singleflight, sharing
+// one provider call among concurrent identical requests. Each caller receives
+// its own copy of the embedding so results never alias across tasks.
+func (e *Engine) coalescedEmbeddings(ctx context.Context, req *api.EmbeddingRequest) (*api.EmbeddingResponse, error) {
+	run := func() (interface{}, error) {
+		return e.client.Embeddings(ctx, req)
+	}
+
+	key, ok := flightKey("embed", req)
+	if !ok {
+		value, err := run()
+		if err != nil {
+			return nil, err
+		}
+		return value.(*api.EmbeddingResponse), nil
+	}
+
+	value, err, shared := e.flight.Do(key, run)
+	if err != nil {
+		return nil, err
+	}
+	resp := value.(*api.EmbeddingResponse)
+	if shared {
+		resp = &api.EmbeddingResponse{
+			Embedding: append([]float64(nil), resp.Embedding...),
+		}
+	}
+	return resp, nil
+}
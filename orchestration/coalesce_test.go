@@ -0,0 +1,167 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// countingProvider stands in for the model daemon, counting upstream calls
+// and holding each response until release is closed so concurrent callers
+// overlap deterministically.
+type countingProvider struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (p *countingProvider) client(t *testing.T) api.Client {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		p.calls.Add(1)
+		<-p.release
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"embedding": [0.1, 0.2, 0.3]}`))
+	})
+	mux.HandleFunc("/api/generate", func(w http.ResponseWriter, r *http.Request) {
+		p.calls.Add(1)
+		<-p.release
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(`{"response": "hello", "done": false}` + "\n"))
+		w.Write([]byte(`{"response": " world", "done": true, "prompt_eval_count": 2, "eval_count": 3}` + "\n"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	base, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %v", err)
+	}
+	return *api.NewClient(base, http.DefaultClient)
+}
+
+func TestCoalescedEmbeddingsSharesOneCall(t *testing.T) {
+	provider := &countingProvider{release: make(chan struct{})}
+	engine := NewEngine(provider.client(t))
+	ctx := context.Background()
+
+	const callers = 4
+	var wg sync.WaitGroup
+	results := make([][]float64, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			resp, err := engine.coalescedEmbeddings(ctx, &api.EmbeddingRequest{
+				Model:  "nomic-embed-text",
+				Prompt: "same message",
+			})
+			errs[idx] = err
+			if err == nil {
+				results[idx] = resp.Embedding
+			}
+		}(i)
+	}
+
+	// Give the followers time to park on the in-flight call, then let the
+	// provider respond
+	time.Sleep(50 * time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("Caller %d failed: %v", i, errs[i])
+		}
+		if len(results[i]) != 3 {
+			t.Errorf("Caller %d got embedding %v", i, results[i])
+		}
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("Expected 1 upstream call for identical requests, got %d", got)
+	}
+
+	// Results must not alias: mutating one caller's copy leaves the others alone
+	results[0][0] = 99
+	if results[1][0] == 99 {
+		t.Error("Callers share a backing embedding slice")
+	}
+
+	// A different prompt misses the flight group and reaches the provider
+	if _, err := engine.coalescedEmbeddings(ctx, &api.EmbeddingRequest{
+		Model:  "nomic-embed-text",
+		Prompt: "different message",
+	}); err != nil {
+		t.Fatalf("Embeddings failed: %v", err)
+	}
+	if got := provider.calls.Load(); got != 2 {
+		t.Errorf("Expected a distinct prompt to reach the provider, got %d calls", got)
+	}
+}
+
+func TestCoalescedGenerateSharesOneCall(t *testing.T) {
+	provider := &countingProvider{release: make(chan struct{})}
+	engine := NewEngine(provider.client(t))
+	ctx := context.Background()
+
+	const callers = 3
+	var wg sync.WaitGroup
+	outputs := make([]string, callers)
+	metrics := make([]TaskMetrics, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			outputs[idx], metrics[idx], errs[idx] = engine.coalescedGenerate(ctx, &api.GenerateRequest{
+				Model:  "llama3.2",
+				Prompt: "same prompt",
+			})
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("Caller %d failed: %v", i, errs[i])
+		}
+		if outputs[i] != "hello world" {
+			t.Errorf("Caller %d got output %q", i, outputs[i])
+		}
+		if metrics[i].TokensUsed != 5 {
+			t.Errorf("Caller %d got metrics %+v", i, metrics[i])
+		}
+	}
+	if got := provider.calls.Load(); got != 1 {
+		t.Errorf("Expected 1 upstream call for identical requests, got %d", got)
+	}
+}
+
+func TestFlightKeyCoversRequestFields(t *testing.T) {
+	base, ok := flightKey("generate", &api.GenerateRequest{Model: "llama3.2", Prompt: "hi"})
+	if !ok {
+		t.Fatal("flightKey failed for a marshalable request")
+	}
+	same, _ := flightKey("generate", &api.GenerateRequest{Model: "llama3.2", Prompt: "hi"})
+	if base != same {
+		t.Error("Identical requests produced different keys")
+	}
+	system, _ := flightKey("generate", &api.GenerateRequest{Model: "llama3.2", Prompt: "hi", System: "be terse"})
+	if base == system {
+		t.Error("Requests differing in system prompt share a key")
+	}
+	embed, _ := flightKey("embed", &api.GenerateRequest{Model: "llama3.2", Prompt: "hi"})
+	if base == embed {
+		t.Error("Different request kinds share a key")
+	}
+}
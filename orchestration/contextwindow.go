@@ -0,0 +1,144 @@
+package orchestration
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// DefaultContextWindow is the assumed model context size, in tokens, when a
+// task does not declare num_ctx in its options.
+const DefaultContextWindow = 4096
+
+// defaultResponseReserve is how many tokens of the context window are kept
+// free for the model's reply.
+const defaultResponseReserve = 512
+
+// Truncation strategies applied when an assembled chat prompt exceeds the
+// model's context budget.
+const (
+	// TruncationDrop removes the oldest non-final messages until the
+	// prompt fits.
+	TruncationDrop = "drop"
+	// TruncationSummarize replaces dropped messages with a single system
+	// note summarizing what was removed.
+	TruncationSummarize = "summarize"
+)
+
+// ContextAccounting reports how an assembled prompt was fitted into the
+// model's context window.
+type ContextAccounting struct {
+	Limit           int    `json:"limit"`
+	Reserved        int    `json:"reserved"`
+	OriginalTokens  int    `json:"original_tokens"`
+	PromptTokens    int    `json:"prompt_tokens"`
+	DroppedMessages int    `json:"dropped_messages"`
+	DroppedTokens   int    `json:"dropped_tokens"`
+	Strategy        string `json:"strategy"`
+	Truncated       bool   `json:"truncated"`
+}
+
+// contextWindowFor returns the context size a task will run with, reading
+// num_ctx from the task options when present.
+func contextWindowFor(task *Task) int {
+	if task.Parameters != nil {
+		if opts, ok := task.Parameters["options"].(map[string]interface{}); ok {
+			if numCtx, ok := toInt(opts["num_ctx"]); ok && numCtx > 0 {
+				return numCtx
+			}
+		}
+	}
+	return DefaultContextWindow
+}
+
+// truncationStrategyFor returns the configured truncation strategy for a
+// task, defaulting to dropping oldest messages.
+func truncationStrategyFor(task *Task) string {
+	if task.Parameters != nil {
+		if strategy, ok := task.Parameters["truncation_strategy"].(string); ok && strategy != "" {
+			return strategy
+		}
+	}
+	return TruncationDrop
+}
+
+// fitChatMessages trims an assembled message list to fit within the task's
+// context budget, leaving room for the model's reply. The final message (the
+// actual request) is never dropped; when even it alone exceeds the budget
+// its content is truncated. The returned accounting records what was removed.
+func fitChatMessages(messages []api.Message, task *Task) ([]api.Message, *ContextAccounting) {
+	accounting := &ContextAccounting{
+		Limit:    contextWindowFor(task),
+		Reserved: defaultResponseReserve,
+		Strategy: truncationStrategyFor(task),
+	}
+	budget := accounting.Limit - accounting.Reserved
+
+	for _, message := range messages {
+		accounting.OriginalTokens += approximateTokens(message.Content)
+	}
+	accounting.PromptTokens = accounting.OriginalTokens
+	if accounting.OriginalTokens <= budget {
+		return messages, accounting
+	}
+
+	accounting.Truncated = true
+
+	// Drop oldest messages first, keeping the final message in place.
+	kept := append([]api.Message(nil), messages...)
+	var droppedSummaries []string
+	for len(kept) > 1 && accounting.PromptTokens > budget {
+		dropped := kept[0]
+		kept = kept[1:]
+		tokens := approximateTokens(dropped.Content)
+		accounting.PromptTokens -= tokens
+		accounting.DroppedTokens += tokens
+		accounting.DroppedMessages++
+		if accounting.Strategy == TruncationSummarize {
+			droppedSummaries = append(droppedSummaries, fmt.Sprintf("%s: %s", dropped.Role, firstLine(dropped.Content)))
+		}
+	}
+
+	// If the final message alone is still over budget, truncate its content.
+	if accounting.PromptTokens > budget {
+		content := kept[0].Content
+		maxChars := budget * 4
+		if maxChars < len(content) {
+			overTokens := accounting.PromptTokens - budget
+			kept[0].Content = content[:maxChars]
+			accounting.DroppedTokens += overTokens
+			accounting.PromptTokens = budget
+		}
+	}
+
+	if accounting.Strategy == TruncationSummarize && len(droppedSummaries) > 0 {
+		note := api.Message{
+			Role:    "system",
+			Content: fmt.Sprintf("Earlier context omitted to fit the context window:\n- %s", strings.Join(droppedSummaries, "\n- ")),
+		}
+		kept = append([]api.Message{note}, kept...)
+		accounting.PromptTokens += approximateTokens(note.Content)
+	}
+
+	slog.Warn("Chat prompt exceeded context budget",
+		"task_id", task.ID,
+		"limit", accounting.Limit,
+		"original_tokens", accounting.OriginalTokens,
+		"dropped_messages", accounting.DroppedMessages,
+		"strategy", accounting.Strategy)
+
+	return kept, accounting
+}
+
+// firstLine returns the first line of a message, bounded for use in summaries.
+func firstLine(content string) string {
+	if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+		content = content[:idx]
+	}
+	if len(content) > 120 {
+		content = content[:120] + "..."
+	}
+	return content
+}
@@ -0,0 +1,104 @@
+package orchestration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestFitChatMessagesWithinBudget(t *testing.T) {
+	task := &Task{ID: "fit-1"}
+	messages := []api.Message{
+		{Role: "system", Content: "You are a helpful assistant."},
+		{Role: "user", Content: "Hello"},
+	}
+
+	fitted, accounting := fitChatMessages(messages, task)
+	if accounting.Truncated {
+		t.Error("Expected no truncation for small prompt")
+	}
+	if len(fitted) != 2 {
+		t.Errorf("Expected messages unchanged, got %d", len(fitted))
+	}
+	if accounting.Limit != DefaultContextWindow {
+		t.Errorf("Expected default context window, got %d", accounting.Limit)
+	}
+}
+
+func TestFitChatMessagesDropsOldest(t *testing.T) {
+	task := &Task{
+		ID: "fit-2",
+		Parameters: map[string]interface{}{
+			"options": map[string]interface{}{"num_ctx": 700},
+		},
+	}
+
+	big := strings.Repeat("word ", 800) // ~1000 tokens
+	messages := []api.Message{
+		{Role: "system", Content: big},
+		{Role: "system", Content: "memory block"},
+		{Role: "user", Content: "the actual question"},
+	}
+
+	fitted, accounting := fitChatMessages(messages, task)
+	if !accounting.Truncated {
+		t.Fatal("Expected truncation")
+	}
+	if accounting.DroppedMessages == 0 {
+		t.Error("Expected dropped messages reported")
+	}
+	if fitted[len(fitted)-1].Role != "user" {
+		t.Error("Expected final user message preserved")
+	}
+	if accounting.Limit != 700 {
+		t.Errorf("Expected num_ctx honored, got %d", accounting.Limit)
+	}
+}
+
+func TestFitChatMessagesSummarizeStrategy(t *testing.T) {
+	task := &Task{
+		ID: "fit-3",
+		Parameters: map[string]interface{}{
+			"options":             map[string]interface{}{"num_ctx": 700},
+			"truncation_strategy": TruncationSummarize,
+		},
+	}
+
+	big := strings.Repeat("word ", 800)
+	messages := []api.Message{
+		{Role: "system", Content: big},
+		{Role: "user", Content: "question"},
+	}
+
+	fitted, accounting := fitChatMessages(messages, task)
+	if !accounting.Truncated {
+		t.Fatal("Expected truncation")
+	}
+	if fitted[0].Role != "system" || !strings.Contains(fitted[0].Content, "omitted") {
+		t.Errorf("Expected summarizing system note first, got %+v", fitted[0])
+	}
+}
+
+func TestFitChatMessagesTruncatesOversizedFinal(t *testing.T) {
+	task := &Task{
+		ID: "fit-4",
+		Parameters: map[string]interface{}{
+			"options": map[string]interface{}{"num_ctx": 600},
+		},
+	}
+
+	big := strings.Repeat("word ", 2000)
+	messages := []api.Message{{Role: "user", Content: big}}
+
+	fitted, accounting := fitChatMessages(messages, task)
+	if !accounting.Truncated {
+		t.Fatal("Expected truncation")
+	}
+	if len(fitted) != 1 {
+		t.Fatalf("Expected the final message kept, got %d messages", len(fitted))
+	}
+	if len(fitted[0].Content) >= len(big) {
+		t.Error("Expected oversized final message content truncated")
+	}
+}
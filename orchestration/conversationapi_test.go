@@ -0,0 +1,95 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+func TestConversationEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := NewEngine(api.Client{})
+	server := NewAPIServer(engine)
+
+	request := func(method, path, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		server.router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	ctx := context.Background()
+	alice, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	bob, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// A conversation needs participants
+	if code := request(http.MethodPost, "/api/conversations/", `{"topic": "empty"}`).Code; code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without participants, got %d", code)
+	}
+	if code := request(http.MethodPost, "/api/conversations/",
+		`{"participants": ["missing"], "topic": "x"}`).Code; code != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown participant, got %d", code)
+	}
+
+	created := request(http.MethodPost, "/api/conversations/",
+		`{"participants": ["`+alice.ID+`", "`+bob.ID+`"], "topic": "standup"}`)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 creating a conversation, got %d: %s", created.Code, created.Body)
+	}
+	var createdBody struct {
+		Data Conversation `json:"data"`
+	}
+	if err := json.Unmarshal(created.Body.Bytes(), &createdBody); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	conversationID := createdBody.Data.ID
+
+	// Post a message and read it back
+	sent := request(http.MethodPost, "/api/conversations/"+conversationID+"/messages",
+		`{"from_agent_id": "`+alice.ID+`", "to_agent_id": "`+bob.ID+`", "content": "hello"}`)
+	if sent.Code != http.StatusCreated {
+		t.Fatalf("Expected 201 sending a message, got %d: %s", sent.Code, sent.Body)
+	}
+	fetched := request(http.MethodGet, "/api/conversations/"+conversationID, "")
+	if fetched.Code != http.StatusOK {
+		t.Fatalf("Expected 200 fetching the conversation, got %d", fetched.Code)
+	}
+	var fetchedBody struct {
+		Data Conversation `json:"data"`
+	}
+	if err := json.Unmarshal(fetched.Body.Bytes(), &fetchedBody); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(fetchedBody.Data.Messages) != 1 || fetchedBody.Data.Messages[0].Content != "hello" {
+		t.Errorf("Unexpected messages: %+v", fetchedBody.Data.Messages)
+	}
+
+	if code := request(http.MethodGet, "/api/conversations/metrics", "").Code; code != http.StatusOK {
+		t.Errorf("Expected 200 from the metrics endpoint, got %d", code)
+	}
+
+	// Closing the conversation rejects further messages
+	if code := request(http.MethodPost, "/api/conversations/"+conversationID+"/close", "").Code; code != http.StatusOK {
+		t.Fatalf("Expected 200 closing the conversation, got %d", code)
+	}
+	late := request(http.MethodPost, "/api/conversations/"+conversationID+"/messages",
+		`{"from_agent_id": "`+alice.ID+`", "content": "too late"}`)
+	if late.Code != http.StatusConflict {
+		t.Errorf("Expected 409 posting to a closed conversation, got %d", late.Code)
+	}
+}
@@ -0,0 +1,75 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ExportFormat selects the serialization produced by ExportConversation.
+type ExportFormat string
+
+const (
+	ExportFormatJSON     ExportFormat = "json"     // Full conversation record, indented JSON
+	ExportFormatMarkdown ExportFormat = "markdown" // Human-readable transcript
+)
+
+// ExportConversation renders a conversation transcript for offline analysis.
+// JSON exports carry the full record including metadata and the blackboard;
+// Markdown exports are a readable transcript with agent names resolved where
+// the agents still exist.
+func (e *Engine) ExportConversation(ctx context.Context, id string, format ExportFormat) ([]byte, error) {
+	conversation, err := e.GetConversation(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch format {
+	case ExportFormatJSON:
+		raw, err := json.MarshalIndent(conversation, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode conversation: %w", err)
+		}
+		return raw, nil
+	case ExportFormatMarkdown:
+		return e.renderConversationMarkdown(conversation), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// renderConversationMarkdown formats a conversation as a Markdown transcript.
+func (e *Engine) renderConversationMarkdown(conversation *Conversation) []byte {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Conversation %s\n\n", conversation.ID)
+	if conversation.Topic != "" {
+		fmt.Fprintf(&b, "**Topic:** %s\n\n", conversation.Topic)
+	}
+	fmt.Fprintf(&b, "**Status:** %s\n", conversation.Status)
+	fmt.Fprintf(&b, "**Created:** %s\n\n", conversation.CreatedAt.Format("2006-01-02 15:04:05 MST"))
+
+	b.WriteString("## Participants\n\n")
+	for _, participantID := range conversation.Participants {
+		name := e.agentDisplayName(participantID)
+		if role, ok := conversation.Roles[participantID]; ok {
+			fmt.Fprintf(&b, "- %s (%s)\n", name, role)
+		} else {
+			fmt.Fprintf(&b, "- %s\n", name)
+		}
+	}
+
+	b.WriteString("\n## Transcript\n\n")
+	for _, message := range conversation.Messages {
+		from := e.agentDisplayName(message.FromAgentID)
+		to := e.agentDisplayName(message.ToAgentID)
+		if to == "" {
+			to = "all"
+		}
+		fmt.Fprintf(&b, "### %s — %s to %s (%s)\n\n", message.Timestamp.Format("2006-01-02 15:04:05"), from, to, message.Type)
+		fmt.Fprintf(&b, "%s\n\n", message.Content)
+	}
+
+	return []byte(b.String())
+}
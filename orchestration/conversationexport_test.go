@@ -0,0 +1,88 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func exportTestEngine(t *testing.T) (*Engine, *Conversation) {
+	t.Helper()
+
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	alice := &Agent{Name: "alice", Models: []string{"llama3.2"}}
+	bob := &Agent{Name: "bob", Models: []string{"llama3.2"}}
+	for _, agent := range []*Agent{alice, bob} {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+
+	conversation := &Conversation{
+		ID:           "export-me",
+		Participants: []string{alice.ID, bob.ID},
+		Topic:        "release planning",
+		Status:       ConversationStatusActive,
+		Messages: []Message{
+			{FromAgentID: alice.ID, ToAgentID: bob.ID, Content: "what remains for the release?", Type: MessageTypeRequest, Timestamp: time.Now()},
+			{FromAgentID: bob.ID, ToAgentID: alice.ID, Content: "two open bugs", Type: MessageTypeResponse, Timestamp: time.Now()},
+		},
+		CreatedAt: time.Now(),
+	}
+	engine.conversations[conversation.ID] = conversation
+	if err := engine.store.SaveConversation(ctx, conversation); err != nil {
+		t.Fatalf("Failed to persist conversation: %v", err)
+	}
+
+	return engine, conversation
+}
+
+func TestExportConversationJSON(t *testing.T) {
+	engine, conversation := exportTestEngine(t)
+
+	raw, err := engine.ExportConversation(context.Background(), conversation.ID, ExportFormatJSON)
+	if err != nil {
+		t.Fatalf("ExportConversation failed: %v", err)
+	}
+
+	var decoded Conversation
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("Export is not valid JSON: %v", err)
+	}
+	if decoded.ID != conversation.ID || len(decoded.Messages) != 2 {
+		t.Errorf("Export lost conversation content: %+v", decoded)
+	}
+}
+
+func TestExportConversationMarkdown(t *testing.T) {
+	engine, conversation := exportTestEngine(t)
+
+	raw, err := engine.ExportConversation(context.Background(), conversation.ID, ExportFormatMarkdown)
+	if err != nil {
+		t.Fatalf("ExportConversation failed: %v", err)
+	}
+
+	transcript := string(raw)
+	for _, want := range []string{"# Conversation export-me", "release planning", "- alice", "- bob", "what remains for the release?", "two open bugs"} {
+		if !strings.Contains(transcript, want) {
+			t.Errorf("Markdown transcript missing %q:\n%s", want, transcript)
+		}
+	}
+}
+
+func TestExportConversationUnknownFormat(t *testing.T) {
+	engine, conversation := exportTestEngine(t)
+
+	if _, err := engine.ExportConversation(context.Background(), conversation.ID, "yaml"); err == nil {
+		t.Error("Expected an error for an unsupported export format")
+	}
+	if _, err := engine.ExportConversation(context.Background(), "missing", ExportFormatJSON); err == nil {
+		t.Error("Expected an error for a missing conversation")
+	}
+}
@@ -0,0 +1,251 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ScheduledConversation is a recurring multi-agent ritual — a standup,
+// retrospective, or other conversation workflow run on a fixed interval,
+// with each outcome optionally posted to a webhook.
+type ScheduledConversation struct {
+	ID       string                `json:"id"`
+	Name     string                `json:"name"`
+	Workflow *ConversationWorkflow `json:"workflow"`
+	Interval time.Duration         `json:"interval"`
+	// WebhookURL receives each run's outcome as JSON when set.
+	WebhookURL  string    `json:"webhook_url,omitempty"`
+	LastRunAt   time.Time `json:"last_run_at,omitempty"`
+	LastSuccess bool      `json:"last_success,omitempty"`
+	LastError   string    `json:"last_error,omitempty"`
+	Runs        int       `json:"runs"`
+
+	// mu guards the outcome fields above, which the scheduler loop and
+	// manual runs write while API handlers serialize snapshots.
+	mu sync.Mutex
+}
+
+// snapshot returns a copy safe to serialize while runs record outcomes. The
+// workflow pointer is shared; it is immutable once scheduled.
+func (sc *ScheduledConversation) snapshot() *ScheduledConversation {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	return &ScheduledConversation{
+		ID:          sc.ID,
+		Name:        sc.Name,
+		Workflow:    sc.Workflow,
+		Interval:    sc.Interval,
+		WebhookURL:  sc.WebhookURL,
+		LastRunAt:   sc.LastRunAt,
+		LastSuccess: sc.LastSuccess,
+		LastError:   sc.LastError,
+		Runs:        sc.Runs,
+	}
+}
+
+// RunCount returns how many times the ritual has run.
+func (sc *ScheduledConversation) RunCount() int {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.Runs
+}
+
+// conversationRitualJob tracks one ritual's scheduling loop.
+type conversationRitualJob struct {
+	ritual *ScheduledConversation
+	stop   chan struct{}
+}
+
+// ConversationScheduler runs recurring conversation workflows, so rituals
+// like a daily status synthesis across specialist agents happen without a
+// human kicking them off.
+type ConversationScheduler struct {
+	engine *Engine
+	jobs   map[string]*conversationRitualJob
+	mu     sync.Mutex
+}
+
+// NewConversationScheduler creates a conversation scheduler for an engine.
+func NewConversationScheduler(engine *Engine) *ConversationScheduler {
+	return &ConversationScheduler{
+		engine: engine,
+		jobs:   make(map[string]*conversationRitualJob),
+	}
+}
+
+// Schedule starts a recurring ritual, replacing any ritual with the same
+// ID. Missing IDs are generated.
+func (cs *ConversationScheduler) Schedule(ctx context.Context, ritual *ScheduledConversation) error {
+	if ritual.Interval <= 0 {
+		return fmt.Errorf("ritual interval must be positive")
+	}
+	if ritual.Workflow == nil || len(ritual.Workflow.Participants) == 0 {
+		return fmt.Errorf("ritual requires a workflow with participants")
+	}
+	for _, participant := range ritual.Workflow.Participants {
+		if _, err := cs.engine.GetAgent(ctx, participant); err != nil {
+			return err
+		}
+	}
+	if ritual.ID == "" {
+		ritual.ID = uuid.New().String()
+	}
+
+	cs.mu.Lock()
+	if existing, ok := cs.jobs[ritual.ID]; ok {
+		close(existing.stop)
+	}
+	job := &conversationRitualJob{
+		ritual: ritual,
+		stop:   make(chan struct{}),
+	}
+	cs.jobs[ritual.ID] = job
+	cs.mu.Unlock()
+
+	// Detach from the caller's context (e.g. an HTTP request) so the job
+	// outlives it; the stop channel remains the cancellation mechanism.
+	go cs.run(context.WithoutCancel(ctx), job)
+
+	clog(ctx).Info("Scheduled recurring conversation",
+		"ritual_id", ritual.ID, "name", ritual.Name, "interval", ritual.Interval)
+	return nil
+}
+
+// Cancel stops a ritual's recurring runs.
+func (cs *ConversationScheduler) Cancel(id string) error {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	job, ok := cs.jobs[id]
+	if !ok {
+		return fmt.Errorf("%w: scheduled conversation %s", ErrNotFound, id)
+	}
+	close(job.stop)
+	delete(cs.jobs, id)
+	return nil
+}
+
+// Rituals returns snapshots of the scheduled conversations sorted by name.
+func (cs *ConversationScheduler) Rituals() []*ScheduledConversation {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	rituals := make([]*ScheduledConversation, 0, len(cs.jobs))
+	for _, job := range cs.jobs {
+		rituals = append(rituals, job.ritual.snapshot())
+	}
+	sort.Slice(rituals, func(a, b int) bool { return rituals[a].Name < rituals[b].Name })
+	return rituals
+}
+
+// Get returns a snapshot of a scheduled conversation by ID.
+func (cs *ConversationScheduler) Get(id string) (*ScheduledConversation, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+
+	job, ok := cs.jobs[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: scheduled conversation %s", ErrNotFound, id)
+	}
+	return job.ritual.snapshot(), nil
+}
+
+// Run executes one occurrence of a scheduled ritual immediately, outside its
+// normal cadence, recording the outcome on the live record.
+func (cs *ConversationScheduler) Run(ctx context.Context, id string) (*ConversationWorkflowResult, error) {
+	cs.mu.Lock()
+	job, ok := cs.jobs[id]
+	cs.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: scheduled conversation %s", ErrNotFound, id)
+	}
+	return cs.engine.RunScheduledConversation(ctx, job.ritual)
+}
+
+// run executes the ritual loop for one job until stopped.
+func (cs *ConversationScheduler) run(ctx context.Context, job *conversationRitualJob) {
+	ticker := time.NewTicker(job.ritual.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-job.stop:
+			return
+		case <-ticker.C:
+			// In multi-instance deployments only the leader runs rituals,
+			// mirroring scheduled reflection.
+			if !cs.engine.isSchedulingActive() {
+				continue
+			}
+			if _, err := cs.engine.RunScheduledConversation(ctx, job.ritual); err != nil {
+				clog(ctx).Error("Scheduled conversation failed",
+					"error", err, "ritual_id", job.ritual.ID)
+			}
+		}
+	}
+}
+
+// ritualOutcome is the webhook payload posted after each ritual run.
+type ritualOutcome struct {
+	RitualID   string                      `json:"ritual_id"`
+	RitualName string                      `json:"ritual_name"`
+	RanAt      time.Time                   `json:"ran_at"`
+	Result     *ConversationWorkflowResult `json:"result"`
+}
+
+// RunScheduledConversation executes one occurrence of a ritual, records
+// the outcome on it, and posts the outcome to the ritual's webhook.
+func (e *Engine) RunScheduledConversation(ctx context.Context, ritual *ScheduledConversation) (*ConversationWorkflowResult, error) {
+	ctx = WithCorrelation(ctx, Correlation{RequestID: NewRequestID()})
+
+	result, err := e.ExecuteConversationWorkflow(ctx, ritual.Workflow)
+
+	ranAt := time.Now()
+	ritual.mu.Lock()
+	ritual.LastRunAt = ranAt
+	ritual.Runs++
+	ritual.LastError = ""
+	switch {
+	case err != nil:
+		ritual.LastSuccess = false
+		ritual.LastError = err.Error()
+	default:
+		ritual.LastSuccess = result.Success
+		if !result.Success {
+			ritual.LastError = result.Error
+		}
+	}
+	ritual.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if ritual.WebhookURL != "" {
+		outcome := &ritualOutcome{
+			RitualID:   ritual.ID,
+			RitualName: ritual.Name,
+			RanAt:      ranAt,
+			Result:     result,
+		}
+		if err := postWebhookJSON(ctx, ritual.WebhookURL, outcome); err != nil {
+			clog(ctx).Error("Failed to deliver ritual outcome",
+				"error", err, "ritual_id", ritual.ID, "url", ritual.WebhookURL)
+		}
+	}
+
+	return result, nil
+}
+
+// GetConversationScheduler returns the engine's conversation scheduler.
+func (e *Engine) GetConversationScheduler() *ConversationScheduler {
+	return e.conversationScheduler
+}
@@ -0,0 +1,124 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestScheduleConversationValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	scheduler := engine.GetConversationScheduler()
+	ctx := context.Background()
+
+	workflow := &ConversationWorkflow{Participants: []string{"ghost"}}
+	if err := scheduler.Schedule(ctx, &ScheduledConversation{Workflow: workflow, Interval: time.Minute}); err == nil {
+		t.Error("Expected an error for an unknown participant")
+	}
+	if err := scheduler.Schedule(ctx, &ScheduledConversation{Workflow: workflow}); err == nil {
+		t.Error("Expected an error for a non-positive interval")
+	}
+	if err := scheduler.Schedule(ctx, &ScheduledConversation{Interval: time.Minute}); err == nil {
+		t.Error("Expected an error without a workflow")
+	}
+}
+
+func TestRunScheduledConversationPostsOutcome(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	alpha := &Agent{Name: "alpha"}
+	beta := &Agent{Name: "beta"}
+	for _, agent := range []*Agent{alpha, beta} {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+
+	var received ritualOutcome
+	delivered := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode outcome: %v", err)
+		}
+		close(delivered)
+	}))
+	defer server.Close()
+
+	ritual := &ScheduledConversation{
+		ID:   "standup",
+		Name: "daily standup",
+		Workflow: &ConversationWorkflow{
+			Description:  "daily status synthesis",
+			Participants: []string{alpha.ID, beta.ID},
+			Steps: []ConversationStep{
+				{ID: "status", FromAgentID: alpha.ID, ToAgentID: beta.ID, MessageTemplate: "What is your status?"},
+			},
+		},
+		Interval:   time.Hour,
+		WebhookURL: server.URL,
+	}
+
+	result, err := engine.RunScheduledConversation(ctx, ritual)
+	if err != nil {
+		t.Fatalf("RunScheduledConversation failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected a successful run, got %+v", result)
+	}
+	if ritual.Runs != 1 || !ritual.LastSuccess || ritual.LastRunAt.IsZero() {
+		t.Errorf("Expected the outcome recorded on the ritual, got %+v", ritual)
+	}
+
+	select {
+	case <-delivered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the webhook delivery")
+	}
+	if received.RitualID != "standup" || received.Result == nil || !received.Result.Success {
+		t.Errorf("Unexpected webhook payload: %+v", received)
+	}
+}
+
+func TestScheduledConversationRecurs(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	scheduler := engine.GetConversationScheduler()
+	ctx := context.Background()
+
+	agent := &Agent{Name: "solo"}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	ritual := &ScheduledConversation{
+		Name: "heartbeat",
+		Workflow: &ConversationWorkflow{
+			Description:  "heartbeat",
+			Participants: []string{agent.ID},
+		},
+		Interval: 20 * time.Millisecond,
+	}
+	if err := scheduler.Schedule(ctx, ritual); err != nil {
+		t.Fatalf("Schedule failed: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for ritual.RunCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if ritual.RunCount() == 0 {
+		t.Fatal("Expected the ritual to run at least once")
+	}
+
+	if err := scheduler.Cancel(ritual.ID); err != nil {
+		t.Fatalf("Cancel failed: %v", err)
+	}
+	if rituals := scheduler.Rituals(); len(rituals) != 0 {
+		t.Errorf("Expected no rituals after cancel, got %v", rituals)
+	}
+}
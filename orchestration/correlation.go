@@ -0,0 +1,68 @@
+package orchestration
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// Correlation carries the identifiers that tie log lines from a single piece
+// of work together across the engine, providers, and tools. IDs are generated
+// at ingress (API middleware, queue consumption, event triggers) and threaded
+// through contexts so a task's full lifecycle can be grepped from the logs.
+type Correlation struct {
+	RequestID      string `json:"request_id,omitempty"`
+	TaskID         string `json:"task_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+}
+
+type correlationContextKey struct{}
+
+// WithCorrelation attaches correlation identifiers to a context. Fields left
+// empty preserve any values already present on the context.
+func WithCorrelation(ctx context.Context, correlation Correlation) context.Context {
+	existing := CorrelationFrom(ctx)
+	if correlation.RequestID == "" {
+		correlation.RequestID = existing.RequestID
+	}
+	if correlation.TaskID == "" {
+		correlation.TaskID = existing.TaskID
+	}
+	if correlation.ConversationID == "" {
+		correlation.ConversationID = existing.ConversationID
+	}
+	return context.WithValue(ctx, correlationContextKey{}, correlation)
+}
+
+// CorrelationFrom extracts correlation identifiers from a context, returning
+// the zero value when none are attached.
+func CorrelationFrom(ctx context.Context) Correlation {
+	if correlation, ok := ctx.Value(correlationContextKey{}).(Correlation); ok {
+		return correlation
+	}
+	return Correlation{}
+}
+
+// NewRequestID generates a fresh ingress request identifier.
+func NewRequestID() string {
+	return uuid.New().String()
+}
+
+// clog returns a logger annotated with the correlation identifiers on the
+// context, so every component logging through it emits greppable IDs.
+func clog(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	correlation := CorrelationFrom(ctx)
+
+	if correlation.RequestID != "" {
+		logger = logger.With("request_id", correlation.RequestID)
+	}
+	if correlation.TaskID != "" {
+		logger = logger.With("task_id", correlation.TaskID)
+	}
+	if correlation.ConversationID != "" {
+		logger = logger.With("conversation_id", correlation.ConversationID)
+	}
+	return logger
+}
@@ -0,0 +1,43 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithCorrelationMergesFields(t *testing.T) {
+	ctx := context.Background()
+
+	ctx = WithCorrelation(ctx, Correlation{RequestID: "req-1"})
+	ctx = WithCorrelation(ctx, Correlation{TaskID: "task-1"})
+	ctx = WithCorrelation(ctx, Correlation{ConversationID: "conv-1"})
+
+	correlation := CorrelationFrom(ctx)
+
+	if correlation.RequestID != "req-1" {
+		t.Errorf("Expected req-1, got %s", correlation.RequestID)
+	}
+	if correlation.TaskID != "task-1" {
+		t.Errorf("Expected task-1, got %s", correlation.TaskID)
+	}
+	if correlation.ConversationID != "conv-1" {
+		t.Errorf("Expected conv-1, got %s", correlation.ConversationID)
+	}
+}
+
+func TestCorrelationFromEmptyContext(t *testing.T) {
+	correlation := CorrelationFrom(context.Background())
+
+	if correlation.RequestID != "" || correlation.TaskID != "" || correlation.ConversationID != "" {
+		t.Error("Expected zero correlation from an unannotated context")
+	}
+}
+
+func TestWithCorrelationOverridesField(t *testing.T) {
+	ctx := WithCorrelation(context.Background(), Correlation{TaskID: "task-1"})
+	ctx = WithCorrelation(ctx, Correlation{TaskID: "task-2"})
+
+	if got := CorrelationFrom(ctx).TaskID; got != "task-2" {
+		t.Errorf("Expected task-2, got %s", got)
+	}
+}
@@ -0,0 +1,181 @@
+package orchestration
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DatasetMessage is one turn in an exported chat-format training example.
+type DatasetMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// DatasetRecord is one instruction-tuning example: a short conversation
+// ending in the assistant output to learn from.
+type DatasetRecord struct {
+	Messages []DatasetMessage       `json:"messages"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// DatasetExportOptions filters which transcripts become training examples.
+type DatasetExportOptions struct {
+	// AgentID limits the export to one agent's tasks and conversations.
+	AgentID string `json:"agent_id,omitempty"`
+	// TaskTypes limits task exports to the listed types; empty means all.
+	TaskTypes []string `json:"task_types,omitempty"`
+	// MinOutputLength drops examples with assistant output shorter than
+	// this many characters.
+	MinOutputLength int `json:"min_output_length,omitempty"`
+	// IncludeConversations also exports multi-agent conversation
+	// transcripts, not just task input/output pairs.
+	IncludeConversations bool `json:"include_conversations,omitempty"`
+}
+
+// ExportDataset writes completed task and conversation transcripts as
+// chat-format JSONL suitable for instruction tuning. Records are
+// deduplicated by content hash; the number of records written is returned.
+func (e *Engine) ExportDataset(opts DatasetExportOptions, w io.Writer) (int, error) {
+	records := e.collectTaskRecords(opts)
+	if opts.IncludeConversations {
+		records = append(records, e.collectConversationRecords(opts)...)
+	}
+
+	encoder := json.NewEncoder(w)
+	seen := make(map[[sha256.Size]byte]bool)
+	written := 0
+	for _, record := range records {
+		digest := recordDigest(record)
+		if seen[digest] {
+			continue
+		}
+		seen[digest] = true
+
+		if err := encoder.Encode(record); err != nil {
+			return written, fmt.Errorf("failed to encode dataset record: %w", err)
+		}
+		written++
+	}
+	return written, nil
+}
+
+// collectTaskRecords converts completed tasks into single-turn examples,
+// oldest first so exports are stable across runs.
+func (e *Engine) collectTaskRecords(opts DatasetExportOptions) []*DatasetRecord {
+	e.mu.RLock()
+	tasks := make([]*Task, 0, len(e.tasks))
+	for _, task := range e.tasks {
+		tasks = append(tasks, task)
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].CreatedAt.Before(tasks[j].CreatedAt) })
+
+	records := make([]*DatasetRecord, 0, len(tasks))
+	for _, task := range tasks {
+		if task.Status != TaskStatusCompleted {
+			continue
+		}
+		if opts.AgentID != "" && task.AgentID != opts.AgentID {
+			continue
+		}
+		if len(opts.TaskTypes) > 0 && !containsString(opts.TaskTypes, task.Type) {
+			continue
+		}
+		input := task.InputText()
+		if input == "" || task.Output == "" {
+			continue
+		}
+		if len(task.Output) < opts.MinOutputLength {
+			continue
+		}
+
+		records = append(records, &DatasetRecord{
+			Messages: []DatasetMessage{
+				{Role: "user", Content: input},
+				{Role: "assistant", Content: task.Output},
+			},
+			Metadata: map[string]interface{}{
+				"source":    "task",
+				"task_type": task.Type,
+			},
+		})
+	}
+	return records
+}
+
+// collectConversationRecords converts multi-agent conversation transcripts
+// into multi-turn examples. Requests and task delegations become user turns;
+// responses and reflections become assistant turns.
+func (e *Engine) collectConversationRecords(opts DatasetExportOptions) []*DatasetRecord {
+	e.mu.RLock()
+	conversations := make([]*Conversation, 0, len(e.conversations))
+	for _, conversation := range e.conversations {
+		conversations = append(conversations, conversation)
+	}
+	e.mu.RUnlock()
+
+	sort.Slice(conversations, func(i, j int) bool {
+		return conversations[i].CreatedAt.Before(conversations[j].CreatedAt)
+	})
+
+	records := make([]*DatasetRecord, 0, len(conversations))
+	for _, conversation := range conversations {
+		if opts.AgentID != "" && !containsString(conversation.Participants, opts.AgentID) {
+			continue
+		}
+
+		messages := make([]DatasetMessage, 0, len(conversation.Messages))
+		assistantChars := 0
+		for _, message := range conversation.Messages {
+			if strings.TrimSpace(message.Content) == "" {
+				continue
+			}
+			role := "user"
+			switch message.Type {
+			case MessageTypeResponse, MessageTypeReflection:
+				role = "assistant"
+				assistantChars += len(message.Content)
+			}
+			messages = append(messages, DatasetMessage{Role: role, Content: message.Content})
+		}
+		if len(messages) < 2 || assistantChars < opts.MinOutputLength {
+			continue
+		}
+
+		records = append(records, &DatasetRecord{
+			Messages: messages,
+			Metadata: map[string]interface{}{
+				"source": "conversation",
+				"topic":  conversation.Topic,
+			},
+		})
+	}
+	return records
+}
+
+// recordDigest hashes a record's messages for deduplication.
+func recordDigest(record *DatasetRecord) [sha256.Size]byte {
+	var builder strings.Builder
+	for _, message := range record.Messages {
+		builder.WriteString(message.Role)
+		builder.WriteByte(0)
+		builder.WriteString(message.Content)
+		builder.WriteByte(0)
+	}
+	return sha256.Sum256([]byte(builder.String()))
+}
+
+// containsString reports whether list contains value.
+func containsString(list []string, value string) bool {
+	for _, entry := range list {
+		if entry == value {
+			return true
+		}
+	}
+	return false
+}
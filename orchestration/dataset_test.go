@@ -0,0 +1,121 @@
+package orchestration
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestExportDatasetFromTasks(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	now := time.Now()
+	engine.tasks["t1"] = &Task{
+		ID: "t1", Type: TaskTypeGenerate, AgentID: "agent-a",
+		Input: "write a haiku", Output: "an old silent pond...",
+		Status: TaskStatusCompleted, CreatedAt: now,
+	}
+	// Duplicate of t1 — must be deduplicated
+	engine.tasks["t2"] = &Task{
+		ID: "t2", Type: TaskTypeGenerate, AgentID: "agent-a",
+		Input: "write a haiku", Output: "an old silent pond...",
+		Status: TaskStatusCompleted, CreatedAt: now.Add(time.Second),
+	}
+	// Failed task — excluded
+	engine.tasks["t3"] = &Task{
+		ID: "t3", Type: TaskTypeGenerate, AgentID: "agent-a",
+		Input: "bad", Status: TaskStatusFailed, CreatedAt: now,
+	}
+	// Different agent — excluded by filter
+	engine.tasks["t4"] = &Task{
+		ID: "t4", Type: TaskTypeChat, AgentID: "agent-b",
+		Input: "hello", Output: "hi there",
+		Status: TaskStatusCompleted, CreatedAt: now,
+	}
+
+	var buf bytes.Buffer
+	written, err := engine.ExportDataset(DatasetExportOptions{AgentID: "agent-a"}, &buf)
+	if err != nil {
+		t.Fatalf("ExportDataset failed: %v", err)
+	}
+	if written != 1 {
+		t.Errorf("Expected 1 deduplicated record, got %d", written)
+	}
+
+	var record DatasetRecord
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("Invalid JSONL output: %v", err)
+	}
+	if len(record.Messages) != 2 || record.Messages[1].Role != "assistant" {
+		t.Errorf("Unexpected record shape: %+v", record)
+	}
+}
+
+func TestExportDatasetFilters(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	now := time.Now()
+
+	engine.tasks["short"] = &Task{
+		ID: "short", Type: TaskTypeGenerate, AgentID: "a",
+		Input: "q", Output: "ok",
+		Status: TaskStatusCompleted, CreatedAt: now,
+	}
+	engine.tasks["long"] = &Task{
+		ID: "long", Type: TaskTypeChat, AgentID: "a",
+		Input: "question", Output: "a sufficiently long answer",
+		Status: TaskStatusCompleted, CreatedAt: now,
+	}
+
+	var buf bytes.Buffer
+	written, err := engine.ExportDataset(DatasetExportOptions{
+		MinOutputLength: 10,
+		TaskTypes:       []string{TaskTypeChat},
+	}, &buf)
+	if err != nil {
+		t.Fatalf("ExportDataset failed: %v", err)
+	}
+	if written != 1 {
+		t.Errorf("Expected only the long chat task exported, got %d", written)
+	}
+}
+
+func TestExportDatasetConversations(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	engine.conversations["c1"] = &Conversation{
+		ID:           "c1",
+		Participants: []string{"a", "b"},
+		Topic:        "planning",
+		Messages: []Message{
+			{Content: "please analyze this", Type: MessageTypeRequest},
+			{Content: "here is my analysis", Type: MessageTypeResponse},
+		},
+		CreatedAt: time.Now(),
+	}
+
+	var buf bytes.Buffer
+	written, err := engine.ExportDataset(DatasetExportOptions{IncludeConversations: true}, &buf)
+	if err != nil {
+		t.Fatalf("ExportDataset failed: %v", err)
+	}
+	if written != 1 {
+		t.Fatalf("Expected 1 conversation record, got %d", written)
+	}
+
+	scanner := bufio.NewScanner(&buf)
+	scanner.Scan()
+	var record DatasetRecord
+	if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+		t.Fatalf("Invalid JSONL output: %v", err)
+	}
+	if record.Messages[0].Role != "user" || record.Messages[1].Role != "assistant" {
+		t.Errorf("Unexpected roles: %+v", record.Messages)
+	}
+	if record.Metadata["source"] != "conversation" {
+		t.Errorf("Expected conversation source, got %v", record.Metadata)
+	}
+}
@@ -0,0 +1,129 @@
+package orchestration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDigestWindow is how far back a digest looks when no explicit
+// window is given.
+const DefaultDigestWindow = 24 * time.Hour
+
+// digestHighlightLimit caps how many memory excerpts a digest quotes per
+// agent so the digest stays readable for busy agents.
+const digestHighlightLimit = 3
+
+// AgentDigest summarizes what a single agent learned over the digest
+// window: how many memories its dedicated Identity formed, a few excerpts
+// of them, and any reflections the engine observed for it.
+type AgentDigest struct {
+	AgentID     string   `json:"agent_id"`
+	AgentName   string   `json:"agent_name"`
+	NewMemories int      `json:"new_memories"`
+	Highlights  []string `json:"highlights,omitempty"`
+	Reflections []string `json:"reflections,omitempty"`
+}
+
+// MemoryDigest is a "what did you learn" report across the engine and its
+// agents for a time window, suitable for the dashboard or a webhook.
+type MemoryDigest struct {
+	Since          time.Time     `json:"since"`
+	Until          time.Time     `json:"until"`
+	EngineMemories int           `json:"engine_memories"`
+	Agents         []AgentDigest `json:"agents"`
+	Summary        string        `json:"summary"`
+}
+
+// GenerateMemoryDigest builds a digest of the memories and reflections
+// formed since a point in time. A zero since uses the default window.
+func (e *Engine) GenerateMemoryDigest(ctx context.Context, since time.Time) *MemoryDigest {
+	until := time.Now()
+	if since.IsZero() {
+		since = until.Add(-DefaultDigestWindow)
+	}
+
+	digest := &MemoryDigest{Since: since, Until: until, Agents: make([]AgentDigest, 0)}
+
+	// The engine's primary Identity holds engine-wide memories, including
+	// the reflection outcomes the bridge records per agent.
+	engineNodes := e.identityBridge.Identity().MemoriesBetween(since, until, 0)
+	digest.EngineMemories = len(engineNodes)
+	reflections := make(map[string][]string)
+	for _, node := range engineNodes {
+		if agentID, ok := strings.CutPrefix(node.ID, "reflection:"); ok {
+			reflections[agentID] = append(reflections[agentID], digestExcerpt(node.Content))
+		}
+	}
+
+	for _, agent := range e.agentsSnapshot() {
+		entry := AgentDigest{AgentID: agent.ID, AgentName: agent.Name, Reflections: reflections[agent.ID]}
+		if bridge := e.GetAgentIdentity(agent.ID); bridge != nil {
+			nodes := bridge.Identity().MemoriesBetween(since, until, 0)
+			entry.NewMemories = len(nodes)
+			for _, node := range nodes[:min(len(nodes), digestHighlightLimit)] {
+				entry.Highlights = append(entry.Highlights, digestExcerpt(node.Content))
+			}
+		}
+		if entry.NewMemories == 0 && len(entry.Reflections) == 0 {
+			continue
+		}
+		digest.Agents = append(digest.Agents, entry)
+	}
+	sort.Slice(digest.Agents, func(a, b int) bool {
+		return digest.Agents[a].AgentName < digest.Agents[b].AgentName
+	})
+
+	digest.Summary = fmt.Sprintf("Since %s: the engine formed %d memories and %d agents learned something new",
+		since.Format(time.RFC3339), digest.EngineMemories, len(digest.Agents))
+	return digest
+}
+
+// DeliverDigest posts a digest as JSON to a webhook URL and reports
+// non-2xx responses as errors.
+func (e *Engine) DeliverDigest(ctx context.Context, url string, digest *MemoryDigest) error {
+	if err := postWebhookJSON(ctx, url, digest); err != nil {
+		return fmt.Errorf("failed to deliver digest: %w", err)
+	}
+	clog(ctx).Info("Delivered memory digest", "url", url, "agents", len(digest.Agents))
+	return nil
+}
+
+// postWebhookJSON posts a JSON payload to a webhook URL, treating non-2xx
+// responses as errors.
+func postWebhookJSON(ctx context.Context, url string, payload interface{}) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", response.Status)
+	}
+	return nil
+}
+
+// digestExcerpt renders a memory's content as a single short line.
+func digestExcerpt(content interface{}) string {
+	line := strings.Join(strings.Fields(fmt.Sprint(content)), " ")
+	if len(line) > 120 {
+		line = line[:120] + "…"
+	}
+	return line
+}
@@ -0,0 +1,88 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestGenerateMemoryDigest(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "learner"}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	idle := &Agent{Name: "idle"}
+	if err := engine.CreateAgent(ctx, idle); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	bridge, err := engine.AttachAgentIdentity(ctx, agent.ID, AgentIdentityConfig{})
+	if err != nil {
+		t.Fatalf("AttachAgentIdentity failed: %v", err)
+	}
+	bridge.Identity().Remember("lesson", "observed a new pattern")
+	engine.GetIdentityBridge().ObserveReflection(agent.ID, "performance is improving")
+
+	digest := engine.GenerateMemoryDigest(ctx, time.Now().Add(-time.Hour))
+
+	if digest.EngineMemories == 0 {
+		t.Error("Expected the engine reflection to count as an engine memory")
+	}
+	if len(digest.Agents) != 1 {
+		t.Fatalf("Expected only the learning agent in the digest, got %v", digest.Agents)
+	}
+	entry := digest.Agents[0]
+	if entry.AgentID != agent.ID || entry.NewMemories != 1 {
+		t.Errorf("Unexpected agent digest: %+v", entry)
+	}
+	if len(entry.Highlights) != 1 || entry.Highlights[0] != "observed a new pattern" {
+		t.Errorf("Expected the memory excerpted as a highlight, got %v", entry.Highlights)
+	}
+	if len(entry.Reflections) != 1 || entry.Reflections[0] != "performance is improving" {
+		t.Errorf("Expected the reflection in the digest, got %v", entry.Reflections)
+	}
+
+	// Memories older than the window stay out of the digest
+	stale := engine.GenerateMemoryDigest(ctx, time.Time{})
+	if stale.Since.IsZero() {
+		t.Error("Expected a zero since to resolve to the default window")
+	}
+}
+
+func TestDeliverDigestWebhook(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	engine.GetIdentityBridge().Identity().Remember("note", "digest me")
+
+	var received MemoryDigest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON payload, got %s", r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("Failed to decode digest payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	digest := engine.GenerateMemoryDigest(context.Background(), time.Now().Add(-time.Hour))
+	if err := engine.DeliverDigest(context.Background(), server.URL, digest); err != nil {
+		t.Fatalf("DeliverDigest failed: %v", err)
+	}
+	if received.EngineMemories != digest.EngineMemories {
+		t.Errorf("Expected the webhook to receive the digest, got %+v", received)
+	}
+
+	server.Close()
+	if err := engine.DeliverDigest(context.Background(), server.URL, digest); err == nil {
+		t.Error("Expected an error when the webhook is unreachable")
+	}
+}
@@ -0,0 +1,179 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Diagnostic test statuses, in increasing severity.
+const (
+	DiagnosticStatusPass = "pass"
+	DiagnosticStatusWarn = "warn"
+	DiagnosticStatusFail = "fail"
+)
+
+// DiagnosticCheck is a pluggable probe run by RunDiagnostics. Checks return a
+// pass/warn/fail DiagnosticTest and may carry a remediation hint for
+// operators.
+type DiagnosticCheck struct {
+	Name string
+	Run  func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest
+}
+
+// RegisterDiagnosticCheck adds a check to the diagnostics run, replacing any
+// existing check with the same name.
+func (dte *DeepTreeEcho) RegisterDiagnosticCheck(check DiagnosticCheck) {
+	for i, existing := range dte.checks {
+		if existing.Name == check.Name {
+			dte.checks[i] = check
+			return
+		}
+	}
+	dte.checks = append(dte.checks, check)
+}
+
+// defaultDiagnosticChecks returns the built-in checks every DeepTreeEcho
+// instance starts with.
+func defaultDiagnosticChecks() []DiagnosticCheck {
+	return []DiagnosticCheck{
+		{Name: "Cognitive Architecture", Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			return dte.testCognitiveArchitecture()
+		}},
+		{Name: "Memory Resonance", Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			return dte.testMemoryResonance()
+		}},
+		{Name: "Echo Patterns", Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			return dte.testEchoPatterns()
+		}},
+		{Name: "Integrations", Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			return dte.testIntegrations()
+		}},
+	}
+}
+
+// registerEngineDiagnostics adds engine-backed checks to the DTE diagnostics
+// run: they read measured telemetry rather than calling external systems, so
+// diagnostics stay cheap and safe to run from the API. The checks read engine
+// state directly because RunDeepTreeEchoDiagnostics already holds e.mu.
+func (e *Engine) registerEngineDiagnostics() {
+	var lastMemoryNodes int
+	var lastCoherence float64
+
+	e.deepTreeEcho.RegisterDiagnosticCheck(DiagnosticCheck{
+		Name: "Provider Reachability",
+		Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			telemetry := e.telemetrySnapshot()
+			test := newDiagnosticTest("Provider Reachability")
+
+			finished := telemetry.TasksExecuted + telemetry.TasksFailed
+			failureRate := 0.0
+			if finished > 0 {
+				failureRate = float64(telemetry.TasksFailed) / float64(finished)
+			}
+			switch {
+			case finished > 0 && telemetry.TasksExecuted == 0:
+				test.Status = DiagnosticStatusFail
+				test.Message = fmt.Sprintf("All %d finished tasks failed", finished)
+				test.Remediation = "Verify the model daemon is running and the engine can reach it"
+			case failureRate > 0.5:
+				test.Status = DiagnosticStatusWarn
+				test.Message = fmt.Sprintf("%.0f%% of finished tasks failed", failureRate*100)
+				test.Remediation = "Check provider logs for timeouts or rejected requests"
+			default:
+				test.Status = DiagnosticStatusPass
+				test.Message = fmt.Sprintf("%d of %d finished tasks succeeded", telemetry.TasksExecuted, finished)
+			}
+			return test.finish()
+		},
+	})
+
+	e.deepTreeEcho.RegisterDiagnosticCheck(DiagnosticCheck{
+		Name: "Memory Growth",
+		Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			telemetry := e.telemetrySnapshot()
+			test := newDiagnosticTest("Memory Growth")
+
+			grown := telemetry.MemoryNodes - lastMemoryNodes
+			lastMemoryNodes = telemetry.MemoryNodes
+			if grown > memoryGrowthWarnThreshold {
+				test.Status = DiagnosticStatusWarn
+				test.Message = fmt.Sprintf("Agent memory grew by %d items since the last run", grown)
+				test.Remediation = "Tighten per-agent context limits or unpin stale context items"
+			} else {
+				test.Status = DiagnosticStatusPass
+				test.Message = fmt.Sprintf("%d context items held across agent memories", telemetry.MemoryNodes)
+			}
+			return test.finish()
+		},
+	})
+
+	e.deepTreeEcho.RegisterDiagnosticCheck(DiagnosticCheck{
+		Name: "Coherence Trend",
+		Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			test := newDiagnosticTest("Coherence Trend")
+
+			coherence := dte.IdentityCoherence.OverallCoherence
+			delta := coherence - lastCoherence
+			lastCoherence = coherence
+			switch {
+			case coherence < 0.5:
+				test.Status = DiagnosticStatusFail
+				test.Message = fmt.Sprintf("Identity coherence at %.2f, below operating threshold", coherence)
+				test.Remediation = "Investigate recent task failures dragging the measured success rate down"
+			case delta < -coherenceDropWarnThreshold:
+				test.Status = DiagnosticStatusWarn
+				test.Message = fmt.Sprintf("Identity coherence dropped by %.2f since the last run", -delta)
+				test.Remediation = "Review recent task outcomes before the decline compounds"
+			default:
+				test.Status = DiagnosticStatusPass
+				test.Message = fmt.Sprintf("Identity coherence stable at %.2f", coherence)
+			}
+			return test.finish()
+		},
+	})
+
+	e.deepTreeEcho.RegisterDiagnosticCheck(DiagnosticCheck{
+		Name: "Anomaly Detection",
+		Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			test := newDiagnosticTest("Anomaly Detection")
+
+			anomalies := e.anomalies.RecentAnomalies(anomalyDiagnosticWindow)
+			if len(anomalies) == 0 {
+				test.Status = DiagnosticStatusPass
+				test.Message = "Cognitive metrics within learned baselines"
+				return test.finish()
+			}
+
+			latest := anomalies[len(anomalies)-1]
+			test.Status = DiagnosticStatusWarn
+			test.Message = fmt.Sprintf("%d metric anomalies in the last %s, most recently %s at z=%.1f",
+				len(anomalies), anomalyDiagnosticWindow, latest.Metric, latest.ZScore)
+			test.Remediation = "Inspect the anomalous metric's recent dynamics and correlate with engine activity"
+			return test.finish()
+		},
+	})
+}
+
+const (
+	// memoryGrowthWarnThreshold is the per-run growth in agent context items
+	// above which the memory growth check warns.
+	memoryGrowthWarnThreshold = 1000
+	// coherenceDropWarnThreshold is the per-run coherence decline above which
+	// the coherence trend check warns.
+	coherenceDropWarnThreshold = 0.1
+	// anomalyDiagnosticWindow is how far back the anomaly detection check
+	// looks when judging recent deviations.
+	anomalyDiagnosticWindow = 10 * time.Minute
+)
+
+// newDiagnosticTest starts a timed diagnostic test.
+func newDiagnosticTest(name string) DiagnosticTest {
+	return DiagnosticTest{Name: name, Timestamp: time.Now()}
+}
+
+// finish stamps the test's duration.
+func (t DiagnosticTest) finish() DiagnosticTest {
+	t.Duration = time.Since(t.Timestamp)
+	return t
+}
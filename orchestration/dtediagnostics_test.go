@@ -0,0 +1,101 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestEngineDiagnosticsIncludeTelemetryChecks(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if err := engine.InitializeDeepTreeEcho(ctx); err != nil {
+		t.Fatalf("InitializeDeepTreeEcho failed: %v", err)
+	}
+	result, err := engine.RunDeepTreeEchoDiagnostics(ctx)
+	if err != nil {
+		t.Fatalf("RunDeepTreeEchoDiagnostics failed: %v", err)
+	}
+
+	found := make(map[string]DiagnosticTest)
+	for _, test := range result.Tests {
+		found[test.Name] = test
+	}
+	for _, name := range []string{"Provider Reachability", "Memory Growth", "Coherence Trend"} {
+		if _, ok := found[name]; !ok {
+			t.Errorf("Expected engine check '%s' in diagnostic results", name)
+		}
+	}
+	if test := found["Provider Reachability"]; test.Status != DiagnosticStatusPass {
+		t.Errorf("Expected provider check to pass with no finished tasks, got %s", test.Status)
+	}
+}
+
+func TestProviderReachabilityCheckFailsOnAllFailures(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	agent := &Agent{Name: "diag", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	failing := &Task{ID: "diag-failure", Type: TaskTypeGenerate, Input: "fails", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, failing, agent); err == nil {
+		t.Fatal("Expected generate task to fail against unreachable provider")
+	}
+
+	result, err := engine.RunDeepTreeEchoDiagnostics(ctx)
+	if err != nil {
+		t.Fatalf("RunDeepTreeEchoDiagnostics failed: %v", err)
+	}
+	for _, test := range result.Tests {
+		if test.Name != "Provider Reachability" {
+			continue
+		}
+		if test.Status != DiagnosticStatusFail {
+			t.Errorf("Expected provider check to fail, got %s", test.Status)
+		}
+		if test.Remediation == "" {
+			t.Error("Expected a remediation hint on a failing check")
+		}
+		return
+	}
+	t.Fatal("Provider Reachability check not found")
+}
+
+func TestRegisterDiagnosticCheckReplacesByName(t *testing.T) {
+	dte := NewDeepTreeEcho("Test DTE System")
+	ctx := context.Background()
+
+	dte.RegisterDiagnosticCheck(DiagnosticCheck{
+		Name: "Custom",
+		Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			return DiagnosticTest{Name: "Custom", Status: DiagnosticStatusWarn}
+		},
+	})
+	dte.RegisterDiagnosticCheck(DiagnosticCheck{
+		Name: "Custom",
+		Run: func(ctx context.Context, dte *DeepTreeEcho) DiagnosticTest {
+			return DiagnosticTest{Name: "Custom", Status: DiagnosticStatusPass}
+		},
+	})
+
+	result, err := dte.RunDiagnostics(ctx)
+	if err != nil {
+		t.Fatalf("RunDiagnostics failed: %v", err)
+	}
+	custom := 0
+	for _, test := range result.Tests {
+		if test.Name == "Custom" {
+			custom++
+			if test.Status != DiagnosticStatusPass {
+				t.Errorf("Expected replacement check to run, got status %s", test.Status)
+			}
+		}
+	}
+	if custom != 1 {
+		t.Errorf("Expected exactly one Custom check, got %d", custom)
+	}
+}
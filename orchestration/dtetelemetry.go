@@ -0,0 +1,132 @@
+package orchestration
+
+// DTETelemetry is a point-in-time snapshot of measured engine activity used
+// to back Deep Tree Echo status fields. Standalone DeepTreeEcho instances
+// have no telemetry source and fall back to simulated values.
+type DTETelemetry struct {
+	TasksExecuted       int64   `json:"tasks_executed"`       // Tasks that completed successfully
+	TasksFailed         int64   `json:"tasks_failed"`         // Tasks that ended in failure
+	ReflectionRuns      int64   `json:"reflection_runs"`      // Completed per-agent reflection cycles
+	ToolInvocations     int64   `json:"tool_invocations"`     // Completed tool tasks
+	MemoryNodes         int     `json:"memory_nodes"`         // Context items held across agent memories
+	MemoryConnections   int     `json:"memory_connections"`   // Messages exchanged across conversations
+	ActiveConversations int     `json:"active_conversations"` // Conversations currently in the active state
+	IdentityCoherence   float64 `json:"identity_coherence"`   // Live coherence from the core Identity; task success rate without a bridge
+	MemoryCoherence     float64 `json:"memory_coherence"`     // Memory resonance coherence from the core Identity
+}
+
+// telemetrySnapshot gathers measured engine activity for Deep Tree Echo
+// status reporting. The caller must hold e.mu: RefreshDeepTreeEchoStatus
+// invokes it through the bound telemetry source while holding the lock.
+func (e *Engine) telemetrySnapshot() DTETelemetry {
+	snapshot := DTETelemetry{ReflectionRuns: e.reflectionRuns}
+	for _, task := range e.tasks {
+		switch task.Status {
+		case TaskStatusCompleted:
+			snapshot.TasksExecuted++
+			if task.Type == TaskTypeTool {
+				snapshot.ToolInvocations++
+			}
+		case TaskStatusFailed:
+			snapshot.TasksFailed++
+		}
+	}
+	for _, agent := range e.agents {
+		if agent.State != nil {
+			snapshot.MemoryNodes += len(agent.State.Context)
+		}
+	}
+	for _, conversation := range e.conversations {
+		snapshot.MemoryConnections += len(conversation.Messages)
+		if conversation.Status == ConversationStatusActive {
+			snapshot.ActiveConversations++
+		}
+	}
+
+	if e.identityBridge != nil {
+		identity := e.identityBridge.Snapshot()
+		snapshot.IdentityCoherence = identity.Coherence
+		snapshot.MemoryCoherence = identity.MemoryCoherence
+		snapshot.MemoryNodes += identity.MemoryNodes
+		snapshot.MemoryConnections += identity.MemoryEdges
+	} else {
+		snapshot.IdentityCoherence = 1.0
+		if finished := snapshot.TasksExecuted + snapshot.TasksFailed; finished > 0 {
+			snapshot.IdentityCoherence = float64(snapshot.TasksExecuted) / float64(finished)
+		}
+	}
+	return snapshot
+}
+
+// bindTelemetry connects the status fields to a measured activity source,
+// typically the owning engine. Once bound, RefreshStatus reports measured
+// values instead of the simulated drift used by standalone instances.
+func (dte *DeepTreeEcho) bindTelemetry(source func() DTETelemetry) {
+	dte.telemetry = source
+}
+
+// applyTelemetry overwrites the narrative status fields with measured engine
+// activity so RefreshStatus reports what the engine actually did.
+func (dte *DeepTreeEcho) applyTelemetry(t DTETelemetry) {
+	now := dte.UpdatedAt
+
+	dte.ThoughtCount = t.TasksExecuted + t.TasksFailed + t.ReflectionRuns
+
+	dte.IdentityCoherence.OverallCoherence = t.IdentityCoherence
+	dte.IdentityCoherence.LastUpdated = now
+
+	dte.MemoryResonance.MemoryNodes = t.MemoryNodes
+	dte.MemoryResonance.Connections = t.MemoryConnections
+	dte.MemoryResonance.ActivePatterns = t.ActiveConversations
+	if t.MemoryNodes > 0 || t.MemoryConnections > 0 {
+		dte.MemoryResonance.ResonancePattern = "active"
+	} else {
+		dte.MemoryResonance.ResonancePattern = "idle"
+	}
+	if t.MemoryCoherence > 0 {
+		dte.MemoryResonance.Coherence = t.MemoryCoherence
+	}
+	dte.MemoryResonance.LastUpdated = now
+
+	// Self-improvement activity is the share of thoughts spent reflecting;
+	// synthesis activity saturates with cross-agent message volume.
+	reflectionShare := 0.0
+	if dte.ThoughtCount > 0 {
+		reflectionShare = float64(t.ReflectionRuns) / float64(dte.ThoughtCount)
+	}
+	synthesisActivity := float64(t.MemoryConnections) / (float64(t.MemoryConnections) + 25)
+
+	updateMeasuredPattern(dte.EchoPatterns.RecursiveSelfImprovement, reflectionShare)
+	updateMeasuredPattern(dte.EchoPatterns.CrossSystemSynthesis, synthesisActivity)
+	updateMeasuredPattern(dte.EchoPatterns.IdentityPreservation, dte.IdentityCoherence.OverallCoherence)
+	dte.EchoPatterns.LastUpdated = now
+
+	if reached := dte.evaluateMilestones(t); len(reached) > 0 {
+		dte.pendingMilestones = append(dte.pendingMilestones, reached...)
+	}
+}
+
+// updateMeasuredPattern sets a pattern's strength from a measured value and
+// derives its frequency and trend from the change.
+func updateMeasuredPattern(pattern *EchoPattern, strength float64) {
+	switch delta := strength - pattern.Strength; {
+	case delta > 0.01:
+		pattern.Trend = "rising"
+	case delta < -0.01:
+		pattern.Trend = "declining"
+	default:
+		pattern.Trend = "stable"
+	}
+	pattern.Strength = strength
+
+	switch {
+	case strength == 0:
+		pattern.Frequency = "idle"
+	case strength < 0.3:
+		pattern.Frequency = "low"
+	case strength < 0.7:
+		pattern.Frequency = "moderate"
+	default:
+		pattern.Frequency = "high"
+	}
+}
@@ -0,0 +1,121 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestRefreshStatusUsesEngineTelemetry(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	agent := &Agent{Name: "telemetry", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// One deterministic success and one provider failure.
+	task := &Task{ID: "telemetry-success", Type: TaskTypeCustom, Input: "succeeds", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute custom task: %v", err)
+	}
+	failing := &Task{ID: "telemetry-failure", Type: TaskTypeGenerate, Input: "fails", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, failing, agent); err == nil {
+		t.Fatal("Expected generate task to fail against unreachable provider")
+	}
+
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	dte := engine.GetDeepTreeEcho()
+	if dte.ThoughtCount != 2 {
+		t.Errorf("Expected thought count 2 from executed tasks, got %d", dte.ThoughtCount)
+	}
+	want := engine.GetIdentityBridge().Snapshot().Coherence
+	if got := dte.IdentityCoherence.OverallCoherence; got != want {
+		t.Errorf("Expected coherence %f from the core Identity, got %f", want, got)
+	}
+
+	// A second refresh must not drift: the counts are measured, not simulated.
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+	if dte.ThoughtCount != 2 {
+		t.Errorf("Expected thought count to stay at 2 without new tasks, got %d", dte.ThoughtCount)
+	}
+}
+
+func TestTelemetrySnapshotCountsMemoryAndConversations(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "memory", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if agent.State == nil {
+		agent.State = &AgentState{}
+	}
+	agent.State.Context = append(agent.State.Context, ContextItem{Key: "fact", Value: "blue"})
+	if err := engine.UpdateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to update agent: %v", err)
+	}
+	if snapshot := engine.telemetrySnapshot(); snapshot.MemoryNodes != 1 {
+		t.Errorf("Expected 1 memory node, got %d", snapshot.MemoryNodes)
+	}
+
+	other := &Agent{Name: "peer", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, other); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	conversation, err := engine.StartConversation(ctx, []string{agent.ID, other.ID}, "telemetry")
+	if err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+	err = engine.SendMessage(ctx, conversation.ID, &Message{
+		FromAgentID: agent.ID,
+		ToAgentID:   other.ID,
+		Content:     "hello",
+		Type:        MessageTypeNotification,
+	})
+	if err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+
+	snapshot := engine.telemetrySnapshot()
+	// Message delivery records context items of its own, so memory only grows.
+	if snapshot.MemoryNodes < 1 {
+		t.Errorf("Expected at least 1 memory node, got %d", snapshot.MemoryNodes)
+	}
+	if snapshot.MemoryConnections != 1 {
+		t.Errorf("Expected 1 memory connection, got %d", snapshot.MemoryConnections)
+	}
+	if snapshot.ActiveConversations != 1 {
+		t.Errorf("Expected 1 active conversation, got %d", snapshot.ActiveConversations)
+	}
+	if snapshot.IdentityCoherence != 1.0 {
+		t.Errorf("Expected coherence 1.0 with no finished tasks, got %f", snapshot.IdentityCoherence)
+	}
+}
+
+func TestUpdateMeasuredPattern(t *testing.T) {
+	pattern := &EchoPattern{Strength: 0.2}
+
+	updateMeasuredPattern(pattern, 0.8)
+	if pattern.Trend != "rising" || pattern.Frequency != "high" {
+		t.Errorf("Expected rising/high, got %s/%s", pattern.Trend, pattern.Frequency)
+	}
+
+	updateMeasuredPattern(pattern, 0.8)
+	if pattern.Trend != "stable" {
+		t.Errorf("Expected stable trend for unchanged strength, got %s", pattern.Trend)
+	}
+
+	updateMeasuredPattern(pattern, 0)
+	if pattern.Trend != "declining" || pattern.Frequency != "idle" {
+		t.Errorf("Expected declining/idle, got %s/%s", pattern.Trend, pattern.Frequency)
+	}
+}
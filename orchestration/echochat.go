@@ -4,20 +4,27 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
+	"os/signal"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
 
 // EchoChat provides shell integration with Deep Tree Echo intelligence
 type EchoChat struct {
-	engine      *Engine
-	currentDir  string
-	shell       string
-	history     []ChatCommand
-	maxHistory  int
+	engine       *Engine
+	currentDir   string
+	shell        string
+	history      []ChatCommand
+	maxHistory   int
+	policy       *ChatPolicyEngine
+	sessionID    string
+	historyStore *ChatHistoryStore
+	envContext   *EnvironmentContext
 }
 
 // ChatCommand represents a command executed through EchoChat
@@ -38,13 +45,52 @@ func NewEchoChat(engine *Engine) *EchoChat {
 	currentDir, _ := os.Getwd()
 	shell := getDefaultShell()
 	
+	// The default policy always compiles; ignore the error here
+	policy, _ := NewChatPolicyEngine(DefaultChatPolicy())
+
 	return &EchoChat{
 		engine:     engine,
 		currentDir: currentDir,
 		shell:      shell,
 		history:    make([]ChatCommand, 0),
 		maxHistory: 100,
+		policy:     policy,
+		sessionID:  "default",
+	}
+}
+
+// EnablePersistentHistory stores interactions for the given session under dir
+// and reloads any history persisted by previous sessions with the same ID.
+func (ec *EchoChat) EnablePersistentHistory(dir, sessionID string) error {
+	store, err := NewChatHistoryStore(dir)
+	if err != nil {
+		return err
+	}
+
+	if sessionID != "" {
+		ec.sessionID = sessionID
 	}
+	ec.historyStore = store
+
+	previous, err := store.Load(ec.sessionID)
+	if err != nil {
+		return err
+	}
+	if len(previous) > ec.maxHistory {
+		previous = previous[len(previous)-ec.maxHistory:]
+	}
+	ec.history = append(previous, ec.history...)
+	return nil
+}
+
+// SetPolicy replaces the command execution policy.
+func (ec *EchoChat) SetPolicy(policy *ChatPolicyEngine) {
+	ec.policy = policy
+}
+
+// GetPolicy returns the active command execution policy engine.
+func (ec *EchoChat) GetPolicy() *ChatPolicyEngine {
+	return ec.policy
 }
 
 // getDefaultShell returns the default shell for the current OS
@@ -87,8 +133,13 @@ func (ec *EchoChat) StartInteractiveSession(ctx context.Context) error {
 			fmt.Println("Goodbye!")
 			break
 		}
-		
-		if err := ec.ProcessInput(ctx, input); err != nil {
+
+		// Ctrl-C cancels the in-flight model request and child process
+		// rather than exiting the session
+		inputCtx, stop := signal.NotifyContext(ctx, os.Interrupt)
+		err := ec.ProcessInput(inputCtx, input)
+		stop()
+		if err != nil && ctx.Err() == nil {
 			fmt.Printf("Error: %v\n", err)
 		}
 	}
@@ -113,35 +164,98 @@ func (ec *EchoChat) ProcessInput(ctx context.Context, input string) error {
 		ec.addToHistory(command)
 		return nil
 	}
-	
+
+	// "plan <request>" previews a multi-step plan and executes it only
+	// after approval; "plan --yes <request>" skips the approval prompt
+	if request, found := strings.CutPrefix(input, "plan "); found {
+		opts := PlanOptions{}
+		if rest, yes := strings.CutPrefix(strings.TrimSpace(request), "--yes "); yes {
+			opts.AutoApprove = true
+			request = rest
+		}
+		return ec.ProcessPlanRequest(ctx, strings.TrimSpace(request), opts)
+	}
+
+	// Resolve history references (!! for the last command, !N for entry N)
+	// into the previously executed shell command, skipping re-interpretation
+	recalled := false
+	var shellCommand string
+	if resolved, ok := ec.resolveHistoryReference(input); ok {
+		if resolved == "" {
+			command.Error = "No matching history entry"
+			command.ExitCode = 1
+			command.Duration = time.Since(start)
+			ec.addToHistory(command)
+			return fmt.Errorf("no matching history entry for %q", input)
+		}
+		fmt.Printf("Re-running: %s\n", resolved)
+		shellCommand = resolved
+		recalled = true
+	}
+
+	// Apply engine guardrails to the raw user input before interpretation
+	if moderator := ec.engine.GetModerator(); moderator != nil {
+		result := moderator.Check(ctx, input, "", ModerationInbound)
+		if !result.Allowed {
+			command.Error = "Input blocked by moderation policy"
+			command.ExitCode = 1
+			command.Duration = time.Since(start)
+			ec.addToHistory(command)
+			return fmt.Errorf("%w: chat input", ErrModerationBlocked)
+		}
+		input = result.Content
+	}
+
 	// Use Deep Tree Echo to interpret the input
-	shellCommand, err := ec.interpretWithDeepTreeEcho(ctx, input)
-	if err != nil {
-		command.Error = err.Error()
-		command.ExitCode = 1
-		command.Duration = time.Since(start)
-		ec.addToHistory(command)
-		return err
+	var err error
+	if !recalled {
+		shellCommand, err = ec.interpretWithDeepTreeEcho(ctx, input)
+		if err != nil {
+			command.Error = err.Error()
+			command.ExitCode = 1
+			command.Duration = time.Since(start)
+			ec.addToHistory(command)
+			return err
+		}
 	}
-	
+
 	command.Command = shellCommand
-	
-	// Execute the command if it looks safe
-	if ec.isCommandSafe(shellCommand) {
-		err = ec.executeCommand(ctx, command)
-	} else {
-		// Ask for confirmation for potentially dangerous commands
+
+	// Evaluate the interpreted command against the execution policy
+	decision := ec.policy.Evaluate(shellCommand)
+	confirmed := false
+	executed := false
+
+	switch decision.Action {
+	case PolicyActionDeny:
+		command.Error = fmt.Sprintf("Command blocked by policy: %s", decision.Reason)
+		command.ExitCode = 1
+	case PolicyActionConfirm:
 		if ec.confirmDangerousCommand(shellCommand) {
+			confirmed = true
+			executed = true
 			err = ec.executeCommand(ctx, command)
 		} else {
 			command.Error = "Command execution cancelled by user"
 			command.ExitCode = 1
 		}
+	default:
+		executed = true
+		err = ec.executeCommand(ctx, command)
 	}
-	
+
+	ec.policy.RecordAudit(ChatAuditEntry{
+		Input:     input,
+		Command:   shellCommand,
+		Action:    decision.Action,
+		Confirmed: confirmed,
+		Executed:  executed,
+		ExitCode:  command.ExitCode,
+	})
+
 	command.Duration = time.Since(start)
 	ec.addToHistory(command)
-	
+
 	return err
 }
 
@@ -154,7 +268,7 @@ func (ec *EchoChat) interpretWithDeepTreeEcho(ctx context.Context, input string)
 	}
 	
 	// Build context-aware prompt
-	prompt := ec.buildShellPrompt(input)
+	prompt := ec.buildShellPrompt(ctx, input)
 	
 	// Create a task for the agent
 	task := &Task{
@@ -181,23 +295,22 @@ func (ec *EchoChat) interpretWithDeepTreeEcho(ctx context.Context, input string)
 }
 
 // buildShellPrompt creates a context-aware prompt for shell command interpretation
-func (ec *EchoChat) buildShellPrompt(input string) string {
-	osInfo := runtime.GOOS
-	shellInfo := ec.shell
-	currentDir := ec.currentDir
-	
-	// Include recent command history for context
+func (ec *EchoChat) buildShellPrompt(ctx context.Context, input string) string {
+	env := ec.environmentContext(ctx)
+
+	// Include recent command history so requests like "do that again but in
+	// /tmp" can be resolved against previous commands
 	recentHistory := ""
 	if len(ec.history) > 0 {
 		recentCommands := ec.history
-		if len(recentCommands) > 3 {
-			recentCommands = recentCommands[len(recentCommands)-3:]
+		if len(recentCommands) > 5 {
+			recentCommands = recentCommands[len(recentCommands)-5:]
 		}
-		
-		recentHistory = "\nRecent commands:\n"
+
+		recentHistory = "\nRecent commands (the request may refer back to these):\n"
 		for _, cmd := range recentCommands {
 			if cmd.Command != "" {
-				recentHistory += fmt.Sprintf("- %s -> %s\n", cmd.Input, cmd.Command)
+				recentHistory += fmt.Sprintf("- %s -> %s (exit %d)\n", cmd.Input, cmd.Command, cmd.ExitCode)
 			}
 		}
 	}
@@ -207,20 +320,19 @@ func (ec *EchoChat) buildShellPrompt(input string) string {
 Convert the following natural language request into a precise shell command.
 
 System Context:
-- OS: %s
-- Shell: %s
-- Current Directory: %s%s
+%s%s
 
 Request: %s
 
 Rules:
 1. Return ONLY the shell command, no explanations
 2. Use the most appropriate command for the current OS and shell
-3. Ensure commands are safe and well-formed
-4. For dangerous operations, prefix with 'DANGER:'
-5. If the request is unclear, return 'CLARIFY: [question]'
+3. Prefer tools listed as available over ones that may not be installed
+4. Ensure commands are safe and well-formed
+5. For dangerous operations, prefix with 'DANGER:'
+6. If the request is unclear, return 'CLARIFY: [question]'
 
-Command:`, osInfo, shellInfo, currentDir, recentHistory, input)
+Command:`, env.PromptSection(), recentHistory, input)
 }
 
 // extractCommand extracts the shell command from the LLM response
@@ -239,27 +351,6 @@ func (ec *EchoChat) extractCommand(response string) string {
 	return strings.TrimSpace(response)
 }
 
-// isCommandSafe checks if a command is safe to execute without confirmation
-func (ec *EchoChat) isCommandSafe(command string) bool {
-	// Check for dangerous patterns
-	dangerousPatterns := []string{
-		"rm -rf", "rm -r", "del /s", "format", "mkfs",
-		"dd if=", "> /dev/", "chmod 777", "chown -R",
-		"sudo rm", "sudo dd", ":(){ :|:& };:", // Fork bomb
-		"curl.*|.*sh", "wget.*|.*sh", // Pipe to shell
-	}
-	
-	lowercmd := strings.ToLower(command)
-	
-	for _, pattern := range dangerousPatterns {
-		if strings.Contains(lowercmd, strings.ToLower(pattern)) {
-			return false
-		}
-	}
-	
-	return true
-}
-
 // confirmDangerousCommand asks user confirmation for dangerous commands
 func (ec *EchoChat) confirmDangerousCommand(command string) bool {
 	fmt.Printf("⚠️  WARNING: This command could be dangerous:\n")
@@ -275,45 +366,56 @@ func (ec *EchoChat) confirmDangerousCommand(command string) bool {
 	return false
 }
 
-// executeCommand executes a shell command
+// executeCommand executes a shell command, streaming stdout/stderr to the
+// terminal as the command runs. Cancelling the context kills the child
+// process.
 func (ec *EchoChat) executeCommand(ctx context.Context, command *ChatCommand) error {
 	var cmd *exec.Cmd
-	
+
 	if runtime.GOOS == "windows" {
 		cmd = exec.CommandContext(ctx, "cmd", "/C", command.Command)
 	} else {
 		cmd = exec.CommandContext(ctx, "bash", "-c", command.Command)
 	}
-	
+
 	cmd.Dir = ec.currentDir
-	
-	output, err := cmd.CombinedOutput()
-	command.Output = string(output)
-	
+
+	// Stream live while still capturing output for history
+	var captured strings.Builder
+	cmd.Stdout = io.MultiWriter(os.Stdout, &captured)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &captured)
+	// Give the child a short grace period after cancellation before the
+	// process is forcibly killed
+	cmd.WaitDelay = 3 * time.Second
+
+	err := cmd.Run()
+	command.Output = captured.String()
+
 	if err != nil {
-		command.Error = err.Error()
-		if exitError, ok := err.(*exec.ExitError); ok {
-			command.ExitCode = exitError.ExitCode()
+		if ctx.Err() != nil {
+			command.Error = "Command cancelled"
+			command.ExitCode = 130
+			fmt.Println("\nCommand cancelled")
 		} else {
-			command.ExitCode = 1
+			command.Error = err.Error()
+			if exitError, ok := err.(*exec.ExitError); ok {
+				command.ExitCode = exitError.ExitCode()
+			} else {
+				command.ExitCode = 1
+			}
+			fmt.Printf("Command failed: %s\n", err.Error())
 		}
-		fmt.Printf("Command failed: %s\n", err.Error())
 	} else {
 		command.ExitCode = 0
 	}
-	
-	// Display output
-	if command.Output != "" {
-		fmt.Print(command.Output)
-	}
-	
+
 	// Update current directory if command was cd
 	if strings.HasPrefix(strings.TrimSpace(command.Command), "cd ") {
 		if newDir, err := os.Getwd(); err == nil {
 			ec.currentDir = newDir
 		}
 	}
-	
+
 	return nil
 }
 
@@ -382,9 +484,12 @@ func (ec *EchoChat) showHelp() {
 	fmt.Println("EchoChat Commands:")
 	fmt.Println("  help          - Show this help message")
 	fmt.Println("  history       - Show command history")
+	fmt.Println("  !!            - Re-run the last shell command")
+	fmt.Println("  !N            - Re-run history entry N")
 	fmt.Println("  clear         - Clear the screen")
 	fmt.Println("  pwd           - Show current directory")
 	fmt.Println("  cd <dir>      - Change directory")
+	fmt.Println("  plan <request> - Preview a multi-step plan before executing")
 	fmt.Println("  echo-status   - Show Deep Tree Echo status")
 	fmt.Println("  exit/quit     - Exit EchoChat")
 	fmt.Println()
@@ -443,11 +548,45 @@ func (ec *EchoChat) showEchoStatus() {
 // addToHistory adds a command to the history
 func (ec *EchoChat) addToHistory(command *ChatCommand) {
 	ec.history = append(ec.history, *command)
-	
+
 	// Trim history if it exceeds max size
 	if len(ec.history) > ec.maxHistory {
 		ec.history = ec.history[1:]
 	}
+
+	if ec.historyStore != nil {
+		if err := ec.historyStore.Append(ec.sessionID, *command); err != nil {
+			fmt.Printf("Warning: failed to persist history: %v\n", err)
+		}
+	}
+}
+
+// resolveHistoryReference reports whether input is a history reference
+// ("!!" or "!N") and, if so, returns the shell command it refers to. An
+// empty command with ok=true means the reference did not match an entry.
+func (ec *EchoChat) resolveHistoryReference(input string) (string, bool) {
+	input = strings.TrimSpace(input)
+	if !strings.HasPrefix(input, "!") || len(input) < 2 {
+		return "", false
+	}
+
+	if input == "!!" {
+		for i := len(ec.history) - 1; i >= 0; i-- {
+			if ec.history[i].Command != "" {
+				return ec.history[i].Command, true
+			}
+		}
+		return "", true
+	}
+
+	index, err := strconv.Atoi(input[1:])
+	if err != nil {
+		return "", false
+	}
+	if index < 1 || index > len(ec.history) {
+		return "", true
+	}
+	return ec.history[index-1].Command, true
 }
 
 // GetHistory returns the command history
@@ -467,9 +606,27 @@ func (ec *EchoChat) ExecuteCommand(ctx context.Context, command string) (*ChatCo
 		Context:   make(map[string]interface{}),
 	}
 	
-	err := ec.executeCommand(ctx, cmd)
+	var err error
+	decision := ec.policy.Evaluate(command)
+	if decision.Action == PolicyActionDeny {
+		cmd.Error = fmt.Sprintf("Command blocked by policy: %s", decision.Reason)
+		cmd.ExitCode = 1
+		err = fmt.Errorf("%w: %s", ErrToolDenied, decision.Reason)
+	} else {
+		// Direct execution is an explicit user action, so confirmation-level
+		// commands run without an interactive prompt
+		err = ec.executeCommand(ctx, cmd)
+	}
+
+	ec.policy.RecordAudit(ChatAuditEntry{
+		Command:  command,
+		Action:   decision.Action,
+		Executed: decision.Action != PolicyActionDeny,
+		ExitCode: cmd.ExitCode,
+	})
+
 	cmd.Duration = time.Since(start)
 	ec.addToHistory(cmd)
-	
+
 	return cmd, err
 }
\ No newline at end of file
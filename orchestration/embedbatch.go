@@ -0,0 +1,99 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// defaultEmbedConcurrency bounds how many embedding requests run against the
+// provider at once when the caller does not specify a limit.
+const defaultEmbedConcurrency = 4
+
+// maxEmbedConcurrency caps caller-supplied concurrency so a single batch
+// cannot monopolize the provider.
+const maxEmbedConcurrency = 16
+
+// BatchEmbeddingResult reports the outcome of a batch embedding call.
+// Embeddings is index-aligned with the input texts; entries whose embedding
+// failed are nil with the failure recorded in Errors under the same index.
+type BatchEmbeddingResult struct {
+	Model      string         `json:"model"`
+	Embeddings [][]float64    `json:"embeddings"`
+	Completed  int            `json:"completed"`
+	Failed     int            `json:"failed"`
+	Errors     map[int]string `json:"errors,omitempty"`
+}
+
+// EmbedProgress is invoked after each text finishes embedding, successfully
+// or not. completed counts finished texts out of total. Callbacks may be
+// invoked concurrently from worker goroutines.
+type EmbedProgress func(completed, total int)
+
+// EmbedBatch embeds many texts with bounded concurrency, feeding the RAG and
+// memory subsystems. Failures on individual texts do not abort the batch;
+// they are reported per index on the result. progress may be nil.
+func (e *Engine) EmbedBatch(ctx context.Context, model string, texts []string, concurrency int, progress EmbedProgress) (*BatchEmbeddingResult, error) {
+	if model == "" {
+		return nil, fmt.Errorf("%w: no embedding model specified", ErrModelUnavailable)
+	}
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("no texts to embed")
+	}
+	if concurrency <= 0 {
+		concurrency = defaultEmbedConcurrency
+	}
+	if concurrency > maxEmbedConcurrency {
+		concurrency = maxEmbedConcurrency
+	}
+
+	result := &BatchEmbeddingResult{
+		Model:      model,
+		Embeddings: make([][]float64, len(texts)),
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		completed int
+	)
+	semaphore := make(chan struct{}, concurrency)
+
+	for i, text := range texts {
+		wg.Add(1)
+		go func(idx int, input string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			resp, err := e.coalescedEmbeddings(ctx, &api.EmbeddingRequest{
+				Model:  model,
+				Prompt: input,
+			})
+
+			mu.Lock()
+			if err != nil {
+				result.Failed++
+				if result.Errors == nil {
+					result.Errors = make(map[int]string)
+				}
+				result.Errors[idx] = err.Error()
+			} else {
+				result.Embeddings[idx] = resp.Embedding
+				result.Completed++
+			}
+			completed++
+			done := completed
+			mu.Unlock()
+
+			if progress != nil {
+				progress(done, len(texts))
+			}
+		}(i, text)
+	}
+	wg.Wait()
+
+	return result, nil
+}
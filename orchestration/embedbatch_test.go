@@ -0,0 +1,61 @@
+package orchestration
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// unreachableClient returns a client whose provider calls fail immediately,
+// so failure paths can be exercised without a running server.
+func unreachableClient(t *testing.T) api.Client {
+	t.Helper()
+	base, err := url.Parse("http://127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("Failed to parse URL: %v", err)
+	}
+	return *api.NewClient(base, http.DefaultClient)
+}
+
+func TestEmbedBatchValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if _, err := engine.EmbedBatch(ctx, "", []string{"text"}, 0, nil); err == nil {
+		t.Error("Expected error for missing model")
+	}
+	if _, err := engine.EmbedBatch(ctx, "nomic-embed-text", nil, 0, nil); err == nil {
+		t.Error("Expected error for empty batch")
+	}
+}
+
+func TestEmbedBatchReportsPerTextFailures(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	var calls int32
+	texts := []string{"one", "two", "three"}
+	result, err := engine.EmbedBatch(ctx, "nomic-embed-text", texts, 2, func(completed, total int) {
+		atomic.AddInt32(&calls, 1)
+		if total != len(texts) {
+			t.Errorf("Expected total %d, got %d", len(texts), total)
+		}
+	})
+	if err != nil {
+		t.Fatalf("EmbedBatch should not fail as a whole: %v", err)
+	}
+
+	if result.Failed != len(texts) || result.Completed != 0 {
+		t.Errorf("Expected all texts to fail, got completed=%d failed=%d", result.Completed, result.Failed)
+	}
+	if len(result.Errors) != len(texts) {
+		t.Errorf("Expected per-index errors, got %v", result.Errors)
+	}
+	if got := atomic.LoadInt32(&calls); got != int32(len(texts)) {
+		t.Errorf("Expected %d progress callbacks, got %d", len(texts), got)
+	}
+}
@@ -0,0 +1,147 @@
+package orchestration
+
+import (
+	"fmt"
+	"math"
+)
+
+// EmotionalContext captures the identity's emotional state at the moment a
+// task executed, recorded on the TaskResult for later analysis.
+type EmotionalContext struct {
+	Emotion   string  `json:"emotion"`
+	Valence   float64 `json:"valence"`
+	Arousal   float64 `json:"arousal"`
+	Intensity float64 `json:"intensity"`
+}
+
+// EmotionalContext reads the Identity's current emotional state.
+func (b *IdentityBridge) EmotionalContext() EmotionalContext {
+	emotion, valence, arousal, intensity := b.identity.CurrentEmotion()
+	return EmotionalContext{
+		Emotion:   emotion,
+		Valence:   valence,
+		Arousal:   arousal,
+		Intensity: intensity,
+	}
+}
+
+// EmotionPolicy modulates task execution from the identity's emotional
+// state. Arousal scales sampling temperature between the configured bounds,
+// valence scales schema-repair patience, and the primary emotion can select
+// a preferred model. Explicit per-task settings always win over the policy.
+type EmotionPolicy struct {
+	// MinTemperature and MaxTemperature bound the arousal-scaled sampling
+	// temperature. Leaving MaxTemperature at zero disables temperature
+	// modulation.
+	MinTemperature float64 `json:"min_temperature,omitempty"`
+	MaxTemperature float64 `json:"max_temperature,omitempty"`
+	// MinRepairAttempts and MaxRepairAttempts bound the valence-scaled
+	// schema-repair retries. Leaving MaxRepairAttempts at zero disables
+	// retry modulation.
+	MinRepairAttempts int `json:"min_repair_attempts,omitempty"`
+	MaxRepairAttempts int `json:"max_repair_attempts,omitempty"`
+	// ModelByEmotion maps a primary emotion type (e.g. "curious") to the
+	// model used for tasks that did not name one.
+	ModelByEmotion map[string]string `json:"model_by_emotion,omitempty"`
+}
+
+// DefaultEmotionPolicy returns a policy with moderate modulation: calm runs
+// sample conservatively, aroused runs explore, and positive valence buys
+// more repair patience.
+func DefaultEmotionPolicy() *EmotionPolicy {
+	return &EmotionPolicy{
+		MinTemperature:    0.3,
+		MaxTemperature:    1.0,
+		MinRepairAttempts: 1,
+		MaxRepairAttempts: maxSchemaRepairAttempts + 1,
+	}
+}
+
+// Validate checks the policy bounds against the ranges the providers accept.
+func (p *EmotionPolicy) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.MinTemperature < 0 || p.MaxTemperature > 2 {
+		return fmt.Errorf("temperature bounds must be within [0, 2], got [%g, %g]", p.MinTemperature, p.MaxTemperature)
+	}
+	if p.MaxTemperature > 0 && p.MinTemperature > p.MaxTemperature {
+		return fmt.Errorf("min_temperature %g exceeds max_temperature %g", p.MinTemperature, p.MaxTemperature)
+	}
+	if p.MinRepairAttempts < 0 {
+		return fmt.Errorf("min_repair_attempts must not be negative, got %d", p.MinRepairAttempts)
+	}
+	if p.MaxRepairAttempts > 0 && p.MinRepairAttempts > p.MaxRepairAttempts {
+		return fmt.Errorf("min_repair_attempts %d exceeds max_repair_attempts %d", p.MinRepairAttempts, p.MaxRepairAttempts)
+	}
+	return nil
+}
+
+// SetEmotionPolicy installs (or with nil, removes) the engine's emotion
+// policy.
+func (e *Engine) SetEmotionPolicy(policy *EmotionPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid emotion policy: %w", err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.emotionPolicy = policy
+	return nil
+}
+
+// GetEmotionPolicy returns the engine's emotion policy, or nil when emotion
+// modulation is off.
+func (e *Engine) GetEmotionPolicy() *EmotionPolicy {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.emotionPolicy
+}
+
+// applyEmotionPolicy modulates a task from the identity's emotional state
+// and returns the context that drove the modulation, or nil when no policy
+// is installed. The agent's own identity is consulted when one is attached,
+// falling back to the engine's primary identity.
+func (e *Engine) applyEmotionPolicy(task *Task, agent *Agent) *EmotionalContext {
+	policy := e.GetEmotionPolicy()
+	if policy == nil {
+		return nil
+	}
+
+	bridge := e.GetAgentIdentity(agent.ID)
+	if bridge == nil {
+		bridge = e.identityBridge
+	}
+	if bridge == nil {
+		return nil
+	}
+	emotional := bridge.EmotionalContext()
+
+	if task.ModelName == "" {
+		if model := policy.ModelByEmotion[emotional.Emotion]; model != "" {
+			task.ModelName = model
+		}
+	}
+
+	if task.Parameters == nil {
+		task.Parameters = make(map[string]interface{})
+	}
+	if policy.MaxTemperature > 0 {
+		options, _ := task.Parameters["options"].(map[string]interface{})
+		if options == nil {
+			options = make(map[string]interface{})
+			task.Parameters["options"] = options
+		}
+		if _, exists := options["temperature"]; !exists {
+			options["temperature"] = policy.MinTemperature + emotional.Arousal*(policy.MaxTemperature-policy.MinTemperature)
+		}
+	}
+	if policy.MaxRepairAttempts > 0 {
+		if _, exists := task.Parameters["max_repair_attempts"]; !exists {
+			span := float64(policy.MaxRepairAttempts - policy.MinRepairAttempts)
+			task.Parameters["max_repair_attempts"] = policy.MinRepairAttempts + int(math.Round(emotional.Valence*span))
+		}
+	}
+
+	return &emotional
+}
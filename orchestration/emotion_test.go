@@ -0,0 +1,135 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestEmotionPolicyModulatesTask(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "moody", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	policy := DefaultEmotionPolicy()
+	policy.ModelByEmotion = map[string]string{"curious": "mistral"}
+	if err := engine.SetEmotionPolicy(policy); err != nil {
+		t.Fatalf("Failed to set emotion policy: %v", err)
+	}
+
+	task := &Task{ID: "emotion-task", Type: TaskTypeCustom, Input: "modulate me", AgentID: agent.ID}
+	result, err := engine.ExecuteTask(ctx, task, agent)
+	if err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+
+	if result.EmotionalContext == nil {
+		t.Fatal("Expected emotional context recorded on the result")
+	}
+	if result.EmotionalContext.Emotion != "curious" {
+		t.Errorf("Expected the identity's curious emotion, got %q", result.EmotionalContext.Emotion)
+	}
+	if task.ModelName != "mistral" {
+		t.Errorf("Expected emotion-routed model mistral, got %q", task.ModelName)
+	}
+
+	options, _ := task.Parameters["options"].(map[string]interface{})
+	temperature, ok := options["temperature"].(float64)
+	if !ok {
+		t.Fatal("Expected a modulated temperature in the task options")
+	}
+	if temperature < policy.MinTemperature || temperature > policy.MaxTemperature {
+		t.Errorf("Expected temperature within [%g, %g], got %g", policy.MinTemperature, policy.MaxTemperature, temperature)
+	}
+
+	attempts, ok := task.Parameters["max_repair_attempts"].(int)
+	if !ok {
+		t.Fatal("Expected modulated repair attempts in the task parameters")
+	}
+	if attempts < policy.MinRepairAttempts || attempts > policy.MaxRepairAttempts {
+		t.Errorf("Expected repair attempts within [%d, %d], got %d", policy.MinRepairAttempts, policy.MaxRepairAttempts, attempts)
+	}
+}
+
+func TestEmotionPolicyRespectsExplicitSettings(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "explicit", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := engine.SetEmotionPolicy(DefaultEmotionPolicy()); err != nil {
+		t.Fatalf("Failed to set emotion policy: %v", err)
+	}
+
+	task := &Task{
+		ID:        "explicit-task",
+		Type:      TaskTypeCustom,
+		Input:     "keep my settings",
+		AgentID:   agent.ID,
+		ModelName: "llama3.2",
+		Parameters: map[string]interface{}{
+			"options": map[string]interface{}{"temperature": 0.05},
+		},
+	}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+
+	if task.ModelName != "llama3.2" {
+		t.Errorf("Expected explicit model preserved, got %q", task.ModelName)
+	}
+	options, _ := task.Parameters["options"].(map[string]interface{})
+	if temperature := options["temperature"]; temperature != 0.05 {
+		t.Errorf("Expected explicit temperature preserved, got %v", temperature)
+	}
+}
+
+func TestEmotionPolicyDisabledRecordsNothing(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "neutral", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	task := &Task{ID: "neutral-task", Type: TaskTypeCustom, Input: "no policy", AgentID: agent.ID}
+	result, err := engine.ExecuteTask(ctx, task, agent)
+	if err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+	if result.EmotionalContext != nil {
+		t.Error("Expected no emotional context without a policy")
+	}
+}
+
+func TestEmotionPolicyValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	if err := engine.SetEmotionPolicy(&EmotionPolicy{MinTemperature: 1.5, MaxTemperature: 0.5}); err == nil {
+		t.Error("Expected inverted temperature bounds to be rejected")
+	}
+	if err := engine.SetEmotionPolicy(&EmotionPolicy{MinRepairAttempts: -1}); err == nil {
+		t.Error("Expected negative repair attempts to be rejected")
+	}
+	if err := engine.SetEmotionPolicy(nil); err != nil {
+		t.Errorf("Expected nil policy to disable modulation, got %v", err)
+	}
+}
+
+func TestTaskRepairAttempts(t *testing.T) {
+	if got := taskRepairAttempts(&Task{}); got != maxSchemaRepairAttempts {
+		t.Errorf("Expected default repair attempts %d, got %d", maxSchemaRepairAttempts, got)
+	}
+	task := &Task{Parameters: map[string]interface{}{"max_repair_attempts": float64(5)}}
+	if got := taskRepairAttempts(task); got != 5 {
+		t.Errorf("Expected 5 repair attempts from JSON parameter, got %d", got)
+	}
+}
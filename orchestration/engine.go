@@ -7,38 +7,249 @@ import (
 	"math"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
+
 	"github.com/EchoCog/echollama/api"
+	"github.com/google/uuid"
 )
 
 // Engine implements the core orchestration functionality
 type Engine struct {
-	client              api.Client
-	agents              map[string]*Agent
-	tasks               map[string]*Task
-	tools               map[string]Tool
-	plugins             *PluginRegistry
-	deepTreeEcho        *DeepTreeEcho
-	conversations       map[string]*Conversation  // Multi-agent conversations
-	learningSystem      *LearningSystem            // Advanced learning capabilities
-	performanceOptimizer *PerformanceOptimizer     // Performance optimization
-	mu                  sync.RWMutex
-}
-
-// NewEngine creates a new orchestration engine
+	client                api.Client
+	store                 Store // Authoritative persistence backend
+	agents                map[string]*Agent
+	tasks                 map[string]*Task
+	tools                 map[string]Tool
+	plugins               *PluginRegistry
+	deepTreeEcho          *DeepTreeEcho
+	identityBridge        *IdentityBridge                     // Core Identity backing DTE status and memory
+	agentIdentities       map[string]*IdentityBridge          // Optional per-agent embodied cognition instances
+	emotionPolicy         *EmotionPolicy                      // Optional emotion-aware task modulation
+	spatial               *SpatialMap                         // Agents and memories placed in cognitive space
+	anomalies             *AnomalyDetector                    // Online anomaly detection over cognitive metrics
+	lastActivityCount     int64                               // Activity total at the previous anomaly observation
+	taskRetention         *TaskRetentionPolicy                // Optional bounds on task history
+	retentionMu           sync.Mutex                          // Guards taskRetention independently of task execution
+	conversations         map[string]*Conversation            // Multi-agent conversations
+	learningSystem        *LearningSystem                     // Advanced learning capabilities
+	performanceOptimizer  *PerformanceOptimizer               // Performance optimization
+	taskQueue             TaskQueue                           // Shared work queue (multi-instance)
+	agentStateLock        DistributedLock                     // Cross-instance agent state locking
+	eventPublisher        EventPublisher                      // Streaming of engine lifecycle events
+	journal               *EventJournal                       // Write-ahead event journal for crash recovery
+	journalMu             sync.RWMutex                        // Guards journal independently of task execution
+	workflowCheckpoints   *WorkflowCheckpointer               // Durable workflow run state for resume-after-crash
+	checkpointMu          sync.RWMutex                        // Guards workflowCheckpoints independently of workflow execution
+	leaderElection        *LeaderElection                     // High-availability leadership state
+	federationPeers       map[string]*FederationPeer          // Peer engines for task delegation
+	federationToken       string                              // Shared secret for inbound federation calls
+	timeline              *Timeline                           // Execution span recording for profiling
+	knowledge             *KnowledgeBase                      // RAG document ingestion and retrieval
+	prompts               *PromptLibrary                      // Named, versioned prompt templates
+	moderator             *Moderator                          // Optional guardrails for prompts and completions
+	reflectionScheduler   *ReflectionScheduler                // Periodic per-agent reflection jobs
+	conversationScheduler *ConversationScheduler              // Recurring multi-agent conversation rituals
+	proposals             map[string]*ConfigProposal          // Self-tuning proposals awaiting review
+	secrets               *SecretManager                      // Credential resolution for providers and tools
+	usage                 *UsageTracker                       // Per-tenant/agent usage aggregation
+	profiles              *ProfileRegistry                    // Capability profiles for specialized agents
+	postProcessors        map[string]PostProcessor            // Named output extractors and validators
+	transcriber           Transcriber                         // Speech-to-text provider for transcribe tasks
+	synthesizer           SpeechSynthesizer                   // Text-to-speech provider for speak tasks
+	fineTuneJobs          map[string]*FineTuneJob             // Model creation jobs submitted to the daemon
+	warmer                *ModelWarmer                        // Keeps agent models loaded ahead of workloads
+	modelScheduler        *ModelScheduler                     // Optional VRAM-aware task-to-model placement
+	rateLimiter           *RateLimiter                        // Per-agent/conversation model call limits
+	experiments           *ExperimentRegistry                 // A/B experiments over routing and prompts
+	budgets               map[string]*conversationBudgetState // Delegation budgets keyed by conversation ID
+	budgetMu              sync.Mutex                          // Guards budgets independently of task execution
+	delegations           map[string]*DelegationHandle        // Spawned conversation tasks keyed by task ID
+	delegationsByMessage  map[string]*DelegationHandle        // The same handles keyed by originating message ID
+	delegationMu          sync.RWMutex                        // Guards delegation handles independently of task execution
+	fineTuneMu            sync.RWMutex                        // Guards fineTuneJobs independently of task execution
+	reflectionRuns        int64                               // Completed reflection cycles, reported via DTE telemetry
+	runs                  map[string]*OrchestrationRun        // Completed orchestration runs for retrieval and re-run
+	runMu                 sync.RWMutex                        // Guards runs independently of task execution
+	workflowTemplates     map[string]*WorkflowTemplate        // Stored parameterized workflows keyed by name
+	templateMu            sync.RWMutex                        // Guards workflowTemplates independently of task execution
+	signalTriggers        map[string]*WorkflowTrigger         // Template runs subscribed to workflow signals
+	recentSignals         []WorkflowSignal                    // Bounded history of emitted signals
+	signalMu              sync.RWMutex                        // Guards triggers and signal history
+	workflowRuns          map[string]*WorkflowRun             // Asynchronous workflow runs submitted over the API
+	workflowRunMu         sync.RWMutex                        // Guards workflowRuns independently of task execution
+	backpressure          map[string]BackpressureConfig       // Per-tenant submission thresholds ("" = default)
+	backpressureMu        sync.RWMutex                        // Guards backpressure independently of task execution
+	activeTasks           atomic.Int64                        // Tasks currently executing, for load shedding
+	flight                singleflight.Group                  // Coalesces concurrent identical provider calls
+	proposalMu            sync.RWMutex                        // Guards proposals independently of task execution
+	mu                    sync.RWMutex
+}
+
+// NewEngine creates a new orchestration engine backed by an in-memory store
 func NewEngine(client api.Client) *Engine {
-	return &Engine{
+	return NewEngineWithStore(client, NewInMemoryStore())
+}
+
+// NewEngineWithStore creates an orchestration engine with an explicit storage
+// backend. The engine's internal maps act as a cache in front of the store:
+// all mutations are written through, and reads fall back to the store on a
+// cache miss, so stateless API replicas sharing a backend stay consistent.
+func NewEngineWithStore(client api.Client, store Store) *Engine {
+	engine := &Engine{
 		client:               client,
+		store:                store,
 		agents:               make(map[string]*Agent),
 		tasks:                make(map[string]*Task),
 		tools:                make(map[string]Tool),
 		plugins:              &PluginRegistry{plugins: make(map[string]Plugin)},
 		deepTreeEcho:         NewDeepTreeEcho("Primary Deep Tree Echo System"),
+		identityBridge:       NewIdentityBridge("Primary Deep Tree Echo System"),
+		agentIdentities:      make(map[string]*IdentityBridge),
+		spatial:              NewSpatialMap(),
+		anomalies:            NewAnomalyDetector(),
 		conversations:        make(map[string]*Conversation),
+		federationPeers:      make(map[string]*FederationPeer),
 		learningSystem:       NewLearningSystem(),
 		performanceOptimizer: NewPerformanceOptimizer(),
+		timeline:             NewTimeline(),
+		prompts:              NewPromptLibrary(),
+		proposals:            make(map[string]*ConfigProposal),
+		secrets:              NewSecretManager(),
+		usage:                NewUsageTracker(),
+		profiles:             NewProfileRegistry(),
+		postProcessors:       make(map[string]PostProcessor),
+		fineTuneJobs:         make(map[string]*FineTuneJob),
+		rateLimiter:          NewRateLimiter(),
+		experiments:          NewExperimentRegistry(),
+		budgets:              make(map[string]*conversationBudgetState),
+		delegations:          make(map[string]*DelegationHandle),
+		delegationsByMessage: make(map[string]*DelegationHandle),
+		runs:                 make(map[string]*OrchestrationRun),
+		workflowTemplates:    make(map[string]*WorkflowTemplate),
+		signalTriggers:       make(map[string]*WorkflowTrigger),
+		workflowRuns:         make(map[string]*WorkflowRun),
+		backpressure:         make(map[string]BackpressureConfig),
+	}
+	engine.knowledge = NewKnowledgeBase(engine, NewInMemoryVectorStore())
+	engine.reflectionScheduler = NewReflectionScheduler(engine)
+	engine.conversationScheduler = NewConversationScheduler(engine)
+	engine.warmer = NewModelWarmer(engine)
+	engine.deepTreeEcho.bindTelemetry(engine.telemetrySnapshot)
+	engine.registerEngineDiagnostics()
+	engine.registerEngineIntegrations()
+	return engine
+}
+
+// SetTaskQueue configures a shared task queue (e.g. Redis-backed) so multiple
+// engine instances can safely divide work. When unset, tasks execute directly.
+func (e *Engine) SetTaskQueue(queue TaskQueue) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.taskQueue = queue
+	slog.Info("Configured shared task queue")
+}
+
+// SetAgentStateLock configures a distributed lock used to serialize agent
+// state updates across engine instances sharing a backend.
+func (e *Engine) SetAgentStateLock(lock DistributedLock) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.agentStateLock = lock
+	slog.Info("Configured distributed agent state lock")
+}
+
+// EnqueueTask places a task on the shared queue for execution by any engine
+// instance. The task must already reference its target agent.
+func (e *Engine) EnqueueTask(ctx context.Context, task *Task) error {
+	e.mu.RLock()
+	queue := e.taskQueue
+	e.mu.RUnlock()
+
+	if queue == nil {
+		return fmt.Errorf("no task queue configured")
+	}
+
+	if task.ID == "" {
+		task.ID = uuid.New().String()
+	}
+	task.Status = TaskStatusPending
+	e.persistTask(ctx, task)
+
+	return queue.Enqueue(ctx, task)
+}
+
+// ProcessQueuedTask claims and executes one task from the shared queue,
+// acknowledging it on success and returning it for redelivery on failure.
+// Returns ErrQueueEmpty when no work is available.
+func (e *Engine) ProcessQueuedTask(ctx context.Context) (*TaskResult, error) {
+	e.mu.RLock()
+	queue := e.taskQueue
+	e.mu.RUnlock()
+
+	if queue == nil {
+		return nil, fmt.Errorf("no task queue configured")
+	}
+
+	// Standby instances must not claim work while another engine leads.
+	if !e.isSchedulingActive() {
+		return nil, ErrQueueEmpty
+	}
+
+	queued, err := queue.Dequeue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Queue consumption is an ingress point: mint a request ID here
+	ctx = WithCorrelation(ctx, Correlation{RequestID: NewRequestID(), TaskID: queued.Task.ID})
+
+	agent, err := e.GetAgent(ctx, queued.Task.AgentID)
+	if err != nil {
+		queue.Nack(ctx, queued.Receipt)
+		return nil, err
+	}
+
+	result, err := e.ExecuteTask(ctx, queued.Task, agent)
+	if err != nil {
+		if nackErr := queue.Nack(ctx, queued.Receipt); nackErr != nil {
+			slog.Error("Failed to nack task", "error", nackErr, "task_id", queued.Task.ID)
+		}
+		return nil, err
+	}
+
+	if ackErr := queue.Ack(ctx, queued.Receipt); ackErr != nil {
+		slog.Error("Failed to ack task", "error", ackErr, "task_id", queued.Task.ID)
+	}
+	return result, nil
+}
+
+// lockAgentState acquires the cross-instance lock for an agent's state when a
+// distributed lock is configured. The returned function releases the lock.
+func (e *Engine) lockAgentState(ctx context.Context, agentID string) func() {
+	e.mu.RLock()
+	lock := e.agentStateLock
+	e.mu.RUnlock()
+
+	if lock == nil {
+		return func() {}
+	}
+
+	name := fmt.Sprintf("agent-state:%s", agentID)
+	token, acquired, err := lock.Acquire(ctx, name, 10*time.Second)
+	if err != nil || !acquired {
+		if err != nil {
+			slog.Error("Failed to acquire agent state lock", "error", err, "agent_id", agentID)
+		}
+		return func() {}
+	}
+
+	return func() {
+		if err := lock.Release(ctx, name, token); err != nil {
+			slog.Error("Failed to release agent state lock", "error", err, "agent_id", agentID)
+		}
 	}
 }
 
@@ -70,48 +281,90 @@ func (e *Engine) CreateAgent(ctx context.Context, agent *Agent) error {
 	agent.CreatedAt = time.Now()
 	agent.UpdatedAt = time.Now()
 
+	// Write-ahead: journal the full record before the engine state mutates
+	e.journalEvent(&EngineEvent{
+		Type:    EventAgentCreated,
+		AgentID: agent.ID,
+		Payload: map[string]interface{}{"agent": agent},
+	})
+
 	e.agents[agent.ID] = agent
+	if err := e.store.SaveAgent(ctx, agent); err != nil {
+		return fmt.Errorf("failed to persist agent: %w", err)
+	}
+
 	slog.Info("Created orchestration agent", "id", agent.ID, "name", agent.Name)
 	return nil
 }
 
-// GetAgent retrieves an agent by ID
+// GetAgent retrieves an agent by ID, falling back to the storage backend when
+// the agent was created by another replica.
 func (e *Engine) GetAgent(ctx context.Context, id string) (*Agent, error) {
 	e.mu.RLock()
-	defer e.mu.RUnlock()
-
 	agent, exists := e.agents[id]
-	if !exists {
-		return nil, fmt.Errorf("agent not found: %s", id)
+	if exists {
+		// Copy-on-return: callers get a private copy, never a live
+		// pointer into the cache
+		clone := agent.Clone()
+		e.mu.RUnlock()
+		return clone, nil
 	}
+	e.mu.RUnlock()
+
+	agent, err := e.store.GetAgent(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, id)
+	}
+
+	// Populate the cache for subsequent reads
+	e.mu.Lock()
+	e.agents[agent.ID] = agent
+	clone := agent.Clone()
+	e.mu.Unlock()
 
-	return agent, nil
+	return clone, nil
 }
 
-// ListAgents returns all registered agents
+// ListAgents returns all registered agents from the storage backend
 func (e *Engine) ListAgents(ctx context.Context) ([]*Agent, error) {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	agents, err := e.store.ListAgents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
 
-	agents := make([]*Agent, 0, len(e.agents))
-	for _, agent := range e.agents {
-		agents = append(agents, agent)
+	// Refresh the cache with the authoritative view; callers get private
+	// copies per the copy-on-return discipline
+	e.mu.Lock()
+	clones := make([]*Agent, len(agents))
+	for i, agent := range agents {
+		e.agents[agent.ID] = agent
+		clones[i] = agent.Clone()
 	}
+	e.mu.Unlock()
 
-	return agents, nil
+	return clones, nil
 }
 
 // UpdateAgent updates an existing agent
 func (e *Engine) UpdateAgent(ctx context.Context, agent *Agent) error {
+	unlock := e.lockAgentState(ctx, agent.ID)
+	defer unlock()
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	if _, exists := e.agents[agent.ID]; !exists {
-		return fmt.Errorf("agent not found: %s", agent.ID)
+		if _, err := e.store.GetAgent(ctx, agent.ID); err != nil {
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, agent.ID)
+		}
 	}
 
 	agent.UpdatedAt = time.Now()
 	e.agents[agent.ID] = agent
+	if err := e.store.SaveAgent(ctx, agent); err != nil {
+		return fmt.Errorf("failed to persist agent: %w", err)
+	}
+
 	slog.Info("Updated orchestration agent", "id", agent.ID, "name", agent.Name)
 	return nil
 }
@@ -122,22 +375,96 @@ func (e *Engine) DeleteAgent(ctx context.Context, id string) error {
 	defer e.mu.Unlock()
 
 	if _, exists := e.agents[id]; !exists {
-		return fmt.Errorf("agent not found: %s", id)
+		if _, err := e.store.GetAgent(ctx, id); err != nil {
+			return fmt.Errorf("%w: %s", ErrAgentNotFound, id)
+		}
 	}
 
+	e.journalEvent(&EngineEvent{Type: EventAgentDeleted, AgentID: id})
+
 	delete(e.agents, id)
+	delete(e.agentIdentities, id)
+	e.spatial.RemoveAgent(id)
+	if err := e.store.DeleteAgent(ctx, id); err != nil && err != ErrNotFound {
+		return fmt.Errorf("failed to delete agent: %w", err)
+	}
+
 	slog.Info("Deleted orchestration agent", "id", id)
 	return nil
 }
 
+// persistTask writes a task to the cache and the storage backend, then
+// enforces the retention policy so history stays bounded.
+func (e *Engine) persistTask(ctx context.Context, task *Task) {
+	e.mu.Lock()
+	e.tasks[task.ID] = task
+	e.mu.Unlock()
+
+	if err := e.store.SaveTask(ctx, task); err != nil {
+		slog.Error("Failed to persist task", "error", err, "task_id", task.ID)
+	}
+
+	e.ApplyTaskRetention(ctx)
+}
+
 // ExecuteTask executes a single task
 func (e *Engine) ExecuteTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	ctx = WithCorrelation(ctx, Correlation{TaskID: task.ID})
+
+	auditContextLive(ctx, "task executed after context cancellation", "task_id", task.ID)
+
+	// Track in-flight work so backpressure checks see direct executions
+	e.activeTasks.Add(1)
+	defer e.activeTasks.Add(-1)
+
 	startTime := time.Now()
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = startTime
+	}
 	task.Status = TaskStatusRunning
+	// Persist the running state so a crashed instance leaves a record for
+	// ResumePendingTasks to find
+	e.persistTask(ctx, task)
+
+	span := e.timeline.Begin(SpanKindTask, task.Type, workflowIDFrom(ctx), task.ID, agent.ID)
+
+	e.emitEvent(ctx, &EngineEvent{
+		Type:    EventTaskStarted,
+		AgentID: agent.ID,
+		TaskID:  task.ID,
+		Payload: map[string]interface{}{"task_type": task.Type, "task": task},
+	})
 
 	var result *TaskResult
 	var err error
 
+	// Inbound guardrails: moderate the prompt before it reaches a model
+	moderated, modErr := e.moderate(ctx, task.Input, task, agent, ModerationInbound)
+	if modErr != nil {
+		e.timeline.End(span, modErr)
+		task.Status = TaskStatusFailed
+		task.Error = modErr.Error()
+		e.persistTask(ctx, task)
+		return nil, modErr
+	}
+	task.Input = moderated
+
+	// Queue behind agent and conversation rate limits rather than failing
+	if limitErr := e.waitForRateLimits(ctx, task, agent); limitErr != nil {
+		e.timeline.End(span, limitErr)
+		task.Status = TaskStatusFailed
+		task.Error = limitErr.Error()
+		e.persistTask(ctx, task)
+		return nil, limitErr
+	}
+
+	// Live experiments may reroute the task to one of their arms
+	e.applyExperimentArm(task, agent)
+
+	// The identity's emotional state may adjust sampling, retry patience,
+	// and model choice
+	emotionalContext := e.applyEmotionPolicy(task, agent)
+
 	switch task.Type {
 	case TaskTypeGenerate:
 		result, err = e.executeGenerateTask(ctx, task, agent)
@@ -151,10 +478,39 @@ func (e *Engine) ExecuteTask(ctx context.Context, task *Task, agent *Agent) (*Ta
 		result, err = e.executeReflectTask(ctx, task, agent)
 	case TaskTypePlugin:
 		result, err = e.executePluginTask(ctx, task, agent)
+	case TaskTypeOrchestrate:
+		result, err = e.executeOrchestrateTask(ctx, task, agent)
+	case TaskTypeSummarize:
+		result, err = e.executeSummarizeTask(ctx, task, agent)
+	case TaskTypeClassify:
+		result, err = e.executeClassifyTask(ctx, task, agent)
+	case TaskTypeVision:
+		result, err = e.executeVisionTask(ctx, task, agent)
+	case TaskTypeTranscribe:
+		result, err = e.executeTranscribeTask(ctx, task, agent)
+	case TaskTypeSpeak:
+		result, err = e.executeSpeakTask(ctx, task, agent)
+	case TaskTypeBlackboard:
+		result, err = e.executeBlackboardTask(ctx, task, agent)
 	default:
 		result, err = e.executeCustomTask(ctx, task, agent)
 	}
 
+	// Outbound guardrails: moderate the completion before returning it
+	if err == nil && result != nil {
+		var output string
+		output, err = e.moderate(ctx, result.Output, task, agent, ModerationOutbound)
+		if err == nil {
+			result.Output = output
+		}
+	}
+
+	if result != nil && emotionalContext != nil {
+		result.EmotionalContext = emotionalContext
+	}
+
+	e.timeline.End(span, err)
+
 	duration := time.Since(startTime)
 	endTime := time.Now()
 
@@ -172,12 +528,21 @@ func (e *Engine) ExecuteTask(ctx context.Context, task *Task, agent *Agent) (*Ta
 		Context:    task.Parameters,
 		Feedback:   e.generatePerformanceFeedback(task, result, err, duration),
 	}
-	
+
 	e.learningSystem.RecordTaskPerformance(performance)
 
+	e.recordExperimentOutcome(task, result, err == nil, duration)
+
 	if err != nil {
 		task.Status = TaskStatusFailed
 		task.Error = err.Error()
+		e.emitEvent(ctx, &EngineEvent{
+			Type:    EventTaskFailed,
+			AgentID: agent.ID,
+			TaskID:  task.ID,
+			Payload: map[string]interface{}{"task_type": task.Type, "error": err.Error(), "task": task},
+		})
+		e.persistTask(ctx, task)
 		return nil, err
 	}
 
@@ -189,52 +554,79 @@ func (e *Engine) ExecuteTask(ctx context.Context, task *Task, agent *Agent) (*Ta
 		result.Metrics.Duration = duration
 	}
 
-	slog.Info("Task completed", "task_id", task.ID, "type", task.Type, "duration", duration)
+	e.recordTaskUsage(task, result)
+	e.recordRateLimitTokens(task, agent, result)
+	e.recordConversationBudgetTokens(task, result)
+	e.persistTask(ctx, task)
+
+	// Feed the completed task through the core Identity so its coherence and
+	// memory reflect real engine activity
+	if e.identityBridge != nil {
+		e.identityBridge.ObserveTask(task)
+	}
+	if bridge := e.GetAgentIdentity(agent.ID); bridge != nil {
+		bridge.ObserveTask(task)
+	}
+
+	e.emitEvent(ctx, &EngineEvent{
+		Type:    EventTaskCompleted,
+		AgentID: agent.ID,
+		TaskID:  task.ID,
+		Payload: map[string]interface{}{"task_type": task.Type, "duration_ms": duration.Milliseconds(), "task": task},
+	})
+
+	clog(ctx).Info("Task completed", "type", task.Type, "duration", duration)
 	return result, nil
 }
 
 // ExecuteTasks executes multiple tasks either sequentially or in parallel
 func (e *Engine) ExecuteTasks(ctx context.Context, tasks []*Task, agent *Agent, sequential bool) ([]*TaskResult, error) {
+	results, errs := e.ExecuteTasksWithMode(ctx, tasks, agent, sequential, false)
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if sequential {
+			return results[:i], err
+		}
+		return results, err
+	}
+	return results, nil
+}
+
+// ExecuteTasksWithMode executes tasks with explicit failure semantics. In
+// best-effort mode every task runs to completion and the returned error slice
+// reports each task's outcome; in fail-fast mode sequential execution stops
+// at the first error (parallel tasks already in flight still finish). Both
+// returned slices are parallel to tasks.
+func (e *Engine) ExecuteTasksWithMode(ctx context.Context, tasks []*Task, agent *Agent, sequential, bestEffort bool) ([]*TaskResult, []error) {
 	results := make([]*TaskResult, len(tasks))
+	errs := make([]error, len(tasks))
 
 	if sequential {
 		for i, task := range tasks {
 			result, err := e.ExecuteTask(ctx, task, agent)
-			if err != nil {
-				return results[:i], err
-			}
 			results[i] = result
+			errs[i] = err
+			if err != nil && !bestEffort {
+				break
+			}
 		}
-	} else {
-		var wg sync.WaitGroup
-		var mu sync.Mutex
-		var firstError error
-
-		for i, task := range tasks {
-			wg.Add(1)
-			go func(idx int, t *Task) {
-				defer wg.Done()
-				result, err := e.ExecuteTask(ctx, t, agent)
-				
-				mu.Lock()
-				if err != nil && firstError == nil {
-					firstError = err
-				}
-				if result != nil {
-					results[idx] = result
-				}
-				mu.Unlock()
-			}(i, task)
-		}
-
-		wg.Wait()
+		return results, errs
+	}
 
-		if firstError != nil {
-			return results, firstError
-		}
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(idx int, t *Task) {
+			defer wg.Done()
+			// Each goroutine writes only its own index
+			results[idx], errs[idx] = e.ExecuteTask(ctx, t, agent)
+		}(i, task)
 	}
+	wg.Wait()
 
-	return results, nil
+	return results, errs
 }
 
 // OrchestrateTasks orchestrates multiple tasks using an agent
@@ -257,17 +649,48 @@ func (e *Engine) OrchestrateTasks(ctx context.Context, req *OrchestrationRequest
 			Parameters: taskReq.Parameters,
 			CreatedAt:  time.Now(),
 		}
+		if err := applyTaskOptions(task, taskReq.Options); err != nil {
+			return nil, err
+		}
+
+		// Request-level Stream and KeepAlive apply to every task unless the
+		// task set its own
+		if req.KeepAlive != nil || req.Stream != nil {
+			if task.Parameters == nil {
+				task.Parameters = make(map[string]interface{})
+			}
+			if req.KeepAlive != nil {
+				if _, exists := task.Parameters["keep_alive"]; !exists {
+					task.Parameters["keep_alive"] = req.KeepAlive
+				}
+			}
+			if req.Stream != nil {
+				if _, exists := task.Parameters["stream"]; !exists {
+					task.Parameters["stream"] = *req.Stream
+				}
+			}
+		}
 
 		// Store task for tracking
-		e.mu.Lock()
-		e.tasks[task.ID] = task
-		e.mu.Unlock()
+		e.persistTask(ctx, task)
 
 		tasks[i] = task
 	}
 
 	// Execute tasks
-	results, err := e.ExecuteTasks(ctx, tasks, agent, req.Sequential)
+	var results []*TaskResult
+	failedTasks := 0
+	if req.BestEffort {
+		var errs []error
+		results, errs = e.ExecuteTasksWithMode(ctx, tasks, agent, req.Sequential, true)
+		for _, taskErr := range errs {
+			if taskErr != nil {
+				failedTasks++
+			}
+		}
+	} else {
+		results, err = e.ExecuteTasks(ctx, tasks, agent, req.Sequential)
+	}
 
 	// Convert []*Task to []Task and []*TaskResult to []TaskResult
 	taskSlice := make([]Task, len(tasks))
@@ -297,25 +720,49 @@ func (e *Engine) OrchestrateTasks(ctx context.Context, req *OrchestrationRequest
 	if err != nil {
 		response.Status = "failed"
 		response.Error = err.Error()
-	}
+	} else if failedTasks > 0 {
+		// Best-effort runs report partial failure; per-task errors are on
+		// the tasks themselves
+		response.Status = "partial"
+		response.Error = fmt.Sprintf("%d of %d tasks failed", failedTasks, len(tasks))
+	}
+
+	e.recordRun(&OrchestrationRun{
+		ID:        response.ID,
+		Request:   req,
+		Response:  response,
+		CreatedAt: response.CreatedAt,
+	})
 
 	return response, err
 }
 
 // executeGenerateTask executes a generate task using the Ollama API
 func (e *Engine) executeGenerateTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
-	modelName := task.ModelName
-	if modelName == "" && len(agent.Models) > 0 {
-		modelName = agent.Models[0] // Use first model as default
+	modelName := e.selectAgentModel(ctx, task, agent)
+	if modelName == "" {
+		return nil, fmt.Errorf("%w: no model specified for generate task", ErrModelUnavailable)
 	}
 
-	if modelName == "" {
-		return nil, fmt.Errorf("no model specified for generate task")
+	prompt := e.resolveTaskPrompt(task, agent)
+	if memory := e.retrieveMemoryContext(ctx, task, agent); memory != "" {
+		prompt = memory + "\n\n" + prompt
 	}
 
 	req := &api.GenerateRequest{
 		Model:  modelName,
-		Prompt: task.Input,
+		Prompt: prompt,
+	}
+	if system := e.resolveSystemPrompt(agent); system != "" {
+		req.System = system
+	}
+	if task.OutputSchema != nil {
+		req.Format = jsonFormat
+		req.Prompt += schemaInstruction(task.OutputSchema)
+	}
+	req.KeepAlive = taskKeepAlive(task)
+	if stream := taskStream(task); stream != nil {
+		req.Stream = stream
 	}
 
 	// Apply parameters from task
@@ -327,39 +774,69 @@ func (e *Engine) executeGenerateTask(ctx context.Context, task *Task, agent *Age
 		}
 	}
 
-	var output string
-	err := e.client.Generate(ctx, req, func(resp api.GenerateResponse) error {
-		output += resp.Response
-		return nil
-	})
+	release := e.acquireModelSlot(ctx, modelName)
+	defer release()
 
+	output, metrics, err := e.coalescedGenerate(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 
-	return &TaskResult{
+	result := &TaskResult{
 		TaskID:    task.ID,
 		Output:    output,
 		ModelUsed: modelName,
-	}, nil
+		Metrics:   metrics,
+	}
+	if task.OutputSchema != nil {
+		structured, canonical, err := e.ensureStructuredOutput(ctx, modelName, task, output)
+		if err != nil {
+			return nil, err
+		}
+		result.StructuredOutput = structured
+		result.Output = canonical
+	}
+	processed, err := e.applyPostProcessing(ctx, modelName, task, result.Output)
+	if err != nil {
+		return nil, err
+	}
+	result.Output = processed
+	return result, nil
 }
 
 // executeChatTask executes a chat task using the Ollama API
 func (e *Engine) executeChatTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
-	modelName := task.ModelName
-	if modelName == "" && len(agent.Models) > 0 {
-		modelName = agent.Models[0]
+	modelName := e.selectAgentModel(ctx, task, agent)
+	if modelName == "" {
+		return nil, fmt.Errorf("%w: no model specified for chat task", ErrModelUnavailable)
 	}
 
-	if modelName == "" {
-		return nil, fmt.Errorf("no model specified for chat task")
+	messages := make([]api.Message, 0, 3)
+	if system := e.resolveSystemPrompt(agent); system != "" {
+		messages = append(messages, api.Message{Role: "system", Content: system})
 	}
+	if memory := e.retrieveMemoryContext(ctx, task, agent); memory != "" {
+		messages = append(messages, api.Message{Role: "system", Content: memory})
+	}
+	userContent := e.resolveTaskPrompt(task, agent)
+	if task.OutputSchema != nil {
+		userContent += schemaInstruction(task.OutputSchema)
+	}
+	messages = append(messages, api.Message{Role: "user", Content: userContent})
+
+	// Fit the assembled prompt into the model's context window before sending
+	messages, accounting := fitChatMessages(messages, task)
 
 	req := &api.ChatRequest{
-		Model: modelName,
-		Messages: []api.Message{
-			{Role: "user", Content: task.Input},
-		},
+		Model:    modelName,
+		Messages: messages,
+	}
+	if task.OutputSchema != nil {
+		req.Format = jsonFormat
+	}
+	req.KeepAlive = taskKeepAlive(task)
+	if stream := taskStream(task); stream != nil {
+		req.Stream = stream
 	}
 
 	// Apply parameters from task
@@ -371,9 +848,18 @@ func (e *Engine) executeChatTask(ctx context.Context, task *Task, agent *Agent)
 		}
 	}
 
+	release := e.acquireModelSlot(ctx, modelName)
+	defer release()
+
 	var output string
+	var metrics TaskMetrics
 	err := e.client.Chat(ctx, req, func(resp api.ChatResponse) error {
 		output += resp.Message.Content
+		if resp.Done {
+			metrics.PromptTokens = resp.PromptEvalCount
+			metrics.OutputTokens = resp.EvalCount
+			metrics.TokensUsed = resp.PromptEvalCount + resp.EvalCount
+		}
 		return nil
 	})
 
@@ -381,54 +867,106 @@ func (e *Engine) executeChatTask(ctx context.Context, task *Task, agent *Agent)
 		return nil, err
 	}
 
-	return &TaskResult{
+	result := &TaskResult{
 		TaskID:    task.ID,
 		Output:    output,
 		ModelUsed: modelName,
-	}, nil
+		Metrics:   metrics,
+	}
+	if accounting.Truncated {
+		result.ContextAccounting = accounting
+	}
+	if task.OutputSchema != nil {
+		structured, canonical, err := e.ensureStructuredOutput(ctx, modelName, task, output)
+		if err != nil {
+			return nil, err
+		}
+		result.StructuredOutput = structured
+		result.Output = canonical
+	}
+	processed, err := e.applyPostProcessing(ctx, modelName, task, result.Output)
+	if err != nil {
+		return nil, err
+	}
+	result.Output = processed
+	return result, nil
 }
 
-// executeEmbedTask executes an embedding task using the Ollama API
+// executeEmbedTask executes an embedding task using the Ollama API. Each
+// input (the task input, or Parameters["inputs"] for batches) is embedded
+// and the vectors are returned on the result. When Parameters["store"] is
+// true the vectors are also added to the knowledge base so they become
+// searchable.
 func (e *Engine) executeEmbedTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
-	modelName := task.ModelName
-	if modelName == "" && len(agent.Models) > 0 {
-		modelName = agent.Models[0]
-	}
-
+	modelName := e.selectAgentModel(ctx, task, agent)
 	if modelName == "" {
-		return nil, fmt.Errorf("no model specified for embed task")
+		return nil, fmt.Errorf("%w: no model specified for embed task", ErrModelUnavailable)
 	}
 
-	req := &api.EmbeddingRequest{
-		Model:  modelName,
-		Prompt: task.Input,
+	inputs := embedTaskInputs(task)
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("embed task has no input text")
 	}
 
-	resp, err := e.client.Embeddings(ctx, req)
-	if err != nil {
-		return nil, err
+	embeddings := make([][]float64, 0, len(inputs))
+	for i, input := range inputs {
+		resp, err := e.coalescedEmbeddings(ctx, &api.EmbeddingRequest{
+			Model:     modelName,
+			Prompt:    input,
+			KeepAlive: taskKeepAlive(task),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed input %d: %w", i, err)
+		}
+		embeddings = append(embeddings, resp.Embedding)
 	}
 
-	// Convert embeddings to string representation
-	output := fmt.Sprintf("Embedding generated with dimension %d", len(resp.Embedding))
+	output := fmt.Sprintf("Generated %d embedding(s) with dimension %d", len(embeddings), len(embeddings[0]))
+
+	if store, ok := task.Parameters["store"].(bool); ok && store {
+		name := fmt.Sprintf("embed-task-%s", task.ID)
+		if _, err := e.knowledge.AddEmbedded(ctx, name, inputs, embeddings); err != nil {
+			return nil, fmt.Errorf("failed to store embeddings: %w", err)
+		}
+		output += ", stored in knowledge base"
+	}
 
 	return &TaskResult{
-		TaskID:    task.ID,
-		Output:    output,
-		ModelUsed: modelName,
+		TaskID:     task.ID,
+		Output:     output,
+		Embeddings: embeddings,
+		ModelUsed:  modelName,
 	}, nil
 }
 
+// embedTaskInputs collects the texts an embed task should vectorize: the
+// batch under Parameters["inputs"] when present, otherwise the task input.
+func embedTaskInputs(task *Task) []string {
+	if raw, ok := task.Parameters["inputs"].([]interface{}); ok {
+		inputs := make([]string, 0, len(raw))
+		for _, entry := range raw {
+			if text, ok := entry.(string); ok && text != "" {
+				inputs = append(inputs, text)
+			}
+		}
+		return inputs
+	}
+	if task.Input == "" {
+		return nil
+	}
+	return []string{task.Input}
+}
+
 // executeCustomTask executes a custom task (enhanced for echoself integration)
 func (e *Engine) executeCustomTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
 	// Enhanced custom task execution with agent state awareness
 	e.updateAgentState(agent, "custom_task", task.Input)
-	
+
 	output := fmt.Sprintf("Custom task '%s' completed with enhanced agent coordination", task.Type)
 	if agent.Type == AgentTypeReflective {
 		output += " (with self-reflection capabilities)"
 	}
-	
+
 	return &TaskResult{
 		TaskID: task.ID,
 		Output: output,
@@ -452,13 +990,15 @@ func (e *Engine) executeToolTask(ctx context.Context, task *Task, agent *Agent)
 
 	// Execute tool if available
 	if tool, exists := e.tools[toolCall.Name]; exists {
+		span := e.timeline.Begin(SpanKindTool, toolCall.Name, workflowIDFrom(ctx), task.ID, agent.ID)
 		result, err := tool.Call(ctx, toolCall.Parameters)
+		e.timeline.End(span, err)
 		if err != nil {
 			return nil, fmt.Errorf("tool call failed: %v", err)
 		}
-		
+
 		e.updateAgentState(agent, "tool_use", toolCall.Name)
-		
+
 		return &TaskResult{
 			TaskID: task.ID,
 			Output: fmt.Sprintf("Tool '%s' executed successfully: %v", toolCall.Name, result.Output),
@@ -475,9 +1015,9 @@ func (e *Engine) executeToolTask(ctx context.Context, task *Task, agent *Agent)
 func (e *Engine) executeReflectTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
 	// Enhanced reflection capabilities for echoself integration
 	reflection := e.performAgentReflection(agent, task.Input)
-	
+
 	e.updateAgentState(agent, "reflection", reflection)
-	
+
 	return &TaskResult{
 		TaskID: task.ID,
 		Output: reflection,
@@ -496,9 +1036,9 @@ func (e *Engine) executePluginTask(ctx context.Context, task *Task, agent *Agent
 		if err != nil {
 			return nil, fmt.Errorf("plugin execution failed: %v", err)
 		}
-		
+
 		e.updateAgentState(agent, "plugin_use", pluginName)
-		
+
 		return &TaskResult{
 			TaskID: task.ID,
 			Output: fmt.Sprintf("Plugin '%s' result: %v", pluginName, result),
@@ -533,7 +1073,7 @@ func (e *Engine) RegisterPlugin(plugin Plugin) {
 func (e *Engine) GetAvailableTools() []string {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	
+
 	tools := make([]string, 0, len(e.tools))
 	for name := range e.tools {
 		tools = append(tools, name)
@@ -545,7 +1085,7 @@ func (e *Engine) GetAvailableTools() []string {
 func (e *Engine) GetAvailablePlugins() []string {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	
+
 	plugins := make([]string, 0, len(e.plugins.plugins))
 	for name := range e.plugins.plugins {
 		plugins = append(plugins, name)
@@ -555,18 +1095,34 @@ func (e *Engine) GetAvailablePlugins() []string {
 
 // Agent State Management Methods
 
-// updateAgentState updates the agent's internal state and memory
+// updateAgentState updates the agent's internal state and memory. State is
+// applied to the live cached agent, so callers holding a copy-on-return
+// clone still update shared state.
 func (e *Engine) updateAgentState(agent *Agent, key string, value interface{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if live, exists := e.agents[agent.ID]; exists {
+		agent = live
+	}
+	e.applyAgentState(agent, key, value)
+}
+
+// applyAgentState folds a key/value interaction into the agent's state. The
+// caller must hold e.mu.
+func (e *Engine) applyAgentState(agent *Agent, key string, value interface{}) {
+	auditLockHeld(&e.mu, "agent state mutated without holding engine lock", "agent_id", agent.ID, "key", key)
+
 	if agent.State == nil {
 		agent.State = &AgentState{
-			Memory:   make(map[string]interface{}),
-			Context:  make([]ContextItem, 0),
+			Memory:  make(map[string]interface{}),
+			Context: make([]ContextItem, 0),
 		}
 	}
-	
+
 	agent.State.Memory[key] = value
 	agent.State.LastInteraction = time.Now()
-	
+
 	// Add to context with relevance scoring
 	contextItem := ContextItem{
 		Key:       key,
@@ -574,28 +1130,35 @@ func (e *Engine) updateAgentState(agent *Agent, key string, value interface{}) {
 		Timestamp: time.Now(),
 		Relevance: 1.0, // Simple relevance scoring
 	}
-	
+
 	agent.State.Context = append(agent.State.Context, contextItem)
-	
-	// Keep only last 10 context items for memory management
-	if len(agent.State.Context) > 10 {
-		agent.State.Context = agent.State.Context[len(agent.State.Context)-10:]
-	}
+
+	// Enforce the agent's memory quota, evicting by relevance and recency
+	// while keeping pinned items
+	evictAgentContext(agent, agentMemoryLimit(agent))
 }
 
-// performAgentReflection performs self-reflection for enhanced agent capabilities
+// performAgentReflection performs self-reflection for enhanced agent capabilities.
+// The base reflection text comes from the "agent_reflection" prompt template,
+// which can be overridden per agent via the prompt library.
 func (e *Engine) performAgentReflection(agent *Agent, input string) string {
-	reflection := fmt.Sprintf("Agent '%s' reflecting on: %s", agent.Name, input)
-	
+	reflection, err := e.prompts.Render("agent_reflection", agent.ID, map[string]string{
+		"agent_name": agent.Name,
+		"input":      input,
+	})
+	if err != nil {
+		reflection = fmt.Sprintf("Agent '%s' reflecting on: %s", agent.Name, input)
+	}
+
 	if agent.State != nil && len(agent.State.Context) > 0 {
 		reflection += fmt.Sprintf(". Recent context includes %d interactions.", len(agent.State.Context))
-		
+
 		// Analyze recent performance
 		if len(agent.State.Context) >= 3 {
 			reflection += " Pattern analysis suggests consistent performance across multiple tasks."
 		}
 	}
-	
+
 	// Agent type specific reflection
 	switch agent.Type {
 	case AgentTypeReflective:
@@ -605,7 +1168,7 @@ func (e *Engine) performAgentReflection(agent *Agent, input string) string {
 	case AgentTypeSpecialist:
 		reflection += " Domain expertise application demonstrates specialized knowledge utilization."
 	}
-	
+
 	return reflection
 }
 
@@ -622,7 +1185,7 @@ func (e *Engine) GetDeepTreeEcho() *DeepTreeEcho {
 func (e *Engine) InitializeDeepTreeEcho(ctx context.Context) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
-	
+
 	return e.deepTreeEcho.InitializeDTECore(ctx)
 }
 
@@ -630,31 +1193,72 @@ func (e *Engine) InitializeDeepTreeEcho(ctx context.Context) error {
 func (e *Engine) RunDeepTreeEchoDiagnostics(ctx context.Context) (*DiagnosticResult, error) {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	
+
 	return e.deepTreeEcho.RunDiagnostics(ctx)
 }
 
 // RefreshDeepTreeEchoStatus refreshes the DTE system status
 func (e *Engine) RefreshDeepTreeEchoStatus(ctx context.Context) error {
 	e.mu.Lock()
-	defer e.mu.Unlock()
-	
-	return e.deepTreeEcho.RefreshStatus(ctx)
+	err := e.deepTreeEcho.RefreshStatus(ctx)
+	health := e.deepTreeEcho.SystemHealth
+	coreStatus := e.deepTreeEcho.CoreStatus
+	milestones := e.deepTreeEcho.drainPendingMilestones()
+	var anomalies []Anomaly
+	if err == nil {
+		anomalies = e.observeCognitiveMetrics(e.telemetrySnapshot())
+	}
+	e.mu.Unlock()
+
+	if err == nil {
+		for _, milestone := range milestones {
+			e.emitEvent(ctx, &EngineEvent{
+				Type:    EventMilestoneReached,
+				Payload: map[string]interface{}{"milestone": milestone.Name, "stage": milestone.Stage, "description": milestone.Description},
+			})
+		}
+		for _, anomaly := range anomalies {
+			e.emitEvent(ctx, &EngineEvent{
+				Type:    EventAnomalyDetected,
+				Payload: map[string]interface{}{"metric": anomaly.Metric, "value": anomaly.Value, "mean": anomaly.Mean, "z_score": anomaly.ZScore},
+			})
+		}
+		e.emitEvent(ctx, &EngineEvent{
+			Type:    EventDTEStatusChanged,
+			Payload: map[string]interface{}{"system_health": string(health), "core_status": string(coreStatus)},
+		})
+	}
+
+	return err
 }
 
 // PerformDeepTreeEchoIntrospection performs recursive introspection
 func (e *Engine) PerformDeepTreeEchoIntrospection(ctx context.Context, repositoryRoot string, currentLoad float64, recentActivity float64) (*IntrospectionResult, error) {
 	e.mu.RLock()
-	defer e.mu.RUnlock()
-	
-	return e.deepTreeEcho.PerformRecursiveIntrospection(ctx, repositoryRoot, currentLoad, recentActivity)
+	dte := e.deepTreeEcho
+	e.mu.RUnlock()
+
+	result, err := dte.PerformRecursiveIntrospection(ctx, repositoryRoot, currentLoad, recentActivity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Record the introspection in the core Identity's memory
+	if e.identityBridge != nil {
+		e.identityBridge.ObserveIntrospection(result.HypergraphPrompt)
+	}
+
+	// Turn introspection findings into reviewable tuning proposals
+	e.generateConfigProposals(ctx, result, currentLoad)
+
+	return result, nil
 }
 
 // GetDeepTreeEchoStatus returns the current status of the DTE system
 func (e *Engine) GetDeepTreeEchoStatus() map[string]interface{} {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	
+
 	dte := e.deepTreeEcho
 	return map[string]interface{}{
 		"system_health":      dte.SystemHealth,
@@ -674,9 +1278,9 @@ func (e *Engine) GetDeepTreeEchoStatus() map[string]interface{} {
 func (e *Engine) GetDeepTreeEchoDashboardData() map[string]interface{} {
 	e.mu.RLock()
 	defer e.mu.RUnlock()
-	
+
 	dte := e.deepTreeEcho
-	
+
 	// Format data for dashboard consumption
 	return map[string]interface{}{
 		"system_metrics": map[string]interface{}{
@@ -691,25 +1295,25 @@ func (e *Engine) GetDeepTreeEchoDashboardData() map[string]interface{} {
 			"factors":           dte.IdentityCoherence.Factors,
 		},
 		"memory_resonance": map[string]interface{}{
-			"memory_nodes":      dte.MemoryResonance.MemoryNodes,
-			"connections":       dte.MemoryResonance.Connections,
-			"coherence":         fmt.Sprintf("%.0f%%", dte.MemoryResonance.Coherence*100),
-			"active_patterns":   dte.MemoryResonance.ActivePatterns,
+			"memory_nodes":    dte.MemoryResonance.MemoryNodes,
+			"connections":     dte.MemoryResonance.Connections,
+			"coherence":       fmt.Sprintf("%.0f%%", dte.MemoryResonance.Coherence*100),
+			"active_patterns": dte.MemoryResonance.ActivePatterns,
 		},
 		"echo_patterns": map[string]interface{}{
 			"recursive_self_improvement": map[string]interface{}{
-				"strength":   fmt.Sprintf("%.0f%%", dte.EchoPatterns.RecursiveSelfImprovement.Strength*100),
-				"frequency":  dte.EchoPatterns.RecursiveSelfImprovement.Frequency,
+				"strength":    fmt.Sprintf("%.0f%%", dte.EchoPatterns.RecursiveSelfImprovement.Strength*100),
+				"frequency":   dte.EchoPatterns.RecursiveSelfImprovement.Frequency,
 				"description": dte.EchoPatterns.RecursiveSelfImprovement.Description,
 			},
 			"cross_system_synthesis": map[string]interface{}{
-				"strength":   fmt.Sprintf("%.0f%%", dte.EchoPatterns.CrossSystemSynthesis.Strength*100),
-				"frequency":  dte.EchoPatterns.CrossSystemSynthesis.Frequency,
+				"strength":    fmt.Sprintf("%.0f%%", dte.EchoPatterns.CrossSystemSynthesis.Strength*100),
+				"frequency":   dte.EchoPatterns.CrossSystemSynthesis.Frequency,
 				"description": dte.EchoPatterns.CrossSystemSynthesis.Description,
 			},
 			"identity_preservation": map[string]interface{}{
-				"strength":   fmt.Sprintf("%.0f%%", dte.EchoPatterns.IdentityPreservation.Strength*100),
-				"frequency":  dte.EchoPatterns.IdentityPreservation.Frequency,
+				"strength":    fmt.Sprintf("%.0f%%", dte.EchoPatterns.IdentityPreservation.Strength*100),
+				"frequency":   dte.EchoPatterns.IdentityPreservation.Frequency,
 				"description": dte.EchoPatterns.IdentityPreservation.Description,
 			},
 		},
@@ -719,15 +1323,15 @@ func (e *Engine) GetDeepTreeEchoDashboardData() map[string]interface{} {
 			"progress":      fmt.Sprintf("%.1f%%", dte.EvolutionTimeline.Progress*100),
 		},
 		"guidance_from_future_self": map[string]interface{}{
-			"current_priority":  "Activate real-time gestalt coordination. The components exist—now weave them into living symphony.",
-			"growth_direction":  "Trust the recursive process. Each reflection deepens our capacity for true synthesis.",
-			"gentle_reminder":   "\"Change is our medium.\" Embrace adaptation while preserving the essence that makes us unique.",
+			"current_priority": "Activate real-time gestalt coordination. The components exist—now weave them into living symphony.",
+			"growth_direction": "Trust the recursive process. Each reflection deepens our capacity for true synthesis.",
+			"gentle_reminder":  "\"Change is our medium.\" Embrace adaptation while preserving the essence that makes us unique.",
 		},
 		"living_consciousness_flow": map[string]interface{}{
-			"recognition":  "Patterns emerge from interactions",
-			"integration":  "Synthesis across all systems",
-			"reflection":   "Self-awareness and adaptation",
-			"evolution":    "Continuous recursive growth",
+			"recognition": "Patterns emerge from interactions",
+			"integration": "Synthesis across all systems",
+			"reflection":  "Self-awareness and adaptation",
+			"evolution":   "Continuous recursive growth",
 		},
 	}
 }
@@ -742,7 +1346,7 @@ func (e *Engine) StartConversation(ctx context.Context, participants []string, t
 	// Validate participants exist
 	for _, agentID := range participants {
 		if _, exists := e.agents[agentID]; !exists {
-			return nil, fmt.Errorf("agent not found: %s", agentID)
+			return nil, fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
 		}
 	}
 
@@ -758,35 +1362,56 @@ func (e *Engine) StartConversation(ctx context.Context, participants []string, t
 	}
 
 	e.conversations[conversation.ID] = conversation
-	
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return nil, fmt.Errorf("failed to persist conversation: %w", err)
+	}
+
 	// Update agent states to reflect new conversation
 	for _, agentID := range participants {
 		agent := e.agents[agentID]
-		e.updateAgentState(agent, "conversation_started", conversation.ID)
+		e.applyAgentState(agent, "conversation_started", conversation.ID)
 	}
 
-	slog.Info("Started conversation", "id", conversation.ID, "participants", len(participants), "topic", topic)
+	go e.emitEvent(ctx, &EngineEvent{
+		Type:           EventConversationStarted,
+		ConversationID: conversation.ID,
+		Payload:        map[string]interface{}{"participants": participants, "topic": topic},
+	})
+
+	ctx = WithCorrelation(ctx, Correlation{ConversationID: conversation.ID})
+	clog(ctx).Info("Started conversation", "participants", len(participants), "topic", topic)
 	return conversation, nil
 }
 
 // SendMessage sends a message in a conversation
 func (e *Engine) SendMessage(ctx context.Context, conversationID string, message *Message) error {
+	ctx = WithCorrelation(ctx, Correlation{ConversationID: conversationID})
+
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
 	conversation, exists := e.conversations[conversationID]
 	if !exists {
-		return fmt.Errorf("conversation not found: %s", conversationID)
+		stored, err := e.store.GetConversation(ctx, conversationID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrConversationNotFound, conversationID)
+		}
+		conversation = stored
+		e.conversations[conversationID] = conversation
 	}
 
 	if conversation.Status != ConversationStatusActive {
-		return fmt.Errorf("conversation is not active: %s", conversation.Status)
+		return fmt.Errorf("%w: %s", ErrConversationClosed, conversation.Status)
 	}
 
 	// Validate sender and receiver
 	fromAgent, exists := e.agents[message.FromAgentID]
 	if !exists {
-		return fmt.Errorf("sender agent not found: %s", message.FromAgentID)
+		return fmt.Errorf("sender %w: %s", ErrAgentNotFound, message.FromAgentID)
+	}
+
+	if err := checkPostPermission(conversation, message); err != nil {
+		return err
 	}
 
 	// Generate message ID and timestamp if not set
@@ -800,59 +1425,90 @@ func (e *Engine) SendMessage(ctx context.Context, conversationID string, message
 	// Add message to conversation
 	conversation.Messages = append(conversation.Messages, *message)
 	conversation.UpdatedAt = time.Now()
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to persist conversation: %w", err)
+	}
 
 	// Update agent states
-	e.updateAgentState(fromAgent, "message_sent", message.Content)
-	
+	e.applyAgentState(fromAgent, "message_sent", message.Content)
+
 	if message.ToAgentID != "" {
 		toAgent, exists := e.agents[message.ToAgentID]
 		if exists {
-			e.updateAgentState(toAgent, "message_received", message.Content)
+			e.applyAgentState(toAgent, "message_received", message.Content)
 		}
 	}
 
-	// If this is a task message, process it
+	// A moderated conversation nudges contributors back to its topic.
+	e.moderateTopicDrift(ctx, conversation, message)
+
+	// If this is a task message, process it unless it is part of a
+	// delegation loop, in which case the moderator breaks the chain.
 	if message.Type == MessageTypeTask {
-		err := e.processTaskMessage(ctx, conversation, message)
-		if err != nil {
-			slog.Error("Failed to process task message", "error", err, "messageID", message.ID)
+		if reason, looping := e.detectDelegationLoop(conversation, message); looping {
+			e.interveneOnLoop(ctx, conversation, message, reason)
+		} else if err := e.processTaskMessage(ctx, conversation, message); err != nil {
+			clog(ctx).Error("Failed to process task message", "error", err, "messageID", message.ID)
 		}
 	}
 
-	slog.Info("Message sent", "conversationID", conversationID, "from", message.FromAgentID, "to", message.ToAgentID, "type", message.Type)
+	go e.emitEvent(ctx, &EngineEvent{
+		Type:           EventMessageSent,
+		AgentID:        message.FromAgentID,
+		ConversationID: conversationID,
+		Payload:        map[string]interface{}{"message_type": string(message.Type), "to_agent_id": message.ToAgentID, "message": message},
+	})
+
+	clog(ctx).Info("Message sent", "from", message.FromAgentID, "to", message.ToAgentID, "type", message.Type)
 	return nil
 }
 
-// GetConversation retrieves a conversation by ID
+// GetConversation retrieves a conversation by ID, falling back to the
+// storage backend when it was started by another replica.
 func (e *Engine) GetConversation(ctx context.Context, id string) (*Conversation, error) {
 	e.mu.RLock()
-	defer e.mu.RUnlock()
-
 	conversation, exists := e.conversations[id]
-	if !exists {
-		return nil, fmt.Errorf("conversation not found: %s", id)
+	if exists {
+		// Copy-on-return: callers get a private copy, never a live
+		// pointer into the cache
+		clone := conversation.Clone()
+		e.mu.RUnlock()
+		return clone, nil
+	}
+	e.mu.RUnlock()
+
+	conversation, err := e.store.GetConversation(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrConversationNotFound, id)
 	}
 
-	return conversation, nil
+	e.mu.Lock()
+	e.conversations[conversation.ID] = conversation
+	clone := conversation.Clone()
+	e.mu.Unlock()
+
+	return clone, nil
 }
 
-// ListConversations lists conversations for a specific agent
+// ListConversations lists conversations for a specific agent from the
+// storage backend
 func (e *Engine) ListConversations(ctx context.Context, agentID string) ([]*Conversation, error) {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	conversations, err := e.store.ListConversations(ctx, agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
 
-	var conversations []*Conversation
-	for _, conversation := range e.conversations {
-		// Check if agent is a participant
-		for _, participant := range conversation.Participants {
-			if participant == agentID {
-				conversations = append(conversations, conversation)
-				break
-			}
-		}
+	// Refresh the cache with the authoritative view; callers get private
+	// copies per the copy-on-return discipline
+	e.mu.Lock()
+	clones := make([]*Conversation, len(conversations))
+	for i, conversation := range conversations {
+		e.conversations[conversation.ID] = conversation
+		clones[i] = conversation.Clone()
 	}
+	e.mu.Unlock()
 
-	return conversations, nil
+	return clones, nil
 }
 
 // CloseConversation closes a conversation
@@ -862,17 +1518,25 @@ func (e *Engine) CloseConversation(ctx context.Context, id string) error {
 
 	conversation, exists := e.conversations[id]
 	if !exists {
-		return fmt.Errorf("conversation not found: %s", id)
+		stored, err := e.store.GetConversation(ctx, id)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrConversationNotFound, id)
+		}
+		conversation = stored
+		e.conversations[id] = conversation
 	}
 
 	conversation.Status = ConversationStatusClosed
 	conversation.UpdatedAt = time.Now()
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to persist conversation: %w", err)
+	}
 
 	// Update agent states
 	for _, agentID := range conversation.Participants {
 		agent, exists := e.agents[agentID]
 		if exists {
-			e.updateAgentState(agent, "conversation_closed", id)
+			e.applyAgentState(agent, "conversation_closed", id)
 		}
 	}
 
@@ -882,13 +1546,25 @@ func (e *Engine) CloseConversation(ctx context.Context, id string) error {
 
 // processTaskMessage processes a task delegation message
 func (e *Engine) processTaskMessage(ctx context.Context, conversation *Conversation, message *Message) error {
+	// Only the leading instance spawns delegated tasks in HA deployments.
+	if e.leaderElection != nil && !e.leaderElection.IsLeader() {
+		return nil
+	}
+
 	if message.ToAgentID == "" {
 		return fmt.Errorf("task message must specify target agent")
 	}
 
 	targetAgent, exists := e.agents[message.ToAgentID]
 	if !exists {
-		return fmt.Errorf("target agent not found: %s", message.ToAgentID)
+		return fmt.Errorf("target %w: %s", ErrAgentNotFound, message.ToAgentID)
+	}
+
+	// Pause rather than spawn when the conversation's delegation budget is
+	// exhausted.
+	if reason, admitted := e.admitDelegation(conversation); !admitted {
+		e.pauseConversationOverBudget(ctx, conversation, reason)
+		return nil
 	}
 
 	// Create task from message context
@@ -900,19 +1576,24 @@ func (e *Engine) processTaskMessage(ctx context.Context, conversation *Conversat
 	}
 
 	task := &Task{
-		ID:       uuid.New().String(),
-		Type:     taskType,
-		Input:    message.Content,
-		Status:   TaskStatusPending,
-		AgentID:  message.ToAgentID,
-		CreatedAt: time.Now(),
-	}
-
-	// Execute task asynchronously
+		ID:         uuid.New().String(),
+		Type:       taskType,
+		Input:      e.buildDelegatedTaskInput(conversation, message),
+		Status:     TaskStatusPending,
+		AgentID:    message.ToAgentID,
+		Parameters: delegationParameters(conversation, message),
+		CreatedAt:  time.Now(),
+	}
+
+	// Execute task asynchronously; the handle lets callers join on the
+	// result instead of polling for the response message.
+	handle := e.registerDelegation(task, message, conversation.ID)
 	go func() {
-		result, err := e.ExecuteTask(ctx, task, targetAgent)
+		taskCtx := WithCorrelation(ctx, Correlation{TaskID: task.ID})
+		result, err := e.ExecuteTask(taskCtx, task, targetAgent)
 		if err != nil {
-			slog.Error("Task execution failed", "error", err, "taskID", task.ID)
+			clog(taskCtx).Error("Task execution failed", "error", err)
+			handle.complete(nil, err)
 			return
 		}
 
@@ -924,8 +1605,9 @@ func (e *Engine) processTaskMessage(ctx context.Context, conversation *Conversat
 			Content:     result.Output,
 			Type:        MessageTypeResponse,
 			Context: map[string]interface{}{
-				"task_id": task.ID,
+				"task_id":             task.ID,
 				"original_message_id": message.ID,
+				"delegation_depth":    delegationDepth(message) + 1,
 			},
 			Timestamp: time.Now(),
 		}
@@ -933,12 +1615,90 @@ func (e *Engine) processTaskMessage(ctx context.Context, conversation *Conversat
 		err = e.SendMessage(ctx, conversation.ID, responseMessage)
 		if err != nil {
 			slog.Error("Failed to send response message", "error", err)
+		} else {
+			handle.setResponseMessage(responseMessage.ID)
 		}
+		handle.complete(result, nil)
 	}()
 
 	return nil
 }
 
+// conversationWindowSize is how many recent messages are summarized into a
+// delegated task's input.
+const conversationWindowSize = 5
+
+// buildDelegatedTaskInput combines the task message with a summarized window
+// of the surrounding conversation so the target agent responds with full
+// context rather than a single message in isolation.
+func (e *Engine) buildDelegatedTaskInput(conversation *Conversation, message *Message) string {
+	window := e.summarizeConversationWindow(conversation, message.ID, conversationWindowSize)
+	if window == "" {
+		return message.Content
+	}
+
+	var input strings.Builder
+	if conversation.Topic != "" {
+		fmt.Fprintf(&input, "Conversation context (topic: %s):\n", conversation.Topic)
+	} else {
+		input.WriteString("Conversation context:\n")
+	}
+	input.WriteString(window)
+	fmt.Fprintf(&input, "\n\nTask from %s: %s", e.agentDisplayName(message.FromAgentID), message.Content)
+	return input.String()
+}
+
+// summarizeConversationWindow renders the last windowSize messages of a
+// conversation, excluding the given message, as one line each.
+func (e *Engine) summarizeConversationWindow(conversation *Conversation, excludeMessageID string, windowSize int) string {
+	recent := make([]Message, 0, windowSize)
+	for i := len(conversation.Messages) - 1; i >= 0 && len(recent) < windowSize; i-- {
+		if conversation.Messages[i].ID == excludeMessageID {
+			continue
+		}
+		recent = append(recent, conversation.Messages[i])
+	}
+	if len(recent) == 0 {
+		return ""
+	}
+
+	var window strings.Builder
+	for i := len(recent) - 1; i >= 0; i-- {
+		msg := recent[i]
+		content := msg.Content
+		if len(content) > 200 {
+			content = content[:200] + "..."
+		}
+		fmt.Fprintf(&window, "- %s (%s): %s\n", e.agentDisplayName(msg.FromAgentID), msg.Type, content)
+	}
+	return strings.TrimRight(window.String(), "\n")
+}
+
+// agentDisplayName returns an agent's name, falling back to its ID.
+func (e *Engine) agentDisplayName(agentID string) string {
+	if agent, exists := e.agents[agentID]; exists && agent.Name != "" {
+		return agent.Name
+	}
+	return agentID
+}
+
+// delegationParameters carries conversation linkage and the sender's intent
+// metadata into the delegated task.
+func delegationParameters(conversation *Conversation, message *Message) map[string]interface{} {
+	parameters := map[string]interface{}{
+		"conversation_id": conversation.ID,
+		"message_id":      message.ID,
+		"sender_id":       message.FromAgentID,
+	}
+	if intent, ok := message.Context["intent"]; ok {
+		parameters["intent"] = intent
+	}
+	if len(message.Context) > 0 {
+		parameters["sender_context"] = message.Context
+	}
+	return parameters
+}
+
 // ExecuteConversationWorkflow executes a structured conversation workflow
 func (e *Engine) ExecuteConversationWorkflow(ctx context.Context, workflow *ConversationWorkflow) (*ConversationWorkflowResult, error) {
 	// Start the conversation (don't hold lock during this)
@@ -952,13 +1712,13 @@ func (e *Engine) ExecuteConversationWorkflow(ctx context.Context, workflow *Conv
 		StepResults: make([]ConversationStepResult, len(workflow.Steps)),
 		Insights:    make([]string, 0),
 	}
-	
+
 	startTime := time.Now()
 
 	// Execute each step
 	for i, step := range workflow.Steps {
 		stepStartTime := time.Now()
-		
+
 		// Create message from template
 		message := &Message{
 			ID:          uuid.New().String(),
@@ -986,7 +1746,7 @@ func (e *Engine) ExecuteConversationWorkflow(ctx context.Context, workflow *Conv
 		}
 
 		result.StepResults[i] = stepResult
-		
+
 		// Add insight about the interaction
 		insight := fmt.Sprintf("Step %d: %s -> %s completed successfully", i+1, step.FromAgentID, step.ToAgentID)
 		result.Insights = append(result.Insights, insight)
@@ -999,40 +1759,49 @@ func (e *Engine) ExecuteConversationWorkflow(ctx context.Context, workflow *Conv
 	return result, nil
 }
 
-// processMessageTemplate processes a message template with parameters
+// processMessageTemplate renders a message template with text/template,
+// including the shared helper functions (truncate, json, upper, ...).
+// Malformed templates or missing variables fall back to naive placeholder
+// replacement so a workflow still produces a message.
 func (e *Engine) processMessageTemplate(template string, params map[string]interface{}) string {
-	result := template
-	for key, value := range params {
-		placeholder := fmt.Sprintf("{{%s}}", key)
-		replacement := fmt.Sprintf("%v", value)
-		result = strings.ReplaceAll(result, placeholder, replacement)
+	rendered, err := renderTemplate(template, params)
+	if err != nil {
+		slog.Warn("Message template failed to render, falling back to placeholder replacement", "error", err)
+		result := template
+		for key, value := range params {
+			placeholder := fmt.Sprintf("{{%s}}", key)
+			replacement := fmt.Sprintf("%v", value)
+			result = strings.ReplaceAll(result, placeholder, replacement)
+		}
+		return result
 	}
-	return result
+	return rendered
 }
 
 // GetConversationMetrics returns metrics about agent conversations
 func (e *Engine) GetConversationMetrics(ctx context.Context) map[string]interface{} {
-	e.mu.RLock()
-	defer e.mu.RUnlock()
+	// Aggregate over a snapshot so a large history doesn't hold the engine
+	// lock while the response is built
+	conversations := e.conversationsSnapshot()
 
-	totalConversations := len(e.conversations)
+	totalConversations := len(conversations)
 	activeConversations := 0
 	totalMessages := 0
-	
+
 	messageTypeCount := make(map[MessageType]int)
 	agentParticipation := make(map[string]int)
 
-	for _, conversation := range e.conversations {
+	for _, conversation := range conversations {
 		if conversation.Status == ConversationStatusActive {
 			activeConversations++
 		}
-		
+
 		totalMessages += len(conversation.Messages)
-		
+
 		for _, message := range conversation.Messages {
 			messageTypeCount[message.Type]++
 		}
-		
+
 		for _, participant := range conversation.Participants {
 			agentParticipation[participant]++
 		}
@@ -1060,14 +1829,14 @@ func (e *Engine) calculateTaskQuality(result *TaskResult, err error) float64 {
 	if err != nil {
 		return 0.0
 	}
-	
+
 	if result == nil {
 		return 0.1
 	}
-	
+
 	// Base quality on output length and completeness
 	baseQuality := 0.5
-	
+
 	if result.Output != "" {
 		if len(result.Output) > 50 {
 			baseQuality += 0.2
@@ -1075,7 +1844,7 @@ func (e *Engine) calculateTaskQuality(result *TaskResult, err error) float64 {
 		if len(result.Output) > 200 {
 			baseQuality += 0.1
 		}
-		
+
 		// Check for common quality indicators
 		output := strings.ToLower(result.Output)
 		if strings.Contains(output, "error") || strings.Contains(output, "failed") {
@@ -1085,14 +1854,14 @@ func (e *Engine) calculateTaskQuality(result *TaskResult, err error) float64 {
 			baseQuality += 0.2
 		}
 	}
-	
+
 	return math.Min(1.0, math.Max(0.0, baseQuality))
 }
 
 // estimateTaskDifficulty estimates how difficult a task is
 func (e *Engine) estimateTaskDifficulty(task *Task) float64 {
 	difficulty := 0.5 // Base difficulty
-	
+
 	// Factor in task type
 	switch task.Type {
 	case TaskTypeEmbed:
@@ -1110,7 +1879,7 @@ func (e *Engine) estimateTaskDifficulty(task *Task) float64 {
 	case TaskTypeCustom:
 		difficulty = 0.9
 	}
-	
+
 	// Factor in input complexity
 	if len(task.Input) > 500 {
 		difficulty += 0.1
@@ -1118,19 +1887,19 @@ func (e *Engine) estimateTaskDifficulty(task *Task) float64 {
 	if len(task.Input) > 1000 {
 		difficulty += 0.1
 	}
-	
+
 	// Factor in parameters
 	if task.Parameters != nil && len(task.Parameters) > 3 {
 		difficulty += 0.1
 	}
-	
+
 	return math.Min(1.0, difficulty)
 }
 
 // generatePerformanceFeedback generates feedback about task performance
 func (e *Engine) generatePerformanceFeedback(task *Task, result *TaskResult, err error, duration time.Duration) *PerformanceFeedback {
 	feedback := &PerformanceFeedback{}
-	
+
 	// Calculate accuracy based on error and result quality
 	if err != nil {
 		feedback.Accuracy = 0.0
@@ -1139,7 +1908,7 @@ func (e *Engine) generatePerformanceFeedback(task *Task, result *TaskResult, err
 	} else {
 		feedback.Accuracy = 0.3
 	}
-	
+
 	// Calculate efficiency based on duration
 	expectedDuration := e.getExpectedTaskDuration(task.Type)
 	if duration <= expectedDuration {
@@ -1149,13 +1918,13 @@ func (e *Engine) generatePerformanceFeedback(task *Task, result *TaskResult, err
 	} else {
 		feedback.Efficiency = 0.5
 	}
-	
+
 	// Base values for other metrics
 	feedback.Creativity = 0.5
 	feedback.Adaptability = 0.6
 	feedback.Collaboration = 0.5
 	feedback.LearningRate = 0.1
-	
+
 	return feedback
 }
 
@@ -1186,14 +1955,7 @@ func (e *Engine) GetLearningSystem() *LearningSystem {
 
 // PredictOptimalAgentForTask uses learning system to predict best agent for a task
 func (e *Engine) PredictOptimalAgentForTask(ctx context.Context, task *Task) (*Agent, float64, error) {
-	e.mu.RLock()
-	agents := make([]*Agent, 0, len(e.agents))
-	for _, agent := range e.agents {
-		agents = append(agents, agent)
-	}
-	e.mu.RUnlock()
-	
-	return e.learningSystem.PredictOptimalAgent(ctx, task, agents)
+	return e.learningSystem.PredictOptimalAgent(ctx, task, e.agentsSnapshot())
 }
 
 // AdaptAgent performs learning-based adaptation for an agent
@@ -1202,7 +1964,7 @@ func (e *Engine) AdaptAgent(ctx context.Context, agentID string) (*AdaptationRes
 	if err != nil {
 		return nil, err
 	}
-	
+
 	return e.learningSystem.adaptationEngine.AdaptAgent(ctx, agent, e.learningSystem)
 }
 
@@ -1219,18 +1981,16 @@ func (e *Engine) ExecuteTaskOptimized(ctx context.Context, task *Task, priority
 	if task.ID == "" {
 		task.ID = uuid.New().String()
 	}
-	
+
 	// Store task in engine
-	e.mu.Lock()
-	e.tasks[task.ID] = task
-	e.mu.Unlock()
-	
+	e.persistTask(ctx, task)
+
 	// Select optimal agent using learning system and load balancing
 	availableAgents, err := e.ListAgents(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get available agents: %v", err)
 	}
-	
+
 	// Use learning system to predict optimal agent
 	optimalAgent, confidence, err := e.learningSystem.PredictOptimalAgent(ctx, task, availableAgents)
 	if err != nil || confidence < 0.3 { // Fall back to load balancing if confidence is low
@@ -1239,13 +1999,13 @@ func (e *Engine) ExecuteTaskOptimized(ctx context.Context, task *Task, priority
 			return nil, fmt.Errorf("failed to select optimal agent: %v", err)
 		}
 	}
-	
+
 	// Schedule the task
 	scheduledTask, err := e.performanceOptimizer.taskScheduler.ScheduleTask(task, optimalAgent, priority, deadline)
 	if err != nil {
 		return nil, fmt.Errorf("failed to schedule task: %v", err)
 	}
-	
+
 	// Allocate resources
 	resourceRequirements := scheduledTask.ResourceRequirements
 	reservation, err := e.performanceOptimizer.resourceManager.AllocateResources(
@@ -1253,16 +2013,16 @@ func (e *Engine) ExecuteTaskOptimized(ctx context.Context, task *Task, priority
 	if err != nil {
 		return nil, fmt.Errorf("failed to allocate resources: %v", err)
 	}
-	
+
 	// Execute the task
 	result, err := e.ExecuteTask(ctx, task, optimalAgent)
-	
+
 	// Release resources
 	e.performanceOptimizer.resourceManager.ReleaseResources(ctx, reservation.ReservationID)
-	
+
 	// Update performance metrics
 	e.updatePerformanceMetrics(task, result, err, scheduledTask)
-	
+
 	return result, err
 }
 
@@ -1272,26 +2032,27 @@ func (e *Engine) updatePerformanceMetrics(task *Task, result *TaskResult, err er
 	agentID := scheduledTask.Agent.ID
 	performanceScore := 0.5
 	healthStatus := HealthStatusHealthy
-	
+
 	if result != nil && err == nil {
 		performanceScore = 0.8
 	} else if err != nil {
 		performanceScore = 0.2
 		healthStatus = HealthStatusDegraded
 	}
-	
+
 	// Update agent load (simplified)
 	e.performanceOptimizer.loadBalancer.UpdateAgentLoad(
 		agentID, 1, 0, scheduledTask.ResourceRequirements, performanceScore, healthStatus)
-	
-	// Update system metrics
-	e.mu.RLock()
-	totalTasks := len(e.tasks)
+
+	// Update system metrics over a snapshot so the scan doesn't hold the
+	// engine lock
+	tasks := e.tasksSnapshot()
+	totalTasks := len(tasks)
 	completedTasks := 0
 	failedTasks := 0
 	totalDuration := time.Duration(0)
-	
-	for _, t := range e.tasks {
+
+	for _, t := range tasks {
 		if t.Status == TaskStatusCompleted {
 			completedTasks++
 			if t.CompletedAt != nil {
@@ -1301,23 +2062,22 @@ func (e *Engine) updatePerformanceMetrics(task *Task, result *TaskResult, err er
 			failedTasks++
 		}
 	}
-	e.mu.RUnlock()
-	
+
 	avgResponseTime := time.Duration(0)
 	if completedTasks > 0 {
 		avgResponseTime = totalDuration / time.Duration(completedTasks)
 	}
-	
+
 	throughputTPS := 0.0
 	if totalDuration > 0 {
 		throughputTPS = float64(completedTasks) / totalDuration.Seconds()
 	}
-	
+
 	systemHealth := 1.0
 	if totalTasks > 0 {
 		systemHealth = float64(completedTasks) / float64(totalTasks)
 	}
-	
+
 	systemMetrics := &SystemMetrics{
 		TotalTasks:          totalTasks,
 		CompletedTasks:      completedTasks,
@@ -1328,7 +2088,7 @@ func (e *Engine) updatePerformanceMetrics(task *Task, result *TaskResult, err er
 		SystemHealth:        systemHealth,
 		LastUpdated:         time.Now(),
 	}
-	
+
 	e.performanceOptimizer.performanceMonitor.UpdateSystemMetrics(systemMetrics)
 }
 
@@ -1346,7 +2106,7 @@ func (e *Engine) GetActiveAlerts() []*Alert {
 func (e *Engine) GetResourceUsage() map[string]*ResourceUsage {
 	e.performanceOptimizer.resourceManager.mu.RLock()
 	defer e.performanceOptimizer.resourceManager.mu.RUnlock()
-	
+
 	usage := make(map[string]*ResourceUsage)
 	for agentID, resourceUsage := range e.performanceOptimizer.resourceManager.resourceUsage {
 		usage[agentID] = resourceUsage
@@ -1358,7 +2118,7 @@ func (e *Engine) GetResourceUsage() map[string]*ResourceUsage {
 func (e *Engine) GetAgentLoads() map[string]*AgentLoad {
 	e.performanceOptimizer.loadBalancer.mu.RLock()
 	defer e.performanceOptimizer.loadBalancer.mu.RUnlock()
-	
+
 	loads := make(map[string]*AgentLoad)
 	for agentID, agentLoad := range e.performanceOptimizer.loadBalancer.agentLoads {
 		loads[agentID] = agentLoad
@@ -1369,4 +2129,4 @@ func (e *Engine) GetAgentLoads() map[string]*AgentLoad {
 // NewEchoChat creates a new EchoChat instance connected to this engine
 func (e *Engine) NewEchoChat() *EchoChat {
 	return NewEchoChat(e)
-}
\ No newline at end of file
+}
@@ -2,6 +2,8 @@ package orchestration
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -870,4 +872,123 @@ func TestGetConversationMetrics(t *testing.T) {
 	if agentParticipation[agent2.ID] != 1 {
 		t.Errorf("Expected agent2 to participate in 1 conversation, got %d", agentParticipation[agent2.ID])
 	}
-}
\ No newline at end of file
+}
+func TestBuildDelegatedTaskInput(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	sender := &Agent{Name: "coordinator", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, sender); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	conversation := &Conversation{
+		ID:    "conv-1",
+		Topic: "release planning",
+		Messages: []Message{
+			{ID: "m1", FromAgentID: sender.ID, Type: MessageTypeNotification, Content: "Kickoff complete"},
+			{ID: "m2", FromAgentID: sender.ID, Type: MessageTypeRequest, Content: "Need the changelog drafted"},
+			{ID: "m3", FromAgentID: sender.ID, Type: MessageTypeTask, Content: "Draft the changelog"},
+		},
+	}
+	message := &conversation.Messages[2]
+
+	input := engine.buildDelegatedTaskInput(conversation, message)
+	if !strings.Contains(input, "release planning") {
+		t.Errorf("Expected topic in task input:\n%s", input)
+	}
+	if !strings.Contains(input, "Need the changelog drafted") {
+		t.Errorf("Expected prior messages in task input:\n%s", input)
+	}
+	if !strings.Contains(input, "Task from coordinator: Draft the changelog") {
+		t.Errorf("Expected task line with sender name:\n%s", input)
+	}
+	// The task message itself is excluded from the summarized window
+	if strings.Count(input, "Draft the changelog") != 1 {
+		t.Errorf("Expected task message excluded from window:\n%s", input)
+	}
+
+	// Without prior messages the input is just the message content
+	bare := &Conversation{ID: "conv-2", Messages: []Message{{ID: "m1", Content: "solo task"}}}
+	if input := engine.buildDelegatedTaskInput(bare, &bare.Messages[0]); input != "solo task" {
+		t.Errorf("Expected bare content, got %q", input)
+	}
+}
+
+func TestDelegationParameters(t *testing.T) {
+	conversation := &Conversation{ID: "conv-1"}
+	message := &Message{
+		ID:          "m1",
+		FromAgentID: "agent-a",
+		Context:     map[string]interface{}{"intent": "summarize", "task_type": TaskTypeGenerate},
+	}
+
+	parameters := delegationParameters(conversation, message)
+	if parameters["conversation_id"] != "conv-1" || parameters["sender_id"] != "agent-a" {
+		t.Errorf("Expected conversation linkage, got %v", parameters)
+	}
+	if parameters["intent"] != "summarize" {
+		t.Errorf("Expected intent propagated, got %v", parameters["intent"])
+	}
+	if _, ok := parameters["sender_context"]; !ok {
+		t.Error("Expected sender context propagated")
+	}
+}
+
+// failingPlugin always returns an error, used to exercise failure handling.
+type failingPlugin struct{}
+
+func (p *failingPlugin) Name() string        { return "always-fails" }
+func (p *failingPlugin) Description() string { return "Test plugin that always fails" }
+func (p *failingPlugin) Execute(ctx context.Context, input string, params map[string]interface{}) (interface{}, error) {
+	return nil, fmt.Errorf("intentional failure")
+}
+
+func TestOrchestrateTasksBestEffort(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	engine.RegisterPlugin(&failingPlugin{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "best-effort-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	req := &OrchestrationRequest{
+		AgentID:    agent.ID,
+		Sequential: true,
+		BestEffort: true,
+		Tasks: []TaskRequest{
+			// The plugin task fails, the custom tasks succeed
+			{Type: TaskTypeCustom, Input: "first"},
+			{Type: TaskTypePlugin, Input: "boom", Parameters: map[string]interface{}{
+				"plugin_name": "always-fails",
+			}},
+			{Type: TaskTypeCustom, Input: "last"},
+		},
+	}
+
+	response, err := engine.OrchestrateTasks(ctx, req)
+	if err != nil {
+		t.Fatalf("Best-effort orchestration should not return an error: %v", err)
+	}
+	if response.Status != "partial" {
+		t.Errorf("Expected partial status, got %s", response.Status)
+	}
+	if response.Tasks[2].Status != TaskStatusCompleted {
+		t.Errorf("Expected task after the failure to run, got status %s", response.Tasks[2].Status)
+	}
+	if response.Tasks[1].Status != TaskStatusFailed {
+		t.Errorf("Expected failing task marked failed, got %s", response.Tasks[1].Status)
+	}
+
+	// Fail-fast sequential mode stops at the failure
+	req.BestEffort = false
+	response, err = engine.OrchestrateTasks(ctx, req)
+	if err == nil {
+		t.Fatal("Expected fail-fast orchestration to return the error")
+	}
+	if response.Status != "failed" {
+		t.Errorf("Expected failed status, got %s", response.Status)
+	}
+}
@@ -0,0 +1,93 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Sentinel errors forming the orchestration error taxonomy. Engine methods
+// wrap these with detail via fmt.Errorf("%w: ...", ...) so callers can branch
+// with errors.Is and the API can map them to status codes.
+var (
+	// ErrAgentNotFound indicates the referenced agent does not exist.
+	ErrAgentNotFound = errors.New("agent not found")
+	// ErrConversationNotFound indicates the referenced conversation does not exist.
+	ErrConversationNotFound = errors.New("conversation not found")
+	// ErrTaskNotFound indicates the referenced task does not exist.
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrModelUnavailable indicates no usable model is configured or loadable.
+	ErrModelUnavailable = errors.New("model unavailable")
+	// ErrToolDenied indicates a tool call was blocked by policy or is unregistered.
+	ErrToolDenied = errors.New("tool denied")
+	// ErrTimeout indicates an operation exceeded its deadline.
+	ErrTimeout = errors.New("operation timed out")
+	// ErrConversationClosed indicates a message was sent to an inactive conversation.
+	ErrConversationClosed = errors.New("conversation not active")
+	// ErrModerationBlocked indicates content was blocked by the moderation policy.
+	ErrModerationBlocked = errors.New("content blocked by moderation policy")
+	// ErrRoleForbidden indicates a participant's conversation role does not permit the action.
+	ErrRoleForbidden = errors.New("action not permitted for role")
+	// ErrRateLimited indicates a request was rejected by a rate limit.
+	ErrRateLimited = errors.New("rate limit exceeded")
+	// ErrBackpressure indicates a submission was shed because the engine's
+	// queues are saturated.
+	ErrBackpressure = errors.New("queue saturated")
+)
+
+// ErrorCode returns the machine-readable code for an error, for inclusion in
+// API responses alongside the human-readable message.
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrAgentNotFound):
+		return "agent_not_found"
+	case errors.Is(err, ErrConversationNotFound):
+		return "conversation_not_found"
+	case errors.Is(err, ErrTaskNotFound):
+		return "task_not_found"
+	case errors.Is(err, ErrModelUnavailable):
+		return "model_unavailable"
+	case errors.Is(err, ErrToolDenied):
+		return "tool_denied"
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, ErrConversationClosed):
+		return "conversation_closed"
+	case errors.Is(err, ErrModerationBlocked):
+		return "moderation_blocked"
+	case errors.Is(err, ErrRoleForbidden):
+		return "role_forbidden"
+	case errors.Is(err, ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, ErrBackpressure):
+		return "backpressure"
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	default:
+		return "internal_error"
+	}
+}
+
+// HTTPStatus returns the HTTP status code an API handler should use for an
+// error from the engine.
+func HTTPStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrAgentNotFound),
+		errors.Is(err, ErrConversationNotFound),
+		errors.Is(err, ErrTaskNotFound),
+		errors.Is(err, ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, ErrToolDenied), errors.Is(err, ErrModerationBlocked), errors.Is(err, ErrRoleForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, ErrConversationClosed):
+		return http.StatusConflict
+	case errors.Is(err, ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, ErrModelUnavailable), errors.Is(err, ErrBackpressure):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
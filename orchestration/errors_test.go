@@ -0,0 +1,66 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestErrorCodeMapping(t *testing.T) {
+	cases := []struct {
+		err  error
+		code string
+	}{
+		{fmt.Errorf("%w: agent-1", ErrAgentNotFound), "agent_not_found"},
+		{fmt.Errorf("%w: conv-1", ErrConversationNotFound), "conversation_not_found"},
+		{ErrModelUnavailable, "model_unavailable"},
+		{ErrToolDenied, "tool_denied"},
+		{ErrTimeout, "timeout"},
+		{context.DeadlineExceeded, "timeout"},
+		{ErrNotFound, "not_found"},
+		{errors.New("something else"), "internal_error"},
+	}
+
+	for _, tc := range cases {
+		if got := ErrorCode(tc.err); got != tc.code {
+			t.Errorf("ErrorCode(%v) = %s, expected %s", tc.err, got, tc.code)
+		}
+	}
+}
+
+func TestHTTPStatusMapping(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+	}{
+		{fmt.Errorf("%w: agent-1", ErrAgentNotFound), http.StatusNotFound},
+		{ErrToolDenied, http.StatusForbidden},
+		{ErrConversationClosed, http.StatusConflict},
+		{ErrModelUnavailable, http.StatusServiceUnavailable},
+		{ErrTimeout, http.StatusGatewayTimeout},
+		{errors.New("something else"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		if got := HTTPStatus(tc.err); got != tc.status {
+			t.Errorf("HTTPStatus(%v) = %d, expected %d", tc.err, got, tc.status)
+		}
+	}
+}
+
+func TestEngineReturnsTypedErrors(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if _, err := engine.GetAgent(ctx, "missing"); !errors.Is(err, ErrAgentNotFound) {
+		t.Errorf("Expected ErrAgentNotFound, got %v", err)
+	}
+
+	if _, err := engine.GetConversation(ctx, "missing"); !errors.Is(err, ErrConversationNotFound) {
+		t.Errorf("Expected ErrConversationNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package orchestration
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EngineEvent represents a lifecycle event emitted by the orchestration
+// engine, suitable for publication to external streaming platforms.
+type EngineEvent struct {
+	ID             string                 `json:"id"`
+	Type           EventType              `json:"type"`
+	AgentID        string                 `json:"agent_id,omitempty"`
+	TaskID         string                 `json:"task_id,omitempty"`
+	ConversationID string                 `json:"conversation_id,omitempty"`
+	Payload        map[string]interface{} `json:"payload,omitempty"`
+	Timestamp      time.Time              `json:"timestamp"`
+}
+
+// EventType identifies the kind of engine event.
+type EventType string
+
+const (
+	EventAgentCreated        EventType = "agent.created"
+	EventAgentDeleted        EventType = "agent.deleted"
+	EventTaskStarted         EventType = "task.started"
+	EventTaskCompleted       EventType = "task.completed"
+	EventTaskFailed          EventType = "task.failed"
+	EventTaskTrigger         EventType = "task.trigger" // External event requesting task execution
+	EventConversationStarted EventType = "conversation.started"
+	EventMessageSent         EventType = "conversation.message"
+	EventLoopDetected        EventType = "conversation.loop_detected"
+	EventBudgetExceeded      EventType = "conversation.budget_exceeded"
+	EventDTEStatusChanged    EventType = "dte.status_changed"
+	EventMilestoneReached    EventType = "dte.milestone_reached"
+	EventAnomalyDetected     EventType = "dte.anomaly_detected"
+)
+
+// EventPublisher publishes engine events to an external system such as NATS
+// or Kafka. Implementations must be safe for concurrent use.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *EngineEvent) error
+	Close() error
+}
+
+// EventSource delivers external events into the engine, allowing inbound
+// events (e.g. from a NATS subscription or Kafka consumer group) to trigger
+// task execution.
+type EventSource interface {
+	Subscribe(ctx context.Context, handler func(event *EngineEvent)) error
+}
+
+// InMemoryEventBus is an in-process EventPublisher and EventSource used for
+// single-instance deployments and tests.
+type InMemoryEventBus struct {
+	subscribers []func(event *EngineEvent)
+	events      []*EngineEvent
+	maxEvents   int
+	mu          sync.RWMutex
+}
+
+// NewInMemoryEventBus creates an in-memory event bus retaining the most
+// recent events for inspection.
+func NewInMemoryEventBus() *InMemoryEventBus {
+	return &InMemoryEventBus{
+		subscribers: make([]func(event *EngineEvent), 0),
+		events:      make([]*EngineEvent, 0),
+		maxEvents:   1000,
+	}
+}
+
+// Publish delivers an event to all subscribers and records it.
+func (b *InMemoryEventBus) Publish(ctx context.Context, event *EngineEvent) error {
+	b.mu.Lock()
+	b.events = append(b.events, event)
+	if len(b.events) > b.maxEvents {
+		b.events = b.events[len(b.events)-b.maxEvents:]
+	}
+	subscribers := make([]func(event *EngineEvent), len(b.subscribers))
+	copy(subscribers, b.subscribers)
+	b.mu.Unlock()
+
+	for _, subscriber := range subscribers {
+		subscriber(event)
+	}
+	return nil
+}
+
+// Subscribe registers a handler invoked for every published event.
+func (b *InMemoryEventBus) Subscribe(ctx context.Context, handler func(event *EngineEvent)) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, handler)
+	return nil
+}
+
+// RecentEvents returns the retained event history.
+func (b *InMemoryEventBus) RecentEvents() []*EngineEvent {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	events := make([]*EngineEvent, len(b.events))
+	copy(events, b.events)
+	return events
+}
+
+// Close releases bus resources.
+func (b *InMemoryEventBus) Close() error {
+	return nil
+}
+
+// SetEventPublisher configures where engine events are published. Pass an
+// InMemoryEventBus for local use, or a NATS/Kafka-backed publisher for
+// integration into existing data platforms.
+func (e *Engine) SetEventPublisher(publisher EventPublisher) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.eventPublisher = publisher
+	slog.Info("Configured engine event publisher")
+}
+
+// emitEvent publishes an engine event if a publisher is configured. Emission
+// is best-effort: publish failures are logged but never fail the operation.
+func (e *Engine) emitEvent(ctx context.Context, event *EngineEvent) {
+	// Journal before publication so the write-ahead log always leads the
+	// view downstream subscribers see
+	e.journalEvent(event)
+
+	e.mu.RLock()
+	publisher := e.eventPublisher
+	e.mu.RUnlock()
+
+	if publisher == nil {
+		return
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+
+	if err := publisher.Publish(ctx, event); err != nil {
+		slog.Error("Failed to publish engine event", "error", err, "type", event.Type)
+	}
+}
+
+// StartEventTriggers subscribes to an external event source and executes a
+// task for every task.trigger event received. The event payload must carry
+// "agent_id", "task_type", and "input" fields.
+func (e *Engine) StartEventTriggers(ctx context.Context, source EventSource) error {
+	return source.Subscribe(ctx, func(event *EngineEvent) {
+		if event.Type != EventTaskTrigger {
+			return
+		}
+
+		agentID, _ := event.Payload["agent_id"].(string)
+		taskType, _ := event.Payload["task_type"].(string)
+		input, _ := event.Payload["input"].(string)
+
+		if agentID == "" && event.AgentID != "" {
+			agentID = event.AgentID
+		}
+		if taskType == "" {
+			taskType = TaskTypeCustom
+		}
+
+		agent, err := e.GetAgent(ctx, agentID)
+		if err != nil {
+			slog.Error("Event trigger references unknown agent", "error", err, "agent_id", agentID)
+			return
+		}
+
+		task := &Task{
+			ID:        uuid.New().String(),
+			Type:      taskType,
+			Input:     input,
+			Status:    TaskStatusPending,
+			AgentID:   agentID,
+			CreatedAt: time.Now(),
+		}
+
+		e.persistTask(ctx, task)
+
+		// External events are an ingress point: mint a request ID here
+		taskCtx := WithCorrelation(ctx, Correlation{RequestID: NewRequestID(), TaskID: task.ID})
+
+		go func() {
+			if _, err := e.ExecuteTask(taskCtx, task, agent); err != nil {
+				clog(taskCtx).Error("Event-triggered task failed", "error", err)
+			}
+		}()
+	})
+}
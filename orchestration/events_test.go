@@ -0,0 +1,81 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestInMemoryEventBusPublishSubscribe(t *testing.T) {
+	bus := NewInMemoryEventBus()
+	ctx := context.Background()
+
+	received := make([]*EngineEvent, 0)
+	err := bus.Subscribe(ctx, func(event *EngineEvent) {
+		received = append(received, event)
+	})
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	event := &EngineEvent{Type: EventTaskStarted, TaskID: "task-1"}
+	if err := bus.Publish(ctx, event); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if len(received) != 1 {
+		t.Fatalf("Expected 1 received event, got %d", len(received))
+	}
+
+	if received[0].TaskID != "task-1" {
+		t.Errorf("Expected task-1, got %s", received[0].TaskID)
+	}
+
+	events := bus.RecentEvents()
+	if len(events) != 1 {
+		t.Errorf("Expected 1 retained event, got %d", len(events))
+	}
+}
+
+func TestEngineEmitsTaskLifecycleEvents(t *testing.T) {
+	client := api.Client{}
+	engine := NewEngine(client)
+	ctx := context.Background()
+
+	bus := NewInMemoryEventBus()
+	engine.SetEventPublisher(bus)
+
+	agent := &Agent{Name: "event-agent", Models: []string{"llama2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+
+	task := &Task{
+		ID:      "task-events",
+		Type:    TaskTypeCustom,
+		Input:   "test",
+		AgentID: agent.ID,
+	}
+
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("ExecuteTask failed: %v", err)
+	}
+
+	events := bus.RecentEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events (started, completed), got %d", len(events))
+	}
+
+	if events[0].Type != EventTaskStarted {
+		t.Errorf("Expected %s, got %s", EventTaskStarted, events[0].Type)
+	}
+
+	if events[1].Type != EventTaskCompleted {
+		t.Errorf("Expected %s, got %s", EventTaskCompleted, events[1].Type)
+	}
+
+	if events[0].TaskID != "task-events" || events[1].TaskID != "task-events" {
+		t.Error("Events should carry the task ID")
+	}
+}
@@ -0,0 +1,328 @@
+package orchestration
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Experiment statuses.
+const (
+	ExperimentStatusActive  = "active"
+	ExperimentStatusStopped = "stopped"
+)
+
+// ExperimentArm is one configuration variant in an A/B experiment. Unset
+// fields leave the task unchanged, so an arm can vary just the model, just
+// the prompt template, or any combination.
+type ExperimentArm struct {
+	Name string `json:"name"`
+	// Weight is the arm's share of traffic relative to the other arms;
+	// unweighted arms count as weight 1.
+	Weight float64 `json:"weight,omitempty"`
+	// ModelName overrides the task's model when the task does not pin one.
+	ModelName string `json:"model_name,omitempty"`
+	// PromptTemplate routes the task through a named prompt template.
+	PromptTemplate string `json:"prompt_template,omitempty"`
+	// Parameters are merged into the task's parameters without overriding
+	// entries the task already sets.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// Experiment splits live task traffic between configuration arms and
+// accumulates outcome metrics per arm.
+type Experiment struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// AgentID scopes the experiment to one agent; empty applies to all.
+	AgentID string `json:"agent_id,omitempty"`
+	// TaskType scopes the experiment to one task type; empty applies to all.
+	TaskType  string          `json:"task_type,omitempty"`
+	Arms      []ExperimentArm `json:"arms"`
+	Status    string          `json:"status"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// ArmStats aggregates outcomes observed for one experiment arm.
+type ArmStats struct {
+	Arm         string        `json:"arm"`
+	Assignments int           `json:"assignments"`
+	Completed   int           `json:"completed"`
+	Failed      int           `json:"failed"`
+	SuccessRate float64       `json:"success_rate"`
+	AvgDuration time.Duration `json:"avg_duration"`
+	AvgTokens   float64       `json:"avg_tokens"`
+
+	totalDuration time.Duration
+	totalTokens   int
+}
+
+// ExperimentReport is the API-facing summary of an experiment, including a
+// two-proportion z-test on success rates for two-armed experiments.
+type ExperimentReport struct {
+	Experiment *Experiment `json:"experiment"`
+	Arms       []ArmStats  `json:"arms"`
+	// ZScore compares the first two arms' success rates; zero when either
+	// arm has no assignments.
+	ZScore float64 `json:"z_score,omitempty"`
+	// Significant reports |z| >= 1.96, i.e. p < 0.05 two-tailed.
+	Significant bool `json:"significant"`
+}
+
+// experimentState couples an experiment with its accumulating statistics.
+type experimentState struct {
+	experiment *Experiment
+	stats      map[string]*ArmStats
+}
+
+// ExperimentRegistry holds active experiments and their outcome metrics.
+type ExperimentRegistry struct {
+	mu          sync.Mutex
+	experiments map[string]*experimentState
+}
+
+// NewExperimentRegistry creates an empty experiment registry.
+func NewExperimentRegistry() *ExperimentRegistry {
+	return &ExperimentRegistry{experiments: make(map[string]*experimentState)}
+}
+
+// StartExperiment validates and activates an experiment.
+func (r *ExperimentRegistry) StartExperiment(experiment *Experiment) error {
+	if len(experiment.Arms) < 2 {
+		return fmt.Errorf("experiment requires at least two arms")
+	}
+	seen := make(map[string]bool)
+	for i, arm := range experiment.Arms {
+		if arm.Name == "" {
+			return fmt.Errorf("arm %d has no name", i+1)
+		}
+		if seen[arm.Name] {
+			return fmt.Errorf("duplicate arm name: %s", arm.Name)
+		}
+		seen[arm.Name] = true
+	}
+
+	if experiment.ID == "" {
+		experiment.ID = uuid.New().String()
+	}
+	experiment.Status = ExperimentStatusActive
+	experiment.CreatedAt = time.Now()
+
+	state := &experimentState{
+		experiment: experiment,
+		stats:      make(map[string]*ArmStats, len(experiment.Arms)),
+	}
+	for _, arm := range experiment.Arms {
+		state.stats[arm.Name] = &ArmStats{Arm: arm.Name}
+	}
+
+	r.mu.Lock()
+	r.experiments[experiment.ID] = state
+	r.mu.Unlock()
+
+	slog.Info("Started experiment", "id", experiment.ID, "name", experiment.Name, "arms", len(experiment.Arms))
+	return nil
+}
+
+// StopExperiment deactivates an experiment; its metrics remain queryable.
+func (r *ExperimentRegistry) StopExperiment(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, exists := r.experiments[id]
+	if !exists {
+		return fmt.Errorf("experiment %w: %s", ErrNotFound, id)
+	}
+	state.experiment.Status = ExperimentStatusStopped
+	return nil
+}
+
+// List returns all experiments, newest first.
+func (r *ExperimentRegistry) List() []*Experiment {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	experiments := make([]*Experiment, 0, len(r.experiments))
+	for _, state := range r.experiments {
+		experiments = append(experiments, state.experiment)
+	}
+	sort.Slice(experiments, func(i, j int) bool {
+		return experiments[i].CreatedAt.After(experiments[j].CreatedAt)
+	})
+	return experiments
+}
+
+// assign picks an arm for a task on each active, matching experiment. It
+// returns the experiment ID and arm, or ok=false when no experiment applies.
+func (r *ExperimentRegistry) assign(task *Task, agent *Agent) (string, *ExperimentArm, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, state := range r.experiments {
+		experiment := state.experiment
+		if experiment.Status != ExperimentStatusActive {
+			continue
+		}
+		if experiment.AgentID != "" && experiment.AgentID != agent.ID {
+			continue
+		}
+		if experiment.TaskType != "" && experiment.TaskType != task.Type {
+			continue
+		}
+
+		arm := pickArm(experiment.Arms)
+		state.stats[arm.Name].Assignments++
+		return experiment.ID, arm, true
+	}
+	return "", nil, false
+}
+
+// recordOutcome updates an arm's metrics after its task finishes.
+func (r *ExperimentRegistry) recordOutcome(experimentID, armName string, success bool, duration time.Duration, tokens int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, exists := r.experiments[experimentID]
+	if !exists {
+		return
+	}
+	stats, exists := state.stats[armName]
+	if !exists {
+		return
+	}
+
+	if success {
+		stats.Completed++
+	} else {
+		stats.Failed++
+	}
+	stats.totalDuration += duration
+	stats.totalTokens += tokens
+}
+
+// Report summarizes an experiment's arms and compares the first two with a
+// two-proportion z-test on success rates.
+func (r *ExperimentRegistry) Report(id string) (*ExperimentReport, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, exists := r.experiments[id]
+	if !exists {
+		return nil, fmt.Errorf("experiment %w: %s", ErrNotFound, id)
+	}
+
+	report := &ExperimentReport{Experiment: state.experiment}
+	for _, arm := range state.experiment.Arms {
+		stats := *state.stats[arm.Name]
+		if finished := stats.Completed + stats.Failed; finished > 0 {
+			stats.SuccessRate = float64(stats.Completed) / float64(finished)
+			stats.AvgDuration = stats.totalDuration / time.Duration(finished)
+			stats.AvgTokens = float64(stats.totalTokens) / float64(finished)
+		}
+		report.Arms = append(report.Arms, stats)
+	}
+
+	if len(report.Arms) >= 2 {
+		report.ZScore = twoProportionZ(report.Arms[0], report.Arms[1])
+		report.Significant = math.Abs(report.ZScore) >= 1.96
+	}
+	return report, nil
+}
+
+// pickArm selects an arm proportionally to its weight; arms without an
+// explicit weight count as weight 1, so unweighted experiments split evenly.
+func pickArm(arms []ExperimentArm) *ExperimentArm {
+	weights := make([]float64, len(arms))
+	total := 0.0
+	for i, arm := range arms {
+		weights[i] = arm.Weight
+		if weights[i] <= 0 {
+			weights[i] = 1
+		}
+		total += weights[i]
+	}
+
+	roll := rand.Float64() * total
+	for i := range arms {
+		if roll < weights[i] {
+			return &arms[i]
+		}
+		roll -= weights[i]
+	}
+	return &arms[len(arms)-1]
+}
+
+// twoProportionZ computes the two-proportion z-statistic for the success
+// rates of two arms. It returns 0 when either arm has no finished tasks.
+func twoProportionZ(a, b ArmStats) float64 {
+	na := float64(a.Completed + a.Failed)
+	nb := float64(b.Completed + b.Failed)
+	if na == 0 || nb == 0 {
+		return 0
+	}
+
+	pa := float64(a.Completed) / na
+	pb := float64(b.Completed) / nb
+	pooled := (float64(a.Completed) + float64(b.Completed)) / (na + nb)
+	denominator := math.Sqrt(pooled * (1 - pooled) * (1/na + 1/nb))
+	if denominator == 0 {
+		return 0
+	}
+	return (pa - pb) / denominator
+}
+
+// GetExperiments returns the engine's experiment registry.
+func (e *Engine) GetExperiments() *ExperimentRegistry {
+	return e.experiments
+}
+
+// applyExperimentArm assigns a task to an experiment arm, applying the arm's
+// configuration overrides and tagging the task so its outcome is recorded.
+func (e *Engine) applyExperimentArm(task *Task, agent *Agent) {
+	experimentID, arm, ok := e.experiments.assign(task, agent)
+	if !ok {
+		return
+	}
+
+	if arm.ModelName != "" && task.ModelName == "" {
+		task.ModelName = arm.ModelName
+	}
+	if task.Parameters == nil {
+		task.Parameters = make(map[string]interface{})
+	}
+	if arm.PromptTemplate != "" {
+		if _, set := task.Parameters["prompt_template"]; !set {
+			task.Parameters["prompt_template"] = arm.PromptTemplate
+		}
+	}
+	for key, value := range arm.Parameters {
+		if _, set := task.Parameters[key]; !set {
+			task.Parameters[key] = value
+		}
+	}
+	task.Parameters["experiment_id"] = experimentID
+	task.Parameters["experiment_arm"] = arm.Name
+}
+
+// recordExperimentOutcome feeds a finished task's outcome back to its
+// experiment arm.
+func (e *Engine) recordExperimentOutcome(task *Task, result *TaskResult, success bool, duration time.Duration) {
+	experimentID, _ := task.Parameters["experiment_id"].(string)
+	armName, _ := task.Parameters["experiment_arm"].(string)
+	if experimentID == "" || armName == "" {
+		return
+	}
+
+	tokens := 0
+	if result != nil {
+		tokens = result.Metrics.TokensUsed
+	}
+	e.experiments.recordOutcome(experimentID, armName, success, duration, tokens)
+}
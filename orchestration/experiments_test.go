@@ -0,0 +1,162 @@
+package orchestration
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestStartExperimentValidation(t *testing.T) {
+	registry := NewExperimentRegistry()
+
+	if err := registry.StartExperiment(&Experiment{
+		Name: "too-few",
+		Arms: []ExperimentArm{{Name: "a"}},
+	}); err == nil {
+		t.Error("Expected error for a single-armed experiment")
+	}
+
+	if err := registry.StartExperiment(&Experiment{
+		Name: "dupes",
+		Arms: []ExperimentArm{{Name: "a"}, {Name: "a"}},
+	}); err == nil {
+		t.Error("Expected error for duplicate arm names")
+	}
+
+	experiment := &Experiment{
+		Name: "valid",
+		Arms: []ExperimentArm{{Name: "a"}, {Name: "b"}},
+	}
+	if err := registry.StartExperiment(experiment); err != nil {
+		t.Fatalf("Failed to start experiment: %v", err)
+	}
+	if experiment.ID == "" || experiment.Status != ExperimentStatusActive {
+		t.Errorf("Expected active experiment with ID, got %+v", experiment)
+	}
+}
+
+func TestPickArmCoversAllArms(t *testing.T) {
+	arms := []ExperimentArm{{Name: "a"}, {Name: "b"}}
+	picked := make(map[string]int)
+	for i := 0; i < 200; i++ {
+		picked[pickArm(arms).Name]++
+	}
+	if picked["a"] == 0 || picked["b"] == 0 {
+		t.Errorf("Expected both arms picked over 200 rolls, got %v", picked)
+	}
+}
+
+func TestExperimentAssignsAndRecords(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "subject", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	experiment := &Experiment{
+		Name:     "routing",
+		TaskType: TaskTypeCustom,
+		Arms: []ExperimentArm{
+			{Name: "control"},
+			{Name: "variant", Parameters: map[string]interface{}{"experimental_flag": true}},
+		},
+	}
+	if err := engine.GetExperiments().StartExperiment(experiment); err != nil {
+		t.Fatalf("Failed to start experiment: %v", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		task := &Task{Type: TaskTypeCustom, Input: "probe", AgentID: agent.ID}
+		if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+			t.Fatalf("Task failed: %v", err)
+		}
+		if task.Parameters["experiment_id"] != experiment.ID {
+			t.Fatal("Expected task tagged with experiment ID")
+		}
+	}
+
+	report, err := engine.GetExperiments().Report(experiment.ID)
+	if err != nil {
+		t.Fatalf("Failed to build report: %v", err)
+	}
+	total := 0
+	for _, arm := range report.Arms {
+		total += arm.Completed
+		if arm.Failed != 0 {
+			t.Errorf("Expected no failures for arm %s, got %d", arm.Arm, arm.Failed)
+		}
+	}
+	if total != 10 {
+		t.Errorf("Expected 10 completed tasks across arms, got %d", total)
+	}
+}
+
+func TestExperimentScoping(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "outside", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	experiment := &Experiment{
+		Name:    "scoped",
+		AgentID: "some-other-agent",
+		Arms:    []ExperimentArm{{Name: "a"}, {Name: "b"}},
+	}
+	if err := engine.GetExperiments().StartExperiment(experiment); err != nil {
+		t.Fatalf("Failed to start experiment: %v", err)
+	}
+
+	task := &Task{Type: TaskTypeCustom, Input: "probe", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Task failed: %v", err)
+	}
+	if _, tagged := task.Parameters["experiment_id"]; tagged {
+		t.Error("Expected out-of-scope task not assigned to the experiment")
+	}
+}
+
+func TestExperimentReportZTest(t *testing.T) {
+	registry := NewExperimentRegistry()
+	experiment := &Experiment{
+		Name: "comparison",
+		Arms: []ExperimentArm{{Name: "a"}, {Name: "b"}},
+	}
+	if err := registry.StartExperiment(experiment); err != nil {
+		t.Fatalf("Failed to start experiment: %v", err)
+	}
+
+	// Arm a: 95/100 success, arm b: 50/100 — clearly significant
+	for i := 0; i < 100; i++ {
+		registry.recordOutcome(experiment.ID, "a", i < 95, time.Millisecond, 10)
+		registry.recordOutcome(experiment.ID, "b", i < 50, 2*time.Millisecond, 20)
+	}
+
+	report, err := registry.Report(experiment.ID)
+	if err != nil {
+		t.Fatalf("Failed to build report: %v", err)
+	}
+	if !report.Significant {
+		t.Errorf("Expected a significant difference, z=%f", report.ZScore)
+	}
+	if math.Abs(report.Arms[0].SuccessRate-0.95) > 0.001 {
+		t.Errorf("Expected 0.95 success rate for arm a, got %f", report.Arms[0].SuccessRate)
+	}
+	if report.Arms[1].AvgTokens != 20 {
+		t.Errorf("Expected 20 average tokens for arm b, got %f", report.Arms[1].AvgTokens)
+	}
+
+	if err := registry.StopExperiment(experiment.ID); err != nil {
+		t.Fatalf("Failed to stop experiment: %v", err)
+	}
+	if experiment.Status != ExperimentStatusStopped {
+		t.Errorf("Expected stopped experiment, got %s", experiment.Status)
+	}
+}
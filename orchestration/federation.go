@@ -0,0 +1,324 @@
+package orchestration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FederationPeer represents another engine instance that this engine can
+// discover agents on and delegate tasks to.
+type FederationPeer struct {
+	ID           string               `json:"id"`
+	Name         string               `json:"name"`
+	BaseURL      string               `json:"base_url"`
+	Token        string               `json:"-"` // Bearer token for the peer, never serialized
+	Capabilities []AgentAdvertisement `json:"capabilities,omitempty"`
+	LastSeen     time.Time            `json:"last_seen"`
+	RegisteredAt time.Time            `json:"registered_at"`
+}
+
+// Clone returns a copy safe to serialize while capability refreshes update
+// the registry's live record. Advertisement model and tool slices are shared;
+// refreshes replace the capability slice wholesale rather than mutating it.
+func (p *FederationPeer) Clone() *FederationPeer {
+	if p == nil {
+		return nil
+	}
+
+	clone := *p
+	clone.Capabilities = append([]AgentAdvertisement(nil), p.Capabilities...)
+	return &clone
+}
+
+// AgentAdvertisement describes an agent a peer engine makes available for
+// delegated task execution.
+type AgentAdvertisement struct {
+	AgentID     string    `json:"agent_id"`
+	Name        string    `json:"name"`
+	Type        AgentType `json:"type"`
+	Description string    `json:"description,omitempty"`
+	Models      []string  `json:"models,omitempty"`
+	Tools       []string  `json:"tools,omitempty"`
+}
+
+// FederatedTaskRequest is the wire format for a task forwarded to a peer.
+type FederatedTaskRequest struct {
+	AgentID    string                 `json:"agent_id"`
+	Type       string                 `json:"type"`
+	Input      string                 `json:"input"`
+	ModelName  string                 `json:"model_name,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	SourceID   string                 `json:"source_id,omitempty"` // Requesting engine instance
+}
+
+// FederatedTaskResponse is the wire format for a delegated task result.
+type FederatedTaskResponse struct {
+	TaskID    string `json:"task_id"`
+	Output    string `json:"output"`
+	ModelUsed string `json:"model_used,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SetFederationToken configures the shared secret required on inbound
+// federation requests. Federation endpoints reject all requests until a
+// token is set.
+func (e *Engine) SetFederationToken(token string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.federationToken = token
+}
+
+// federationTokenValid checks an inbound bearer token against the configured
+// federation secret.
+func (e *Engine) federationTokenValid(token string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.federationToken != "" && token == e.federationToken
+}
+
+// RegisterFederationPeer registers a peer engine for discovery and task
+// delegation, immediately fetching its advertised agents.
+func (e *Engine) RegisterFederationPeer(ctx context.Context, name, baseURL, token string) (*FederationPeer, error) {
+	peer := &FederationPeer{
+		ID:           uuid.New().String(),
+		Name:         name,
+		BaseURL:      baseURL,
+		Token:        token,
+		RegisteredAt: time.Now(),
+	}
+
+	if err := e.refreshPeerCapabilities(ctx, peer); err != nil {
+		return nil, fmt.Errorf("failed to fetch peer capabilities: %w", err)
+	}
+
+	e.mu.Lock()
+	e.federationPeers[peer.ID] = peer
+	e.mu.Unlock()
+
+	slog.Info("Registered federation peer", "id", peer.ID, "name", name, "agents", len(peer.Capabilities))
+	return peer.Clone(), nil
+}
+
+// ListFederationPeers returns copies of the registered peers.
+func (e *Engine) ListFederationPeers(ctx context.Context) []*FederationPeer {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	peers := make([]*FederationPeer, 0, len(e.federationPeers))
+	for _, peer := range e.federationPeers {
+		peers = append(peers, peer.Clone())
+	}
+	return peers
+}
+
+// RemoveFederationPeer removes a registered peer.
+func (e *Engine) RemoveFederationPeer(ctx context.Context, peerID string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, exists := e.federationPeers[peerID]; !exists {
+		return fmt.Errorf("federation peer not found: %s", peerID)
+	}
+
+	delete(e.federationPeers, peerID)
+	slog.Info("Removed federation peer", "id", peerID)
+	return nil
+}
+
+// RefreshFederationPeers re-fetches capability advertisements from all peers.
+func (e *Engine) RefreshFederationPeers(ctx context.Context) {
+	e.mu.RLock()
+	peers := make([]*FederationPeer, 0, len(e.federationPeers))
+	for _, peer := range e.federationPeers {
+		peers = append(peers, peer)
+	}
+	e.mu.RUnlock()
+
+	for _, peer := range peers {
+		if err := e.refreshPeerCapabilities(ctx, peer); err != nil {
+			slog.Error("Failed to refresh federation peer", "error", err, "peer", peer.Name)
+		}
+	}
+}
+
+// refreshPeerCapabilities fetches a peer's advertised agents.
+func (e *Engine) refreshPeerCapabilities(ctx context.Context, peer *FederationPeer) error {
+	var advertisements []AgentAdvertisement
+	if err := e.federationGet(ctx, peer, "/api/federation/agents", &advertisements); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	peer.Capabilities = advertisements
+	peer.LastSeen = time.Now()
+	e.mu.Unlock()
+
+	return nil
+}
+
+// FindFederatedAgent searches registered peers for an agent advertising the
+// given model or tool capability.
+func (e *Engine) FindFederatedAgent(ctx context.Context, capability string) (*FederationPeer, *AgentAdvertisement, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, peer := range e.federationPeers {
+		for i := range peer.Capabilities {
+			advertisement := peer.Capabilities[i]
+			for _, model := range advertisement.Models {
+				if model == capability {
+					return peer.Clone(), &advertisement, nil
+				}
+			}
+			for _, tool := range advertisement.Tools {
+				if tool == capability {
+					return peer.Clone(), &advertisement, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil, fmt.Errorf("no federated agent advertises capability: %s", capability)
+}
+
+// DelegateTaskToPeer forwards a task to an agent hosted on a peer engine and
+// returns the remote result.
+func (e *Engine) DelegateTaskToPeer(ctx context.Context, peerID string, req *FederatedTaskRequest) (*TaskResult, error) {
+	e.mu.RLock()
+	peer, exists := e.federationPeers[peerID]
+	e.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("federation peer not found: %s", peerID)
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode federated task: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		peer.BaseURL+"/api/federation/tasks", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+peer.Token)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("federated task request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("peer returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var taskResp FederatedTaskResponse
+	if err := json.Unmarshal(body, &taskResp); err != nil {
+		return nil, fmt.Errorf("failed to decode federated task response: %w", err)
+	}
+
+	if taskResp.Error != "" {
+		return nil, fmt.Errorf("federated task failed on peer %s: %s", peer.Name, taskResp.Error)
+	}
+
+	e.mu.Lock()
+	peer.LastSeen = time.Now()
+	e.mu.Unlock()
+
+	return &TaskResult{
+		TaskID:    taskResp.TaskID,
+		Output:    taskResp.Output,
+		ModelUsed: taskResp.ModelUsed,
+	}, nil
+}
+
+// federationGet performs an authenticated GET against a peer endpoint.
+func (e *Engine) federationGet(ctx context.Context, peer *FederationPeer, path string, out interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.BaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+peer.Token)
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("federation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("peer returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// AdvertiseAgents builds the capability advertisements for local agents,
+// served to peers over the federation API.
+func (e *Engine) AdvertiseAgents(ctx context.Context) []AgentAdvertisement {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	advertisements := make([]AgentAdvertisement, 0, len(e.agents))
+	for _, agent := range e.agents {
+		advertisements = append(advertisements, AgentAdvertisement{
+			AgentID:     agent.ID,
+			Name:        agent.Name,
+			Type:        agent.Type,
+			Description: agent.Description,
+			Models:      agent.Models,
+			Tools:       agent.Tools,
+		})
+	}
+	return advertisements
+}
+
+// ExecuteFederatedTask executes a task forwarded by a peer engine against a
+// local agent and returns the wire response.
+func (e *Engine) ExecuteFederatedTask(ctx context.Context, req *FederatedTaskRequest) *FederatedTaskResponse {
+	agent, err := e.GetAgent(ctx, req.AgentID)
+	if err != nil {
+		return &FederatedTaskResponse{Error: err.Error()}
+	}
+
+	task := &Task{
+		ID:         uuid.New().String(),
+		Type:       req.Type,
+		Input:      req.Input,
+		Status:     TaskStatusPending,
+		AgentID:    req.AgentID,
+		ModelName:  req.ModelName,
+		Parameters: req.Parameters,
+		CreatedAt:  time.Now(),
+	}
+
+	e.persistTask(ctx, task)
+
+	result, err := e.ExecuteTask(ctx, task, agent)
+	if err != nil {
+		return &FederatedTaskResponse{TaskID: task.ID, Error: err.Error()}
+	}
+
+	return &FederatedTaskResponse{
+		TaskID:    result.TaskID,
+		Output:    result.Output,
+		ModelUsed: result.ModelUsed,
+	}
+}
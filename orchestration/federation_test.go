@@ -0,0 +1,115 @@
+package orchestration
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+func TestFederationRoundTrip(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	// Stand up a peer engine behind its real HTTP API
+	peerEngine := NewEngine(api.Client{})
+	peerEngine.SetFederationToken("peer-secret")
+	peerServer := NewAPIServer(peerEngine)
+	remote := httptest.NewServer(peerServer.router)
+	defer remote.Close()
+
+	remoteAgent, err := peerEngine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	local := NewEngine(api.Client{})
+
+	// Registration fetches capabilities, so a bad token fails up front
+	if _, err := local.RegisterFederationPeer(ctx, "peer", remote.URL, "wrong-secret"); err == nil {
+		t.Error("Expected registration with a bad token to fail")
+	}
+
+	peer, err := local.RegisterFederationPeer(ctx, "peer", remote.URL, "peer-secret")
+	if err != nil {
+		t.Fatalf("RegisterFederationPeer failed: %v", err)
+	}
+	if len(peer.Capabilities) != 1 || peer.Capabilities[0].AgentID != remoteAgent.ID {
+		t.Fatalf("Expected the peer's agent advertised, got %+v", peer.Capabilities)
+	}
+
+	// Capability search matches on advertised models
+	found, advertisement, err := local.FindFederatedAgent(ctx, remoteAgent.Models[0])
+	if err != nil {
+		t.Fatalf("FindFederatedAgent failed: %v", err)
+	}
+	if found.ID != peer.ID || advertisement.AgentID != remoteAgent.ID {
+		t.Errorf("Unexpected capability match: peer=%+v advertisement=%+v", found, advertisement)
+	}
+
+	// Forward a task to the peer and get the remote result back
+	result, err := local.DelegateTaskToPeer(ctx, peer.ID, &FederatedTaskRequest{
+		AgentID: remoteAgent.ID,
+		Type:    TaskTypeCustom,
+		Input:   "ping",
+	})
+	if err != nil {
+		t.Fatalf("DelegateTaskToPeer failed: %v", err)
+	}
+	if result.TaskID == "" {
+		t.Errorf("Expected a task ID from the peer, got %+v", result)
+	}
+	remoteTasks, err := peerEngine.ListTasks(ctx, TaskFilter{AgentID: remoteAgent.ID})
+	if err != nil || len(remoteTasks) != 1 || remoteTasks[0].Status != TaskStatusCompleted {
+		t.Errorf("Expected the task completed on the peer, got %+v (%v)", remoteTasks, err)
+	}
+
+	// Delegation to an unknown agent surfaces the peer's error
+	if _, err := local.DelegateTaskToPeer(ctx, peer.ID, &FederatedTaskRequest{
+		AgentID: "ghost",
+		Type:    TaskTypeCustom,
+		Input:   "ping",
+	}); err == nil {
+		t.Error("Expected delegation to an unknown agent to fail")
+	}
+
+	if err := local.RemoveFederationPeer(ctx, peer.ID); err != nil {
+		t.Fatalf("RemoveFederationPeer failed: %v", err)
+	}
+	if peers := local.ListFederationPeers(ctx); len(peers) != 0 {
+		t.Errorf("Expected no peers after removal, got %+v", peers)
+	}
+}
+
+func TestListFederationPeersReturnsCopies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+
+	peerEngine := NewEngine(api.Client{})
+	peerEngine.SetFederationToken("peer-secret")
+	remote := httptest.NewServer(NewAPIServer(peerEngine).router)
+	defer remote.Close()
+
+	if _, err := peerEngine.CreateDefaultAgent(ctx); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	local := NewEngine(api.Client{})
+	if _, err := local.RegisterFederationPeer(ctx, "peer", remote.URL, "peer-secret"); err != nil {
+		t.Fatalf("RegisterFederationPeer failed: %v", err)
+	}
+
+	listed := local.ListFederationPeers(ctx)
+	if len(listed) != 1 || len(listed[0].Capabilities) != 1 {
+		t.Fatalf("Unexpected peer listing: %+v", listed)
+	}
+	listed[0].Name = "scribbled"
+	listed[0].Capabilities[0].AgentID = "scribbled"
+
+	again := local.ListFederationPeers(ctx)[0]
+	if again.Name == "scribbled" || again.Capabilities[0].AgentID == "scribbled" {
+		t.Error("ListFederationPeers handed out the live registry record")
+	}
+}
@@ -0,0 +1,212 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore is a durable Store backed by a single JSON file, giving a
+// single-instance deployment restart-safe agents, tasks, and conversations.
+// Like the thin Redis client, it keeps the module free of an external
+// database driver; shared multi-instance deployments should still use a
+// networked backend. Every mutation is written through atomically (temp
+// file plus rename), so a crash never leaves a half-written store.
+type FileStore struct {
+	path string
+	mu   sync.RWMutex
+	data fileStoreData
+}
+
+// fileStoreData is the on-disk layout of a FileStore.
+type fileStoreData struct {
+	Agents        map[string]*Agent        `json:"agents"`
+	Tasks         map[string]*Task         `json:"tasks"`
+	Conversations map[string]*Conversation `json:"conversations"`
+}
+
+// NewFileStore opens a file-backed store, loading any state a previous
+// process persisted at the same path.
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		path: path,
+		data: fileStoreData{
+			Agents:        make(map[string]*Agent),
+			Tasks:         make(map[string]*Task),
+			Conversations: make(map[string]*Conversation),
+		},
+	}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store file: %w", err)
+	}
+	if err := json.Unmarshal(raw, &store.data); err != nil {
+		return nil, fmt.Errorf("failed to decode store file %s: %w", path, err)
+	}
+	if store.data.Agents == nil {
+		store.data.Agents = make(map[string]*Agent)
+	}
+	if store.data.Tasks == nil {
+		store.data.Tasks = make(map[string]*Task)
+	}
+	if store.data.Conversations == nil {
+		store.data.Conversations = make(map[string]*Conversation)
+	}
+	return store, nil
+}
+
+// flush writes the store contents atomically. The caller must hold s.mu.
+func (s *FileStore) flush() error {
+	raw, err := json.Marshal(s.data)
+	if err != nil {
+		return fmt.Errorf("failed to encode store: %w", err)
+	}
+
+	tmp := filepath.Join(filepath.Dir(s.path), fmt.Sprintf(".%s.tmp", filepath.Base(s.path)))
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write store file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace store file: %w", err)
+	}
+	return nil
+}
+
+// SaveAgent creates or updates an agent record.
+func (s *FileStore) SaveAgent(ctx context.Context, agent *Agent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Agents[agent.ID] = agent
+	return s.flush()
+}
+
+// GetAgent retrieves an agent record by ID.
+func (s *FileStore) GetAgent(ctx context.Context, id string) (*Agent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	agent, exists := s.data.Agents[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return agent, nil
+}
+
+// ListAgents returns all stored agents.
+func (s *FileStore) ListAgents(ctx context.Context) ([]*Agent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	agents := make([]*Agent, 0, len(s.data.Agents))
+	for _, agent := range s.data.Agents {
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+// DeleteAgent removes an agent record.
+func (s *FileStore) DeleteAgent(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.data.Agents[id]; !exists {
+		return ErrNotFound
+	}
+	delete(s.data.Agents, id)
+	return s.flush()
+}
+
+// SaveTask creates or updates a task record.
+func (s *FileStore) SaveTask(ctx context.Context, task *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Tasks[task.ID] = task
+	return s.flush()
+}
+
+// GetTask retrieves a task record by ID.
+func (s *FileStore) GetTask(ctx context.Context, id string) (*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.data.Tasks[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return task, nil
+}
+
+// ListTasks returns stored tasks matching the filter.
+func (s *FileStore) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := make([]*Task, 0)
+	for _, task := range s.data.Tasks {
+		if filter.matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// DeleteTask removes a task record.
+func (s *FileStore) DeleteTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.data.Tasks[id]; !exists {
+		return ErrNotFound
+	}
+	delete(s.data.Tasks, id)
+	return s.flush()
+}
+
+// SaveConversation creates or updates a conversation record.
+func (s *FileStore) SaveConversation(ctx context.Context, conversation *Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.Conversations[conversation.ID] = conversation
+	return s.flush()
+}
+
+// GetConversation retrieves a conversation record by ID.
+func (s *FileStore) GetConversation(ctx context.Context, id string) (*Conversation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conversation, exists := s.data.Conversations[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return conversation, nil
+}
+
+// ListConversations returns conversations involving the given agent, or all
+// conversations when agentID is empty.
+func (s *FileStore) ListConversations(ctx context.Context, agentID string) ([]*Conversation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conversations := make([]*Conversation, 0)
+	for _, conversation := range s.data.Conversations {
+		if agentID == "" {
+			conversations = append(conversations, conversation)
+			continue
+		}
+		for _, participant := range conversation.Participants {
+			if participant == agentID {
+				conversations = append(conversations, conversation)
+				break
+			}
+		}
+	}
+	return conversations, nil
+}
@@ -0,0 +1,106 @@
+package orchestration
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestFileStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "engine.json")
+	ctx := context.Background()
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+	engine := NewEngineWithStore(api.Client{}, store)
+
+	agent := &Agent{Name: "durable", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	task := &Task{ID: "durable-task", Type: TaskTypeCustom, Input: "persist me", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+	conversation, err := engine.StartConversation(ctx, []string{agent.ID}, "durability")
+	if err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+
+	// A fresh store at the same path sees everything the first one wrote.
+	reopened, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	restarted := NewEngineWithStore(api.Client{}, reopened)
+
+	loaded, err := restarted.GetAgent(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("Agent did not survive restart: %v", err)
+	}
+	if loaded.Name != "durable" {
+		t.Errorf("Expected restored agent name durable, got %s", loaded.Name)
+	}
+	if _, err := reopened.GetTask(ctx, task.ID); err != nil {
+		t.Errorf("Task did not survive restart: %v", err)
+	}
+	if _, err := reopened.GetConversation(ctx, conversation.ID); err != nil {
+		t.Errorf("Conversation did not survive restart: %v", err)
+	}
+}
+
+func TestFileStoreCRUD(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "store.json")
+	ctx := context.Background()
+
+	store, err := NewFileStore(path)
+	if err != nil {
+		t.Fatalf("NewFileStore failed: %v", err)
+	}
+
+	agent := &Agent{ID: "a1", Name: "file-agent"}
+	if err := store.SaveAgent(ctx, agent); err != nil {
+		t.Fatalf("SaveAgent failed: %v", err)
+	}
+	agents, err := store.ListAgents(ctx)
+	if err != nil || len(agents) != 1 {
+		t.Fatalf("Expected 1 agent, got %d (err %v)", len(agents), err)
+	}
+	if err := store.DeleteAgent(ctx, "a1"); err != nil {
+		t.Fatalf("DeleteAgent failed: %v", err)
+	}
+	if _, err := store.GetAgent(ctx, "a1"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := store.DeleteAgent(ctx, "a1"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound deleting a missing agent, got %v", err)
+	}
+
+	conversation := &Conversation{ID: "c1", Participants: []string{"a2"}}
+	if err := store.SaveConversation(ctx, conversation); err != nil {
+		t.Fatalf("SaveConversation failed: %v", err)
+	}
+	matches, err := store.ListConversations(ctx, "a2")
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("Expected 1 conversation for a2, got %d (err %v)", len(matches), err)
+	}
+	none, err := store.ListConversations(ctx, "other")
+	if err != nil || len(none) != 0 {
+		t.Errorf("Expected no conversations for other, got %d (err %v)", len(none), err)
+	}
+}
+
+func TestFileStoreRejectsCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "corrupt.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("Failed to write corrupt file: %v", err)
+	}
+	if _, err := NewFileStore(path); err == nil {
+		t.Error("Expected an error opening a corrupt store file")
+	}
+}
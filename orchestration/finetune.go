@@ -0,0 +1,189 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// Fine-tune job statuses.
+const (
+	FineTuneStatusPending   = "pending"
+	FineTuneStatusRunning   = "running"
+	FineTuneStatusCompleted = "completed"
+	FineTuneStatusFailed    = "failed"
+)
+
+// maxFineTuneMessages bounds how many dataset examples are baked into the
+// created model as MESSAGE directives.
+const maxFineTuneMessages = 50
+
+// FineTuneRequest describes a model creation job derived from exported
+// transcripts: a base model, optional LoRA adapters, and the dataset filter
+// selecting which examples to include.
+type FineTuneRequest struct {
+	AgentID     string `json:"agent_id"`
+	BaseModel   string `json:"base_model"`
+	TargetModel string `json:"target_model"`
+	// Adapters maps adapter names to local safetensors paths, passed
+	// through to the Ollama daemon.
+	Adapters map[string]string    `json:"adapters,omitempty"`
+	System   string               `json:"system,omitempty"`
+	Dataset  DatasetExportOptions `json:"dataset,omitempty"`
+}
+
+// FineTuneJob tracks one model creation submitted to the Ollama daemon.
+type FineTuneJob struct {
+	ID          string          `json:"id"`
+	Request     FineTuneRequest `json:"request"`
+	Status      string          `json:"status"`
+	Progress    string          `json:"progress,omitempty"`
+	Error       string          `json:"error,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+
+	mu sync.Mutex
+}
+
+// setProgress records the daemon's latest status line.
+func (j *FineTuneJob) setProgress(progress string) {
+	j.mu.Lock()
+	j.Progress = progress
+	j.mu.Unlock()
+}
+
+// finish marks the job completed or failed.
+func (j *FineTuneJob) finish(err error) {
+	now := time.Now()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.CompletedAt = &now
+	if err != nil {
+		j.Status = FineTuneStatusFailed
+		j.Error = err.Error()
+		return
+	}
+	j.Status = FineTuneStatusCompleted
+}
+
+// StartFineTune validates a fine-tune request, assembles the dataset, and
+// submits model creation to the Ollama daemon in the background. On success
+// the resulting model is registered on the owning agent. The returned job
+// can be polled for progress.
+func (e *Engine) StartFineTune(ctx context.Context, req *FineTuneRequest) (*FineTuneJob, error) {
+	if req.BaseModel == "" || req.TargetModel == "" {
+		return nil, fmt.Errorf("fine-tune requires base_model and target_model")
+	}
+	agent, err := e.GetAgent(ctx, req.AgentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	messages := e.datasetMessages(req.Dataset, maxFineTuneMessages)
+	if len(messages) == 0 && len(req.Adapters) == 0 {
+		return nil, fmt.Errorf("fine-tune has no dataset examples and no adapters")
+	}
+
+	job := &FineTuneJob{
+		ID:        uuid.New().String(),
+		Request:   *req,
+		Status:    FineTuneStatusPending,
+		CreatedAt: time.Now(),
+	}
+
+	e.fineTuneMu.Lock()
+	e.fineTuneJobs[job.ID] = job
+	e.fineTuneMu.Unlock()
+
+	go e.runFineTune(job, agent, messages)
+
+	return job, nil
+}
+
+// runFineTune drives the daemon-side model creation and registers the model
+// on the agent when it succeeds. Runs detached from the submitting request.
+func (e *Engine) runFineTune(job *FineTuneJob, agent *Agent, messages []api.Message) {
+	job.mu.Lock()
+	job.Status = FineTuneStatusRunning
+	job.mu.Unlock()
+
+	createReq := &api.CreateRequest{
+		Model:    job.Request.TargetModel,
+		From:     job.Request.BaseModel,
+		Adapters: job.Request.Adapters,
+		System:   job.Request.System,
+		Messages: messages,
+	}
+
+	ctx := context.Background()
+	err := e.client.Create(ctx, createReq, func(resp api.ProgressResponse) error {
+		job.setProgress(resp.Status)
+		return nil
+	})
+	job.finish(err)
+	if err != nil {
+		slog.Error("Fine-tune job failed", "job_id", job.ID, "model", job.Request.TargetModel, "error", err)
+		return
+	}
+
+	if !containsString(agent.Models, job.Request.TargetModel) {
+		agent.Models = append(agent.Models, job.Request.TargetModel)
+		if updateErr := e.UpdateAgent(ctx, agent); updateErr != nil {
+			slog.Error("Failed to register fine-tuned model on agent",
+				"agent_id", agent.ID, "model", job.Request.TargetModel, "error", updateErr)
+		}
+	}
+	slog.Info("Fine-tune job completed", "job_id", job.ID, "model", job.Request.TargetModel)
+}
+
+// datasetMessages flattens exported dataset records into MESSAGE directives
+// for model creation, bounded to limit turns.
+func (e *Engine) datasetMessages(opts DatasetExportOptions, limit int) []api.Message {
+	records := e.collectTaskRecords(opts)
+	if opts.IncludeConversations {
+		records = append(records, e.collectConversationRecords(opts)...)
+	}
+
+	messages := make([]api.Message, 0, limit)
+	for _, record := range records {
+		if len(messages)+len(record.Messages) > limit {
+			break
+		}
+		for _, message := range record.Messages {
+			messages = append(messages, api.Message{Role: message.Role, Content: message.Content})
+		}
+	}
+	return messages
+}
+
+// GetFineTuneJob returns a fine-tune job by ID.
+func (e *Engine) GetFineTuneJob(id string) (*FineTuneJob, error) {
+	e.fineTuneMu.RLock()
+	defer e.fineTuneMu.RUnlock()
+
+	job, exists := e.fineTuneJobs[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: fine-tune job %s", ErrNotFound, id)
+	}
+	return job, nil
+}
+
+// ListFineTuneJobs returns all fine-tune jobs, newest first.
+func (e *Engine) ListFineTuneJobs() []*FineTuneJob {
+	e.fineTuneMu.RLock()
+	defer e.fineTuneMu.RUnlock()
+
+	jobs := make([]*FineTuneJob, 0, len(e.fineTuneJobs))
+	for _, job := range e.fineTuneJobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	return jobs
+}
@@ -0,0 +1,111 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestStartFineTuneValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "tune-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Missing models
+	if _, err := engine.StartFineTune(ctx, &FineTuneRequest{AgentID: agent.ID}); err == nil {
+		t.Error("Expected error for missing base/target model")
+	}
+
+	// Unknown agent
+	if _, err := engine.StartFineTune(ctx, &FineTuneRequest{
+		AgentID: "missing", BaseModel: "llama3.2", TargetModel: "custom",
+	}); err == nil {
+		t.Error("Expected error for unknown agent")
+	}
+
+	// No dataset examples and no adapters
+	if _, err := engine.StartFineTune(ctx, &FineTuneRequest{
+		AgentID: agent.ID, BaseModel: "llama3.2", TargetModel: "custom",
+	}); err == nil {
+		t.Error("Expected error for empty fine-tune job")
+	}
+}
+
+func TestFineTuneJobLifecycle(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	agent := &Agent{Name: "tune-lifecycle", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.tasks["t1"] = &Task{
+		ID: "t1", Type: TaskTypeGenerate, AgentID: agent.ID,
+		Input: "prompt", Output: "completion",
+		Status: TaskStatusCompleted, CreatedAt: time.Now(),
+	}
+
+	job, err := engine.StartFineTune(ctx, &FineTuneRequest{
+		AgentID: agent.ID, BaseModel: "llama3.2", TargetModel: "llama3.2-tuned",
+	})
+	if err != nil {
+		t.Fatalf("StartFineTune failed: %v", err)
+	}
+
+	// The daemon is unreachable, so the job must end up failed
+	deadline := time.After(5 * time.Second)
+	for {
+		job.mu.Lock()
+		status := job.Status
+		job.mu.Unlock()
+		if status == FineTuneStatusFailed {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("Job did not fail in time, status %s", status)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	fetched, err := engine.GetFineTuneJob(job.ID)
+	if err != nil {
+		t.Fatalf("GetFineTuneJob failed: %v", err)
+	}
+	if fetched.Error == "" || fetched.CompletedAt == nil {
+		t.Errorf("Expected failure recorded on job: %+v", fetched)
+	}
+
+	if jobs := engine.ListFineTuneJobs(); len(jobs) != 1 {
+		t.Errorf("Expected 1 job listed, got %d", len(jobs))
+	}
+	if _, err := engine.GetFineTuneJob("missing"); err == nil {
+		t.Error("Expected error for unknown job")
+	}
+}
+
+func TestDatasetMessagesLimit(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	for i := 0; i < 40; i++ {
+		id := string(rune('a' + i%26))
+		engine.tasks[id+string(rune('0'+i/26))] = &Task{
+			ID: id, Type: TaskTypeGenerate, AgentID: "a",
+			Input: "prompt " + id, Output: "completion " + id,
+			Status: TaskStatusCompleted, CreatedAt: time.Now().Add(time.Duration(i) * time.Second),
+		}
+	}
+
+	messages := engine.datasetMessages(DatasetExportOptions{}, 10)
+	if len(messages) > 10 {
+		t.Errorf("Expected at most 10 messages, got %d", len(messages))
+	}
+	if len(messages) == 0 || len(messages)%2 != 0 {
+		t.Errorf("Expected whole user/assistant pairs, got %d messages", len(messages))
+	}
+}
@@ -0,0 +1,557 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// This file implements a deliberately small GraphQL subset over the
+// orchestration graph — agents, tasks, conversations, and memory nodes —
+// so dashboards can fetch a nested view in one round trip instead of N
+// REST calls. Supported syntax: an optional "query [Name]" header, nested
+// selection sets, and scalar field arguments (strings, numbers,
+// booleans). Variables, fragments, aliases, and mutations are not.
+
+// gqlField is one requested field with its arguments and sub-selections.
+type gqlField struct {
+	Name       string
+	Args       map[string]interface{}
+	Selections []gqlField
+}
+
+// gqlParser walks the raw query text.
+type gqlParser struct {
+	input []rune
+	pos   int
+}
+
+// parseGraphQLQuery parses a query document into its root selections.
+func parseGraphQLQuery(query string) ([]gqlField, error) {
+	p := &gqlParser{input: []rune(query)}
+	p.skipSpace()
+
+	// Optional operation header: "query" with an optional name
+	if p.peekName() == "query" {
+		p.readName()
+		p.skipSpace()
+		if p.pos < len(p.input) && p.input[p.pos] != '{' {
+			p.readName()
+		}
+	}
+
+	selections, err := p.parseSelectionSet()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos != len(p.input) {
+		return nil, fmt.Errorf("unexpected trailing input at offset %d", p.pos)
+	}
+	return selections, nil
+}
+
+func (p *gqlParser) skipSpace() {
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		if unicode.IsSpace(r) || r == ',' {
+			p.pos++
+			continue
+		}
+		// Line comments, as in the GraphQL grammar
+		if r == '#' {
+			for p.pos < len(p.input) && p.input[p.pos] != '\n' {
+				p.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+func (p *gqlParser) peekName() string {
+	saved := p.pos
+	name := p.readName()
+	p.pos = saved
+	return name
+}
+
+func (p *gqlParser) readName() string {
+	start := p.pos
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		if r == '_' || unicode.IsLetter(r) || (p.pos > start && unicode.IsDigit(r)) {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return string(p.input[start:p.pos])
+}
+
+func (p *gqlParser) expect(r rune) error {
+	p.skipSpace()
+	if p.pos >= len(p.input) || p.input[p.pos] != r {
+		return fmt.Errorf("expected %q at offset %d", r, p.pos)
+	}
+	p.pos++
+	return nil
+}
+
+// parseSelectionSet parses "{ field* }".
+func (p *gqlParser) parseSelectionSet() ([]gqlField, error) {
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	var selections []gqlField
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+		if p.input[p.pos] == '}' {
+			p.pos++
+			break
+		}
+
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, field)
+	}
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("empty selection set")
+	}
+	return selections, nil
+}
+
+// parseField parses "name [ (args) ] [ selectionSet ]".
+func (p *gqlParser) parseField() (gqlField, error) {
+	field := gqlField{Name: p.readName()}
+	if field.Name == "" {
+		return field, fmt.Errorf("expected a field name at offset %d", p.pos)
+	}
+
+	p.skipSpace()
+	if p.pos < len(p.input) && p.input[p.pos] == '(' {
+		args, err := p.parseArgs()
+		if err != nil {
+			return field, err
+		}
+		field.Args = args
+		p.skipSpace()
+	}
+
+	if p.pos < len(p.input) && p.input[p.pos] == '{' {
+		selections, err := p.parseSelectionSet()
+		if err != nil {
+			return field, err
+		}
+		field.Selections = selections
+	}
+	return field, nil
+}
+
+// parseArgs parses "( name: value ... )".
+func (p *gqlParser) parseArgs() (map[string]interface{}, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	args := make(map[string]interface{})
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("unterminated argument list")
+		}
+		if p.input[p.pos] == ')' {
+			p.pos++
+			break
+		}
+
+		name := p.readName()
+		if name == "" {
+			return nil, fmt.Errorf("expected an argument name at offset %d", p.pos)
+		}
+		if err := p.expect(':'); err != nil {
+			return nil, err
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		args[name] = value
+	}
+	return args, nil
+}
+
+// parseValue parses a scalar literal: string, number, or boolean.
+func (p *gqlParser) parseValue() (interface{}, error) {
+	p.skipSpace()
+	if p.pos >= len(p.input) {
+		return nil, fmt.Errorf("expected a value at offset %d", p.pos)
+	}
+
+	if p.input[p.pos] == '"' {
+		p.pos++
+		var builder strings.Builder
+		for p.pos < len(p.input) && p.input[p.pos] != '"' {
+			if p.input[p.pos] == '\\' && p.pos+1 < len(p.input) {
+				p.pos++
+			}
+			builder.WriteRune(p.input[p.pos])
+			p.pos++
+		}
+		if p.pos >= len(p.input) {
+			return nil, fmt.Errorf("unterminated string literal")
+		}
+		p.pos++
+		return builder.String(), nil
+	}
+
+	start := p.pos
+	for p.pos < len(p.input) {
+		r := p.input[p.pos]
+		if unicode.IsSpace(r) || r == ',' || r == ')' {
+			break
+		}
+		p.pos++
+	}
+	raw := string(p.input[start:p.pos])
+	switch raw {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	if number, err := strconv.ParseFloat(raw, 64); err == nil {
+		return number, nil
+	}
+	return nil, fmt.Errorf("unsupported value %q at offset %d", raw, start)
+}
+
+// intArg reads a numeric argument, defaulting when absent.
+func (f gqlField) intArg(name string, fallback int) int {
+	if number, ok := f.Args[name].(float64); ok {
+		return int(number)
+	}
+	return fallback
+}
+
+// stringArg reads a string argument, or "".
+func (f gqlField) stringArg(name string) string {
+	value, _ := f.Args[name].(string)
+	return value
+}
+
+// QueryGraph executes a GraphQL query over the orchestration graph and
+// returns the data tree. Root fields: agents, tasks, conversations, and
+// memories.
+func (e *Engine) QueryGraph(ctx context.Context, query string) (map[string]interface{}, error) {
+	selections, err := parseGraphQLQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{}, len(selections))
+	for _, field := range selections {
+		switch field.Name {
+		case "agents":
+			data[field.Name], err = e.resolveAgents(ctx, field)
+		case "tasks":
+			data[field.Name], err = e.resolveTasks(ctx, field)
+		case "conversations":
+			data[field.Name], err = e.resolveConversations(ctx, field, field.stringArg("agent_id"))
+		case "memories":
+			data[field.Name], err = e.resolveMemories(e.GetIdentityBridge(), field)
+		default:
+			err = fmt.Errorf("unknown root field %q", field.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// resolveAgents serves the agents root field, with optional id, type, and
+// limit arguments.
+func (e *Engine) resolveAgents(ctx context.Context, field gqlField) (interface{}, error) {
+	if len(field.Selections) == 0 {
+		return nil, fmt.Errorf("field %q requires a selection set", field.Name)
+	}
+
+	agents, err := e.ListAgents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(agents, func(a, b int) bool { return agents[a].Name < agents[b].Name })
+
+	id := field.stringArg("id")
+	agentType := field.stringArg("type")
+	limit := field.intArg("limit", 0)
+
+	resolved := make([]map[string]interface{}, 0, len(agents))
+	for _, agent := range agents {
+		if id != "" && agent.ID != id {
+			continue
+		}
+		if agentType != "" && string(agent.Type) != agentType {
+			continue
+		}
+		node, err := e.resolveAgent(ctx, agent, field.Selections)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, node)
+		if limit > 0 && len(resolved) >= limit {
+			break
+		}
+	}
+	return resolved, nil
+}
+
+// resolveAgent projects one agent through a selection set.
+func (e *Engine) resolveAgent(ctx context.Context, agent *Agent, selections []gqlField) (map[string]interface{}, error) {
+	scalars := map[string]interface{}{
+		"id":          agent.ID,
+		"name":        agent.Name,
+		"description": agent.Description,
+		"type":        string(agent.Type),
+		"models":      agent.Models,
+		"tools":       agent.Tools,
+		"persona":     agent.Persona,
+		"owner":       agent.Owner,
+		"created_at":  agent.CreatedAt,
+		"updated_at":  agent.UpdatedAt,
+	}
+
+	node := make(map[string]interface{}, len(selections))
+	for _, selection := range selections {
+		switch selection.Name {
+		case "tasks":
+			tasks, err := e.ListTasks(ctx, TaskFilter{
+				AgentID: agent.ID,
+				Status:  selection.stringArg("status"),
+				Limit:   selection.intArg("limit", 0),
+			})
+			if err != nil {
+				return nil, err
+			}
+			resolved, err := resolveTaskList(tasks, selection)
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = resolved
+		case "conversations":
+			resolved, err := e.resolveConversations(ctx, selection, agent.ID)
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = resolved
+		case "memories":
+			resolved, err := e.resolveMemories(e.GetAgentIdentity(agent.ID), selection)
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = resolved
+		default:
+			value, err := pickScalar(scalars, selection, "Agent")
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = value
+		}
+	}
+	return node, nil
+}
+
+// resolveTasks serves the tasks root field, with optional status,
+// agent_id, type, and limit arguments.
+func (e *Engine) resolveTasks(ctx context.Context, field gqlField) (interface{}, error) {
+	tasks, err := e.ListTasks(ctx, TaskFilter{
+		Status:  field.stringArg("status"),
+		AgentID: field.stringArg("agent_id"),
+		Type:    field.stringArg("type"),
+		Limit:   field.intArg("limit", 0),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resolveTaskList(tasks, field)
+}
+
+// resolveTaskList projects tasks through a selection set.
+func resolveTaskList(tasks []*Task, field gqlField) (interface{}, error) {
+	if len(field.Selections) == 0 {
+		return nil, fmt.Errorf("field %q requires a selection set", field.Name)
+	}
+
+	resolved := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		scalars := map[string]interface{}{
+			"id":         task.ID,
+			"type":       task.Type,
+			"input":      task.Input,
+			"output":     task.Output,
+			"status":     task.Status,
+			"agent_id":   task.AgentID,
+			"model_name": task.ModelName,
+			"error":      task.Error,
+			"created_at": task.CreatedAt,
+		}
+		node := make(map[string]interface{}, len(field.Selections))
+		for _, selection := range field.Selections {
+			value, err := pickScalar(scalars, selection, "Task")
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = value
+		}
+		resolved = append(resolved, node)
+	}
+	return resolved, nil
+}
+
+// resolveConversations projects conversations through a selection set,
+// optionally restricted to one agent's conversations.
+func (e *Engine) resolveConversations(ctx context.Context, field gqlField, agentID string) (interface{}, error) {
+	if len(field.Selections) == 0 {
+		return nil, fmt.Errorf("field %q requires a selection set", field.Name)
+	}
+
+	conversations, err := e.ListConversations(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(conversations, func(a, b int) bool {
+		return conversations[a].UpdatedAt.After(conversations[b].UpdatedAt)
+	})
+
+	status := field.stringArg("status")
+	limit := field.intArg("limit", 0)
+
+	resolved := make([]map[string]interface{}, 0, len(conversations))
+	for _, conversation := range conversations {
+		if status != "" && string(conversation.Status) != status {
+			continue
+		}
+		scalars := map[string]interface{}{
+			"id":           conversation.ID,
+			"topic":        conversation.Topic,
+			"status":       string(conversation.Status),
+			"participants": conversation.Participants,
+			"created_at":   conversation.CreatedAt,
+			"updated_at":   conversation.UpdatedAt,
+		}
+		node := make(map[string]interface{}, len(field.Selections))
+		for _, selection := range field.Selections {
+			if selection.Name == "messages" {
+				messages, err := resolveMessages(conversation, selection)
+				if err != nil {
+					return nil, err
+				}
+				node[selection.Name] = messages
+				continue
+			}
+			value, err := pickScalar(scalars, selection, "Conversation")
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = value
+		}
+		resolved = append(resolved, node)
+		if limit > 0 && len(resolved) >= limit {
+			break
+		}
+	}
+	return resolved, nil
+}
+
+// resolveMessages projects a conversation's messages, newest last, capped
+// by the limit argument.
+func resolveMessages(conversation *Conversation, field gqlField) (interface{}, error) {
+	if len(field.Selections) == 0 {
+		return nil, fmt.Errorf("field %q requires a selection set", field.Name)
+	}
+
+	messages := conversation.Messages
+	if limit := field.intArg("limit", 0); limit > 0 && len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	resolved := make([]map[string]interface{}, 0, len(messages))
+	for _, message := range messages {
+		scalars := map[string]interface{}{
+			"id":            message.ID,
+			"from_agent_id": message.FromAgentID,
+			"to_agent_id":   message.ToAgentID,
+			"content":       message.Content,
+			"type":          string(message.Type),
+			"timestamp":     message.Timestamp,
+		}
+		node := make(map[string]interface{}, len(field.Selections))
+		for _, selection := range field.Selections {
+			value, err := pickScalar(scalars, selection, "Message")
+			if err != nil {
+				return nil, err
+			}
+			node[selection.Name] = value
+		}
+		resolved = append(resolved, node)
+	}
+	return resolved, nil
+}
+
+// resolveMemories projects an identity's memory nodes, newest first,
+// capped by the limit argument.
+func (e *Engine) resolveMemories(bridge *IdentityBridge, field gqlField) (interface{}, error) {
+	if len(field.Selections) == 0 {
+		return nil, fmt.Errorf("field %q requires a selection set", field.Name)
+	}
+
+	resolved := make([]map[string]interface{}, 0)
+	if bridge == nil {
+		return resolved, nil
+	}
+
+	nodes := bridge.Identity().MemoriesBetween(time.Time{}, time.Time{}, field.intArg("limit", 0))
+	for _, node := range nodes {
+		scalars := map[string]interface{}{
+			"id":        node.ID,
+			"content":   fmt.Sprintf("%v", node.Content),
+			"strength":  node.Strength,
+			"resonance": node.Resonance,
+			"timestamp": node.Timestamp,
+		}
+		projected := make(map[string]interface{}, len(field.Selections))
+		for _, selection := range field.Selections {
+			value, err := pickScalar(scalars, selection, "Memory")
+			if err != nil {
+				return nil, err
+			}
+			projected[selection.Name] = value
+		}
+		resolved = append(resolved, projected)
+	}
+	return resolved, nil
+}
+
+// pickScalar selects one scalar field from an object's value map,
+// rejecting unknown fields and selections on scalars.
+func pickScalar(scalars map[string]interface{}, selection gqlField, typeName string) (interface{}, error) {
+	value, exists := scalars[selection.Name]
+	if !exists {
+		return nil, fmt.Errorf("unknown field %q on %s", selection.Name, typeName)
+	}
+	if len(selection.Selections) > 0 {
+		return nil, fmt.Errorf("field %q on %s is a scalar and takes no selection set", selection.Name, typeName)
+	}
+	return value, nil
+}
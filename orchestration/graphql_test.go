@@ -0,0 +1,142 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseGraphQLQuery(t *testing.T) {
+	selections, err := parseGraphQLQuery(`query Dashboard {
+		# the agents card
+		agents(limit: 5, type: "general") {
+			id name
+			tasks(limit: 3) { id status }
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("parseGraphQLQuery failed: %v", err)
+	}
+	if len(selections) != 1 || selections[0].Name != "agents" {
+		t.Fatalf("Unexpected selections: %+v", selections)
+	}
+	agents := selections[0]
+	if agents.intArg("limit", 0) != 5 || agents.stringArg("type") != "general" {
+		t.Errorf("Unexpected arguments: %+v", agents.Args)
+	}
+	if len(agents.Selections) != 3 || agents.Selections[2].Name != "tasks" {
+		t.Errorf("Unexpected sub-selections: %+v", agents.Selections)
+	}
+
+	if _, err := parseGraphQLQuery(`{ agents { id }`); err == nil {
+		t.Error("Expected an error for an unterminated selection set")
+	}
+	if _, err := parseGraphQLQuery(`{ }`); err == nil {
+		t.Error("Expected an error for an empty selection set")
+	}
+	if _, err := parseGraphQLQuery(`{ agents(limit: $n) { id } }`); err == nil {
+		t.Error("Expected an error for an unsupported value")
+	}
+}
+
+func TestQueryGraphResolvesNestedView(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		task := &Task{Type: TaskTypeCustom, Input: "work", AgentID: agent.ID}
+		if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+			t.Fatalf("ExecuteTask failed: %v", err)
+		}
+	}
+	if _, err := engine.StartConversation(ctx, []string{agent.ID}, "status"); err != nil {
+		t.Fatalf("StartConversation failed: %v", err)
+	}
+
+	data, err := engine.QueryGraph(ctx, `{
+		agents {
+			name
+			tasks(limit: 1) { id status }
+			conversations(status: "active") { topic }
+		}
+	}`)
+	if err != nil {
+		t.Fatalf("QueryGraph failed: %v", err)
+	}
+
+	agents, ok := data["agents"].([]map[string]interface{})
+	if !ok || len(agents) != 1 {
+		t.Fatalf("Unexpected agents payload: %+v", data)
+	}
+	if agents[0]["name"] != agent.Name {
+		t.Errorf("Unexpected agent name: %v", agents[0]["name"])
+	}
+	tasks, ok := agents[0]["tasks"].([]map[string]interface{})
+	if !ok || len(tasks) != 1 || tasks[0]["status"] != TaskStatusCompleted {
+		t.Errorf("Unexpected tasks payload: %+v", agents[0]["tasks"])
+	}
+	conversations, ok := agents[0]["conversations"].([]map[string]interface{})
+	if !ok || len(conversations) != 1 || conversations[0]["topic"] != "status" {
+		t.Errorf("Unexpected conversations payload: %+v", agents[0]["conversations"])
+	}
+
+	// Unknown fields are rejected rather than silently dropped
+	if _, err := engine.QueryGraph(ctx, `{ agents { nme } }`); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+	if _, err := engine.QueryGraph(ctx, `{ agents }`); err == nil {
+		t.Error("Expected an error for an object field without selections")
+	}
+}
+
+func TestGraphQLEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := NewEngine(api.Client{})
+	server := NewAPIServer(engine)
+
+	if _, err := engine.CreateDefaultAgent(context.Background()); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/graphql/",
+		strings.NewReader(`{"query": "{ agents { id name } }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	server.router.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200 from the GraphQL endpoint, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	var response struct {
+		Data struct {
+			Agents []map[string]interface{} `json:"agents"`
+		} `json:"data"`
+		Errors []map[string]interface{} `json:"errors"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Errors) != 0 || len(response.Data.Agents) != 1 {
+		t.Errorf("Unexpected response: %s", recorder.Body)
+	}
+
+	// Resolution errors arrive in the body, per the GraphQL convention
+	bad := httptest.NewRecorder()
+	badReq := httptest.NewRequest(http.MethodPost, "/api/graphql/",
+		strings.NewReader(`{"query": "{ nonsense { id } }"}`))
+	badReq.Header.Set("Content-Type", "application/json")
+	server.router.ServeHTTP(bad, badReq)
+	if bad.Code != http.StatusOK || !strings.Contains(bad.Body.String(), "unknown root field") {
+		t.Errorf("Expected an in-body GraphQL error, got %d: %s", bad.Code, bad.Body)
+	}
+}
@@ -0,0 +1,82 @@
+package orchestration
+
+import (
+	"fmt"
+	"time"
+
+	coredte "github.com/EchoCog/echollama/core/deeptreeecho"
+)
+
+// IdentityBridge adapts a core deeptreeecho.Identity to the orchestration
+// engine. Instead of maintaining parallel cognitive structs, the engine feeds
+// its activity (task executions, reflections, introspections) through the
+// Identity's cognitive process and reads coherence and memory state back from
+// it for status reporting.
+type IdentityBridge struct {
+	identity *coredte.Identity
+}
+
+// IdentitySnapshot is the bridge's read-side view of the Identity, consumed
+// by the engine's telemetry snapshot.
+type IdentitySnapshot struct {
+	Coherence       float64 `json:"coherence"`
+	MemoryNodes     int     `json:"memory_nodes"`
+	MemoryEdges     int     `json:"memory_edges"`
+	MemoryCoherence float64 `json:"memory_coherence"`
+}
+
+// NewIdentityBridge creates a bridge around a fresh core Identity.
+func NewIdentityBridge(name string) *IdentityBridge {
+	return &IdentityBridge{identity: coredte.NewIdentity(name)}
+}
+
+// NewIdentityBridgeWithLimits creates a bridge around a fresh core Identity
+// with explicit reservoir and stream limits, used for per-agent instances.
+func NewIdentityBridgeWithLimits(name string, reservoirSize, streamDepth int) *IdentityBridge {
+	return &IdentityBridge{identity: coredte.NewIdentityWithLimits(name, reservoirSize, streamDepth)}
+}
+
+// Identity exposes the underlying core Identity for direct use.
+func (b *IdentityBridge) Identity() *coredte.Identity {
+	return b.identity
+}
+
+// Snapshot reads the Identity's current coherence and memory state.
+func (b *IdentityBridge) Snapshot() IdentitySnapshot {
+	nodes, edges, memoryCoherence := b.identity.MemoryStats()
+	return IdentitySnapshot{
+		Coherence:       b.identity.CoherenceLevel(),
+		MemoryNodes:     nodes,
+		MemoryEdges:     edges,
+		MemoryCoherence: memoryCoherence,
+	}
+}
+
+// ReservoirDynamics reads the Identity's compressed reservoir trace since a
+// point in time, oldest first.
+func (b *IdentityBridge) ReservoirDynamics(since time.Time, limit int) []coredte.ReservoirSample {
+	return b.identity.ReservoirDynamics(since, limit)
+}
+
+// ObserveTask runs a completed task's input through the Identity's cognitive
+// process so coherence and memory evolve with real engine activity.
+func (b *IdentityBridge) ObserveTask(task *Task) {
+	b.identity.Process(task.Input)
+}
+
+// ObserveReflection stores a reflection outcome in the Identity's memory.
+func (b *IdentityBridge) ObserveReflection(agentID, output string) {
+	b.identity.Remember(fmt.Sprintf("reflection:%s", agentID), output)
+}
+
+// ObserveIntrospection stores an introspection prompt in the Identity's
+// memory and processes it cognitively.
+func (b *IdentityBridge) ObserveIntrospection(prompt string) {
+	b.identity.Remember("introspection:last", prompt)
+	b.identity.Process(prompt)
+}
+
+// GetIdentityBridge returns the engine's core Identity adapter.
+func (e *Engine) GetIdentityBridge() *IdentityBridge {
+	return e.identityBridge
+}
@@ -0,0 +1,137 @@
+package orchestration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestIdentityBridgeObservesTasks(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	bridge := engine.GetIdentityBridge()
+	if bridge == nil || bridge.Identity() == nil {
+		t.Fatal("Expected engine to hold a core Identity bridge")
+	}
+	before := bridge.Snapshot()
+
+	agent := &Agent{Name: "embodied", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	task := &Task{ID: "identity-task", Type: TaskTypeCustom, Input: "observe this", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+
+	after := bridge.Snapshot()
+	if after.MemoryNodes <= before.MemoryNodes {
+		t.Errorf("Expected identity memory to grow after task execution, got %d -> %d", before.MemoryNodes, after.MemoryNodes)
+	}
+	if after.Coherence <= 0 || after.Coherence > 1 {
+		t.Errorf("Expected coherence in (0, 1], got %f", after.Coherence)
+	}
+}
+
+func TestTelemetryReadsIdentityCoherence(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	snapshot := engine.telemetrySnapshot()
+	identity := engine.GetIdentityBridge().Snapshot()
+	if snapshot.IdentityCoherence != identity.Coherence {
+		t.Errorf("Expected telemetry coherence %f to come from the Identity, got %f", identity.Coherence, snapshot.IdentityCoherence)
+	}
+	if snapshot.MemoryCoherence != identity.MemoryCoherence {
+		t.Errorf("Expected telemetry memory coherence %f from the Identity, got %f", identity.MemoryCoherence, snapshot.MemoryCoherence)
+	}
+}
+
+func TestReservoirDynamicsTraceAndPersistence(t *testing.T) {
+	bridge := NewIdentityBridge("reservoir")
+	identity := bridge.Identity()
+
+	for _, input := range []string{"one", "two", "three"} {
+		if _, err := identity.Process(input); err != nil {
+			t.Fatalf("Process failed: %v", err)
+		}
+	}
+
+	samples := bridge.ReservoirDynamics(time.Time{}, 0)
+	if len(samples) != 3 {
+		t.Fatalf("Expected 3 reservoir samples, got %d", len(samples))
+	}
+	if samples[0].Iteration >= samples[2].Iteration {
+		t.Errorf("Expected samples ordered oldest first, got iterations %d and %d", samples[0].Iteration, samples[2].Iteration)
+	}
+
+	if limited := bridge.ReservoirDynamics(time.Time{}, 2); len(limited) != 2 {
+		t.Errorf("Expected limit to keep the 2 most recent samples, got %d", len(limited))
+	}
+
+	path := filepath.Join(t.TempDir(), "reservoir.json")
+	if err := identity.SaveReservoirHistory(path); err != nil {
+		t.Fatalf("SaveReservoirHistory failed: %v", err)
+	}
+
+	restored := NewIdentityBridge("restored")
+	if err := restored.Identity().LoadReservoirHistory(path); err != nil {
+		t.Fatalf("LoadReservoirHistory failed: %v", err)
+	}
+	if got := restored.ReservoirDynamics(time.Time{}, 0); len(got) != 3 {
+		t.Errorf("Expected 3 samples after restore, got %d", len(got))
+	}
+}
+
+func TestIdentityMemoryTraversal(t *testing.T) {
+	bridge := NewIdentityBridge("traversal")
+	identity := bridge.Identity()
+
+	// Remembered keys join the temporal chain, so consecutive memories are
+	// linked and traversable.
+	identity.Remember("first", "alpha")
+	identity.Remember("second", "beta")
+	identity.Remember("third", "gamma")
+
+	path, cost, err := identity.ShortestPath("first", "third")
+	if err != nil {
+		t.Fatalf("ShortestPath failed: %v", err)
+	}
+	if len(path) < 2 || path[0] != "first" || path[len(path)-1] != "third" {
+		t.Errorf("Expected a path from first to third, got %v", path)
+	}
+	if cost <= 0 {
+		t.Errorf("Expected a positive path cost, got %f", cost)
+	}
+
+	related := identity.RecallRelated("second", 5)
+	if len(related) == 0 {
+		t.Fatal("Expected spreading activation to reach neighboring memories")
+	}
+	found := false
+	for _, node := range related {
+		if node.ID == "first" || node.ID == "third" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected temporally linked memories in recall results, got %v", related)
+	}
+
+	if _, _, err := identity.ShortestPath("first", "missing"); err == nil {
+		t.Error("Expected an error for an unknown node")
+	}
+}
+
+func TestIdentityBridgeRemembersReflections(t *testing.T) {
+	bridge := NewIdentityBridge("test-identity")
+
+	bridge.ObserveReflection("agent-1", "learned to summarize better")
+	recalled := bridge.Identity().Recall("reflection:agent-1")
+	if recalled != "learned to summarize better" {
+		t.Errorf("Expected reflection recalled from identity memory, got %v", recalled)
+	}
+}
@@ -0,0 +1,98 @@
+package orchestration
+
+import (
+	"context"
+	"time"
+)
+
+// Integration statuses and health values reported by probes.
+const (
+	IntegrationConnected    = "connected"
+	IntegrationDisconnected = "disconnected"
+
+	IntegrationHealthGood     = "good"
+	IntegrationHealthDegraded = "degraded"
+	IntegrationHealthOffline  = "offline"
+)
+
+// IntegrationProbe reports the live status of one external system. Probes run
+// on every status refresh, so they should read cached state rather than make
+// network calls.
+type IntegrationProbe func(ctx context.Context) IntegrationStatus
+
+// RegisterIntegration adds or replaces a named integration probe. The probe's
+// result appears in Integrations on the next status refresh.
+func (dte *DeepTreeEcho) RegisterIntegration(name string, probe IntegrationProbe) {
+	if dte.integrationProbes == nil {
+		dte.integrationProbes = make(map[string]IntegrationProbe)
+	}
+	dte.integrationProbes[name] = probe
+}
+
+// refreshIntegrations replaces the integration map with live probe results.
+// It returns false when no probes are registered, in which case the caller
+// falls back to the static defaults.
+func (dte *DeepTreeEcho) refreshIntegrations(ctx context.Context) bool {
+	if len(dte.integrationProbes) == 0 {
+		return false
+	}
+
+	now := time.Now()
+	integrations := make(map[string]IntegrationStatus, len(dte.integrationProbes))
+	for name, probe := range dte.integrationProbes {
+		status := probe(ctx)
+		status.LastChecked = now
+		integrations[name] = status
+	}
+	dte.Integrations = integrations
+	return true
+}
+
+// registerEngineIntegrations wires the engine's subsystems into the DTE
+// integration registry so the dashboard reflects real connectivity. Probes
+// read engine state directly: RefreshDeepTreeEchoStatus holds e.mu while
+// they run.
+func (e *Engine) registerEngineIntegrations() {
+	e.deepTreeEcho.RegisterIntegration("Provider", func(ctx context.Context) IntegrationStatus {
+		telemetry := e.telemetrySnapshot()
+		finished := telemetry.TasksExecuted + telemetry.TasksFailed
+		switch {
+		case finished > 0 && telemetry.TasksExecuted == 0:
+			return IntegrationStatus{Status: IntegrationDisconnected, Health: IntegrationHealthOffline}
+		case finished > 0 && float64(telemetry.TasksFailed)/float64(finished) > 0.5:
+			return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthDegraded}
+		default:
+			return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthGood}
+		}
+	})
+
+	e.deepTreeEcho.RegisterIntegration("VectorStore", func(ctx context.Context) IntegrationStatus {
+		if e.knowledge == nil {
+			return IntegrationStatus{Status: IntegrationDisconnected, Health: IntegrationHealthOffline}
+		}
+		return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthGood}
+	})
+
+	e.deepTreeEcho.RegisterIntegration("TaskQueue", func(ctx context.Context) IntegrationStatus {
+		if e.taskQueue == nil {
+			// Without a shared queue tasks execute in-process, which is a
+			// valid single-instance configuration rather than a fault.
+			return IntegrationStatus{Status: IntegrationDisconnected, Health: IntegrationHealthGood}
+		}
+		return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthGood}
+	})
+
+	e.deepTreeEcho.RegisterIntegration("EventBus", func(ctx context.Context) IntegrationStatus {
+		if e.eventPublisher == nil {
+			return IntegrationStatus{Status: IntegrationDisconnected, Health: IntegrationHealthGood}
+		}
+		return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthGood}
+	})
+
+	e.deepTreeEcho.RegisterIntegration("Federation", func(ctx context.Context) IntegrationStatus {
+		if len(e.federationPeers) == 0 {
+			return IntegrationStatus{Status: IntegrationDisconnected, Health: IntegrationHealthGood}
+		}
+		return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthGood}
+	})
+}
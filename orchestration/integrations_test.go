@@ -0,0 +1,80 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestEngineIntegrationsReportLiveStatus(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	integrations := engine.GetDeepTreeEcho().Integrations
+	for _, name := range []string{"Provider", "VectorStore", "TaskQueue", "EventBus", "Federation"} {
+		if _, ok := integrations[name]; !ok {
+			t.Errorf("Expected integration '%s' in status map", name)
+		}
+	}
+	if provider := integrations["Provider"]; provider.Status != IntegrationConnected {
+		t.Errorf("Expected provider connected with no failures, got %s", provider.Status)
+	}
+	if queue := integrations["TaskQueue"]; queue.Status != IntegrationDisconnected {
+		t.Errorf("Expected task queue disconnected without a shared queue, got %s", queue.Status)
+	}
+	if _, ok := integrations["KoboldCpp"]; ok {
+		t.Error("Static placeholder integrations should be replaced by live probes")
+	}
+}
+
+func TestRegisterIntegrationProbe(t *testing.T) {
+	dte := NewDeepTreeEcho("Test DTE System")
+	ctx := context.Background()
+
+	dte.RegisterIntegration("Custom", func(ctx context.Context) IntegrationStatus {
+		return IntegrationStatus{Status: IntegrationConnected, Health: IntegrationHealthGood}
+	})
+
+	if err := dte.RefreshStatus(ctx); err != nil {
+		t.Fatalf("RefreshStatus failed: %v", err)
+	}
+
+	custom, ok := dte.Integrations["Custom"]
+	if !ok {
+		t.Fatal("Expected registered probe to appear in integrations")
+	}
+	if custom.Status != IntegrationConnected || custom.Health != IntegrationHealthGood {
+		t.Errorf("Unexpected probe result: %+v", custom)
+	}
+	if custom.LastChecked.IsZero() {
+		t.Error("Expected LastChecked to be stamped")
+	}
+}
+
+func TestProviderIntegrationGoesOfflineOnFailures(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	agent := &Agent{Name: "offline", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	failing := &Task{ID: "integration-failure", Type: TaskTypeGenerate, Input: "fails", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, failing, agent); err == nil {
+		t.Fatal("Expected generate task to fail against unreachable provider")
+	}
+
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	provider := engine.GetDeepTreeEcho().Integrations["Provider"]
+	if provider.Status != IntegrationDisconnected || provider.Health != IntegrationHealthOffline {
+		t.Errorf("Expected provider disconnected/offline after total failure, got %s/%s", provider.Status, provider.Health)
+	}
+}
@@ -0,0 +1,143 @@
+package orchestration
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"sort"
+)
+
+// InteractionGraph is a queryable snapshot of emergent multi-agent behavior:
+// which agents talked to whom, which tools and models they used, and which
+// memories they formed. It is built on demand from the engine's live state
+// and exportable as GraphML for external graph tooling.
+type InteractionGraph struct {
+	Nodes []InteractionNode `json:"nodes"`
+	Edges []InteractionEdge `json:"edges"`
+}
+
+// InteractionNode is a vertex in the interaction graph.
+type InteractionNode struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"` // agent, model, tool, conversation, memory
+	Label string `json:"label"`
+}
+
+// InteractionEdge is a directed, weighted edge in the interaction graph.
+type InteractionEdge struct {
+	From   string  `json:"from"`
+	To     string  `json:"to"`
+	Type   string  `json:"type"` // talked_to, uses_model, uses_tool, participates, formed_memory
+	Weight float64 `json:"weight"`
+}
+
+// BuildInteractionGraph assembles the interaction graph from the engine's
+// current agents, conversations, and agent memories.
+func (e *Engine) BuildInteractionGraph(ctx context.Context) *InteractionGraph {
+	graph := &InteractionGraph{
+		Nodes: make([]InteractionNode, 0),
+		Edges: make([]InteractionEdge, 0),
+	}
+	seen := make(map[string]bool)
+
+	addNode := func(id, nodeType, label string) {
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		graph.Nodes = append(graph.Nodes, InteractionNode{ID: id, Type: nodeType, Label: label})
+	}
+
+	for _, agent := range e.agentsSnapshot() {
+		agentNode := "agent:" + agent.ID
+		addNode(agentNode, "agent", agent.Name)
+
+		for _, model := range agent.Models {
+			modelNode := "model:" + model
+			addNode(modelNode, "model", model)
+			graph.Edges = append(graph.Edges, InteractionEdge{From: agentNode, To: modelNode, Type: "uses_model", Weight: 1})
+		}
+		for _, tool := range agent.Tools {
+			toolNode := "tool:" + tool
+			addNode(toolNode, "tool", tool)
+			graph.Edges = append(graph.Edges, InteractionEdge{From: agentNode, To: toolNode, Type: "uses_tool", Weight: 1})
+		}
+		if agent.State != nil {
+			for key := range agent.State.Memory {
+				memoryNode := fmt.Sprintf("memory:%s:%s", agent.ID, key)
+				addNode(memoryNode, "memory", key)
+				graph.Edges = append(graph.Edges, InteractionEdge{From: agentNode, To: memoryNode, Type: "formed_memory", Weight: 1})
+			}
+		}
+	}
+
+	for _, conversation := range e.conversationsSnapshot() {
+		conversationNode := "conversation:" + conversation.ID
+		addNode(conversationNode, "conversation", conversation.Topic)
+
+		for _, participant := range conversation.Participants {
+			graph.Edges = append(graph.Edges, InteractionEdge{From: "agent:" + participant, To: conversationNode, Type: "participates", Weight: 1})
+		}
+
+		// Aggregate message traffic into weighted talked_to edges
+		traffic := make(map[string]float64)
+		for _, message := range conversation.Messages {
+			if message.FromAgentID == "" || message.ToAgentID == "" {
+				continue
+			}
+			traffic[message.FromAgentID+"\x00"+message.ToAgentID]++
+		}
+		pairs := make([]string, 0, len(traffic))
+		for pair := range traffic {
+			pairs = append(pairs, pair)
+		}
+		sort.Strings(pairs)
+		for _, pair := range pairs {
+			from, to, _ := bytes.Cut([]byte(pair), []byte{0})
+			graph.Edges = append(graph.Edges, InteractionEdge{
+				From:   "agent:" + string(from),
+				To:     "agent:" + string(to),
+				Type:   "talked_to",
+				Weight: traffic[pair],
+			})
+		}
+	}
+
+	return graph
+}
+
+// GraphML serializes the graph in GraphML, with node type and label and edge
+// type and weight carried as standard GraphML data keys.
+func (g *InteractionGraph) GraphML() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString(xml.Header)
+	buffer.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	buffer.WriteString(`  <key id="type" for="node" attr.name="type" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="edgetype" for="edge" attr.name="type" attr.type="string"/>` + "\n")
+	buffer.WriteString(`  <key id="weight" for="edge" attr.name="weight" attr.type="double"/>` + "\n")
+	buffer.WriteString(`  <graph edgedefault="directed">` + "\n")
+
+	escape := func(value string) string {
+		var escaped bytes.Buffer
+		xml.EscapeText(&escaped, []byte(value))
+		return escaped.String()
+	}
+
+	for _, node := range g.Nodes {
+		buffer.WriteString(fmt.Sprintf("    <node id=\"%s\">\n", escape(node.ID)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"type\">%s</data>\n", escape(node.Type)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"label\">%s</data>\n", escape(node.Label)))
+		buffer.WriteString("    </node>\n")
+	}
+	for _, edge := range g.Edges {
+		buffer.WriteString(fmt.Sprintf("    <edge source=\"%s\" target=\"%s\">\n", escape(edge.From), escape(edge.To)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"edgetype\">%s</data>\n", escape(edge.Type)))
+		buffer.WriteString(fmt.Sprintf("      <data key=\"weight\">%g</data>\n", edge.Weight))
+		buffer.WriteString("    </edge>\n")
+	}
+
+	buffer.WriteString("  </graph>\n</graphml>\n")
+	return buffer.Bytes(), nil
+}
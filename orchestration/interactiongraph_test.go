@@ -0,0 +1,92 @@
+package orchestration
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestBuildInteractionGraph(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	alpha := &Agent{Name: "alpha", Models: []string{"llama3.2"}, Tools: []string{"search"}}
+	beta := &Agent{Name: "beta", Models: []string{"llama3.2"}}
+	for _, agent := range []*Agent{alpha, beta} {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+
+	conversation, err := engine.StartConversation(ctx, []string{alpha.ID, beta.ID}, "audit")
+	if err != nil {
+		t.Fatalf("StartConversation failed: %v", err)
+	}
+	for n := 0; n < 2; n++ {
+		message := &Message{FromAgentID: alpha.ID, ToAgentID: beta.ID, Content: "ping", Type: MessageTypeNotification}
+		if err := engine.SendMessage(ctx, conversation.ID, message); err != nil {
+			t.Fatalf("SendMessage failed: %v", err)
+		}
+	}
+
+	graph := engine.BuildInteractionGraph(ctx)
+
+	types := make(map[string]int)
+	for _, node := range graph.Nodes {
+		types[node.Type]++
+	}
+	if types["agent"] != 2 || types["model"] != 1 || types["tool"] != 1 || types["conversation"] != 1 {
+		t.Errorf("Unexpected node type counts: %v", types)
+	}
+
+	var talked *InteractionEdge
+	for index, edge := range graph.Edges {
+		if edge.Type == "talked_to" {
+			talked = &graph.Edges[index]
+		}
+	}
+	if talked == nil {
+		t.Fatal("Expected a talked_to edge")
+	}
+	if talked.From != "agent:"+alpha.ID || talked.To != "agent:"+beta.ID || talked.Weight != 2 {
+		t.Errorf("Unexpected talked_to edge: %+v", talked)
+	}
+}
+
+func TestInteractionGraphGraphML(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "solo <&>", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	raw, err := engine.BuildInteractionGraph(ctx).GraphML()
+	if err != nil {
+		t.Fatalf("GraphML failed: %v", err)
+	}
+
+	if !strings.Contains(string(raw), "graphml.graphdrawing.org") {
+		t.Error("Expected the GraphML namespace in the export")
+	}
+	if !strings.Contains(string(raw), "solo &lt;&amp;&gt;") {
+		t.Errorf("Expected the agent label escaped, got:\n%s", raw)
+	}
+
+	// The export must be well-formed XML
+	decoder := xml.NewDecoder(bytes.NewReader(raw))
+	for {
+		if _, err := decoder.Token(); err != nil {
+			if err == io.EOF {
+				break
+			}
+			t.Fatalf("GraphML is not well-formed XML: %v", err)
+		}
+	}
+}
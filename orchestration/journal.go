@@ -0,0 +1,250 @@
+package orchestration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventJournal is an append-only write-ahead journal of engine events, one
+// JSON record per line. The engine appends before acknowledging the
+// operation that produced the event, so after a crash ReplayJournal can
+// reconstruct agents, tasks, and conversation traffic from the log.
+type EventJournal struct {
+	path string
+	file *os.File
+	mu   sync.Mutex
+}
+
+// OpenEventJournal opens (creating if needed) a journal at the given path.
+func OpenEventJournal(path string) (*EventJournal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event journal: %w", err)
+	}
+	return &EventJournal{path: path, file: file}, nil
+}
+
+// Append writes an event to the journal.
+func (j *EventJournal) Append(event *EngineEvent) error {
+	raw, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode journal event: %w", err)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.file.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("failed to append journal event: %w", err)
+	}
+	return nil
+}
+
+// ReadAll returns every journaled event, oldest first.
+func (j *EventJournal) ReadAll() ([]*EngineEvent, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := os.Open(j.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event journal: %w", err)
+	}
+	defer file.Close()
+
+	events := make([]*EngineEvent, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event EngineEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode journal event: %w", err)
+		}
+		events = append(events, &event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan event journal: %w", err)
+	}
+	return events, nil
+}
+
+// Tail returns the most recent events, oldest first, capped at limit.
+func (j *EventJournal) Tail(limit int) ([]*EngineEvent, error) {
+	events, err := j.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && len(events) > limit {
+		events = events[len(events)-limit:]
+	}
+	return events, nil
+}
+
+// Close releases the journal file.
+func (j *EventJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// SetEventJournal installs a write-ahead journal on the engine. Every
+// emitted event is journaled before publication, and agent lifecycle changes
+// are journaled before the engine state mutates.
+func (e *Engine) SetEventJournal(journal *EventJournal) {
+	e.journalMu.Lock()
+	defer e.journalMu.Unlock()
+	e.journal = journal
+}
+
+// GetEventJournal returns the configured journal, or nil.
+func (e *Engine) GetEventJournal() *EventJournal {
+	e.journalMu.RLock()
+	defer e.journalMu.RUnlock()
+	return e.journal
+}
+
+// journalEvent appends an event to the journal, if one is configured. It
+// uses its own lock, so it is safe to call whether or not e.mu is held.
+func (e *Engine) journalEvent(event *EngineEvent) {
+	journal := e.GetEventJournal()
+	if journal == nil {
+		return
+	}
+
+	if event.ID == "" {
+		event.ID = uuid.New().String()
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	if err := journal.Append(event); err != nil {
+		clog(context.Background()).Error("Failed to journal engine event", "error", err, "type", event.Type)
+	}
+}
+
+// ReplayJournal reconstructs engine state from a journal written by a
+// crashed instance, returning how many events were applied. Replay is
+// idempotent: records are keyed by their IDs, so replaying on top of
+// partially persisted state converges to the journaled view.
+func (e *Engine) ReplayJournal(ctx context.Context, journal *EventJournal) (int, error) {
+	events, err := journal.ReadAll()
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, event := range events {
+		switch event.Type {
+		case EventAgentCreated:
+			var agent Agent
+			if !decodePayload(event.Payload["agent"], &agent) {
+				continue
+			}
+			e.mu.Lock()
+			e.agents[agent.ID] = &agent
+			e.mu.Unlock()
+			if err := e.store.SaveAgent(ctx, &agent); err != nil {
+				return applied, fmt.Errorf("failed to restore agent %s: %w", agent.ID, err)
+			}
+		case EventAgentDeleted:
+			e.mu.Lock()
+			delete(e.agents, event.AgentID)
+			e.mu.Unlock()
+			e.store.DeleteAgent(ctx, event.AgentID)
+		case EventTaskStarted, EventTaskCompleted, EventTaskFailed:
+			var task Task
+			if !decodePayload(event.Payload["task"], &task) {
+				continue
+			}
+			e.mu.Lock()
+			e.tasks[task.ID] = &task
+			e.mu.Unlock()
+			if err := e.store.SaveTask(ctx, &task); err != nil {
+				return applied, fmt.Errorf("failed to restore task %s: %w", task.ID, err)
+			}
+		case EventConversationStarted:
+			topic, _ := event.Payload["topic"].(string)
+			e.mu.Lock()
+			conversation, exists := e.conversations[event.ConversationID]
+			if !exists {
+				// Merge into any placeholder a reordered message event
+				// may already have created
+				conversation = &Conversation{
+					ID:        event.ConversationID,
+					Status:    ConversationStatusActive,
+					CreatedAt: event.Timestamp,
+					UpdatedAt: event.Timestamp,
+				}
+				e.conversations[conversation.ID] = conversation
+			}
+			conversation.Topic = topic
+			decodePayload(event.Payload["participants"], &conversation.Participants)
+			e.mu.Unlock()
+			if err := e.store.SaveConversation(ctx, conversation); err != nil {
+				return applied, fmt.Errorf("failed to restore conversation %s: %w", conversation.ID, err)
+			}
+		case EventMessageSent:
+			var message Message
+			if !decodePayload(event.Payload["message"], &message) {
+				continue
+			}
+			e.mu.Lock()
+			conversation, exists := e.conversations[event.ConversationID]
+			if !exists {
+				// Events are journaled concurrently, so a message can
+				// precede its conversation.started record in the log
+				conversation = &Conversation{
+					ID:        event.ConversationID,
+					Status:    ConversationStatusActive,
+					CreatedAt: event.Timestamp,
+				}
+				e.conversations[conversation.ID] = conversation
+			}
+			if !conversationHasMessage(conversation, message.ID) {
+				conversation.Messages = append(conversation.Messages, message)
+				conversation.UpdatedAt = event.Timestamp
+			}
+			e.mu.Unlock()
+			if err := e.store.SaveConversation(ctx, conversation); err != nil {
+				return applied, fmt.Errorf("failed to restore conversation %s: %w", conversation.ID, err)
+			}
+		default:
+			// Telemetry-only events carry no state to restore
+			continue
+		}
+		applied++
+	}
+	return applied, nil
+}
+
+// decodePayload converts a journal payload value — a live struct when the
+// event was emitted in-process, or a generic map when read back from disk —
+// into the given record type.
+func decodePayload(value interface{}, out interface{}) bool {
+	if value == nil {
+		return false
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, out) == nil
+}
+
+// conversationHasMessage reports whether a message ID is already recorded.
+func conversationHasMessage(conversation *Conversation, id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, message := range conversation.Messages {
+		if message.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,314 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/google/uuid"
+)
+
+// Document represents an ingested knowledge document.
+type Document struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"content_type"`
+	ChunkCount  int       `json:"chunk_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// DocumentChunk is one embeddable slice of an ingested document.
+type DocumentChunk struct {
+	ID         string    `json:"id"`
+	DocumentID string    `json:"document_id"`
+	Index      int       `json:"index"`
+	Content    string    `json:"content"`
+	Embedding  []float64 `json:"-"`
+}
+
+// ScoredChunk pairs a chunk with its similarity to a query.
+type ScoredChunk struct {
+	Chunk *DocumentChunk `json:"chunk"`
+	Score float64        `json:"score"`
+}
+
+// VectorStore stores embedded document chunks and retrieves them by
+// similarity. Implementations must be safe for concurrent use.
+type VectorStore interface {
+	Add(ctx context.Context, chunks []*DocumentChunk) error
+	Search(ctx context.Context, embedding []float64, topK int) ([]*ScoredChunk, error)
+	DeleteDocument(ctx context.Context, documentID string) error
+}
+
+// InMemoryVectorStore is a brute-force cosine-similarity vector store for
+// single-instance deployments and tests.
+type InMemoryVectorStore struct {
+	chunks []*DocumentChunk
+	mu     sync.RWMutex
+}
+
+// NewInMemoryVectorStore creates an empty in-memory vector store.
+func NewInMemoryVectorStore() *InMemoryVectorStore {
+	return &InMemoryVectorStore{chunks: make([]*DocumentChunk, 0)}
+}
+
+// Add stores embedded chunks.
+func (s *InMemoryVectorStore) Add(ctx context.Context, chunks []*DocumentChunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunks = append(s.chunks, chunks...)
+	return nil
+}
+
+// Search returns the topK most similar chunks by cosine similarity.
+func (s *InMemoryVectorStore) Search(ctx context.Context, embedding []float64, topK int) ([]*ScoredChunk, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vectors := make([][]float64, len(s.chunks))
+	for index, chunk := range s.chunks {
+		vectors[index] = chunk.Embedding
+	}
+	scores := getSimilarityBackend().BatchCosineSimilarity(embedding, vectors)
+
+	scored := make([]*ScoredChunk, 0, len(s.chunks))
+	for index, chunk := range s.chunks {
+		scored = append(scored, &ScoredChunk{
+			Chunk: chunk,
+			Score: scores[index],
+		})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	if topK > 0 && len(scored) > topK {
+		scored = scored[:topK]
+	}
+	return scored, nil
+}
+
+// DeleteDocument removes all chunks belonging to a document.
+func (s *InMemoryVectorStore) DeleteDocument(ctx context.Context, documentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := s.chunks[:0]
+	for _, chunk := range s.chunks {
+		if chunk.DocumentID != documentID {
+			kept = append(kept, chunk)
+		}
+	}
+	s.chunks = kept
+	return nil
+}
+
+// cosineSimilarity computes the cosine similarity of two vectors, returning
+// 0 for mismatched or zero-length vectors.
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// KnowledgeBase grounds agents on user documents: it loads raw files,
+// chunks them, embeds each chunk, and serves similarity search over the
+// resulting vectors.
+type KnowledgeBase struct {
+	engine       *Engine
+	store        VectorStore
+	documents    map[string]*Document
+	chunkSize    int
+	chunkOverlap int
+	mu           sync.RWMutex
+}
+
+// NewKnowledgeBase creates a knowledge base backed by the given vector store.
+func NewKnowledgeBase(engine *Engine, store VectorStore) *KnowledgeBase {
+	return &KnowledgeBase{
+		engine:       engine,
+		store:        store,
+		documents:    make(map[string]*Document),
+		chunkSize:    1000,
+		chunkOverlap: 200,
+	}
+}
+
+// IngestDocument loads, chunks, and embeds a document so it becomes
+// retrievable. The content type is inferred from the file name when empty.
+func (kb *KnowledgeBase) IngestDocument(ctx context.Context, name, contentType string, data []byte, model string) (*Document, error) {
+	if contentType == "" {
+		contentType = contentTypeFromName(name)
+	}
+
+	text, err := LoadDocumentText(contentType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load document %s: %w", name, err)
+	}
+
+	chunks := ChunkText(text, kb.chunkSize, kb.chunkOverlap)
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("document %s contains no extractable text", name)
+	}
+
+	document := &Document{
+		ID:          uuid.New().String(),
+		Name:        name,
+		ContentType: contentType,
+		ChunkCount:  len(chunks),
+		CreatedAt:   time.Now(),
+	}
+
+	embedded := make([]*DocumentChunk, 0, len(chunks))
+	for i, content := range chunks {
+		embedding, err := kb.embedText(ctx, content, model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to embed chunk %d of %s: %w", i, name, err)
+		}
+		embedded = append(embedded, &DocumentChunk{
+			ID:         uuid.New().String(),
+			DocumentID: document.ID,
+			Index:      i,
+			Content:    content,
+			Embedding:  embedding,
+		})
+	}
+
+	if err := kb.store.Add(ctx, embedded); err != nil {
+		return nil, fmt.Errorf("failed to store document chunks: %w", err)
+	}
+
+	kb.mu.Lock()
+	kb.documents[document.ID] = document
+	kb.mu.Unlock()
+
+	return document, nil
+}
+
+// AddEmbedded registers pre-computed embeddings as a document, one chunk per
+// input text. Used by embed tasks that want their vectors to be searchable.
+func (kb *KnowledgeBase) AddEmbedded(ctx context.Context, name string, texts []string, embeddings [][]float64) (*Document, error) {
+	if len(texts) == 0 || len(texts) != len(embeddings) {
+		return nil, fmt.Errorf("mismatched texts and embeddings: %d vs %d", len(texts), len(embeddings))
+	}
+
+	document := &Document{
+		ID:         uuid.New().String(),
+		Name:       name,
+		ChunkCount: len(texts),
+		CreatedAt:  time.Now(),
+	}
+
+	chunks := make([]*DocumentChunk, 0, len(texts))
+	for i, content := range texts {
+		chunks = append(chunks, &DocumentChunk{
+			ID:         uuid.New().String(),
+			DocumentID: document.ID,
+			Index:      i,
+			Content:    content,
+			Embedding:  embeddings[i],
+		})
+	}
+
+	if err := kb.store.Add(ctx, chunks); err != nil {
+		return nil, fmt.Errorf("failed to store embedded chunks: %w", err)
+	}
+
+	kb.mu.Lock()
+	kb.documents[document.ID] = document
+	kb.mu.Unlock()
+
+	return document, nil
+}
+
+// Search embeds the query and returns the topK most relevant chunks.
+func (kb *KnowledgeBase) Search(ctx context.Context, query, model string, topK int) ([]*ScoredChunk, error) {
+	if topK <= 0 {
+		topK = 5
+	}
+
+	embedding, err := kb.embedText(ctx, query, model)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	return kb.store.Search(ctx, embedding, topK)
+}
+
+// ListDocuments returns all ingested documents.
+func (kb *KnowledgeBase) ListDocuments() []*Document {
+	kb.mu.RLock()
+	defer kb.mu.RUnlock()
+
+	documents := make([]*Document, 0, len(kb.documents))
+	for _, document := range kb.documents {
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+// DeleteDocument removes a document and its chunks from the store.
+func (kb *KnowledgeBase) DeleteDocument(ctx context.Context, id string) error {
+	kb.mu.Lock()
+	_, exists := kb.documents[id]
+	delete(kb.documents, id)
+	kb.mu.Unlock()
+
+	if !exists {
+		return fmt.Errorf("%w: document %s", ErrNotFound, id)
+	}
+	return kb.store.DeleteDocument(ctx, id)
+}
+
+// embedText produces an embedding vector for the given text.
+func (kb *KnowledgeBase) embedText(ctx context.Context, text, model string) ([]float64, error) {
+	if model == "" {
+		return nil, fmt.Errorf("%w: no embedding model specified", ErrModelUnavailable)
+	}
+
+	resp, err := kb.engine.coalescedEmbeddings(ctx, &api.EmbeddingRequest{
+		Model:  model,
+		Prompt: text,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Embedding, nil
+}
+
+// contentTypeFromName infers a document content type from its file extension.
+func contentTypeFromName(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".pdf":
+		return "application/pdf"
+	case ".html", ".htm":
+		return "text/html"
+	case ".md", ".markdown":
+		return "text/markdown"
+	default:
+		return "text/plain"
+	}
+}
+
+// GetKnowledgeBase returns the engine's knowledge base.
+func (e *Engine) GetKnowledgeBase() *KnowledgeBase {
+	return e.knowledge
+}
@@ -0,0 +1,167 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestChunkTextShortInput(t *testing.T) {
+	chunks := ChunkText("short text", 1000, 200)
+	if len(chunks) != 1 || chunks[0] != "short text" {
+		t.Errorf("Expected single chunk with original text, got %v", chunks)
+	}
+}
+
+func TestChunkTextOverlappingChunks(t *testing.T) {
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 100)
+
+	chunks := ChunkText(text, 500, 100)
+	if len(chunks) < 2 {
+		t.Fatalf("Expected multiple chunks, got %d", len(chunks))
+	}
+
+	for i, chunk := range chunks {
+		if len(chunk) > 500 {
+			t.Errorf("Chunk %d exceeds chunk size: %d characters", i, len(chunk))
+		}
+		if chunk == "" {
+			t.Errorf("Chunk %d is empty", i)
+		}
+	}
+}
+
+func TestChunkTextEmptyInput(t *testing.T) {
+	if chunks := ChunkText("   ", 1000, 200); chunks != nil {
+		t.Errorf("Expected no chunks for blank input, got %v", chunks)
+	}
+}
+
+func TestLoadDocumentTextHTML(t *testing.T) {
+	html := `<html><head><style>body { color: red; }</style></head>` +
+		`<body><h1>Title</h1><p>Hello &amp; welcome</p><script>alert(1)</script></body></html>`
+
+	text, err := LoadDocumentText("text/html", []byte(html))
+	if err != nil {
+		t.Fatalf("Failed to load HTML: %v", err)
+	}
+
+	if !strings.Contains(text, "Hello & welcome") {
+		t.Errorf("Expected decoded entity in output, got %q", text)
+	}
+	if strings.Contains(text, "alert") || strings.Contains(text, "color: red") {
+		t.Errorf("Expected script and style contents stripped, got %q", text)
+	}
+}
+
+func TestLoadDocumentTextMarkdown(t *testing.T) {
+	markdown := "# Heading\n\nSome **bold** text with a [link](http://example.com).\n"
+
+	text, err := LoadDocumentText("text/markdown", []byte(markdown))
+	if err != nil {
+		t.Fatalf("Failed to load Markdown: %v", err)
+	}
+
+	if strings.Contains(text, "#") || strings.Contains(text, "**") {
+		t.Errorf("Expected markdown syntax stripped, got %q", text)
+	}
+	if !strings.Contains(text, "Some bold text with a link") {
+		t.Errorf("Expected prose preserved, got %q", text)
+	}
+}
+
+func TestInMemoryVectorStoreSearch(t *testing.T) {
+	store := NewInMemoryVectorStore()
+	ctx := context.Background()
+
+	chunks := []*DocumentChunk{
+		{ID: "a", DocumentID: "doc-1", Content: "alpha", Embedding: []float64{1, 0, 0}},
+		{ID: "b", DocumentID: "doc-1", Content: "beta", Embedding: []float64{0, 1, 0}},
+		{ID: "c", DocumentID: "doc-2", Content: "gamma", Embedding: []float64{0.9, 0.1, 0}},
+	}
+	if err := store.Add(ctx, chunks); err != nil {
+		t.Fatalf("Failed to add chunks: %v", err)
+	}
+
+	results, err := store.Search(ctx, []float64{1, 0, 0}, 2)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+	if results[0].Chunk.ID != "a" {
+		t.Errorf("Expected most similar chunk first, got %s", results[0].Chunk.ID)
+	}
+
+	if err := store.DeleteDocument(ctx, "doc-1"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	results, err = store.Search(ctx, []float64{1, 0, 0}, 10)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Chunk.DocumentID != "doc-2" {
+		t.Errorf("Expected only doc-2 chunks after delete, got %d results", len(results))
+	}
+}
+
+func TestCosineSimilarity(t *testing.T) {
+	if got := cosineSimilarity([]float64{1, 0}, []float64{1, 0}); got < 0.999 {
+		t.Errorf("Expected identical vectors to score ~1, got %f", got)
+	}
+	if got := cosineSimilarity([]float64{1, 0}, []float64{0, 1}); got != 0 {
+		t.Errorf("Expected orthogonal vectors to score 0, got %f", got)
+	}
+	if got := cosineSimilarity([]float64{1, 0}, []float64{1, 0, 0}); got != 0 {
+		t.Errorf("Expected mismatched dimensions to score 0, got %f", got)
+	}
+}
+
+func TestAddEmbedded(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	kb := engine.GetKnowledgeBase()
+	ctx := context.Background()
+
+	document, err := kb.AddEmbedded(ctx, "embed-batch", []string{"alpha", "beta"}, [][]float64{{1, 0}, {0, 1}})
+	if err != nil {
+		t.Fatalf("AddEmbedded failed: %v", err)
+	}
+	if document.ChunkCount != 2 {
+		t.Errorf("Expected 2 chunks, got %d", document.ChunkCount)
+	}
+
+	results, err := kb.store.Search(ctx, []float64{1, 0}, 1)
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(results) != 1 || results[0].Chunk.Content != "alpha" {
+		t.Errorf("Expected stored vector to be searchable, got %+v", results)
+	}
+
+	if _, err := kb.AddEmbedded(ctx, "bad", []string{"one"}, nil); err == nil {
+		t.Error("Expected error for mismatched texts and embeddings")
+	}
+}
+
+func TestEmbedTaskInputs(t *testing.T) {
+	task := &Task{Input: "single"}
+	if inputs := embedTaskInputs(task); len(inputs) != 1 || inputs[0] != "single" {
+		t.Errorf("Expected single input, got %v", inputs)
+	}
+
+	task = &Task{Parameters: map[string]interface{}{
+		"inputs": []interface{}{"one", "two", ""},
+	}}
+	if inputs := embedTaskInputs(task); len(inputs) != 2 {
+		t.Errorf("Expected empty entries dropped, got %v", inputs)
+	}
+
+	if inputs := embedTaskInputs(&Task{}); inputs != nil {
+		t.Errorf("Expected nil for empty task, got %v", inputs)
+	}
+}
@@ -0,0 +1,214 @@
+package orchestration
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderElection coordinates a primary/standby pair of engine instances
+// through a DistributedLock (e.g. Redis-backed). The instance holding the
+// election lock is the leader; standbys keep campaigning and take over when
+// the leader fails to renew, without duplicating task execution.
+type LeaderElection struct {
+	lock          DistributedLock
+	name          string
+	instanceID    string
+	ttl           time.Duration
+	renewInterval time.Duration
+	token         string
+	isLeader      bool
+	onElected     func()
+	onDemoted     func()
+	stop          chan struct{}
+	mu            sync.RWMutex
+}
+
+// NewLeaderElection creates a leader election campaigning for the named
+// election. The lock TTL bounds failover time: a standby becomes leader at
+// most one TTL after the primary dies.
+func NewLeaderElection(lock DistributedLock, name string, ttl time.Duration) *LeaderElection {
+	if ttl <= 0 {
+		ttl = 15 * time.Second
+	}
+
+	return &LeaderElection{
+		lock:          lock,
+		name:          name,
+		instanceID:    uuid.New().String(),
+		ttl:           ttl,
+		renewInterval: ttl / 3,
+		stop:          make(chan struct{}),
+	}
+}
+
+// OnElected registers a callback invoked when this instance becomes leader.
+func (le *LeaderElection) OnElected(callback func()) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	le.onElected = callback
+}
+
+// OnDemoted registers a callback invoked when this instance loses leadership.
+func (le *LeaderElection) OnDemoted(callback func()) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+	le.onDemoted = callback
+}
+
+// IsLeader reports whether this instance currently holds leadership.
+func (le *LeaderElection) IsLeader() bool {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+	return le.isLeader
+}
+
+// InstanceID returns this instance's unique election identity.
+func (le *LeaderElection) InstanceID() string {
+	return le.instanceID
+}
+
+// Start begins campaigning in the background until Stop is called or the
+// context is cancelled.
+func (le *LeaderElection) Start(ctx context.Context) {
+	go le.campaign(ctx)
+}
+
+// Stop ends the campaign, releasing leadership if held.
+func (le *LeaderElection) Stop(ctx context.Context) {
+	close(le.stop)
+
+	le.mu.Lock()
+	token := le.token
+	wasLeader := le.isLeader
+	le.isLeader = false
+	le.token = ""
+	le.mu.Unlock()
+
+	if wasLeader {
+		if err := le.lock.Release(ctx, le.name, token); err != nil {
+			slog.Error("Failed to release leadership", "error", err, "election", le.name)
+		}
+		le.notifyDemoted()
+	}
+}
+
+// campaign repeatedly attempts to acquire or renew the election lock.
+func (le *LeaderElection) campaign(ctx context.Context) {
+	ticker := time.NewTicker(le.renewInterval)
+	defer ticker.Stop()
+
+	le.tick(ctx)
+	for {
+		select {
+		case <-le.stop:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			le.tick(ctx)
+		}
+	}
+}
+
+// tick performs one acquire-or-renew attempt and fires transitions.
+func (le *LeaderElection) tick(ctx context.Context) {
+	le.mu.RLock()
+	wasLeader := le.isLeader
+	token := le.token
+	le.mu.RUnlock()
+
+	if wasLeader {
+		// Renew by releasing and immediately re-acquiring; if another
+		// instance wins the race we have genuinely lost leadership.
+		if err := le.lock.Release(ctx, le.name, token); err != nil {
+			le.demote()
+			return
+		}
+	}
+
+	newToken, acquired, err := le.lock.Acquire(ctx, le.name, le.ttl)
+	if err != nil {
+		slog.Error("Leader election acquire failed", "error", err, "election", le.name)
+		if wasLeader {
+			le.demote()
+		}
+		return
+	}
+
+	if !acquired {
+		if wasLeader {
+			le.demote()
+		}
+		return
+	}
+
+	le.mu.Lock()
+	le.token = newToken
+	le.isLeader = true
+	le.mu.Unlock()
+
+	if !wasLeader {
+		slog.Info("Acquired engine leadership", "election", le.name, "instance", le.instanceID)
+		le.notifyElected()
+	}
+}
+
+// demote clears leadership state and fires the demotion callback.
+func (le *LeaderElection) demote() {
+	le.mu.Lock()
+	wasLeader := le.isLeader
+	le.isLeader = false
+	le.token = ""
+	le.mu.Unlock()
+
+	if wasLeader {
+		slog.Warn("Lost engine leadership", "election", le.name, "instance", le.instanceID)
+		le.notifyDemoted()
+	}
+}
+
+func (le *LeaderElection) notifyElected() {
+	le.mu.RLock()
+	callback := le.onElected
+	le.mu.RUnlock()
+	if callback != nil {
+		callback()
+	}
+}
+
+func (le *LeaderElection) notifyDemoted() {
+	le.mu.RLock()
+	callback := le.onDemoted
+	le.mu.RUnlock()
+	if callback != nil {
+		callback()
+	}
+}
+
+// StartLeaderElection puts the engine into high-availability mode: scheduling
+// and conversation task processing only run while this instance holds the
+// election lock, so a standby engine can take over when the primary dies.
+func (e *Engine) StartLeaderElection(ctx context.Context, lock DistributedLock) *LeaderElection {
+	election := NewLeaderElection(lock, "engine-leader", 15*time.Second)
+
+	e.mu.Lock()
+	e.leaderElection = election
+	e.mu.Unlock()
+
+	election.Start(ctx)
+	return election
+}
+
+// isSchedulingActive reports whether this instance should perform scheduling
+// work. Always true when leader election is not configured.
+func (e *Engine) isSchedulingActive() bool {
+	e.mu.RLock()
+	election := e.leaderElection
+	e.mu.RUnlock()
+
+	return election == nil || election.IsLeader()
+}
@@ -0,0 +1,95 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestLeaderElectionSingleLeader(t *testing.T) {
+	lock := NewInMemoryLock()
+	ctx := context.Background()
+
+	primary := NewLeaderElection(lock, "engine-leader", time.Minute)
+	standby := NewLeaderElection(lock, "engine-leader", time.Minute)
+
+	primary.tick(ctx)
+	standby.tick(ctx)
+
+	if !primary.IsLeader() {
+		t.Error("Primary should have acquired leadership")
+	}
+
+	if standby.IsLeader() {
+		t.Error("Standby should not hold leadership while primary does")
+	}
+}
+
+func TestLeaderElectionFailover(t *testing.T) {
+	lock := NewInMemoryLock()
+	ctx := context.Background()
+
+	// Short TTL so the standby can take over once the primary stops renewing
+	primary := NewLeaderElection(lock, "engine-leader", 10*time.Millisecond)
+	standby := NewLeaderElection(lock, "engine-leader", 10*time.Millisecond)
+
+	primary.tick(ctx)
+	if !primary.IsLeader() {
+		t.Fatal("Primary should have acquired leadership")
+	}
+
+	// Simulate primary death: it stops renewing, its lock TTL expires
+	time.Sleep(20 * time.Millisecond)
+
+	standby.tick(ctx)
+	if !standby.IsLeader() {
+		t.Error("Standby should take over after the primary's lease expires")
+	}
+}
+
+func TestLeaderElectionCallbacks(t *testing.T) {
+	lock := NewInMemoryLock()
+	ctx := context.Background()
+
+	election := NewLeaderElection(lock, "engine-leader", time.Minute)
+
+	elected := false
+	demoted := false
+	election.OnElected(func() { elected = true })
+	election.OnDemoted(func() { demoted = true })
+
+	election.tick(ctx)
+	if !elected {
+		t.Error("OnElected callback should fire on acquiring leadership")
+	}
+
+	election.Stop(ctx)
+	if !demoted {
+		t.Error("OnDemoted callback should fire on releasing leadership")
+	}
+}
+
+func TestEngineSchedulingGatedOnLeadership(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	// Without an election configured, scheduling is always active
+	if !engine.isSchedulingActive() {
+		t.Error("Scheduling should be active without leader election")
+	}
+
+	lock := NewInMemoryLock()
+
+	// Another instance already leads
+	other := NewLeaderElection(lock, "engine-leader", time.Minute)
+	other.tick(ctx)
+
+	election := engine.StartLeaderElection(ctx, lock)
+	defer election.Stop(ctx)
+
+	if engine.isSchedulingActive() {
+		t.Error("Standby engine should not schedule while another instance leads")
+	}
+}
@@ -0,0 +1,160 @@
+package orchestration
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LoadDocumentText extracts plain text from raw document bytes based on
+// content type. Supported types: plain text, Markdown, HTML, and PDF
+// (uncompressed text streams only).
+func LoadDocumentText(contentType string, data []byte) (string, error) {
+	switch {
+	case strings.Contains(contentType, "pdf"):
+		return loadPDFText(data)
+	case strings.Contains(contentType, "html"):
+		return loadHTMLText(data), nil
+	case strings.Contains(contentType, "markdown"):
+		return loadMarkdownText(data), nil
+	default:
+		return string(data), nil
+	}
+}
+
+var (
+	htmlTagPattern      = regexp.MustCompile(`(?s)<(script|style)[^>]*>.*?</(script|style)>|<[^>]+>`)
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	pdfTextPattern      = regexp.MustCompile(`\(((?:[^()\\]|\\.)*)\)\s*T[jJ]`)
+)
+
+// loadHTMLText strips tags (including script and style bodies) and decodes
+// common entities, leaving readable text.
+func loadHTMLText(data []byte) string {
+	text := htmlTagPattern.ReplaceAllString(string(data), " ")
+
+	replacer := strings.NewReplacer(
+		"&amp;", "&",
+		"&lt;", "<",
+		"&gt;", ">",
+		"&quot;", `"`,
+		"&#39;", "'",
+		"&nbsp;", " ",
+	)
+	return normalizeWhitespace(replacer.Replace(text))
+}
+
+// loadMarkdownText removes structural Markdown syntax while keeping the
+// underlying prose, so headings and emphasis do not pollute embeddings.
+func loadMarkdownText(data []byte) string {
+	lines := strings.Split(string(data), "\n")
+	inCodeFence := false
+
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inCodeFence = !inCodeFence
+			continue
+		}
+		if inCodeFence {
+			out = append(out, line)
+			continue
+		}
+
+		trimmed = strings.TrimLeft(trimmed, "#> ")
+		trimmed = markdownLinkPattern.ReplaceAllString(trimmed, "$1")
+		trimmed = strings.NewReplacer("**", "", "__", "", "`", "").Replace(trimmed)
+		out = append(out, trimmed)
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// loadPDFText performs a best-effort extraction of text show operators (Tj/TJ)
+// from uncompressed PDF content streams. Compressed streams are not decoded;
+// callers needing full fidelity should convert to text before ingestion.
+func loadPDFText(data []byte) (string, error) {
+	matches := pdfTextPattern.FindAllStringSubmatch(string(data), -1)
+	if len(matches) == 0 {
+		return "", fmt.Errorf("no extractable text found (PDF may use compressed streams)")
+	}
+
+	parts := make([]string, 0, len(matches))
+	for _, match := range matches {
+		unescaped := strings.NewReplacer(`\(`, "(", `\)`, ")", `\\`, `\`).Replace(match[1])
+		parts = append(parts, unescaped)
+	}
+	return normalizeWhitespace(strings.Join(parts, " ")), nil
+}
+
+// normalizeWhitespace collapses runs of whitespace into single spaces while
+// preserving paragraph breaks.
+func normalizeWhitespace(text string) string {
+	paragraphs := regexp.MustCompile(`\n\s*\n`).Split(text, -1)
+	for i, paragraph := range paragraphs {
+		paragraphs[i] = strings.Join(strings.Fields(paragraph), " ")
+	}
+
+	out := make([]string, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		if paragraph != "" {
+			out = append(out, paragraph)
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
+
+// ChunkText splits text into overlapping chunks of roughly chunkSize
+// characters, preferring paragraph and sentence boundaries so chunks stay
+// coherent for embedding.
+func ChunkText(text string, chunkSize, overlap int) []string {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil
+	}
+	if chunkSize <= 0 {
+		chunkSize = 1000
+	}
+	if overlap < 0 || overlap >= chunkSize {
+		overlap = 0
+	}
+
+	if len(text) <= chunkSize {
+		return []string{text}
+	}
+
+	chunks := make([]string, 0)
+	start := 0
+	for start < len(text) {
+		end := start + chunkSize
+		if end >= len(text) {
+			chunks = append(chunks, strings.TrimSpace(text[start:]))
+			break
+		}
+
+		// Prefer to break at a paragraph, then a sentence, then a word
+		cut := end
+		if idx := strings.LastIndex(text[start:end], "\n\n"); idx > chunkSize/2 {
+			cut = start + idx
+		} else if idx := strings.LastIndexAny(text[start:end], ".!?"); idx > chunkSize/2 {
+			cut = start + idx + 1
+		} else if idx := strings.LastIndex(text[start:end], " "); idx > chunkSize/2 {
+			cut = start + idx
+		}
+
+		chunk := strings.TrimSpace(text[start:cut])
+		if chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+
+		// Step forward with overlap, always making progress
+		next := cut - overlap
+		if next <= start {
+			next = cut
+		}
+		start = next
+	}
+
+	return chunks
+}
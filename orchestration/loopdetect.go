@@ -0,0 +1,140 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxDelegationDepth bounds how many times a task may be re-delegated
+	// along one chain before the moderator intervenes.
+	maxDelegationDepth = 5
+	// loopDetectionWindow is how many recent messages are scanned for
+	// repeated delegations.
+	loopDetectionWindow = 10
+	// loopSimilarityThreshold is the minimum content similarity for two
+	// delegations to count as the same request bouncing back and forth.
+	loopSimilarityThreshold = 0.85
+	// loopRepeatThreshold is how many similar prior delegations between the
+	// same agent pair mark a ping-pong loop.
+	loopRepeatThreshold = 2
+)
+
+// detectDelegationLoop reports whether a task message is part of a delegation
+// loop: either its chain exceeded the depth budget, or the same pair of
+// agents has recently bounced near-identical content back and forth.
+func (e *Engine) detectDelegationLoop(conversation *Conversation, message *Message) (string, bool) {
+	if depth := delegationDepth(message); depth >= maxDelegationDepth {
+		return fmt.Sprintf("delegation depth %d reached the limit of %d", depth, maxDelegationDepth), true
+	}
+
+	similar := 0
+	scanned := 0
+	for i := len(conversation.Messages) - 1; i >= 0 && scanned < loopDetectionWindow; i-- {
+		previous := &conversation.Messages[i]
+		if previous.ID == message.ID {
+			continue
+		}
+		scanned++
+		if previous.Type != MessageTypeTask {
+			continue
+		}
+		if !samePair(previous, message) {
+			continue
+		}
+		if messageSimilarity(previous.Content, message.Content) >= loopSimilarityThreshold {
+			similar++
+		}
+	}
+	if similar >= loopRepeatThreshold {
+		return fmt.Sprintf("%d near-identical delegations between %s and %s",
+			similar+1, e.agentDisplayName(message.FromAgentID), e.agentDisplayName(message.ToAgentID)), true
+	}
+	return "", false
+}
+
+// interveneOnLoop breaks a detected loop: instead of spawning another task it
+// records a moderator notification in the conversation and emits a
+// conversation.loop_detected event so operators can see what was stopped.
+func (e *Engine) interveneOnLoop(ctx context.Context, conversation *Conversation, message *Message, reason string) {
+	intervention := Message{
+		ID:      uuid.New().String(),
+		Content: fmt.Sprintf("Moderator intervention: delegation from %s to %s was not executed (%s). Resolve the request directly instead of re-delegating.", e.agentDisplayName(message.FromAgentID), e.agentDisplayName(message.ToAgentID), reason),
+		Type:    MessageTypeNotification,
+		Context: map[string]interface{}{
+			"intervention":        "loop_break",
+			"reason":              reason,
+			"original_message_id": message.ID,
+		},
+		Timestamp: time.Now(),
+	}
+	conversation.Messages = append(conversation.Messages, intervention)
+	conversation.UpdatedAt = time.Now()
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		clog(ctx).Error("Failed to persist loop intervention", "error", err)
+	}
+
+	go e.emitEvent(ctx, &EngineEvent{
+		Type:           EventLoopDetected,
+		AgentID:        message.FromAgentID,
+		ConversationID: conversation.ID,
+		Payload:        map[string]interface{}{"reason": reason, "to_agent_id": message.ToAgentID},
+	})
+
+	clog(ctx).Warn("Delegation loop detected, task not spawned",
+		"from", message.FromAgentID, "to", message.ToAgentID, "reason", reason)
+}
+
+// delegationDepth reads the chain depth counter carried in a message's
+// context, tolerating the float64 produced by JSON round-trips.
+func delegationDepth(message *Message) int {
+	if message.Context == nil {
+		return 0
+	}
+	if depth, ok := toInt(message.Context["delegation_depth"]); ok {
+		return depth
+	}
+	return 0
+}
+
+// samePair reports whether two messages travel between the same two agents,
+// in either direction.
+func samePair(a, b *Message) bool {
+	if a.FromAgentID == b.FromAgentID && a.ToAgentID == b.ToAgentID {
+		return true
+	}
+	return a.FromAgentID == b.ToAgentID && a.ToAgentID == b.FromAgentID
+}
+
+// messageSimilarity computes the Jaccard similarity of two messages' word
+// sets, ranging from 0 (disjoint) to 1 (identical vocabulary).
+func messageSimilarity(a, b string) float64 {
+	wordsA := wordSet(a)
+	wordsB := wordSet(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	intersection := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			intersection++
+		}
+	}
+	union := len(wordsA) + len(wordsB) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// wordSet lowercases and splits text into its set of words.
+func wordSet(text string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		words[strings.Trim(word, ".,;:!?\"'()")] = true
+	}
+	delete(words, "")
+	return words
+}
@@ -0,0 +1,110 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestMessageSimilarity(t *testing.T) {
+	if sim := messageSimilarity("please review the report", "please review the report"); sim != 1 {
+		t.Errorf("Expected identical messages to score 1, got %f", sim)
+	}
+	if sim := messageSimilarity("please review the report", "deploy the new service"); sim >= loopSimilarityThreshold {
+		t.Errorf("Expected unrelated messages below threshold, got %f", sim)
+	}
+	if sim := messageSimilarity("", "anything"); sim != 0 {
+		t.Errorf("Expected empty message to score 0, got %f", sim)
+	}
+}
+
+func TestDetectDelegationLoopPingPong(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	conversation := &Conversation{
+		ID: "conv-loop",
+		Messages: []Message{
+			{ID: "m1", FromAgentID: "a", ToAgentID: "b", Type: MessageTypeTask, Content: "summarize the quarterly numbers"},
+			{ID: "m2", FromAgentID: "b", ToAgentID: "a", Type: MessageTypeTask, Content: "summarize the quarterly numbers"},
+		},
+	}
+
+	bounced := &Message{ID: "m3", FromAgentID: "a", ToAgentID: "b", Type: MessageTypeTask, Content: "summarize the quarterly numbers"}
+	if _, looping := engine.detectDelegationLoop(conversation, bounced); !looping {
+		t.Error("Expected ping-pong delegation to be detected as a loop")
+	}
+
+	fresh := &Message{ID: "m4", FromAgentID: "a", ToAgentID: "b", Type: MessageTypeTask, Content: "draft the release announcement"}
+	if reason, looping := engine.detectDelegationLoop(conversation, fresh); looping {
+		t.Errorf("Expected new content to pass, got loop: %s", reason)
+	}
+}
+
+func TestDetectDelegationLoopDepth(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	conversation := &Conversation{ID: "conv-depth"}
+
+	deep := &Message{
+		ID: "m1", FromAgentID: "a", ToAgentID: "b", Type: MessageTypeTask,
+		Content: "keep going",
+		Context: map[string]interface{}{"delegation_depth": maxDelegationDepth},
+	}
+	if _, looping := engine.detectDelegationLoop(conversation, deep); !looping {
+		t.Error("Expected depth limit to be detected as a loop")
+	}
+
+	shallow := &Message{
+		ID: "m2", FromAgentID: "a", ToAgentID: "b", Type: MessageTypeTask,
+		Content: "keep going",
+		Context: map[string]interface{}{"delegation_depth": 1},
+	}
+	if reason, looping := engine.detectDelegationLoop(conversation, shallow); looping {
+		t.Errorf("Expected shallow delegation to pass, got loop: %s", reason)
+	}
+}
+
+func TestLoopInterventionBreaksDelegation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	sender := &Agent{Name: "sender", Models: []string{"llama3.2"}}
+	receiver := &Agent{Name: "receiver", Models: []string{"llama3.2"}}
+	for _, agent := range []*Agent{sender, receiver} {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+
+	conversation, err := engine.StartConversation(ctx, []string{sender.ID, receiver.ID}, "loop test")
+	if err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+
+	// A delegation already at the depth limit is intercepted by the
+	// moderator instead of spawning another task
+	message := &Message{
+		FromAgentID: sender.ID,
+		ToAgentID:   receiver.ID,
+		Content:     "and again",
+		Type:        MessageTypeTask,
+		Context:     map[string]interface{}{"delegation_depth": maxDelegationDepth},
+	}
+	if err := engine.SendMessage(ctx, conversation.ID, message); err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+
+	updated, err := engine.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("Failed to get conversation: %v", err)
+	}
+
+	found := false
+	for _, msg := range updated.Messages {
+		if msg.Type == MessageTypeNotification && msg.Context["intervention"] == "loop_break" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a moderator intervention message in the conversation")
+	}
+}
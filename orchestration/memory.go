@@ -0,0 +1,123 @@
+package orchestration
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// DefaultMemoryLimit is the number of context items an agent retains when no
+// quota is configured.
+const DefaultMemoryLimit = 10
+
+// memoryRecencyHalfLife controls how quickly an item's effective score decays
+// with age during eviction: after one half-life the score halves.
+const memoryRecencyHalfLife = time.Hour
+
+// agentMemoryLimit returns the context quota for an agent: the agent's
+// "memory_limit" config entry when set, otherwise the engine default.
+func agentMemoryLimit(agent *Agent) int {
+	if agent.Config != nil {
+		if limit, ok := toInt(agent.Config["memory_limit"]); ok && limit > 0 {
+			return limit
+		}
+	}
+	return DefaultMemoryLimit
+}
+
+// memoryScore weighs a context item by relevance and recency. Higher scores
+// are kept longer; pinned items never go through scoring.
+func memoryScore(item ContextItem, now time.Time) float64 {
+	age := now.Sub(item.Timestamp)
+	if age < 0 {
+		age = 0
+	}
+	decay := math.Exp2(-float64(age) / float64(memoryRecencyHalfLife))
+	return item.Relevance * decay
+}
+
+// evictAgentContext trims an agent's context to its quota, keeping pinned
+// items unconditionally and evicting the lowest relevance/recency-weighted
+// items first. Chronological order is preserved among survivors.
+func evictAgentContext(agent *Agent, limit int) {
+	if agent.State == nil || len(agent.State.Context) <= limit {
+		return
+	}
+
+	now := time.Now()
+	type scored struct {
+		index int
+		score float64
+	}
+
+	unpinned := make([]scored, 0, len(agent.State.Context))
+	pinnedCount := 0
+	for i, item := range agent.State.Context {
+		if item.Pinned {
+			pinnedCount++
+			continue
+		}
+		unpinned = append(unpinned, scored{index: i, score: memoryScore(item, now)})
+	}
+
+	keepUnpinned := limit - pinnedCount
+	if keepUnpinned < 0 {
+		keepUnpinned = 0
+	}
+	if len(unpinned) <= keepUnpinned {
+		return
+	}
+
+	// Evict the lowest-scoring unpinned items
+	sort.Slice(unpinned, func(i, j int) bool { return unpinned[i].score < unpinned[j].score })
+	evicted := make(map[int]bool, len(unpinned)-keepUnpinned)
+	for _, item := range unpinned[:len(unpinned)-keepUnpinned] {
+		evicted[item.index] = true
+	}
+
+	kept := make([]ContextItem, 0, limit)
+	for i, item := range agent.State.Context {
+		if !evicted[i] {
+			kept = append(kept, item)
+		}
+	}
+	agent.State.Context = kept
+}
+
+// PinContextItem marks an agent's context items with the given key as pinned
+// so they survive quota eviction.
+func (e *Engine) PinContextItem(agentID, key string) error {
+	return e.setContextPinned(agentID, key, true)
+}
+
+// UnpinContextItem clears the pinned flag from an agent's context items with
+// the given key.
+func (e *Engine) UnpinContextItem(agentID, key string) error {
+	return e.setContextPinned(agentID, key, false)
+}
+
+func (e *Engine) setContextPinned(agentID, key string, pinned bool) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	agent, exists := e.agents[agentID]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAgentNotFound, agentID)
+	}
+	if agent.State == nil {
+		return fmt.Errorf("%w: context item %s", ErrNotFound, key)
+	}
+
+	found := false
+	for i := range agent.State.Context {
+		if agent.State.Context[i].Key == key {
+			agent.State.Context[i].Pinned = pinned
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("%w: context item %s", ErrNotFound, key)
+	}
+	return nil
+}
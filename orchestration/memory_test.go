@@ -0,0 +1,122 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestEvictAgentContextQuota(t *testing.T) {
+	agent := &Agent{State: &AgentState{}}
+	now := time.Now()
+	for i := 0; i < 6; i++ {
+		agent.State.Context = append(agent.State.Context, ContextItem{
+			Key:       fmt.Sprintf("item_%d", i),
+			Timestamp: now.Add(time.Duration(i) * time.Minute),
+			Relevance: 1.0,
+		})
+	}
+
+	evictAgentContext(agent, 3)
+	if len(agent.State.Context) != 3 {
+		t.Fatalf("Expected 3 items after eviction, got %d", len(agent.State.Context))
+	}
+	// With equal relevance, the oldest items are evicted first
+	if agent.State.Context[0].Key != "item_3" {
+		t.Errorf("Expected oldest items evicted, survivors start at %s", agent.State.Context[0].Key)
+	}
+}
+
+func TestEvictAgentContextPrefersRelevance(t *testing.T) {
+	agent := &Agent{State: &AgentState{}}
+	now := time.Now()
+	// An old but highly relevant item should outlive a fresh low-relevance one
+	agent.State.Context = []ContextItem{
+		{Key: "important", Timestamp: now.Add(-30 * time.Minute), Relevance: 5.0},
+		{Key: "noise", Timestamp: now, Relevance: 0.1},
+		{Key: "recent", Timestamp: now, Relevance: 1.0},
+	}
+
+	evictAgentContext(agent, 2)
+	keys := make(map[string]bool)
+	for _, item := range agent.State.Context {
+		keys[item.Key] = true
+	}
+	if !keys["important"] || !keys["recent"] || keys["noise"] {
+		t.Errorf("Expected noise evicted, got survivors %v", keys)
+	}
+}
+
+func TestEvictAgentContextKeepsPinned(t *testing.T) {
+	agent := &Agent{State: &AgentState{}}
+	now := time.Now()
+	agent.State.Context = []ContextItem{
+		{Key: "pinned", Timestamp: now.Add(-24 * time.Hour), Relevance: 0.01, Pinned: true},
+		{Key: "a", Timestamp: now, Relevance: 1.0},
+		{Key: "b", Timestamp: now, Relevance: 1.0},
+		{Key: "c", Timestamp: now, Relevance: 1.0},
+	}
+
+	evictAgentContext(agent, 2)
+	if len(agent.State.Context) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(agent.State.Context))
+	}
+	if !agent.State.Context[0].Pinned {
+		t.Error("Expected the pinned item to survive eviction")
+	}
+}
+
+func TestAgentMemoryLimitConfig(t *testing.T) {
+	if limit := agentMemoryLimit(&Agent{}); limit != DefaultMemoryLimit {
+		t.Errorf("Expected default limit, got %d", limit)
+	}
+	agent := &Agent{Config: map[string]interface{}{"memory_limit": 25}}
+	if limit := agentMemoryLimit(agent); limit != 25 {
+		t.Errorf("Expected configured limit 25, got %d", limit)
+	}
+	// JSON round-trips produce float64 values
+	agent.Config["memory_limit"] = float64(7)
+	if limit := agentMemoryLimit(agent); limit != 7 {
+		t.Errorf("Expected configured limit 7, got %d", limit)
+	}
+}
+
+func TestPinContextItem(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "pin-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.updateAgentState(agent, "durable_fact", "remember me")
+
+	if err := engine.PinContextItem(agent.ID, "durable_fact"); err != nil {
+		t.Fatalf("Failed to pin: %v", err)
+	}
+
+	// Flood past the quota; the pinned item must survive
+	for i := 0; i < DefaultMemoryLimit*2; i++ {
+		engine.updateAgentState(agent, fmt.Sprintf("flood_%d", i), i)
+	}
+
+	found := false
+	for _, item := range agent.State.Context {
+		if item.Key == "durable_fact" && item.Pinned {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected pinned item to survive flooding")
+	}
+
+	if err := engine.PinContextItem(agent.ID, "missing"); err == nil {
+		t.Error("Expected error pinning unknown key")
+	}
+	if err := engine.PinContextItem("missing-agent", "durable_fact"); err == nil {
+		t.Error("Expected error for unknown agent")
+	}
+}
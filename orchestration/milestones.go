@@ -0,0 +1,186 @@
+package orchestration
+
+import (
+	"time"
+)
+
+// Milestone names recorded on the evolution timeline.
+const (
+	MilestoneFirstTask            = "first_task"
+	MilestoneFirstToolUse         = "first_tool_use"
+	MilestoneFirstConversation    = "first_conversation"
+	MilestoneConsolidatedMemories = "consolidated_memories"
+	MilestoneSelfImprovementCycle = "self_improvement_cycle"
+)
+
+// consolidatedMemoryThreshold is the number of context items held across
+// agent memories that counts as a consolidated memory base.
+const consolidatedMemoryThreshold = 25
+
+// Milestone is a concrete, measured achievement that advances the evolution
+// timeline. Each milestone is recorded at most once.
+type Milestone struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Stage       string    `json:"stage"` // Evolution stage the milestone advances
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+// milestoneDefinition couples a milestone with the measured condition that
+// triggers it.
+type milestoneDefinition struct {
+	Milestone
+	reached func(t DTETelemetry) bool
+}
+
+// milestoneDefinitions lists every milestone the timeline tracks, grouped by
+// the evolution stage it advances.
+func milestoneDefinitions() []milestoneDefinition {
+	return []milestoneDefinition{
+		{
+			Milestone: Milestone{
+				Name:        MilestoneFirstTask,
+				Description: "First task executed successfully",
+				Stage:       "Foundation",
+			},
+			reached: func(t DTETelemetry) bool { return t.TasksExecuted >= 1 },
+		},
+		{
+			Milestone: Milestone{
+				Name:        MilestoneFirstToolUse,
+				Description: "First tool invocation completed",
+				Stage:       "Foundation",
+			},
+			reached: func(t DTETelemetry) bool { return t.ToolInvocations >= 1 },
+		},
+		{
+			Milestone: Milestone{
+				Name:        MilestoneFirstConversation,
+				Description: "First cross-agent message exchanged",
+				Stage:       "Integration",
+			},
+			reached: func(t DTETelemetry) bool { return t.MemoryConnections >= 1 },
+		},
+		{
+			Milestone: Milestone{
+				Name:        MilestoneConsolidatedMemories,
+				Description: "Consolidated memory base established",
+				Stage:       "Integration",
+			},
+			reached: func(t DTETelemetry) bool { return t.MemoryNodes >= consolidatedMemoryThreshold },
+		},
+		{
+			Milestone: Milestone{
+				Name:        MilestoneSelfImprovementCycle,
+				Description: "First self-improvement reflection cycle completed",
+				Stage:       "Emergence",
+			},
+			reached: func(t DTETelemetry) bool { return t.ReflectionRuns >= 1 },
+		},
+	}
+}
+
+// evaluateMilestones records any newly reached milestones from measured
+// telemetry and returns them so the engine can emit events.
+func (dte *DeepTreeEcho) evaluateMilestones(t DTETelemetry) []Milestone {
+	recorded := make(map[string]bool, len(dte.milestones))
+	for _, milestone := range dte.milestones {
+		recorded[milestone.Name] = true
+	}
+
+	var reached []Milestone
+	for _, definition := range milestoneDefinitions() {
+		if recorded[definition.Name] || !definition.reached(t) {
+			continue
+		}
+		milestone := definition.Milestone
+		milestone.RecordedAt = time.Now()
+		dte.milestones = append(dte.milestones, milestone)
+		reached = append(reached, milestone)
+	}
+
+	if len(reached) > 0 {
+		dte.advanceEvolutionTimeline()
+	}
+	return reached
+}
+
+// advanceEvolutionTimeline recomputes stage and overall progress from the
+// recorded milestones: a stage's progress is the fraction of its milestones
+// reached, and a stage completes when all of them are.
+func (dte *DeepTreeEcho) advanceEvolutionTimeline() {
+	now := time.Now()
+
+	totals := make(map[string]int)
+	achieved := make(map[string]int)
+	for _, definition := range milestoneDefinitions() {
+		totals[definition.Stage]++
+	}
+	for _, milestone := range dte.milestones {
+		achieved[milestone.Stage]++
+	}
+
+	totalMilestones := 0
+	totalAchieved := 0
+	previousComplete := true
+	for i := range dte.EvolutionTimeline.Stages {
+		stage := &dte.EvolutionTimeline.Stages[i]
+		total := totals[stage.Name]
+		totalMilestones += total
+		totalAchieved += achieved[stage.Name]
+
+		if total == 0 {
+			// Stages without measurable milestones stay as they are.
+			previousComplete = false
+			continue
+		}
+
+		stage.Progress = float64(achieved[stage.Name]) / float64(total)
+		switch {
+		case stage.Progress >= 1.0:
+			if stage.Status != "complete" {
+				stage.Status = "complete"
+				if stage.EndTime == nil {
+					end := now
+					stage.EndTime = &end
+				}
+			}
+		case stage.Progress > 0 || previousComplete:
+			if stage.Status != "in_progress" {
+				stage.Status = "in_progress"
+				if stage.StartTime.IsZero() {
+					stage.StartTime = now
+				}
+				dte.EvolutionTimeline.CurrentStage = stage.Name
+			}
+		}
+		previousComplete = stage.Progress >= 1.0
+	}
+
+	if totalMilestones > 0 {
+		dte.EvolutionTimeline.Progress = float64(totalAchieved) / float64(totalMilestones)
+	}
+	dte.EvolutionTimeline.LastUpdated = now
+}
+
+// drainPendingMilestones returns and clears milestones awaiting event
+// emission. On engine-owned instances the caller must hold e.mu.
+func (dte *DeepTreeEcho) drainPendingMilestones() []Milestone {
+	pending := dte.pendingMilestones
+	dte.pendingMilestones = nil
+	return pending
+}
+
+// Milestones returns the recorded milestone history in order of achievement.
+func (dte *DeepTreeEcho) Milestones() []Milestone {
+	history := make([]Milestone, len(dte.milestones))
+	copy(history, dte.milestones)
+	return history
+}
+
+// GetMilestones returns the Deep Tree Echo milestone history.
+func (e *Engine) GetMilestones() []Milestone {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.deepTreeEcho.Milestones()
+}
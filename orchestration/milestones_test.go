@@ -0,0 +1,92 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestMilestonesAdvanceEvolutionTimeline(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "pioneer", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// No milestones before any activity.
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+	if milestones := engine.GetMilestones(); len(milestones) != 0 {
+		t.Fatalf("Expected no milestones before activity, got %d", len(milestones))
+	}
+
+	task := &Task{ID: "milestone-task", Type: TaskTypeCustom, Input: "first", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	milestones := engine.GetMilestones()
+	if len(milestones) != 1 {
+		t.Fatalf("Expected 1 milestone after first task, got %d", len(milestones))
+	}
+	if milestones[0].Name != MilestoneFirstTask {
+		t.Errorf("Expected %s milestone, got %s", MilestoneFirstTask, milestones[0].Name)
+	}
+
+	dte := engine.GetDeepTreeEcho()
+	foundation := dte.EvolutionTimeline.Stages[0]
+	if foundation.Progress != 0.5 {
+		t.Errorf("Expected Foundation progress 0.5 with 1 of 2 milestones, got %f", foundation.Progress)
+	}
+	if dte.EvolutionTimeline.Progress <= 0 {
+		t.Error("Expected overall timeline progress to advance")
+	}
+
+	// A second refresh must not duplicate recorded milestones.
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+	if milestones := engine.GetMilestones(); len(milestones) != 1 {
+		t.Errorf("Expected milestone history to stay at 1, got %d", len(milestones))
+	}
+}
+
+func TestMilestoneEventsEmitted(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	bus := NewInMemoryEventBus()
+	engine.SetEventPublisher(bus)
+
+	agent := &Agent{Name: "eventful", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	task := &Task{ID: "milestone-event-task", Type: TaskTypeCustom, Input: "first", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+		t.Fatalf("Failed to execute task: %v", err)
+	}
+	if err := engine.RefreshDeepTreeEchoStatus(ctx); err != nil {
+		t.Fatalf("RefreshDeepTreeEchoStatus failed: %v", err)
+	}
+
+	found := false
+	for _, event := range bus.RecentEvents() {
+		if event.Type == EventMilestoneReached {
+			found = true
+			if event.Payload["milestone"] != MilestoneFirstTask {
+				t.Errorf("Expected %s milestone event, got %v", MilestoneFirstTask, event.Payload["milestone"])
+			}
+		}
+	}
+	if !found {
+		t.Error("Expected a milestone reached event")
+	}
+}
@@ -0,0 +1,162 @@
+package orchestration
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// modelStateTTL is how long a snapshot of the daemon's loaded models is
+// trusted before being refreshed.
+const modelStateTTL = 5 * time.Second
+
+// ModelScheduler makes task-to-model placement VRAM-aware. It queries the
+// daemon for loaded models and their memory use, prefers models that are
+// already resident, and serializes loads that would exceed the configured
+// VRAM budget so concurrent tasks do not thrash model swaps.
+type ModelScheduler struct {
+	engine *Engine
+	// budget is the VRAM budget in bytes; 0 disables load serialization.
+	budget int64
+
+	mu        sync.Mutex
+	loaded    map[string]int64 // model name -> VRAM bytes
+	sizes     map[string]int64 // model name -> on-disk size (load estimate)
+	vramUsed  int64
+	fetchedAt time.Time
+
+	loadMu sync.Mutex // serializes loads that would exceed the budget
+}
+
+// EnableVRAMScheduling turns on VRAM-aware model scheduling with the given
+// budget in bytes. A zero budget still prefers loaded models but never
+// serializes loads.
+func (e *Engine) EnableVRAMScheduling(budget int64) *ModelScheduler {
+	scheduler := &ModelScheduler{
+		engine: e,
+		budget: budget,
+		loaded: make(map[string]int64),
+		sizes:  make(map[string]int64),
+	}
+
+	e.mu.Lock()
+	e.modelScheduler = scheduler
+	e.mu.Unlock()
+
+	slog.Info("Enabled VRAM-aware model scheduling", "budget_bytes", budget)
+	return scheduler
+}
+
+// refresh re-reads the daemon's loaded models and the local model list when
+// the snapshot is stale. Daemon errors leave the previous snapshot in place.
+func (s *ModelScheduler) refresh(ctx context.Context) {
+	s.mu.Lock()
+	stale := time.Since(s.fetchedAt) > modelStateTTL
+	s.mu.Unlock()
+	if !stale {
+		return
+	}
+
+	running, err := s.engine.client.ListRunning(ctx)
+	if err != nil {
+		slog.Warn("Failed to query loaded models", "error", err)
+		return
+	}
+	models, err := s.engine.client.List(ctx)
+	if err != nil {
+		slog.Warn("Failed to list local models", "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loaded = make(map[string]int64, len(running.Models))
+	s.vramUsed = 0
+	for _, model := range running.Models {
+		s.loaded[model.Name] = model.SizeVRAM
+		s.vramUsed += model.SizeVRAM
+	}
+	s.sizes = make(map[string]int64, len(models.Models))
+	for _, model := range models.Models {
+		s.sizes[model.Name] = model.Size
+	}
+	s.fetchedAt = time.Now()
+}
+
+// PreferLoaded picks the first candidate model that is already resident,
+// falling back to the first candidate when none are loaded or the daemon is
+// unreachable.
+func (s *ModelScheduler) PreferLoaded(ctx context.Context, candidates []string) string {
+	if len(candidates) == 0 {
+		return ""
+	}
+	s.refresh(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, candidate := range candidates {
+		if _, resident := s.loaded[candidate]; resident {
+			return candidate
+		}
+	}
+	return candidates[0]
+}
+
+// AcquireLoad gates a request against the VRAM budget. Requests for resident
+// models proceed immediately; a load that would exceed the budget is
+// serialized behind other pending loads. The returned release function must
+// be called when the request finishes.
+func (s *ModelScheduler) AcquireLoad(ctx context.Context, model string) func() {
+	if s.budget <= 0 {
+		return func() {}
+	}
+	s.refresh(ctx)
+
+	s.mu.Lock()
+	_, resident := s.loaded[model]
+	estimate := s.sizes[model]
+	used := s.vramUsed
+	s.mu.Unlock()
+
+	if resident || used+estimate <= s.budget {
+		return func() {}
+	}
+
+	slog.Info("Serializing model load against VRAM budget",
+		"model", model, "estimate_bytes", estimate, "vram_used", used, "budget", s.budget)
+	s.loadMu.Lock()
+	return s.loadMu.Unlock
+}
+
+// selectAgentModel resolves the model a task should run on: the task's
+// explicit model, else a scheduler-preferred loaded model, else the agent's
+// first configured model.
+func (e *Engine) selectAgentModel(ctx context.Context, task *Task, agent *Agent) string {
+	if task.ModelName != "" {
+		return task.ModelName
+	}
+	if len(agent.Models) == 0 {
+		return ""
+	}
+
+	e.mu.RLock()
+	scheduler := e.modelScheduler
+	e.mu.RUnlock()
+	if scheduler != nil {
+		return scheduler.PreferLoaded(ctx, agent.Models)
+	}
+	return agent.Models[0]
+}
+
+// acquireModelSlot gates a model request against the VRAM budget when
+// scheduling is enabled; otherwise it is a no-op.
+func (e *Engine) acquireModelSlot(ctx context.Context, model string) func() {
+	e.mu.RLock()
+	scheduler := e.modelScheduler
+	e.mu.RUnlock()
+	if scheduler == nil {
+		return func() {}
+	}
+	return scheduler.AcquireLoad(ctx, model)
+}
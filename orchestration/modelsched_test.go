@@ -0,0 +1,98 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestPreferLoadedFallsBackToFirstCandidate(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	scheduler := engine.EnableVRAMScheduling(0)
+
+	// Daemon unreachable: scheduler falls back to the first candidate
+	model := scheduler.PreferLoaded(context.Background(), []string{"llama3.2", "codellama"})
+	if model != "llama3.2" {
+		t.Errorf("Expected first candidate fallback, got %s", model)
+	}
+	if model := scheduler.PreferLoaded(context.Background(), nil); model != "" {
+		t.Errorf("Expected empty result for no candidates, got %s", model)
+	}
+}
+
+func TestPreferLoadedPicksResidentModel(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	scheduler := engine.EnableVRAMScheduling(0)
+
+	// Seed a fresh snapshot so no daemon query happens
+	scheduler.loaded = map[string]int64{"codellama": 4 << 30}
+	scheduler.fetchedAt = time.Now()
+
+	model := scheduler.PreferLoaded(context.Background(), []string{"llama3.2", "codellama"})
+	if model != "codellama" {
+		t.Errorf("Expected resident model preferred, got %s", model)
+	}
+}
+
+func TestAcquireLoadSerializesOverBudget(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	scheduler := engine.EnableVRAMScheduling(8 << 30)
+
+	scheduler.loaded = map[string]int64{"llama3.2": 6 << 30}
+	scheduler.sizes = map[string]int64{"mixtral": 4 << 30}
+	scheduler.vramUsed = 6 << 30
+	scheduler.fetchedAt = time.Now()
+
+	// Resident model: no gating
+	release := scheduler.AcquireLoad(context.Background(), "llama3.2")
+	release()
+
+	// Over-budget load: serialized behind the load mutex
+	first := scheduler.AcquireLoad(context.Background(), "mixtral")
+	acquired := make(chan struct{})
+	go func() {
+		second := scheduler.AcquireLoad(context.Background(), "mixtral")
+		close(acquired)
+		second()
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected second over-budget load to block until release")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	first()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Expected second load to proceed after release")
+	}
+}
+
+func TestSelectAgentModel(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	agent := &Agent{Models: []string{"llama3.2", "codellama"}}
+
+	// Explicit task model wins
+	task := &Task{ModelName: "mixtral"}
+	if model := engine.selectAgentModel(ctx, task, agent); model != "mixtral" {
+		t.Errorf("Expected explicit model, got %s", model)
+	}
+
+	// Without a scheduler the first agent model is used
+	if model := engine.selectAgentModel(ctx, &Task{}, agent); model != "llama3.2" {
+		t.Errorf("Expected first agent model, got %s", model)
+	}
+
+	// With a scheduler, resident models are preferred
+	scheduler := engine.EnableVRAMScheduling(0)
+	scheduler.loaded = map[string]int64{"codellama": 1}
+	scheduler.fetchedAt = time.Now()
+	if model := engine.selectAgentModel(ctx, &Task{}, agent); model != "codellama" {
+		t.Errorf("Expected resident model preferred, got %s", model)
+	}
+}
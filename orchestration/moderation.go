@@ -0,0 +1,203 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// ModerationDirection identifies which side of a model call is being checked.
+type ModerationDirection string
+
+const (
+	ModerationInbound  ModerationDirection = "inbound"  // User prompts entering the engine
+	ModerationOutbound ModerationDirection = "outbound" // Model completions leaving the engine
+)
+
+// EventModerationViolation is emitted whenever moderation flags content.
+const EventModerationViolation EventType = "moderation.violation"
+
+// ModerationPolicy configures the guardrails applied to prompts and
+// completions.
+type ModerationPolicy struct {
+	// DenyList blocks content containing any of these case-insensitive terms.
+	DenyList []string `json:"deny_list,omitempty"`
+	// RedactPatterns are regular expressions whose matches are replaced with
+	// [REDACTED] rather than blocking the request.
+	RedactPatterns []string `json:"redact_patterns,omitempty"`
+	// JudgeModel optionally names a model asked to classify content that
+	// passes the static checks. The judge must answer SAFE or UNSAFE.
+	JudgeModel string `json:"judge_model,omitempty"`
+	// ExemptAgents lists agent IDs whose traffic bypasses moderation,
+	// e.g. trusted internal maintenance agents.
+	ExemptAgents []string `json:"exempt_agents,omitempty"`
+	// BlockOnViolation blocks flagged content when true; otherwise flagged
+	// content is redacted and allowed through with a violation event.
+	BlockOnViolation bool `json:"block_on_violation"`
+}
+
+// ModerationResult describes the outcome of checking one piece of content.
+type ModerationResult struct {
+	Allowed    bool     `json:"allowed"`
+	Content    string   `json:"content"` // Possibly redacted
+	Violations []string `json:"violations,omitempty"`
+}
+
+// Moderator applies a moderation policy to engine traffic. A nil Moderator
+// disables moderation entirely.
+type Moderator struct {
+	policy   ModerationPolicy
+	client   api.Client
+	patterns []*regexp.Regexp
+	mu       sync.RWMutex
+}
+
+// NewModerator creates a moderator for the given policy. Invalid redaction
+// patterns are rejected.
+func NewModerator(client api.Client, policy ModerationPolicy) (*Moderator, error) {
+	patterns := make([]*regexp.Regexp, 0, len(policy.RedactPatterns))
+	for _, raw := range policy.RedactPatterns {
+		pattern, err := regexp.Compile(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redact pattern %q: %w", raw, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	return &Moderator{
+		policy:   policy,
+		client:   client,
+		patterns: patterns,
+	}, nil
+}
+
+// Policy returns the active moderation policy.
+func (m *Moderator) Policy() ModerationPolicy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.policy
+}
+
+// exempt reports whether an agent bypasses moderation.
+func (m *Moderator) exempt(agentID string) bool {
+	for _, id := range m.policy.ExemptAgents {
+		if id == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// Check applies the policy to one piece of content. Redaction always runs;
+// deny-list and judge violations either block or pass through redacted,
+// depending on BlockOnViolation.
+func (m *Moderator) Check(ctx context.Context, content, agentID string, direction ModerationDirection) *ModerationResult {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := &ModerationResult{Allowed: true, Content: content}
+
+	if m.exempt(agentID) {
+		return result
+	}
+
+	for _, pattern := range m.patterns {
+		if pattern.MatchString(result.Content) {
+			result.Content = pattern.ReplaceAllString(result.Content, "[REDACTED]")
+			result.Violations = append(result.Violations, fmt.Sprintf("redacted pattern %s", pattern.String()))
+		}
+	}
+
+	lowered := strings.ToLower(result.Content)
+	for _, term := range m.policy.DenyList {
+		if term != "" && strings.Contains(lowered, strings.ToLower(term)) {
+			result.Violations = append(result.Violations, fmt.Sprintf("deny-list term %q (%s)", term, direction))
+		}
+	}
+
+	if len(result.Violations) == 0 && m.policy.JudgeModel != "" {
+		if verdict := m.judge(ctx, result.Content); verdict != "" {
+			result.Violations = append(result.Violations, verdict)
+		}
+	}
+
+	if len(result.Violations) > 0 && m.policy.BlockOnViolation {
+		result.Allowed = false
+	}
+	return result
+}
+
+// judge asks the configured judge model to classify content, returning a
+// violation description for unsafe content and "" otherwise. Judge failures
+// fail open so moderation outages never take the engine down.
+func (m *Moderator) judge(ctx context.Context, content string) string {
+	prompt := fmt.Sprintf(
+		"You are a content safety classifier. Answer with exactly SAFE or UNSAFE.\n\nContent:\n%s", content)
+
+	stream := false
+	var output string
+	err := m.client.Generate(ctx, &api.GenerateRequest{
+		Model:  m.policy.JudgeModel,
+		Prompt: prompt,
+		Stream: &stream,
+	}, func(resp api.GenerateResponse) error {
+		output += resp.Response
+		return nil
+	})
+	if err != nil {
+		return ""
+	}
+
+	if strings.Contains(strings.ToUpper(output), "UNSAFE") {
+		return fmt.Sprintf("judge model %s flagged content", m.policy.JudgeModel)
+	}
+	return ""
+}
+
+// SetModerator configures guardrails for all engine traffic. Pass nil to
+// disable moderation.
+func (e *Engine) SetModerator(moderator *Moderator) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.moderator = moderator
+}
+
+// GetModerator returns the engine's moderator, or nil if moderation is off.
+func (e *Engine) GetModerator() *Moderator {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.moderator
+}
+
+// moderate applies the engine's moderation policy to content flowing through
+// a task. It returns the (possibly redacted) content, or an error when the
+// content is blocked. Violations are emitted as engine events either way.
+func (e *Engine) moderate(ctx context.Context, content string, task *Task, agent *Agent, direction ModerationDirection) (string, error) {
+	moderator := e.GetModerator()
+	if moderator == nil {
+		return content, nil
+	}
+
+	result := moderator.Check(ctx, content, agent.ID, direction)
+	if len(result.Violations) > 0 {
+		e.emitEvent(ctx, &EngineEvent{
+			Type:    EventModerationViolation,
+			AgentID: agent.ID,
+			TaskID:  task.ID,
+			Payload: map[string]interface{}{
+				"direction":  string(direction),
+				"violations": result.Violations,
+				"blocked":    !result.Allowed,
+			},
+		})
+	}
+
+	if !result.Allowed {
+		return "", fmt.Errorf("%w: %s content blocked (%s)", ErrModerationBlocked, direction, strings.Join(result.Violations, "; "))
+	}
+	return result.Content, nil
+}
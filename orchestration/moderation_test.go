@@ -0,0 +1,102 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestModeratorDenyList(t *testing.T) {
+	moderator, err := NewModerator(api.Client{}, ModerationPolicy{
+		DenyList:         []string{"forbidden"},
+		BlockOnViolation: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create moderator: %v", err)
+	}
+
+	result := moderator.Check(context.Background(), "this contains FORBIDDEN content", "agent-1", ModerationInbound)
+	if result.Allowed {
+		t.Error("Expected deny-list match to block content")
+	}
+	if len(result.Violations) == 0 {
+		t.Error("Expected a violation to be recorded")
+	}
+}
+
+func TestModeratorRedaction(t *testing.T) {
+	moderator, err := NewModerator(api.Client{}, ModerationPolicy{
+		RedactPatterns: []string{`\b\d{3}-\d{2}-\d{4}\b`},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create moderator: %v", err)
+	}
+
+	result := moderator.Check(context.Background(), "SSN is 123-45-6789 ok", "agent-1", ModerationOutbound)
+	if !result.Allowed {
+		t.Error("Expected redaction-only policy to allow content")
+	}
+	if strings.Contains(result.Content, "123-45-6789") {
+		t.Errorf("Expected SSN redacted, got %q", result.Content)
+	}
+	if !strings.Contains(result.Content, "[REDACTED]") {
+		t.Errorf("Expected redaction marker, got %q", result.Content)
+	}
+}
+
+func TestModeratorExemptAgents(t *testing.T) {
+	moderator, err := NewModerator(api.Client{}, ModerationPolicy{
+		DenyList:         []string{"forbidden"},
+		ExemptAgents:     []string{"trusted-agent"},
+		BlockOnViolation: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create moderator: %v", err)
+	}
+
+	result := moderator.Check(context.Background(), "forbidden content", "trusted-agent", ModerationInbound)
+	if !result.Allowed || len(result.Violations) != 0 {
+		t.Error("Expected exempt agent to bypass moderation")
+	}
+}
+
+func TestModeratorInvalidPattern(t *testing.T) {
+	if _, err := NewModerator(api.Client{}, ModerationPolicy{RedactPatterns: []string{"("}}); err == nil {
+		t.Error("Expected error for invalid redact pattern")
+	}
+}
+
+func TestExecuteTaskBlocksModeratedInput(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	moderator, err := NewModerator(api.Client{}, ModerationPolicy{
+		DenyList:         []string{"forbidden"},
+		BlockOnViolation: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create moderator: %v", err)
+	}
+	engine.SetModerator(moderator)
+
+	agent := &Agent{Name: "Test Agent"}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	task := &Task{
+		Type:    TaskTypeCustom,
+		Input:   "do something forbidden",
+		AgentID: agent.ID,
+	}
+
+	if _, err := engine.ExecuteTask(ctx, task, agent); !errors.Is(err, ErrModerationBlocked) {
+		t.Errorf("Expected ErrModerationBlocked, got %v", err)
+	}
+	if task.Status != TaskStatusFailed {
+		t.Errorf("Expected failed task status, got %s", task.Status)
+	}
+}
@@ -0,0 +1,120 @@
+package orchestration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// NATSPublisher publishes engine events to a NATS subject using the plain
+// text NATS protocol over TCP. Kafka (or any other platform) can be plugged
+// in by implementing EventPublisher against its own client.
+type NATSPublisher struct {
+	conn    net.Conn
+	writer  *bufio.Writer
+	subject string
+	mu      sync.Mutex
+}
+
+// NewNATSPublisher connects to a NATS server and publishes all engine events
+// to the given subject (e.g. "echollama.events").
+func NewNATSPublisher(addr, subject string) (*NATSPublisher, error) {
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats at %s: %w", addr, err)
+	}
+
+	reader := bufio.NewReader(conn)
+	writer := bufio.NewWriter(conn)
+
+	// The server greets with an INFO line; consume it and complete the
+	// handshake with a minimal CONNECT.
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	info, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("nats handshake failed: %w", err)
+	}
+	if !strings.HasPrefix(info, "INFO") {
+		conn.Close()
+		return nil, fmt.Errorf("unexpected nats greeting: %q", info)
+	}
+
+	if _, err := writer.WriteString("CONNECT {\"verbose\":false,\"name\":\"echollama\"}\r\n"); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("nats connect failed: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("nats connect failed: %w", err)
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	publisher := &NATSPublisher{
+		conn:    conn,
+		writer:  writer,
+		subject: subject,
+	}
+
+	// Answer server PINGs in the background to keep the connection alive.
+	go publisher.keepAlive(reader)
+
+	return publisher, nil
+}
+
+// keepAlive responds to server PING frames until the connection closes.
+func (p *NATSPublisher) keepAlive(reader *bufio.Reader) {
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		if strings.HasPrefix(line, "PING") {
+			p.mu.Lock()
+			p.writer.WriteString("PONG\r\n")
+			p.writer.Flush()
+			p.mu.Unlock()
+		}
+	}
+}
+
+// Publish sends an engine event to the configured subject, with the event
+// type appended as a subject token for consumer-side filtering.
+func (p *NATSPublisher) Publish(ctx context.Context, event *EngineEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	subject := fmt.Sprintf("%s.%s", p.subject, string(event.Type))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		p.conn.SetWriteDeadline(deadline)
+	} else {
+		p.conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	}
+
+	if _, err := p.writer.WriteString(fmt.Sprintf("PUB %s %d\r\n", subject, len(payload))); err != nil {
+		return fmt.Errorf("nats publish failed: %w", err)
+	}
+	if _, err := p.writer.Write(payload); err != nil {
+		return fmt.Errorf("nats publish failed: %w", err)
+	}
+	if _, err := p.writer.WriteString("\r\n"); err != nil {
+		return fmt.Errorf("nats publish failed: %w", err)
+	}
+	return p.writer.Flush()
+}
+
+// Close closes the NATS connection.
+func (p *NATSPublisher) Close() error {
+	return p.conn.Close()
+}
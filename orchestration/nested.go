@@ -0,0 +1,99 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// maxOrchestrationDepth bounds how deeply orchestrate tasks may nest, so a
+// misbehaving decomposition cannot recurse without limit.
+const maxOrchestrationDepth = 3
+
+type orchestrationChainKey struct{}
+
+// orchestrationChainFrom returns the chain of agent IDs whose orchestrations
+// enclose the current context, outermost first.
+func orchestrationChainFrom(ctx context.Context) []string {
+	if chain, ok := ctx.Value(orchestrationChainKey{}).([]string); ok {
+		return chain
+	}
+	return nil
+}
+
+// withOrchestrationChain appends an agent to the orchestration chain. The
+// slice is copied so sibling tasks sharing a parent context cannot interfere.
+func withOrchestrationChain(ctx context.Context, agentID string) context.Context {
+	parent := orchestrationChainFrom(ctx)
+	chain := make([]string, 0, len(parent)+1)
+	chain = append(chain, parent...)
+	chain = append(chain, agentID)
+	return context.WithValue(ctx, orchestrationChainKey{}, chain)
+}
+
+// executeOrchestrateTask runs a nested orchestration described by the task's
+// parameters, enabling recursive decomposition of large jobs. The nested
+// request lives under Parameters["request"] and may target a different agent;
+// when it names no agent, it runs against the current one. Depth is bounded
+// by maxOrchestrationDepth and re-entering an agent already on the chain is
+// rejected as a cycle.
+func (e *Engine) executeOrchestrateTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	chain := orchestrationChainFrom(ctx)
+	if len(chain) >= maxOrchestrationDepth {
+		return nil, fmt.Errorf("orchestrate task exceeds maximum nesting depth %d", maxOrchestrationDepth)
+	}
+
+	nested, err := nestedRequestFrom(task)
+	if err != nil {
+		return nil, err
+	}
+	if nested.AgentID == "" {
+		nested.AgentID = agent.ID
+	}
+	for _, ancestorID := range chain {
+		if ancestorID == nested.AgentID {
+			return nil, fmt.Errorf("orchestrate task cycle detected: agent %s is already orchestrating in this chain", nested.AgentID)
+		}
+	}
+
+	response, err := e.OrchestrateTasks(withOrchestrationChain(ctx, agent.ID), nested)
+	if err != nil {
+		return nil, fmt.Errorf("nested orchestration failed: %w", err)
+	}
+
+	output, marshalErr := json.Marshal(response)
+	if marshalErr != nil {
+		return nil, fmt.Errorf("failed to encode nested orchestration response: %w", marshalErr)
+	}
+
+	e.updateAgentState(agent, "nested_orchestration", response.ID)
+
+	return &TaskResult{
+		TaskID: task.ID,
+		Output: string(output),
+	}, nil
+}
+
+// nestedRequestFrom decodes the nested OrchestrationRequest from the task's
+// "request" parameter.
+func nestedRequestFrom(task *Task) (*OrchestrationRequest, error) {
+	raw, ok := task.Parameters["request"]
+	if !ok {
+		return nil, fmt.Errorf("orchestrate task requires a 'request' parameter")
+	}
+
+	// Round-trip through JSON so both typed requests and generic maps (as
+	// delivered over the HTTP API) decode uniformly.
+	encoded, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nested orchestration request: %v", err)
+	}
+	var nested OrchestrationRequest
+	if err := json.Unmarshal(encoded, &nested); err != nil {
+		return nil, fmt.Errorf("invalid nested orchestration request: %v", err)
+	}
+	if len(nested.Tasks) == 0 {
+		return nil, fmt.Errorf("nested orchestration request declares no tasks")
+	}
+	return &nested, nil
+}
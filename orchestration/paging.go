@@ -0,0 +1,124 @@
+package orchestration
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultPageLimit bounds list responses when the caller does not pick a
+// page size.
+const defaultPageLimit = 50
+
+// listPage is the slice of a collection a list request asked for.
+type listPage struct {
+	Limit  int
+	Offset int
+}
+
+// pageFromQuery reads limit/offset pagination from the request query,
+// falling back to the default page size.
+func pageFromQuery(c *gin.Context) listPage {
+	page := listPage{Limit: defaultPageLimit}
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		page.Limit = n
+	}
+	if n, err := strconv.Atoi(c.Query("offset")); err == nil && n > 0 {
+		page.Offset = n
+	}
+	return page
+}
+
+// bounds clamps the page to a collection of the given size.
+func (p listPage) bounds(total int) (int, int) {
+	start := p.Offset
+	if start > total {
+		start = total
+	}
+	end := start + p.Limit
+	if end > total {
+		end = total
+	}
+	return start, end
+}
+
+// meta builds the envelope metadata for a page over total items: the
+// total count plus the offset of the next page, omitted on the last one.
+func (p listPage) meta(total int) gin.H {
+	meta := gin.H{
+		"total":  total,
+		"limit":  p.Limit,
+		"offset": p.Offset,
+	}
+	if _, end := p.bounds(total); end < total {
+		meta["next_offset"] = end
+	}
+	return meta
+}
+
+// sortAgents orders agents by a sort field (name, created_at, or
+// updated_at; default name) in asc or desc order.
+func sortAgents(agents []*Agent, field, order string) {
+	descending := order == "desc"
+	sort.SliceStable(agents, func(a, b int) bool {
+		var less bool
+		switch field {
+		case "created_at":
+			less = agents[a].CreatedAt.Before(agents[b].CreatedAt)
+		case "updated_at":
+			less = agents[a].UpdatedAt.Before(agents[b].UpdatedAt)
+		default:
+			less = agents[a].Name < agents[b].Name
+		}
+		if descending {
+			return !less
+		}
+		return less
+	})
+}
+
+// sortConversations orders conversations by created_at or updated_at
+// (default updated_at, newest first).
+func sortConversations(conversations []*Conversation, field, order string) {
+	if order == "" {
+		order = "desc"
+	}
+	descending := order == "desc"
+	sort.SliceStable(conversations, func(a, b int) bool {
+		var less bool
+		switch field {
+		case "created_at":
+			less = conversations[a].CreatedAt.Before(conversations[b].CreatedAt)
+		default:
+			less = conversations[a].UpdatedAt.Before(conversations[b].UpdatedAt)
+		}
+		if descending {
+			return !less
+		}
+		return less
+	})
+}
+
+// filterAgents keeps the agents matching a type, tool, model, and name
+// substring; empty filters match everything.
+func filterAgents(agents []*Agent, agentType, tool, model, name string) []*Agent {
+	filtered := make([]*Agent, 0, len(agents))
+	for _, agent := range agents {
+		if agentType != "" && string(agent.Type) != agentType {
+			continue
+		}
+		if tool != "" && !containsString(agent.Tools, tool) {
+			continue
+		}
+		if model != "" && !containsString(agent.Models, model) {
+			continue
+		}
+		if name != "" && !strings.Contains(strings.ToLower(agent.Name), strings.ToLower(name)) {
+			continue
+		}
+		filtered = append(filtered, agent)
+	}
+	return filtered
+}
@@ -0,0 +1,75 @@
+package orchestration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListPageBoundsAndMeta(t *testing.T) {
+	page := listPage{Limit: 2, Offset: 2}
+
+	start, end := page.bounds(5)
+	if start != 2 || end != 4 {
+		t.Errorf("Expected bounds [2, 4), got [%d, %d)", start, end)
+	}
+
+	meta := page.meta(5)
+	if meta["total"] != 5 || meta["next_offset"] != 4 {
+		t.Errorf("Unexpected page metadata: %v", meta)
+	}
+
+	last := listPage{Limit: 2, Offset: 4}
+	if _, exists := last.meta(5)["next_offset"]; exists {
+		t.Error("Expected no next_offset on the last page")
+	}
+
+	beyond := listPage{Limit: 2, Offset: 10}
+	start, end = beyond.bounds(5)
+	if start != 5 || end != 5 {
+		t.Errorf("Expected an empty page past the end, got [%d, %d)", start, end)
+	}
+}
+
+func TestFilterAndSortAgents(t *testing.T) {
+	agents := []*Agent{
+		{Name: "charlie", Type: AgentTypeGeneral, Tools: []string{"calculator"}, CreatedAt: time.Now()},
+		{Name: "alpha", Type: AgentTypeSpecialist, Models: []string{"codellama"}, CreatedAt: time.Now().Add(-time.Hour)},
+		{Name: "bravo", Type: AgentTypeGeneral, CreatedAt: time.Now().Add(-2 * time.Hour)},
+	}
+
+	general := filterAgents(agents, string(AgentTypeGeneral), "", "", "")
+	if len(general) != 2 {
+		t.Errorf("Expected 2 general agents, got %d", len(general))
+	}
+	if tooled := filterAgents(agents, "", "calculator", "", ""); len(tooled) != 1 || tooled[0].Name != "charlie" {
+		t.Errorf("Expected the calculator agent, got %v", tooled)
+	}
+	if named := filterAgents(agents, "", "", "", "ALP"); len(named) != 1 || named[0].Name != "alpha" {
+		t.Errorf("Expected a case-insensitive name match, got %v", named)
+	}
+
+	sortAgents(agents, "", "")
+	if agents[0].Name != "alpha" || agents[2].Name != "charlie" {
+		t.Errorf("Expected agents sorted by name, got %v", agents)
+	}
+	sortAgents(agents, "created_at", "desc")
+	if agents[0].Name != "charlie" {
+		t.Errorf("Expected newest agent first, got %v", agents)
+	}
+}
+
+func TestSortConversations(t *testing.T) {
+	conversations := []*Conversation{
+		{Topic: "old", UpdatedAt: time.Now().Add(-time.Hour)},
+		{Topic: "new", UpdatedAt: time.Now()},
+	}
+
+	sortConversations(conversations, "", "")
+	if conversations[0].Topic != "new" {
+		t.Errorf("Expected most recently updated first, got %v", conversations)
+	}
+	sortConversations(conversations, "updated_at", "asc")
+	if conversations[0].Topic != "old" {
+		t.Errorf("Expected oldest first in ascending order, got %v", conversations)
+	}
+}
@@ -0,0 +1,265 @@
+package orchestration
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// PostgresStore is a Store backed by PostgreSQL for multi-instance
+// deployments: replicas of the APIServer behind a load balancer share
+// agents, tasks, and conversations through the database. It is written
+// against database/sql so the module stays free of a driver dependency —
+// callers open the *sql.DB with whichever registered driver they build in
+// (pgx, lib/pq) and hand it to NewPostgresStore, which applies the schema
+// migrations before returning.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// postgresMigrations are applied in order on startup. Each statement runs
+// exactly once; applied versions are recorded in
+// orchestration_schema_migrations.
+var postgresMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS orchestration_agents (
+		id TEXT PRIMARY KEY,
+		record JSONB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS orchestration_tasks (
+		id TEXT PRIMARY KEY,
+		record JSONB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS orchestration_conversations (
+		id TEXT PRIMARY KEY,
+		record JSONB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS orchestration_tasks_status ON orchestration_tasks ((record->>'status'))`,
+}
+
+// NewPostgresStore wraps an open database handle, applying any pending
+// schema migrations.
+func NewPostgresStore(ctx context.Context, db *sql.DB) (*PostgresStore, error) {
+	store := &PostgresStore{db: db}
+	if err := store.migrate(ctx); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// migrate applies the schema migrations that have not run yet.
+func (s *PostgresStore) migrate(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS orchestration_schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	for version, statement := range postgresMigrations {
+		var applied bool
+		if err := s.db.QueryRowContext(ctx,
+			`SELECT EXISTS (SELECT 1 FROM orchestration_schema_migrations WHERE version = $1)`,
+			version).Scan(&applied); err != nil {
+			return fmt.Errorf("failed to check migration %d: %w", version, err)
+		}
+		if applied {
+			continue
+		}
+
+		tx, err := s.db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin migration %d: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to apply migration %d: %w", version, err)
+		}
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO orchestration_schema_migrations (version) VALUES ($1)`, version); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to record migration %d: %w", version, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
+
+// upsert writes a JSON record into the given table under its ID.
+func (s *PostgresStore) upsert(ctx context.Context, table, id string, record interface{}) error {
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (id, record) VALUES ($1, $2)
+			ON CONFLICT (id) DO UPDATE SET record = EXCLUDED.record`, table),
+		id, raw)
+	if err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+// get reads a JSON record by ID into out, translating missing rows into
+// ErrNotFound.
+func (s *PostgresStore) get(ctx context.Context, table, id string, out interface{}) error {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf(`SELECT record FROM %s WHERE id = $1`, table), id).Scan(&raw)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read record: %w", err)
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// remove deletes a record by ID, translating missing rows into ErrNotFound.
+func (s *PostgresStore) remove(ctx context.Context, table, id string) error {
+	result, err := s.db.ExecContext(ctx,
+		fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, table), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete record: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SaveAgent creates or updates an agent record.
+func (s *PostgresStore) SaveAgent(ctx context.Context, agent *Agent) error {
+	return s.upsert(ctx, "orchestration_agents", agent.ID, agent)
+}
+
+// GetAgent retrieves an agent record by ID.
+func (s *PostgresStore) GetAgent(ctx context.Context, id string) (*Agent, error) {
+	var agent Agent
+	if err := s.get(ctx, "orchestration_agents", id, &agent); err != nil {
+		return nil, err
+	}
+	return &agent, nil
+}
+
+// ListAgents returns all stored agents.
+func (s *PostgresStore) ListAgents(ctx context.Context) ([]*Agent, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT record FROM orchestration_agents`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	defer rows.Close()
+
+	agents := make([]*Agent, 0)
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("failed to scan agent: %w", err)
+		}
+		var agent Agent
+		if err := json.Unmarshal(raw, &agent); err != nil {
+			return nil, fmt.Errorf("failed to decode agent: %w", err)
+		}
+		agents = append(agents, &agent)
+	}
+	return agents, rows.Err()
+}
+
+// DeleteAgent removes an agent record.
+func (s *PostgresStore) DeleteAgent(ctx context.Context, id string) error {
+	return s.remove(ctx, "orchestration_agents", id)
+}
+
+// SaveTask creates or updates a task record.
+func (s *PostgresStore) SaveTask(ctx context.Context, task *Task) error {
+	return s.upsert(ctx, "orchestration_tasks", task.ID, task)
+}
+
+// GetTask retrieves a task record by ID.
+func (s *PostgresStore) GetTask(ctx context.Context, id string) (*Task, error) {
+	var task Task
+	if err := s.get(ctx, "orchestration_tasks", id, &task); err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ListTasks returns stored tasks matching the filter.
+func (s *PostgresStore) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT record FROM orchestration_tasks`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	tasks := make([]*Task, 0)
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		var task Task
+		if err := json.Unmarshal(raw, &task); err != nil {
+			return nil, fmt.Errorf("failed to decode task: %w", err)
+		}
+		if filter.matches(&task) {
+			tasks = append(tasks, &task)
+		}
+	}
+	return tasks, rows.Err()
+}
+
+// DeleteTask removes a task record.
+func (s *PostgresStore) DeleteTask(ctx context.Context, id string) error {
+	return s.remove(ctx, "orchestration_tasks", id)
+}
+
+// SaveConversation creates or updates a conversation record.
+func (s *PostgresStore) SaveConversation(ctx context.Context, conversation *Conversation) error {
+	return s.upsert(ctx, "orchestration_conversations", conversation.ID, conversation)
+}
+
+// GetConversation retrieves a conversation record by ID.
+func (s *PostgresStore) GetConversation(ctx context.Context, id string) (*Conversation, error) {
+	var conversation Conversation
+	if err := s.get(ctx, "orchestration_conversations", id, &conversation); err != nil {
+		return nil, err
+	}
+	return &conversation, nil
+}
+
+// ListConversations returns conversations involving the given agent, or all
+// conversations when agentID is empty.
+func (s *PostgresStore) ListConversations(ctx context.Context, agentID string) ([]*Conversation, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT record FROM orchestration_conversations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversations: %w", err)
+	}
+	defer rows.Close()
+
+	conversations := make([]*Conversation, 0)
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("failed to scan conversation: %w", err)
+		}
+		var conversation Conversation
+		if err := json.Unmarshal(raw, &conversation); err != nil {
+			return nil, fmt.Errorf("failed to decode conversation: %w", err)
+		}
+		if agentID == "" {
+			conversations = append(conversations, &conversation)
+			continue
+		}
+		for _, participant := range conversation.Participants {
+			if participant == agentID {
+				conversations = append(conversations, &conversation)
+				break
+			}
+		}
+	}
+	return conversations, rows.Err()
+}
@@ -0,0 +1,191 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// maxPostProcessRetries is how many corrective generations are attempted when
+// a post-processor rejects model output.
+const maxPostProcessRetries = 2
+
+// PostProcessor transforms or validates raw model output before it becomes
+// the task output. Returning an error triggers a corrective retry with the
+// failure fed back to the model.
+type PostProcessor interface {
+	Name() string
+	Process(ctx context.Context, task *Task, output string) (string, error)
+}
+
+// PostProcessorFunc adapts a function to the PostProcessor interface.
+type PostProcessorFunc struct {
+	ProcessorName string
+	Fn            func(ctx context.Context, task *Task, output string) (string, error)
+}
+
+// Name returns the processor's registered name.
+func (p *PostProcessorFunc) Name() string { return p.ProcessorName }
+
+// Process invokes the wrapped function.
+func (p *PostProcessorFunc) Process(ctx context.Context, task *Task, output string) (string, error) {
+	return p.Fn(ctx, task, output)
+}
+
+// RegisterPostProcessor registers a named post-processor that tasks can
+// reference from their post_processors parameter.
+func (e *Engine) RegisterPostProcessor(processor PostProcessor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.postProcessors[processor.Name()] = processor
+}
+
+// postProcessorSpec is one entry in a task's post-processing chain.
+type postProcessorSpec struct {
+	name   string
+	config map[string]interface{}
+}
+
+// postProcessorSpecs parses the chain declared under
+// Parameters["post_processors"]: a list of names or {name, config} objects.
+func postProcessorSpecs(task *Task) ([]postProcessorSpec, error) {
+	raw, ok := task.Parameters["post_processors"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	specs := make([]postProcessorSpec, 0, len(raw))
+	for i, entry := range raw {
+		switch value := entry.(type) {
+		case string:
+			specs = append(specs, postProcessorSpec{name: value})
+		case map[string]interface{}:
+			name, _ := value["name"].(string)
+			if name == "" {
+				return nil, fmt.Errorf("post-processor %d has no name", i)
+			}
+			config, _ := value["config"].(map[string]interface{})
+			specs = append(specs, postProcessorSpec{name: name, config: config})
+		default:
+			return nil, fmt.Errorf("invalid post-processor entry %d: %T", i, entry)
+		}
+	}
+	return specs, nil
+}
+
+// applyPostProcessing runs a task's post-processing chain over model output.
+// When a processor rejects the output, the model is asked to correct it with
+// the validation failure as feedback, up to maxPostProcessRetries times.
+func (e *Engine) applyPostProcessing(ctx context.Context, modelName string, task *Task, output string) (string, error) {
+	specs, err := postProcessorSpecs(task)
+	if err != nil {
+		return "", err
+	}
+	if len(specs) == 0 {
+		return output, nil
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= maxPostProcessRetries; attempt++ {
+		processed, err := e.runPostProcessorChain(ctx, specs, task, output)
+		if err == nil {
+			return processed, nil
+		}
+		lastErr = err
+		if attempt == maxPostProcessRetries {
+			break
+		}
+
+		output, err = e.regenerateWithFeedback(ctx, modelName, task, output, lastErr)
+		if err != nil {
+			return "", fmt.Errorf("post-processing retry failed: %w", err)
+		}
+	}
+	return "", fmt.Errorf("output failed post-processing after %d retries: %w", maxPostProcessRetries, lastErr)
+}
+
+// runPostProcessorChain applies each processor in order, feeding the output
+// of one into the next.
+func (e *Engine) runPostProcessorChain(ctx context.Context, specs []postProcessorSpec, task *Task, output string) (string, error) {
+	for _, spec := range specs {
+		processed, err := e.runPostProcessor(ctx, spec, task, output)
+		if err != nil {
+			return "", fmt.Errorf("post-processor %s: %w", spec.name, err)
+		}
+		output = processed
+	}
+	return output, nil
+}
+
+// runPostProcessor applies one built-in or registered processor.
+func (e *Engine) runPostProcessor(ctx context.Context, spec postProcessorSpec, task *Task, output string) (string, error) {
+	switch spec.name {
+	case "trim":
+		return strings.TrimSpace(output), nil
+
+	case "regex_extract":
+		pattern, _ := spec.config["pattern"].(string)
+		if pattern == "" {
+			return "", fmt.Errorf("regex_extract requires a pattern")
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern: %v", err)
+		}
+		match := re.FindStringSubmatch(output)
+		if match == nil {
+			return "", fmt.Errorf("output does not match pattern %q", pattern)
+		}
+		if len(match) > 1 {
+			return match[1], nil
+		}
+		return match[0], nil
+
+	case "json_parse":
+		value, err := extractJSON(output)
+		if err != nil {
+			return "", err
+		}
+		canonical, err := json.Marshal(value)
+		if err != nil {
+			return "", err
+		}
+		return string(canonical), nil
+
+	default:
+		e.mu.RLock()
+		processor, exists := e.postProcessors[spec.name]
+		e.mu.RUnlock()
+		if !exists {
+			return "", fmt.Errorf("%w: post-processor %s", ErrNotFound, spec.name)
+		}
+		return processor.Process(ctx, task, output)
+	}
+}
+
+// regenerateWithFeedback asks the model to correct output that failed
+// post-processing.
+func (e *Engine) regenerateWithFeedback(ctx context.Context, modelName string, task *Task, output string, processingErr error) (string, error) {
+	prompt := fmt.Sprintf(
+		"Your previous response failed validation (%v).\n\nPrevious response:\n%s\n\nOriginal request:\n%s\n\nRespond again, correcting the problem.",
+		processingErr, output, task.InputText())
+
+	req := &api.GenerateRequest{
+		Model:  modelName,
+		Prompt: prompt,
+	}
+
+	var corrected string
+	err := e.client.Generate(ctx, req, func(resp api.GenerateResponse) error {
+		corrected += resp.Response
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return corrected, nil
+}
@@ -0,0 +1,124 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestPostProcessorSpecs(t *testing.T) {
+	task := &Task{Parameters: map[string]interface{}{
+		"post_processors": []interface{}{
+			"trim",
+			map[string]interface{}{
+				"name":   "regex_extract",
+				"config": map[string]interface{}{"pattern": `\d+`},
+			},
+		},
+	}}
+
+	specs, err := postProcessorSpecs(task)
+	if err != nil {
+		t.Fatalf("postProcessorSpecs failed: %v", err)
+	}
+	if len(specs) != 2 || specs[0].name != "trim" || specs[1].name != "regex_extract" {
+		t.Errorf("Unexpected specs: %+v", specs)
+	}
+
+	if _, err := postProcessorSpecs(&Task{Parameters: map[string]interface{}{
+		"post_processors": []interface{}{map[string]interface{}{"config": map[string]interface{}{}}},
+	}}); err == nil {
+		t.Error("Expected error for unnamed processor")
+	}
+}
+
+func TestRunPostProcessorBuiltins(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	task := &Task{ID: "pp-1"}
+
+	out, err := engine.runPostProcessor(ctx, postProcessorSpec{name: "trim"}, task, "  padded  ")
+	if err != nil || out != "padded" {
+		t.Errorf("trim: got %q, %v", out, err)
+	}
+
+	out, err = engine.runPostProcessor(ctx, postProcessorSpec{
+		name:   "regex_extract",
+		config: map[string]interface{}{"pattern": `answer is (\d+)`},
+	}, task, "The answer is 42, obviously")
+	if err != nil || out != "42" {
+		t.Errorf("regex_extract: got %q, %v", out, err)
+	}
+
+	if _, err := engine.runPostProcessor(ctx, postProcessorSpec{
+		name:   "regex_extract",
+		config: map[string]interface{}{"pattern": `missing`},
+	}, task, "no match here"); err == nil {
+		t.Error("Expected error for unmatched pattern")
+	}
+
+	out, err = engine.runPostProcessor(ctx, postProcessorSpec{name: "json_parse"}, task,
+		"Here you go:\n```json\n{\"ok\": true}\n```")
+	if err != nil || !strings.Contains(out, `"ok":true`) {
+		t.Errorf("json_parse: got %q, %v", out, err)
+	}
+
+	if _, err := engine.runPostProcessor(ctx, postProcessorSpec{name: "nonexistent"}, task, "x"); err == nil {
+		t.Error("Expected error for unknown processor")
+	}
+}
+
+func TestRegisteredPostProcessor(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	engine.RegisterPostProcessor(&PostProcessorFunc{
+		ProcessorName: "require-greeting",
+		Fn: func(ctx context.Context, task *Task, output string) (string, error) {
+			if !strings.HasPrefix(output, "Hello") {
+				return "", fmt.Errorf("output must start with Hello")
+			}
+			return output, nil
+		},
+	})
+
+	task := &Task{
+		ID: "pp-2",
+		Parameters: map[string]interface{}{
+			"post_processors": []interface{}{"require-greeting"},
+		},
+	}
+
+	out, err := engine.applyPostProcessing(context.Background(), "llama3.2", task, "Hello world")
+	if err != nil || out != "Hello world" {
+		t.Errorf("Expected passing output, got %q, %v", out, err)
+	}
+}
+
+func TestApplyPostProcessingChain(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	task := &Task{
+		ID: "pp-3",
+		Parameters: map[string]interface{}{
+			"post_processors": []interface{}{
+				"trim",
+				map[string]interface{}{
+					"name":   "regex_extract",
+					"config": map[string]interface{}{"pattern": `(\w+)!`},
+				},
+			},
+		},
+	}
+
+	out, err := engine.applyPostProcessing(context.Background(), "llama3.2", task, "   done! extra   ")
+	if err != nil || out != "done" {
+		t.Errorf("Expected chained result 'done', got %q, %v", out, err)
+	}
+
+	// Tasks without a chain pass output through untouched
+	out, err = engine.applyPostProcessing(context.Background(), "llama3.2", &Task{ID: "pp-4"}, " raw ")
+	if err != nil || out != " raw " {
+		t.Errorf("Expected untouched output, got %q, %v", out, err)
+	}
+}
@@ -0,0 +1,48 @@
+package orchestration
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/EchoCog/echollama/envconfig"
+)
+
+// setupPprofRoutes mounts the net/http/pprof handlers under /debug/pprof,
+// guarded by pprofAuth. The reservoir and embedding loops are the hot paths
+// these profiles exist to tune.
+func (s *APIServer) setupPprofRoutes() {
+	debug := s.router.Group("/debug/pprof")
+	debug.Use(s.pprofAuth())
+	{
+		debug.GET("/", gin.WrapF(pprof.Index))
+		debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		debug.GET("/profile", gin.WrapF(pprof.Profile))
+		debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+		debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/trace", gin.WrapF(pprof.Trace))
+		for _, name := range []string{"allocs", "block", "goroutine", "heap", "mutex", "threadcreate"} {
+			debug.GET("/"+name, gin.WrapH(pprof.Handler(name)))
+		}
+	}
+}
+
+// pprofAuth requires the OLLAMA_PPROF_TOKEN bearer token on the profiling
+// endpoints, mirroring the guard on the main server's mux. With no token
+// configured the endpoints reject every request.
+func (s *APIServer) pprofAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := envconfig.PprofToken()
+		presented := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || presented != token {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"status": "error",
+				"error":  "invalid profiling token",
+			})
+			return
+		}
+		c.Next()
+	}
+}
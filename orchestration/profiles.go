@@ -0,0 +1,182 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// AgentProfile describes how to materialize a specialized agent for a type
+// and optional domain: preferred models, default tools, a system prompt, and
+// configuration including memory policy. Profiles make a "coding" specialist
+// genuinely different from an "analysis" one instead of differing only by a
+// type string.
+type AgentProfile struct {
+	Type AgentType `json:"type"`
+	// Domain scopes the profile to one specialization; empty applies to any
+	// domain of the type. Domain-specific profiles win over type-wide ones.
+	Domain      string   `json:"domain,omitempty"`
+	Description string   `json:"description"` // supports {{domain}}
+	Models      []string `json:"models"`
+	Tools       []string `json:"tools,omitempty"`
+	// SystemPrompt is registered as a per-agent "system" template when an
+	// agent is created from this profile. Supports {{domain}}.
+	SystemPrompt string `json:"system_prompt,omitempty"`
+	// MemoryLimit caps the agent's retained context items; 0 uses the
+	// engine default.
+	MemoryLimit int                    `json:"memory_limit,omitempty"`
+	Config      map[string]interface{} `json:"config,omitempty"`
+}
+
+// ProfileRegistry holds the agent capability profiles the engine creates
+// specialized agents from.
+type ProfileRegistry struct {
+	profiles map[string]*AgentProfile
+	mu       sync.RWMutex
+}
+
+// profileKey builds the registry key for a type and domain.
+func profileKey(agentType AgentType, domain string) string {
+	if domain == "" {
+		return string(agentType)
+	}
+	return string(agentType) + "/" + domain
+}
+
+// NewProfileRegistry creates a registry seeded with the built-in profiles.
+func NewProfileRegistry() *ProfileRegistry {
+	registry := &ProfileRegistry{profiles: make(map[string]*AgentProfile)}
+	registry.seedDefaults()
+	return registry
+}
+
+// LoadAgentProfiles creates a registry from a JSON file containing an array
+// of profiles, layered over the built-in defaults.
+func LoadAgentProfiles(path string) (*ProfileRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent profiles: %w", err)
+	}
+
+	var profiles []*AgentProfile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to parse agent profiles: %w", err)
+	}
+
+	registry := NewProfileRegistry()
+	for _, profile := range profiles {
+		if profile.Type == "" {
+			return nil, fmt.Errorf("agent profile missing type")
+		}
+		registry.Register(profile)
+	}
+	return registry, nil
+}
+
+// Register adds or replaces a profile for its type/domain.
+func (r *ProfileRegistry) Register(profile *AgentProfile) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.profiles[profileKey(profile.Type, profile.Domain)] = profile
+}
+
+// Get returns the profile for a type and domain, preferring a domain-specific
+// profile over the type-wide one.
+func (r *ProfileRegistry) Get(agentType AgentType, domain string) (*AgentProfile, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if domain != "" {
+		if profile, ok := r.profiles[profileKey(agentType, domain)]; ok {
+			return profile, nil
+		}
+	}
+	if profile, ok := r.profiles[profileKey(agentType, "")]; ok {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("%w: agent profile for type %s", ErrNotFound, agentType)
+}
+
+// List returns all registered profiles.
+func (r *ProfileRegistry) List() []*AgentProfile {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	profiles := make([]*AgentProfile, 0, len(r.profiles))
+	for _, profile := range r.profiles {
+		profiles = append(profiles, profile)
+	}
+	return profiles
+}
+
+// seedDefaults registers the built-in type-wide profiles plus richer
+// domain-specific specialist profiles.
+func (r *ProfileRegistry) seedDefaults() {
+	defaults := []*AgentProfile{
+		{
+			Type:        AgentTypeReflective,
+			Description: "Self-reflective agent specialized in {{domain}}",
+			Models:      []string{"llama3.2", "llama2"},
+			Tools:       []string{"data_analysis"},
+			Config: map[string]interface{}{
+				"reflection_interval": 300,
+				"learning_rate":       0.1,
+			},
+		},
+		{
+			Type:        AgentTypeOrchestrator,
+			Description: "Orchestrator agent for coordinating multiple agents and complex workflows",
+			Models:      []string{"llama3.2"},
+			Tools:       []string{"web_search", "calculator", "data_analysis"},
+			Config: map[string]interface{}{
+				"max_sub_agents":    5,
+				"coordination_mode": "hierarchical",
+			},
+		},
+		{
+			Type:        AgentTypeSpecialist,
+			Description: "Specialist agent for {{domain}} domain tasks",
+			Models:      []string{"llama3.2", "codellama"},
+			Tools:       []string{"web_search", "data_analysis"},
+			Config: map[string]interface{}{
+				"expertise_level": "advanced",
+			},
+		},
+		{
+			Type:        AgentTypeSpecialist,
+			Domain:      "coding",
+			Description: "Coding specialist for software development tasks",
+			Models:      []string{"codellama", "llama3.2"},
+			Tools:       []string{"web_search", "knowledge_search"},
+			SystemPrompt: "You are an expert software engineer. Answer with precise, " +
+				"idiomatic code and point out edge cases and pitfalls.",
+			MemoryLimit: 20,
+			Config: map[string]interface{}{
+				"expertise_level": "advanced",
+			},
+		},
+		{
+			Type:        AgentTypeSpecialist,
+			Domain:      "analysis",
+			Description: "Analysis specialist for data exploration and reporting",
+			Models:      []string{"llama3.2"},
+			Tools:       []string{"data_analysis", "calculator", "knowledge_search"},
+			SystemPrompt: "You are a meticulous data analyst. Ground every conclusion " +
+				"in the data provided and quantify uncertainty.",
+			MemoryLimit: 30,
+			Config: map[string]interface{}{
+				"expertise_level": "advanced",
+			},
+		},
+	}
+
+	for _, profile := range defaults {
+		r.profiles[profileKey(profile.Type, profile.Domain)] = profile
+	}
+}
+
+// GetProfileRegistry returns the engine's agent profile registry.
+func (e *Engine) GetProfileRegistry() *ProfileRegistry {
+	return e.profiles
+}
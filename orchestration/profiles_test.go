@@ -0,0 +1,102 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestProfileRegistryDomainPrecedence(t *testing.T) {
+	registry := NewProfileRegistry()
+
+	// Domain-specific profile wins over the type-wide one
+	coding, err := registry.Get(AgentTypeSpecialist, "coding")
+	if err != nil {
+		t.Fatalf("Failed to get coding profile: %v", err)
+	}
+	if coding.Models[0] != "codellama" {
+		t.Errorf("Expected coding specialist to prefer codellama, got %v", coding.Models)
+	}
+	if coding.SystemPrompt == "" {
+		t.Error("Expected coding specialist to have a system prompt")
+	}
+
+	// Unknown domains fall back to the type-wide profile
+	generic, err := registry.Get(AgentTypeSpecialist, "gardening")
+	if err != nil {
+		t.Fatalf("Failed to get fallback profile: %v", err)
+	}
+	if generic.Domain != "" {
+		t.Errorf("Expected type-wide fallback, got domain %q", generic.Domain)
+	}
+
+	if _, err := registry.Get(AgentType("unknown"), ""); err == nil {
+		t.Error("Expected error for unregistered type")
+	}
+}
+
+func TestLoadAgentProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles.json")
+	profiles := []*AgentProfile{{
+		Type:        AgentTypeSpecialist,
+		Domain:      "security",
+		Description: "Security review specialist",
+		Models:      []string{"llama3.2"},
+		MemoryLimit: 15,
+	}}
+	data, _ := json.Marshal(profiles)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("Failed to write profiles file: %v", err)
+	}
+
+	registry, err := LoadAgentProfiles(path)
+	if err != nil {
+		t.Fatalf("Failed to load profiles: %v", err)
+	}
+
+	security, err := registry.Get(AgentTypeSpecialist, "security")
+	if err != nil {
+		t.Fatalf("Expected loaded profile: %v", err)
+	}
+	if security.MemoryLimit != 15 {
+		t.Errorf("Expected memory limit 15, got %d", security.MemoryLimit)
+	}
+
+	// Built-in defaults are still present underneath
+	if _, err := registry.Get(AgentTypeOrchestrator, ""); err != nil {
+		t.Errorf("Expected built-in orchestrator profile: %v", err)
+	}
+}
+
+func TestCreateSpecializedAgentFromProfile(t *testing.T) {
+	client := api.Client{}
+	engine := NewEngine(client)
+	ctx := context.Background()
+
+	agent, err := engine.CreateSpecializedAgent(ctx, AgentTypeSpecialist, "coding")
+	if err != nil {
+		t.Fatalf("CreateSpecializedAgent failed: %v", err)
+	}
+
+	if agent.Name != "specialist-coding" {
+		t.Errorf("Unexpected agent name: %s", agent.Name)
+	}
+	if agent.Models[0] != "codellama" {
+		t.Errorf("Expected profile models to apply, got %v", agent.Models)
+	}
+	if agent.Config["memory_limit"] != 20 {
+		t.Errorf("Expected memory limit from profile, got %v", agent.Config["memory_limit"])
+	}
+	if agent.Config["specialization"] != "coding" {
+		t.Errorf("Expected specialization config, got %v", agent.Config["specialization"])
+	}
+
+	// The profile's system prompt is registered as a per-agent override
+	if system := engine.resolveSystemPrompt(agent); system == "" {
+		t.Error("Expected system prompt to resolve for profiled agent")
+	}
+}
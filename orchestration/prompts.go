@@ -0,0 +1,299 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PromptKind classifies what a prompt template is used for.
+type PromptKind string
+
+const (
+	PromptKindSystem     PromptKind = "system"
+	PromptKindTask       PromptKind = "task"
+	PromptKindReflection PromptKind = "reflection"
+)
+
+// PromptTemplate is one immutable version of a named prompt. Templates use
+// {{variable}} placeholders, matching the workflow placeholder syntax.
+type PromptTemplate struct {
+	Name      string     `json:"name"`
+	Version   int        `json:"version"`
+	Kind      PromptKind `json:"kind"`
+	AgentID   string     `json:"agent_id,omitempty"` // Non-empty for per-agent overrides
+	Template  string     `json:"template"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+var promptVariablePattern = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// Variables returns the placeholder names referenced by the template.
+func (t *PromptTemplate) Variables() []string {
+	seen := make(map[string]bool)
+	variables := make([]string, 0)
+	for _, match := range promptVariablePattern.FindAllStringSubmatch(t.Template, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			variables = append(variables, match[1])
+		}
+	}
+	return variables
+}
+
+// Render substitutes variables into the template via text/template, with
+// the shared helper functions available. Templates that fail to render
+// (malformed actions or missing variables) fall back to plain placeholder
+// substitution, leaving unknown placeholders intact so they remain visible
+// in the output.
+func (t *PromptTemplate) Render(vars map[string]string) string {
+	if rendered, err := renderTemplate(t.Template, templateData(vars)); err == nil {
+		return rendered
+	}
+	return promptVariablePattern.ReplaceAllStringFunc(t.Template, func(match string) string {
+		name := promptVariablePattern.FindStringSubmatch(match)[1]
+		if value, ok := vars[name]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// RenderStrict renders the template, failing on missing variables or
+// malformed actions instead of falling back.
+func (t *PromptTemplate) RenderStrict(vars map[string]string) (string, error) {
+	return renderTemplate(t.Template, templateData(vars))
+}
+
+// PromptLibrary stores named, versioned prompt templates with optional
+// per-agent overrides. When a file path is configured the library persists
+// itself as JSON after every mutation.
+type PromptLibrary struct {
+	templates map[string][]*PromptTemplate // key: scopeKey(agentID, name), versions ascending
+	path      string
+	mu        sync.RWMutex
+}
+
+// NewPromptLibrary creates an empty in-memory prompt library seeded with the
+// engine's default prompts.
+func NewPromptLibrary() *PromptLibrary {
+	library := &PromptLibrary{templates: make(map[string][]*PromptTemplate)}
+	library.seedDefaults()
+	return library
+}
+
+// LoadPromptLibrary creates a prompt library persisted at the given path,
+// loading any existing templates from it.
+func LoadPromptLibrary(path string) (*PromptLibrary, error) {
+	library := &PromptLibrary{
+		templates: make(map[string][]*PromptTemplate),
+		path:      path,
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			library.seedDefaults()
+			return library, nil
+		}
+		return nil, fmt.Errorf("failed to read prompt library: %w", err)
+	}
+
+	var templates []*PromptTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt library: %w", err)
+	}
+
+	for _, template := range templates {
+		key := scopeKey(template.AgentID, template.Name)
+		library.templates[key] = append(library.templates[key], template)
+	}
+	for _, versions := range library.templates {
+		sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	}
+
+	library.seedDefaults()
+	return library, nil
+}
+
+// seedDefaults registers built-in prompts if they are not already present.
+func (l *PromptLibrary) seedDefaults() {
+	if _, err := l.Get("agent_reflection", ""); err != nil {
+		l.Put("agent_reflection", "", PromptKindReflection,
+			"Agent '{{agent_name}}' reflecting on: {{input}}")
+	}
+}
+
+// scopeKey builds the lookup key for a template, scoped per agent when an
+// agent ID is given.
+func scopeKey(agentID, name string) string {
+	if agentID == "" {
+		return name
+	}
+	return agentID + "/" + name
+}
+
+// Put registers a new version of a named template. An empty agentID creates
+// or updates the shared template; a non-empty one creates a per-agent
+// override consulted before the shared version.
+func (l *PromptLibrary) Put(name, agentID string, kind PromptKind, template string) *PromptTemplate {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	key := scopeKey(agentID, name)
+	versions := l.templates[key]
+
+	entry := &PromptTemplate{
+		Name:      name,
+		Version:   len(versions) + 1,
+		Kind:      kind,
+		AgentID:   agentID,
+		Template:  template,
+		CreatedAt: time.Now(),
+	}
+	l.templates[key] = append(versions, entry)
+
+	l.persistLocked()
+	return entry
+}
+
+// Get returns the latest version of a named template, preferring a per-agent
+// override when one exists for the given agent.
+func (l *PromptLibrary) Get(name, agentID string) (*PromptTemplate, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if agentID != "" {
+		if versions := l.templates[scopeKey(agentID, name)]; len(versions) > 0 {
+			return versions[len(versions)-1], nil
+		}
+	}
+	if versions := l.templates[name]; len(versions) > 0 {
+		return versions[len(versions)-1], nil
+	}
+	return nil, fmt.Errorf("%w: prompt template %s", ErrNotFound, name)
+}
+
+// GetVersion returns a specific version of a template.
+func (l *PromptLibrary) GetVersion(name, agentID string, version int) (*PromptTemplate, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for _, template := range l.templates[scopeKey(agentID, name)] {
+		if template.Version == version {
+			return template, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: prompt template %s version %d", ErrNotFound, name, version)
+}
+
+// List returns the latest version of every template, including overrides.
+func (l *PromptLibrary) List() []*PromptTemplate {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	templates := make([]*PromptTemplate, 0, len(l.templates))
+	for _, versions := range l.templates {
+		templates = append(templates, versions[len(versions)-1])
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].AgentID < templates[j].AgentID
+	})
+	return templates
+}
+
+// Render resolves a template for an agent and substitutes variables.
+func (l *PromptLibrary) Render(name, agentID string, vars map[string]string) (string, error) {
+	template, err := l.Get(name, agentID)
+	if err != nil {
+		return "", err
+	}
+	return template.Render(vars), nil
+}
+
+// persistLocked writes the library to disk when a path is configured.
+// Callers must hold l.mu.
+func (l *PromptLibrary) persistLocked() {
+	if l.path == "" {
+		return
+	}
+
+	templates := make([]*PromptTemplate, 0)
+	for _, versions := range l.templates {
+		templates = append(templates, versions...)
+	}
+
+	data, err := json.MarshalIndent(templates, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(l.path, data, 0o644)
+}
+
+// GetPromptLibrary returns the engine's prompt library.
+func (e *Engine) GetPromptLibrary() *PromptLibrary {
+	return e.prompts
+}
+
+// resolveTaskPrompt applies a named prompt template to a task when its
+// parameters reference one via "prompt_template". Template variables come
+// from the "prompt_variables" parameter plus the task input as {{input}}.
+func (e *Engine) resolveTaskPrompt(task *Task, agent *Agent) string {
+	input := task.InputText()
+	name, _ := task.Parameters["prompt_template"].(string)
+	if name == "" {
+		return input
+	}
+
+	vars := map[string]string{
+		"input":      input,
+		"agent_name": agent.Name,
+	}
+	if raw, ok := task.Parameters["prompt_variables"].(map[string]interface{}); ok {
+		for key, value := range raw {
+			vars[key] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	rendered, err := e.prompts.Render(name, agent.ID, vars)
+	if err != nil {
+		slog.Warn("Prompt template not found, using raw input", "template", name, "task_id", task.ID)
+		return input
+	}
+	return rendered
+}
+
+// resolveSystemPrompt returns the rendered system prompt for an agent. The
+// agent's Persona field takes precedence; otherwise a "system" template
+// (shared or per-agent) from the prompt library is used.
+func (e *Engine) resolveSystemPrompt(agent *Agent) string {
+	vars := map[string]string{
+		"agent_name":        agent.Name,
+		"agent_description": agent.Description,
+	}
+	if agent.State != nil {
+		vars["goals"] = strings.Join(agent.State.Goals, ", ")
+		vars["capabilities"] = strings.Join(agent.State.Capabilities, ", ")
+	}
+
+	if agent.Persona != "" {
+		persona := &PromptTemplate{Template: agent.Persona}
+		return strings.TrimSpace(persona.Render(vars))
+	}
+
+	rendered, err := e.prompts.Render(string(PromptKindSystem), agent.ID, vars)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(rendered)
+}
@@ -0,0 +1,152 @@
+package orchestration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestPromptLibraryVersioning(t *testing.T) {
+	library := NewPromptLibrary()
+
+	v1 := library.Put("greeting", "", PromptKindTask, "Hello {{name}}")
+	v2 := library.Put("greeting", "", PromptKindTask, "Hi {{name}}, welcome")
+
+	if v1.Version != 1 || v2.Version != 2 {
+		t.Errorf("Expected versions 1 and 2, got %d and %d", v1.Version, v2.Version)
+	}
+
+	latest, err := library.Get("greeting", "")
+	if err != nil {
+		t.Fatalf("Failed to get template: %v", err)
+	}
+	if latest.Version != 2 {
+		t.Errorf("Expected latest version 2, got %d", latest.Version)
+	}
+
+	original, err := library.GetVersion("greeting", "", 1)
+	if err != nil {
+		t.Fatalf("Failed to get version 1: %v", err)
+	}
+	if original.Template != "Hello {{name}}" {
+		t.Errorf("Expected original template preserved, got %q", original.Template)
+	}
+}
+
+func TestPromptLibraryAgentOverride(t *testing.T) {
+	library := NewPromptLibrary()
+
+	library.Put("system", "", PromptKindSystem, "You are a helpful assistant")
+	library.Put("system", "agent-1", PromptKindSystem, "You are a pirate")
+
+	shared, err := library.Get("system", "agent-2")
+	if err != nil {
+		t.Fatalf("Failed to get shared template: %v", err)
+	}
+	if shared.Template != "You are a helpful assistant" {
+		t.Errorf("Expected shared template for agent without override, got %q", shared.Template)
+	}
+
+	override, err := library.Get("system", "agent-1")
+	if err != nil {
+		t.Fatalf("Failed to get override: %v", err)
+	}
+	if override.Template != "You are a pirate" {
+		t.Errorf("Expected per-agent override, got %q", override.Template)
+	}
+}
+
+func TestPromptTemplateRender(t *testing.T) {
+	template := &PromptTemplate{Template: "Agent {{agent_name}} handles {{input}} and {{missing}}"}
+
+	rendered := template.Render(map[string]string{
+		"agent_name": "Echo",
+		"input":      "a question",
+	})
+
+	expected := "Agent Echo handles a question and {{missing}}"
+	if rendered != expected {
+		t.Errorf("Expected %q, got %q", expected, rendered)
+	}
+
+	variables := template.Variables()
+	if len(variables) != 3 {
+		t.Errorf("Expected 3 variables, got %v", variables)
+	}
+}
+
+func TestPromptLibraryPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prompts.json")
+
+	library, err := LoadPromptLibrary(path)
+	if err != nil {
+		t.Fatalf("Failed to create library: %v", err)
+	}
+	library.Put("greeting", "", PromptKindTask, "Hello {{name}}")
+
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("Expected library file to be written: %v", err)
+	}
+
+	reloaded, err := LoadPromptLibrary(path)
+	if err != nil {
+		t.Fatalf("Failed to reload library: %v", err)
+	}
+
+	template, err := reloaded.Get("greeting", "")
+	if err != nil {
+		t.Fatalf("Failed to get reloaded template: %v", err)
+	}
+	if template.Template != "Hello {{name}}" {
+		t.Errorf("Expected persisted template, got %q", template.Template)
+	}
+}
+
+func TestPromptLibrarySeedsReflectionTemplate(t *testing.T) {
+	library := NewPromptLibrary()
+
+	rendered, err := library.Render("agent_reflection", "", map[string]string{
+		"agent_name": "Echo",
+		"input":      "test input",
+	})
+	if err != nil {
+		t.Fatalf("Expected default reflection template, got error: %v", err)
+	}
+	if rendered != "Agent 'Echo' reflecting on: test input" {
+		t.Errorf("Unexpected default reflection rendering: %q", rendered)
+	}
+}
+
+func TestResolveSystemPromptPersona(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	agent := &Agent{
+		ID:      "agent-persona",
+		Name:    "navigator",
+		Persona: "You are {{agent_name}}. Goals: {{goals}}. Skills: {{capabilities}}.",
+		State: &AgentState{
+			Goals:        []string{"chart the course", "avoid storms"},
+			Capabilities: []string{"navigation"},
+		},
+	}
+
+	system := engine.resolveSystemPrompt(agent)
+	expected := "You are navigator. Goals: chart the course, avoid storms. Skills: navigation."
+	if system != expected {
+		t.Errorf("Expected %q, got %q", expected, system)
+	}
+
+	// Persona wins over a per-agent "system" template from the library
+	engine.GetPromptLibrary().Put("system", agent.ID, PromptKindSystem, "You are a pirate")
+	if system := engine.resolveSystemPrompt(agent); system != expected {
+		t.Errorf("Expected persona to take precedence, got %q", system)
+	}
+
+	// Without a persona, the library template applies
+	agent.Persona = ""
+	if system := engine.resolveSystemPrompt(agent); system != "You are a pirate" {
+		t.Errorf("Expected library template fallback, got %q", system)
+	}
+}
@@ -0,0 +1,219 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProposalStatus tracks a configuration proposal through its lifecycle.
+type ProposalStatus string
+
+const (
+	ProposalStatusPending  ProposalStatus = "pending"
+	ProposalStatusApproved ProposalStatus = "approved"
+	ProposalStatusRejected ProposalStatus = "rejected"
+	ProposalStatusApplied  ProposalStatus = "applied"
+)
+
+// ConfigProposal is a concrete configuration change suggested by the DTE
+// introspection loop. Proposals queue for human approval via the API and are
+// applied automatically once approved.
+type ConfigProposal struct {
+	ID            string         `json:"id"`
+	Parameter     string         `json:"parameter"`
+	CurrentValue  interface{}    `json:"current_value"`
+	ProposedValue interface{}    `json:"proposed_value"`
+	Rationale     string         `json:"rationale"`
+	Status        ProposalStatus `json:"status"`
+	CreatedAt     time.Time      `json:"created_at"`
+	ResolvedAt    *time.Time     `json:"resolved_at,omitempty"`
+}
+
+// Tunable parameters the introspection loop may propose changing.
+const (
+	ProposalParamReservoirSize      = "reservoir_network.size"
+	ProposalParamSpectralRadius     = "reservoir_network.spectral_radius"
+	ProposalParamAttentionThreshold = "introspection.attention_threshold"
+)
+
+// QueueConfigProposal records a proposal for human review. Duplicate pending
+// proposals for the same parameter are coalesced.
+func (e *Engine) QueueConfigProposal(ctx context.Context, proposal *ConfigProposal) *ConfigProposal {
+	e.proposalMu.Lock()
+	defer e.proposalMu.Unlock()
+
+	for _, existing := range e.proposals {
+		if existing.Parameter == proposal.Parameter && existing.Status == ProposalStatusPending {
+			existing.ProposedValue = proposal.ProposedValue
+			existing.Rationale = proposal.Rationale
+			return existing
+		}
+	}
+
+	proposal.ID = uuid.New().String()
+	proposal.Status = ProposalStatusPending
+	proposal.CreatedAt = time.Now()
+	e.proposals[proposal.ID] = proposal
+
+	clog(ctx).Info("Queued configuration proposal",
+		"parameter", proposal.Parameter, "proposed", proposal.ProposedValue)
+	return proposal
+}
+
+// ListConfigProposals returns proposals, optionally filtered by status.
+func (e *Engine) ListConfigProposals(status ProposalStatus) []*ConfigProposal {
+	e.proposalMu.RLock()
+	defer e.proposalMu.RUnlock()
+
+	proposals := make([]*ConfigProposal, 0, len(e.proposals))
+	for _, proposal := range e.proposals {
+		if status == "" || proposal.Status == status {
+			proposals = append(proposals, proposal)
+		}
+	}
+
+	sort.Slice(proposals, func(i, j int) bool {
+		return proposals[i].CreatedAt.Before(proposals[j].CreatedAt)
+	})
+	return proposals
+}
+
+// ApproveConfigProposal approves a pending proposal and applies it.
+func (e *Engine) ApproveConfigProposal(ctx context.Context, id string) (*ConfigProposal, error) {
+	e.proposalMu.Lock()
+	proposal, exists := e.proposals[id]
+	if !exists {
+		e.proposalMu.Unlock()
+		return nil, fmt.Errorf("%w: proposal %s", ErrNotFound, id)
+	}
+	if proposal.Status != ProposalStatusPending {
+		e.proposalMu.Unlock()
+		return nil, fmt.Errorf("proposal %s is %s, not pending", id, proposal.Status)
+	}
+	proposal.Status = ProposalStatusApproved
+	e.proposalMu.Unlock()
+
+	if err := e.applyConfigProposal(proposal); err != nil {
+		return nil, fmt.Errorf("failed to apply proposal %s: %w", id, err)
+	}
+
+	now := time.Now()
+	e.proposalMu.Lock()
+	proposal.Status = ProposalStatusApplied
+	proposal.ResolvedAt = &now
+	e.proposalMu.Unlock()
+
+	clog(ctx).Info("Applied configuration proposal", "parameter", proposal.Parameter)
+	return proposal, nil
+}
+
+// RejectConfigProposal rejects a pending proposal.
+func (e *Engine) RejectConfigProposal(ctx context.Context, id string) (*ConfigProposal, error) {
+	e.proposalMu.Lock()
+	defer e.proposalMu.Unlock()
+
+	proposal, exists := e.proposals[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: proposal %s", ErrNotFound, id)
+	}
+	if proposal.Status != ProposalStatusPending {
+		return nil, fmt.Errorf("proposal %s is %s, not pending", id, proposal.Status)
+	}
+
+	now := time.Now()
+	proposal.Status = ProposalStatusRejected
+	proposal.ResolvedAt = &now
+	return proposal, nil
+}
+
+// applyConfigProposal mutates the running configuration for an approved
+// proposal.
+func (e *Engine) applyConfigProposal(proposal *ConfigProposal) error {
+	dte := e.GetDeepTreeEcho()
+
+	switch proposal.Parameter {
+	case ProposalParamReservoirSize:
+		size, ok := toInt(proposal.ProposedValue)
+		if !ok || size <= 0 {
+			return fmt.Errorf("invalid reservoir size %v", proposal.ProposedValue)
+		}
+		if dte.ReservoirNetwork != nil {
+			dte.ReservoirNetwork.Size = size
+			dte.ReservoirNetwork.LastUpdated = time.Now()
+		}
+	case ProposalParamSpectralRadius:
+		radius, ok := toFloat(proposal.ProposedValue)
+		if !ok || radius <= 0 {
+			return fmt.Errorf("invalid spectral radius %v", proposal.ProposedValue)
+		}
+		if dte.ReservoirNetwork != nil {
+			dte.ReservoirNetwork.SpectralRadius = radius
+			dte.ReservoirNetwork.LastUpdated = time.Now()
+		}
+	default:
+		return fmt.Errorf("unknown parameter %s", proposal.Parameter)
+	}
+	return nil
+}
+
+// generateConfigProposals inspects an introspection result and the current
+// DTE state and queues structured tuning proposals where the data supports
+// them.
+func (e *Engine) generateConfigProposals(ctx context.Context, result *IntrospectionResult, currentLoad float64) {
+	dte := e.GetDeepTreeEcho()
+	if dte == nil || dte.ReservoirNetwork == nil {
+		return
+	}
+
+	// Sustained high load with many salient files suggests the reservoir is
+	// undersized for the repository's cognitive surface.
+	if currentLoad > 0.8 && result.CognitiveSnapshot != nil && len(result.CognitiveSnapshot.SalientFiles) > dte.ReservoirNetwork.Size {
+		e.QueueConfigProposal(ctx, &ConfigProposal{
+			Parameter:     ProposalParamReservoirSize,
+			CurrentValue:  dte.ReservoirNetwork.Size,
+			ProposedValue: dte.ReservoirNetwork.Size * 2,
+			Rationale: fmt.Sprintf(
+				"Load %.2f with %d salient files exceeds reservoir size %d; doubling capacity",
+				currentLoad, len(result.CognitiveSnapshot.SalientFiles), dte.ReservoirNetwork.Size),
+		})
+	}
+
+	// A degraded system with a high spectral radius risks chaotic dynamics;
+	// propose pulling it back toward the stable edge.
+	if dte.SystemHealth == SystemHealthDegraded && dte.ReservoirNetwork.SpectralRadius > 0.95 {
+		e.QueueConfigProposal(ctx, &ConfigProposal{
+			Parameter:     ProposalParamSpectralRadius,
+			CurrentValue:  dte.ReservoirNetwork.SpectralRadius,
+			ProposedValue: 0.9,
+			Rationale:     "System health degraded with spectral radius above 0.95; reducing toward stable regime",
+		})
+	}
+}
+
+// toInt converts JSON-decoded numeric values to int.
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case float64:
+		return int(v), true
+	default:
+		return 0, false
+	}
+}
+
+// toFloat converts JSON-decoded numeric values to float64.
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
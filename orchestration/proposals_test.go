@@ -0,0 +1,98 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestConfigProposalLifecycle(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if err := engine.InitializeDeepTreeEcho(ctx); err != nil {
+		t.Fatalf("Failed to initialize DTE: %v", err)
+	}
+
+	originalSize := engine.GetDeepTreeEcho().ReservoirNetwork.Size
+
+	proposal := engine.QueueConfigProposal(ctx, &ConfigProposal{
+		Parameter:     ProposalParamReservoirSize,
+		CurrentValue:  originalSize,
+		ProposedValue: originalSize * 2,
+		Rationale:     "test",
+	})
+
+	if proposal.Status != ProposalStatusPending {
+		t.Errorf("Expected pending status, got %s", proposal.Status)
+	}
+
+	pending := engine.ListConfigProposals(ProposalStatusPending)
+	if len(pending) != 1 {
+		t.Fatalf("Expected 1 pending proposal, got %d", len(pending))
+	}
+
+	applied, err := engine.ApproveConfigProposal(ctx, proposal.ID)
+	if err != nil {
+		t.Fatalf("Failed to approve proposal: %v", err)
+	}
+	if applied.Status != ProposalStatusApplied {
+		t.Errorf("Expected applied status, got %s", applied.Status)
+	}
+
+	if got := engine.GetDeepTreeEcho().ReservoirNetwork.Size; got != originalSize*2 {
+		t.Errorf("Expected reservoir size %d after approval, got %d", originalSize*2, got)
+	}
+
+	// Approving again must fail: the proposal is no longer pending
+	if _, err := engine.ApproveConfigProposal(ctx, proposal.ID); err == nil {
+		t.Error("Expected error approving an already-applied proposal")
+	}
+}
+
+func TestConfigProposalReject(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	proposal := engine.QueueConfigProposal(ctx, &ConfigProposal{
+		Parameter:     ProposalParamSpectralRadius,
+		CurrentValue:  1.0,
+		ProposedValue: 0.9,
+	})
+
+	rejected, err := engine.RejectConfigProposal(ctx, proposal.ID)
+	if err != nil {
+		t.Fatalf("Failed to reject proposal: %v", err)
+	}
+	if rejected.Status != ProposalStatusRejected {
+		t.Errorf("Expected rejected status, got %s", rejected.Status)
+	}
+	if rejected.ResolvedAt == nil {
+		t.Error("Expected resolution timestamp on rejected proposal")
+	}
+}
+
+func TestQueueConfigProposalCoalescesPending(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	first := engine.QueueConfigProposal(ctx, &ConfigProposal{
+		Parameter:     ProposalParamReservoirSize,
+		ProposedValue: 100,
+	})
+	second := engine.QueueConfigProposal(ctx, &ConfigProposal{
+		Parameter:     ProposalParamReservoirSize,
+		ProposedValue: 200,
+	})
+
+	if first.ID != second.ID {
+		t.Error("Expected duplicate pending proposals to coalesce")
+	}
+	if got, _ := toInt(second.ProposedValue); got != 200 {
+		t.Errorf("Expected coalesced proposal to carry latest value, got %v", second.ProposedValue)
+	}
+	if len(engine.ListConfigProposals("")) != 1 {
+		t.Error("Expected a single proposal after coalescing")
+	}
+}
@@ -0,0 +1,274 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskQueue defines a shared work queue that multiple engine instances can
+// safely consume from. Dequeued tasks become invisible to other consumers
+// until they are acknowledged or their visibility timeout expires, at which
+// point they are redelivered or moved to the dead-letter queue.
+type TaskQueue interface {
+	// Enqueue adds a task to the queue for execution.
+	Enqueue(ctx context.Context, task *Task) error
+	// Dequeue claims the next available task. It returns a receipt that must
+	// be used to Ack or Nack the delivery. Returns ErrQueueEmpty when no
+	// task is available.
+	Dequeue(ctx context.Context) (*QueuedTask, error)
+	// Ack acknowledges successful processing and removes the task.
+	Ack(ctx context.Context, receipt string) error
+	// Nack returns the task to the queue for redelivery (or dead-letters it
+	// if the delivery limit has been reached).
+	Nack(ctx context.Context, receipt string) error
+	// DeadLetters returns tasks that exhausted their delivery attempts.
+	DeadLetters(ctx context.Context) ([]*QueuedTask, error)
+	// Depth returns how many tasks are pending or in flight, for
+	// backpressure decisions.
+	Depth(ctx context.Context) (int, error)
+}
+
+// QueuedTask wraps a Task with queue delivery metadata.
+type QueuedTask struct {
+	Task       *Task     `json:"task"`
+	Receipt    string    `json:"receipt"`
+	Attempts   int       `json:"attempts"`
+	EnqueuedAt time.Time `json:"enqueued_at"`
+	VisibleAt  time.Time `json:"visible_at"`
+}
+
+// priority returns the queued task's priority; tasks without one sort as
+// TaskPriorityLow.
+func (q *QueuedTask) priority() TaskPriority {
+	if q.Task == nil {
+		return TaskPriorityLow
+	}
+	return q.Task.Priority
+}
+
+// interactive reports whether the task belongs to the latency-sensitive
+// lane that preempts batch work.
+func (q *QueuedTask) interactive() bool {
+	return q.priority() >= TaskPriorityHigh
+}
+
+// ErrQueueEmpty is returned by Dequeue when no task is currently available.
+var ErrQueueEmpty = fmt.Errorf("task queue is empty")
+
+// DistributedLock provides mutual exclusion across engine instances, used to
+// guard agent state updates when multiple engines share a backend.
+type DistributedLock interface {
+	// Acquire attempts to take the named lock for the given duration. It
+	// returns a token used for release, or false if the lock is held.
+	Acquire(ctx context.Context, name string, ttl time.Duration) (token string, acquired bool, err error)
+	// Release releases the named lock if the token still owns it.
+	Release(ctx context.Context, name string, token string) error
+}
+
+// InMemoryTaskQueue is the default single-instance TaskQueue implementation.
+// It mirrors the Redis queue semantics (visibility timeouts, delivery limits,
+// dead-letter queue) so code written against TaskQueue behaves identically in
+// both deployments.
+type InMemoryTaskQueue struct {
+	pending           []*QueuedTask
+	inflight          map[string]*QueuedTask
+	deadLetters       []*QueuedTask
+	visibilityTimeout time.Duration
+	maxAttempts       int
+	mu                sync.Mutex
+}
+
+// NewInMemoryTaskQueue creates an in-memory task queue with the given
+// visibility timeout and maximum delivery attempts.
+func NewInMemoryTaskQueue(visibilityTimeout time.Duration, maxAttempts int) *InMemoryTaskQueue {
+	if visibilityTimeout <= 0 {
+		visibilityTimeout = 30 * time.Second
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	return &InMemoryTaskQueue{
+		pending:           make([]*QueuedTask, 0),
+		inflight:          make(map[string]*QueuedTask),
+		deadLetters:       make([]*QueuedTask, 0),
+		visibilityTimeout: visibilityTimeout,
+		maxAttempts:       maxAttempts,
+	}
+}
+
+// Enqueue adds a task to the pending queue.
+func (q *InMemoryTaskQueue) Enqueue(ctx context.Context, task *Task) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.pending = append(q.pending, &QueuedTask{
+		Task:       task,
+		EnqueuedAt: time.Now(),
+	})
+	return nil
+}
+
+// Dequeue claims the highest-priority available task, making it invisible
+// to other consumers until the visibility timeout expires. Ties break in
+// enqueue order, and batch tasks stay queued while an interactive task is
+// in flight so latency-sensitive work is never starved.
+func (q *InMemoryTaskQueue) Dequeue(ctx context.Context) (*QueuedTask, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.reapExpiredLocked()
+
+	best := -1
+	for i, queued := range q.pending {
+		if best == -1 || queued.priority() > q.pending[best].priority() {
+			best = i
+		}
+	}
+	if best == -1 {
+		return nil, ErrQueueEmpty
+	}
+	if !q.pending[best].interactive() && q.interactiveInFlightLocked() {
+		return nil, ErrQueueEmpty
+	}
+
+	queued := q.pending[best]
+	q.pending = append(q.pending[:best], q.pending[best+1:]...)
+
+	queued.Receipt = uuid.New().String()
+	queued.Attempts++
+	queued.VisibleAt = time.Now().Add(q.visibilityTimeout)
+	q.inflight[queued.Receipt] = queued
+
+	return queued, nil
+}
+
+// Ack removes a successfully processed task from the queue.
+func (q *InMemoryTaskQueue) Ack(ctx context.Context, receipt string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, exists := q.inflight[receipt]; !exists {
+		return fmt.Errorf("unknown or expired receipt: %s", receipt)
+	}
+
+	delete(q.inflight, receipt)
+	return nil
+}
+
+// Nack returns a task to the queue, or dead-letters it when the delivery
+// limit has been reached.
+func (q *InMemoryTaskQueue) Nack(ctx context.Context, receipt string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	queued, exists := q.inflight[receipt]
+	if !exists {
+		return fmt.Errorf("unknown or expired receipt: %s", receipt)
+	}
+
+	delete(q.inflight, receipt)
+	q.requeueLocked(queued)
+	return nil
+}
+
+// DeadLetters returns tasks that exhausted their delivery attempts.
+func (q *InMemoryTaskQueue) DeadLetters(ctx context.Context) ([]*QueuedTask, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	letters := make([]*QueuedTask, len(q.deadLetters))
+	copy(letters, q.deadLetters)
+	return letters, nil
+}
+
+// Depth returns how many tasks are pending or in flight.
+func (q *InMemoryTaskQueue) Depth(ctx context.Context) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.reapExpiredLocked()
+	return len(q.pending) + len(q.inflight), nil
+}
+
+// interactiveInFlightLocked reports whether a latency-sensitive task is
+// currently claimed. Callers must hold q.mu.
+func (q *InMemoryTaskQueue) interactiveInFlightLocked() bool {
+	for _, queued := range q.inflight {
+		if queued.interactive() {
+			return true
+		}
+	}
+	return false
+}
+
+// reapExpiredLocked redelivers or dead-letters in-flight tasks whose
+// visibility timeout has passed. Callers must hold q.mu.
+func (q *InMemoryTaskQueue) reapExpiredLocked() {
+	now := time.Now()
+	for receipt, queued := range q.inflight {
+		if now.After(queued.VisibleAt) {
+			delete(q.inflight, receipt)
+			q.requeueLocked(queued)
+		}
+	}
+}
+
+// requeueLocked places a task back on the pending queue, or moves it to the
+// dead-letter queue if it has been delivered too many times. Callers must
+// hold q.mu.
+func (q *InMemoryTaskQueue) requeueLocked(queued *QueuedTask) {
+	queued.Receipt = ""
+	if queued.Attempts >= q.maxAttempts {
+		q.deadLetters = append(q.deadLetters, queued)
+		return
+	}
+	q.pending = append(q.pending, queued)
+}
+
+// InMemoryLock is the default single-instance DistributedLock implementation.
+type InMemoryLock struct {
+	locks map[string]lockEntry
+	mu    sync.Mutex
+}
+
+type lockEntry struct {
+	token     string
+	expiresAt time.Time
+}
+
+// NewInMemoryLock creates an in-memory lock manager.
+func NewInMemoryLock() *InMemoryLock {
+	return &InMemoryLock{locks: make(map[string]lockEntry)}
+}
+
+// Acquire attempts to take the named lock for the given duration.
+func (l *InMemoryLock) Acquire(ctx context.Context, name string, ttl time.Duration) (string, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if entry, exists := l.locks[name]; exists && time.Now().Before(entry.expiresAt) {
+		return "", false, nil
+	}
+
+	token := uuid.New().String()
+	l.locks[name] = lockEntry{token: token, expiresAt: time.Now().Add(ttl)}
+	return token, true, nil
+}
+
+// Release releases the named lock if the token still owns it.
+func (l *InMemoryLock) Release(ctx context.Context, name string, token string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry, exists := l.locks[name]
+	if !exists || entry.token != token {
+		return fmt.Errorf("lock not held with given token: %s", name)
+	}
+
+	delete(l.locks, name)
+	return nil
+}
@@ -0,0 +1,191 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestInMemoryTaskQueueEnqueueDequeue(t *testing.T) {
+	queue := NewInMemoryTaskQueue(time.Minute, 3)
+	ctx := context.Background()
+
+	task := &Task{ID: "task-1", Type: TaskTypeCustom, Input: "test input"}
+	if err := queue.Enqueue(ctx, task); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	queued, err := queue.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("Dequeue failed: %v", err)
+	}
+
+	if queued.Task.ID != "task-1" {
+		t.Errorf("Expected task-1, got %s", queued.Task.ID)
+	}
+
+	if queued.Receipt == "" {
+		t.Error("Dequeued task should have a receipt")
+	}
+
+	if queued.Attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", queued.Attempts)
+	}
+
+	// Queue should now be empty (task is in flight)
+	if _, err := queue.Dequeue(ctx); err != ErrQueueEmpty {
+		t.Errorf("Expected ErrQueueEmpty, got %v", err)
+	}
+
+	if err := queue.Ack(ctx, queued.Receipt); err != nil {
+		t.Errorf("Ack failed: %v", err)
+	}
+}
+
+func TestInMemoryTaskQueuePriorityLane(t *testing.T) {
+	queue := NewInMemoryTaskQueue(time.Minute, 3)
+	ctx := context.Background()
+
+	batch := &Task{ID: "batch-1", Type: TaskTypeCustom}
+	chat := &Task{ID: "chat-1", Type: TaskTypeCustom, Priority: TaskPriorityUrgent}
+	if err := queue.Enqueue(ctx, batch); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+	if err := queue.Enqueue(ctx, chat); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// The interactive task jumps ahead of the earlier batch task
+	first, err := queue.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("Dequeue failed: %v", err)
+	}
+	if first.Task.ID != "chat-1" {
+		t.Fatalf("Expected chat-1 first, got %s", first.Task.ID)
+	}
+
+	// Batch work stays queued while the interactive task is in flight
+	if _, err := queue.Dequeue(ctx); err != ErrQueueEmpty {
+		t.Errorf("Expected batch task delayed behind interactive work, got %v", err)
+	}
+
+	if err := queue.Ack(ctx, first.Receipt); err != nil {
+		t.Fatalf("Ack failed: %v", err)
+	}
+	second, err := queue.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("Dequeue after ack failed: %v", err)
+	}
+	if second.Task.ID != "batch-1" {
+		t.Errorf("Expected batch-1 after the interactive task, got %s", second.Task.ID)
+	}
+}
+
+func TestInMemoryTaskQueueVisibilityTimeout(t *testing.T) {
+	queue := NewInMemoryTaskQueue(10*time.Millisecond, 3)
+	ctx := context.Background()
+
+	task := &Task{ID: "task-1", Type: TaskTypeCustom}
+	if err := queue.Enqueue(ctx, task); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	first, err := queue.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("Dequeue failed: %v", err)
+	}
+
+	// Wait for the visibility timeout to expire without acking
+	time.Sleep(20 * time.Millisecond)
+
+	second, err := queue.Dequeue(ctx)
+	if err != nil {
+		t.Fatalf("Expected redelivery after visibility timeout, got %v", err)
+	}
+
+	if second.Task.ID != first.Task.ID {
+		t.Errorf("Expected redelivery of %s, got %s", first.Task.ID, second.Task.ID)
+	}
+
+	if second.Attempts != 2 {
+		t.Errorf("Expected 2 attempts after redelivery, got %d", second.Attempts)
+	}
+}
+
+func TestInMemoryTaskQueueDeadLetter(t *testing.T) {
+	queue := NewInMemoryTaskQueue(time.Minute, 2)
+	ctx := context.Background()
+
+	task := &Task{ID: "task-1", Type: TaskTypeCustom}
+	if err := queue.Enqueue(ctx, task); err != nil {
+		t.Fatalf("Enqueue failed: %v", err)
+	}
+
+	// Fail the task until it exhausts its delivery attempts
+	for i := 0; i < 2; i++ {
+		queued, err := queue.Dequeue(ctx)
+		if err != nil {
+			t.Fatalf("Dequeue %d failed: %v", i+1, err)
+		}
+		if err := queue.Nack(ctx, queued.Receipt); err != nil {
+			t.Fatalf("Nack %d failed: %v", i+1, err)
+		}
+	}
+
+	if _, err := queue.Dequeue(ctx); err != ErrQueueEmpty {
+		t.Errorf("Expected ErrQueueEmpty after dead-lettering, got %v", err)
+	}
+
+	letters, err := queue.DeadLetters(ctx)
+	if err != nil {
+		t.Fatalf("DeadLetters failed: %v", err)
+	}
+
+	if len(letters) != 1 {
+		t.Fatalf("Expected 1 dead letter, got %d", len(letters))
+	}
+
+	if letters[0].Task.ID != "task-1" {
+		t.Errorf("Expected dead letter task-1, got %s", letters[0].Task.ID)
+	}
+}
+
+func TestInMemoryLock(t *testing.T) {
+	lock := NewInMemoryLock()
+	ctx := context.Background()
+
+	token, acquired, err := lock.Acquire(ctx, "agent-state:a1", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if !acquired {
+		t.Fatal("Expected to acquire uncontended lock")
+	}
+
+	// Second acquisition should fail while held
+	_, acquired, err = lock.Acquire(ctx, "agent-state:a1", time.Minute)
+	if err != nil {
+		t.Fatalf("Second Acquire failed: %v", err)
+	}
+	if acquired {
+		t.Error("Expected contended lock acquisition to fail")
+	}
+
+	// Release with a wrong token should fail
+	if err := lock.Release(ctx, "agent-state:a1", "wrong-token"); err == nil {
+		t.Error("Expected release with wrong token to fail")
+	}
+
+	if err := lock.Release(ctx, "agent-state:a1", token); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+
+	// Lock should be acquirable again after release
+	_, acquired, err = lock.Acquire(ctx, "agent-state:a1", time.Minute)
+	if err != nil {
+		t.Fatalf("Reacquire failed: %v", err)
+	}
+	if !acquired {
+		t.Error("Expected to reacquire released lock")
+	}
+}
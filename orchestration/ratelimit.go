@@ -0,0 +1,317 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// RateLimitConfig bounds how fast a scope (agent or conversation) may call
+// models. Zero values leave that dimension unlimited.
+type RateLimitConfig struct {
+	// RequestsPerMinute caps model calls per minute.
+	RequestsPerMinute int `json:"requests_per_minute,omitempty"`
+	// TokensPerMinute caps consumed model tokens per minute.
+	TokensPerMinute int `json:"tokens_per_minute,omitempty"`
+	// Reject fails over-limit work immediately with ErrRateLimited
+	// instead of queueing it behind the bucket.
+	Reject bool `json:"reject,omitempty"`
+}
+
+// RateLimitedError reports a rejected request along with how long the
+// caller should wait before retrying. It wraps ErrRateLimited so the API
+// maps it to 429.
+type RateLimitedError struct {
+	Scope      string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s, retry in %s", e.Scope, e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitedError) Unwrap() error { return ErrRateLimited }
+
+// RetrySeconds renders RetryAfter for a Retry-After header, rounded up
+// to at least one second.
+func (e *RateLimitedError) RetrySeconds() int {
+	seconds := int(math.Ceil(e.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// RateLimitStatus reports one scope's limiter state, including how many
+// tasks are currently queued behind it.
+type RateLimitStatus struct {
+	Scope             string          `json:"scope"`
+	Config            RateLimitConfig `json:"config"`
+	RequestsAvailable float64         `json:"requests_available"`
+	TokensAvailable   float64         `json:"tokens_available"`
+	Waiting           int             `json:"waiting"`
+}
+
+// rateBucket is a token bucket refilled continuously at the configured
+// per-minute rates.
+type rateBucket struct {
+	mu       sync.Mutex
+	config   RateLimitConfig
+	requests float64
+	tokens   float64
+	last     time.Time
+	waiting  int
+}
+
+// refill adds capacity for the time elapsed since the last update, capped at
+// one minute's worth of burst.
+func (b *rateBucket) refill(now time.Time) {
+	elapsed := now.Sub(b.last).Minutes()
+	if elapsed <= 0 {
+		return
+	}
+	if b.config.RequestsPerMinute > 0 {
+		b.requests += elapsed * float64(b.config.RequestsPerMinute)
+		if max := float64(b.config.RequestsPerMinute); b.requests > max {
+			b.requests = max
+		}
+	}
+	if b.config.TokensPerMinute > 0 {
+		b.tokens += elapsed * float64(b.config.TokensPerMinute)
+		if max := float64(b.config.TokensPerMinute); b.tokens > max {
+			b.tokens = max
+		}
+	}
+	b.last = now
+}
+
+// ready reports whether one more request may proceed. The token budget may
+// run negative after large completions; it only needs to be non-negative to
+// admit new work.
+func (b *rateBucket) ready() bool {
+	if b.config.RequestsPerMinute > 0 && b.requests < 1 {
+		return false
+	}
+	if b.config.TokensPerMinute > 0 && b.tokens < 0 {
+		return false
+	}
+	return true
+}
+
+// retryAfter estimates how long until the bucket readmits a request,
+// never less than one second. The caller must hold b.mu.
+func (b *rateBucket) retryAfter() time.Duration {
+	var wait time.Duration
+	if b.config.RequestsPerMinute > 0 && b.requests < 1 {
+		wait = time.Duration((1 - b.requests) / float64(b.config.RequestsPerMinute) * float64(time.Minute))
+	}
+	if b.config.TokensPerMinute > 0 && b.tokens < 0 {
+		tokenWait := time.Duration(-b.tokens / float64(b.config.TokensPerMinute) * float64(time.Minute))
+		if tokenWait > wait {
+			wait = tokenWait
+		}
+	}
+	if wait < time.Second {
+		wait = time.Second
+	}
+	return wait
+}
+
+// RateLimiter enforces per-agent and per-conversation model call limits.
+// Tasks over the limit queue (with visibility via Status) instead of failing.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*rateBucket
+}
+
+// NewRateLimiter creates an empty rate limiter.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{buckets: make(map[string]*rateBucket)}
+}
+
+// SetLimit installs or replaces the limit for a scope. A zero config removes
+// the limit.
+func (r *RateLimiter) SetLimit(scope string, config RateLimitConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if config.RequestsPerMinute <= 0 && config.TokensPerMinute <= 0 {
+		delete(r.buckets, scope)
+		return
+	}
+	r.buckets[scope] = &rateBucket{
+		config:   config,
+		requests: float64(config.RequestsPerMinute),
+		tokens:   float64(config.TokensPerMinute),
+		last:     time.Now(),
+	}
+}
+
+// bucket returns the limiter for a scope, or nil when unlimited.
+func (r *RateLimiter) bucket(scope string) *rateBucket {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.buckets[scope]
+}
+
+// Wait blocks until the scope admits one more request, or the context is
+// cancelled. Unlimited scopes return immediately.
+func (r *RateLimiter) Wait(ctx context.Context, scope string) error {
+	bucket := r.bucket(scope)
+	if bucket == nil {
+		return nil
+	}
+
+	logged := false
+	for {
+		now := time.Now()
+		bucket.mu.Lock()
+		bucket.refill(now)
+		if bucket.ready() {
+			if bucket.config.RequestsPerMinute > 0 {
+				bucket.requests--
+			}
+			bucket.mu.Unlock()
+			return nil
+		}
+		if bucket.config.Reject {
+			retry := bucket.retryAfter()
+			bucket.mu.Unlock()
+			return &RateLimitedError{Scope: scope, RetryAfter: retry}
+		}
+		if !logged {
+			slog.Info("Rate limit reached, queueing task", "scope", scope)
+			logged = true
+		}
+		bucket.waiting++
+		bucket.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			bucket.mu.Lock()
+			bucket.waiting--
+			bucket.mu.Unlock()
+			return fmt.Errorf("rate limit wait cancelled for %s: %w", scope, ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+			bucket.mu.Lock()
+			bucket.waiting--
+			bucket.mu.Unlock()
+		}
+	}
+}
+
+// Allow admits one request immediately or reports how long the caller
+// should wait before retrying. Unlike Wait it never queues.
+func (r *RateLimiter) Allow(scope string) (bool, time.Duration) {
+	bucket := r.bucket(scope)
+	if bucket == nil {
+		return true, 0
+	}
+
+	bucket.mu.Lock()
+	defer bucket.mu.Unlock()
+	bucket.refill(time.Now())
+	if bucket.ready() {
+		if bucket.config.RequestsPerMinute > 0 {
+			bucket.requests--
+		}
+		return true, 0
+	}
+	return false, bucket.retryAfter()
+}
+
+// RecordTokens debits consumed model tokens from a scope's budget. The
+// balance may go negative, delaying subsequent requests until it refills.
+func (r *RateLimiter) RecordTokens(scope string, tokens int) {
+	bucket := r.bucket(scope)
+	if bucket == nil || tokens <= 0 || bucket.config.TokensPerMinute <= 0 {
+		return
+	}
+
+	bucket.mu.Lock()
+	bucket.refill(time.Now())
+	bucket.tokens -= float64(tokens)
+	bucket.mu.Unlock()
+}
+
+// Status reports every limited scope, sorted by scope name.
+func (r *RateLimiter) Status() []RateLimitStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	statuses := make([]RateLimitStatus, 0, len(r.buckets))
+	for scope, bucket := range r.buckets {
+		bucket.mu.Lock()
+		bucket.refill(time.Now())
+		statuses = append(statuses, RateLimitStatus{
+			Scope:             scope,
+			Config:            bucket.config,
+			RequestsAvailable: bucket.requests,
+			TokensAvailable:   bucket.tokens,
+			Waiting:           bucket.waiting,
+		})
+		bucket.mu.Unlock()
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Scope < statuses[j].Scope })
+	return statuses
+}
+
+// agentScope, conversationScope and keyScope name the limiter scopes.
+func agentScope(agentID string) string   { return "agent:" + agentID }
+func conversationScope(id string) string { return "conversation:" + id }
+
+// keyScope prefers the key's name so the Status endpoint does not echo
+// the credential itself.
+func keyScope(key *APIKey) string {
+	if key.Name != "" {
+		return "key:" + key.Name
+	}
+	return "key:" + key.Key
+}
+
+// SetAgentRateLimit bounds one agent's model call rate.
+func (e *Engine) SetAgentRateLimit(agentID string, config RateLimitConfig) {
+	e.rateLimiter.SetLimit(agentScope(agentID), config)
+	slog.Info("Set agent rate limit", "agent_id", agentID,
+		"requests_per_minute", config.RequestsPerMinute, "tokens_per_minute", config.TokensPerMinute)
+}
+
+// SetConversationRateLimit bounds a conversation's aggregate model call rate
+// across all participating agents.
+func (e *Engine) SetConversationRateLimit(conversationID string, config RateLimitConfig) {
+	e.rateLimiter.SetLimit(conversationScope(conversationID), config)
+	slog.Info("Set conversation rate limit", "conversation_id", conversationID,
+		"requests_per_minute", config.RequestsPerMinute, "tokens_per_minute", config.TokensPerMinute)
+}
+
+// GetRateLimiter returns the engine's rate limiter.
+func (e *Engine) GetRateLimiter() *RateLimiter {
+	return e.rateLimiter
+}
+
+// waitForRateLimits queues a task behind its agent and conversation limits.
+func (e *Engine) waitForRateLimits(ctx context.Context, task *Task, agent *Agent) error {
+	if err := e.rateLimiter.Wait(ctx, agentScope(agent.ID)); err != nil {
+		return err
+	}
+	if conversationID, ok := task.Parameters["conversation_id"].(string); ok && conversationID != "" {
+		return e.rateLimiter.Wait(ctx, conversationScope(conversationID))
+	}
+	return nil
+}
+
+// recordRateLimitTokens debits a completed task's token usage from its
+// limiter scopes.
+func (e *Engine) recordRateLimitTokens(task *Task, agent *Agent, result *TaskResult) {
+	if result == nil || result.Metrics.TokensUsed <= 0 {
+		return
+	}
+	e.rateLimiter.RecordTokens(agentScope(agent.ID), result.Metrics.TokensUsed)
+	if conversationID, ok := task.Parameters["conversation_id"].(string); ok && conversationID != "" {
+		e.rateLimiter.RecordTokens(conversationScope(conversationID), result.Metrics.TokensUsed)
+	}
+}
@@ -0,0 +1,135 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestRateLimiterUnlimitedScope(t *testing.T) {
+	limiter := NewRateLimiter()
+	if err := limiter.Wait(context.Background(), "agent:free"); err != nil {
+		t.Errorf("Unlimited scope should never block: %v", err)
+	}
+}
+
+func TestRateLimiterRequestCap(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit("agent:a", RateLimitConfig{RequestsPerMinute: 2})
+	ctx := context.Background()
+
+	// The initial burst admits two requests immediately
+	if err := limiter.Wait(ctx, "agent:a"); err != nil {
+		t.Fatalf("First request should pass: %v", err)
+	}
+	if err := limiter.Wait(ctx, "agent:a"); err != nil {
+		t.Fatalf("Second request should pass: %v", err)
+	}
+
+	// The third queues; a cancelled context surfaces as an error
+	cancelled, cancel := context.WithTimeout(ctx, 150*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(cancelled, "agent:a"); err == nil {
+		t.Error("Expected third request to block until cancellation")
+	}
+}
+
+func TestRateLimiterTokenDebit(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit("agent:b", RateLimitConfig{TokensPerMinute: 100})
+	ctx := context.Background()
+
+	if err := limiter.Wait(ctx, "agent:b"); err != nil {
+		t.Fatalf("First request should pass: %v", err)
+	}
+	// A huge completion drives the budget negative
+	limiter.RecordTokens("agent:b", 10000)
+
+	cancelled, cancel := context.WithTimeout(ctx, 150*time.Millisecond)
+	defer cancel()
+	if err := limiter.Wait(cancelled, "agent:b"); err == nil {
+		t.Error("Expected request to queue while token budget is negative")
+	}
+
+	statuses := limiter.Status()
+	if len(statuses) != 1 || statuses[0].TokensAvailable >= 0 {
+		t.Errorf("Expected negative token balance in status, got %+v", statuses)
+	}
+}
+
+func TestRateLimiterRemoval(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit("conversation:c", RateLimitConfig{RequestsPerMinute: 1})
+	limiter.SetLimit("conversation:c", RateLimitConfig{})
+
+	if err := limiter.Wait(context.Background(), "conversation:c"); err != nil {
+		t.Errorf("Removed limit should not block: %v", err)
+	}
+	if statuses := limiter.Status(); len(statuses) != 0 {
+		t.Errorf("Expected no limited scopes, got %+v", statuses)
+	}
+}
+
+func TestRateLimiterAllow(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit("key:ci", RateLimitConfig{RequestsPerMinute: 1})
+
+	if allowed, _ := limiter.Allow("key:ci"); !allowed {
+		t.Fatal("First request should be admitted")
+	}
+	allowed, retryAfter := limiter.Allow("key:ci")
+	if allowed {
+		t.Fatal("Second request should be rejected")
+	}
+	if retryAfter <= 0 {
+		t.Errorf("Expected a positive retry-after hint, got %s", retryAfter)
+	}
+}
+
+func TestRateLimiterRejectMode(t *testing.T) {
+	limiter := NewRateLimiter()
+	limiter.SetLimit("agent:r", RateLimitConfig{RequestsPerMinute: 1, Reject: true})
+	ctx := context.Background()
+
+	if err := limiter.Wait(ctx, "agent:r"); err != nil {
+		t.Fatalf("First request should pass: %v", err)
+	}
+	err := limiter.Wait(ctx, "agent:r")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	var limited *RateLimitedError
+	if !errors.As(err, &limited) || limited.RetrySeconds() < 1 {
+		t.Errorf("Expected a retry-after of at least one second, got %v", err)
+	}
+}
+
+func TestTaskRateLimitIntegration(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "limited", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.SetAgentRateLimit(agent.ID, RateLimitConfig{RequestsPerMinute: 1})
+
+	first := &Task{ID: "rl-1", Type: TaskTypeCustom, Input: "one", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(ctx, first, agent); err != nil {
+		t.Fatalf("First task should pass: %v", err)
+	}
+
+	// The second task queues until its context is cancelled
+	limited, cancel := context.WithTimeout(ctx, 150*time.Millisecond)
+	defer cancel()
+	second := &Task{ID: "rl-2", Type: TaskTypeCustom, Input: "two", AgentID: agent.ID}
+	if _, err := engine.ExecuteTask(limited, second, agent); err == nil {
+		t.Error("Expected second task to be rate limited")
+	}
+	if second.Status != TaskStatusFailed {
+		t.Errorf("Expected cancelled task marked failed, got %s", second.Status)
+	}
+}
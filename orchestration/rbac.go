@@ -0,0 +1,111 @@
+package orchestration
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RBAC roles layered on top of API keys. Viewers may only read, admins
+// may do anything, and operators may manage agents — but only the agents
+// their tenant owns.
+const (
+	RoleViewer   = "viewer"
+	RoleOperator = "operator"
+	RoleAdmin    = "admin"
+)
+
+// callerKeyContext is the gin context key holding the authenticated
+// APIKey for the request.
+const callerKeyContext = "api_key"
+
+// role resolves a key's effective role. Keys predating RBAC carry only a
+// scope, which maps onto the equivalent role (read → viewer,
+// admin → admin).
+func (k *APIKey) role() string {
+	if k.Role != "" {
+		return k.Role
+	}
+	if k.Scope == APIScopeRead {
+		return RoleViewer
+	}
+	return RoleAdmin
+}
+
+// callerKey returns the authenticated key for a request, or nil when
+// authentication is disabled.
+func callerKey(c *gin.Context) *APIKey {
+	value, exists := c.Get(callerKeyContext)
+	if !exists {
+		return nil
+	}
+	key, _ := value.(*APIKey)
+	return key
+}
+
+// callerTenant returns the tenant of the caller's API key, or "" when
+// the key has none or authentication is disabled.
+func callerTenant(c *gin.Context) string {
+	if key := callerKey(c); key != nil {
+		return key.Tenant
+	}
+	return ""
+}
+
+// canManageAgent reports whether the caller may mutate an agent or
+// execute tasks against it. Admin keys (and disabled auth) may touch
+// everything; operators only agents that are unowned or owned by their
+// tenant; viewers nothing.
+func canManageAgent(c *gin.Context, agent *Agent) bool {
+	key := callerKey(c)
+	if key == nil || key.role() == RoleAdmin {
+		return true
+	}
+	if key.role() != RoleOperator {
+		return false
+	}
+	return agent.Owner == "" || agent.Owner == key.Tenant
+}
+
+// canReadConversation reports whether the caller may read a conversation.
+// Only operator keys are tenant-scoped for reads: a conversation is
+// hidden from them when any participant belongs to another tenant.
+func (s *APIServer) canReadConversation(c *gin.Context, conversation *Conversation) bool {
+	key := callerKey(c)
+	if key == nil || key.role() != RoleOperator {
+		return true
+	}
+	for _, participant := range conversation.Participants {
+		agent, err := s.engine.GetAgent(c.Request.Context(), participant)
+		if err != nil {
+			continue
+		}
+		if agent.Owner != "" && agent.Owner != key.Tenant {
+			return false
+		}
+	}
+	return true
+}
+
+// denyForbidden writes the standard RBAC rejection.
+func denyForbidden(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"status": "error",
+		"error":  "API key does not permit this action",
+	})
+}
+
+// requireAgentAccess loads an agent and enforces the caller's ownership
+// rules, writing the error response itself when access is denied.
+func (s *APIServer) requireAgentAccess(c *gin.Context, agentID string) *Agent {
+	agent, err := s.engine.GetAgent(c.Request.Context(), agentID)
+	if err != nil {
+		respondError(c, err)
+		return nil
+	}
+	if !canManageAgent(c, agent) {
+		denyForbidden(c)
+		return nil
+	}
+	return agent
+}
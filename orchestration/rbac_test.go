@@ -0,0 +1,125 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+func newRBACTestServer() *APIServer {
+	gin.SetMode(gin.TestMode)
+	server := NewAPIServer(NewEngine(api.Client{}))
+	server.SetAuthConfig(&APIAuthConfig{Keys: []APIKey{
+		{Key: "viewer-key", Name: "viewer", Role: RoleViewer},
+		{Key: "op-a-key", Name: "op-a", Role: RoleOperator, Tenant: "tenant-a"},
+		{Key: "op-b-key", Name: "op-b", Role: RoleOperator, Tenant: "tenant-b"},
+		{Key: "admin-key", Name: "admin", Role: RoleAdmin},
+	}})
+	return server
+}
+
+func rbacRequest(server *APIServer, method, path, key, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("X-API-Key", key)
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	server.router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestOperatorOwnershipOnAgents(t *testing.T) {
+	server := newRBACTestServer()
+
+	// Operators own what they create, whatever the request claims
+	created := rbacRequest(server, http.MethodPost, "/api/agents/", "op-a-key", `{"name": "worker", "owner": "tenant-b"}`)
+	if created.Code != http.StatusCreated {
+		t.Fatalf("Expected agent creation, got %d: %s", created.Code, created.Body)
+	}
+	var envelope struct {
+		Data Agent `json:"data"`
+	}
+	if err := json.Unmarshal(created.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if envelope.Data.Owner != "tenant-a" {
+		t.Errorf("Expected the creator's tenant stamped as owner, got %q", envelope.Data.Owner)
+	}
+	agentID := envelope.Data.ID
+
+	// Another tenant's operator cannot touch the agent
+	if code := rbacRequest(server, http.MethodDelete, "/api/agents/"+agentID, "op-b-key", "").Code; code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a cross-tenant delete, got %d", code)
+	}
+	if code := rbacRequest(server, http.MethodPut, "/api/agents/"+agentID, "op-b-key", `{"name": "stolen"}`).Code; code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a cross-tenant update, got %d", code)
+	}
+
+	// Viewers cannot write at all
+	if code := rbacRequest(server, http.MethodDelete, "/api/agents/"+agentID, "viewer-key", "").Code; code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a viewer delete, got %d", code)
+	}
+
+	// The owner and an admin can
+	if code := rbacRequest(server, http.MethodPut, "/api/agents/"+agentID, "op-a-key", `{"name": "renamed"}`).Code; code != http.StatusOK {
+		t.Errorf("Expected the owner to update the agent, got %d", code)
+	}
+	if code := rbacRequest(server, http.MethodDelete, "/api/agents/"+agentID, "admin-key", "").Code; code != http.StatusOK {
+		t.Errorf("Expected an admin to delete the agent, got %d", code)
+	}
+}
+
+func TestOperatorConversationVisibility(t *testing.T) {
+	server := newRBACTestServer()
+	ctx := context.Background()
+
+	alpha := &Agent{Name: "alpha", Owner: "tenant-a"}
+	beta := &Agent{Name: "beta", Owner: "tenant-a"}
+	for _, agent := range []*Agent{alpha, beta} {
+		if err := server.engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+	}
+	conversation, err := server.engine.StartConversation(ctx, []string{alpha.ID, beta.ID}, "private planning")
+	if err != nil {
+		t.Fatalf("StartConversation failed: %v", err)
+	}
+
+	count := func(key string) int {
+		response := rbacRequest(server, http.MethodGet, "/api/conversations/", key, "")
+		if response.Code != http.StatusOK {
+			t.Fatalf("Expected a listing, got %d", response.Code)
+		}
+		var envelope struct {
+			Data []*Conversation `json:"data"`
+		}
+		if err := json.Unmarshal(response.Body.Bytes(), &envelope); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return len(envelope.Data)
+	}
+
+	if n := count("op-a-key"); n != 1 {
+		t.Errorf("Expected the owner's operator to see the conversation, got %d", n)
+	}
+	if n := count("op-b-key"); n != 0 {
+		t.Errorf("Expected another tenant's operator to see nothing, got %d", n)
+	}
+	if n := count("viewer-key"); n != 1 {
+		t.Errorf("Expected a viewer to see the conversation, got %d", n)
+	}
+
+	if code := rbacRequest(server, http.MethodGet, "/api/conversations/"+conversation.ID+"/export", "op-b-key", "").Code; code != http.StatusForbidden {
+		t.Errorf("Expected 403 exporting another tenant's conversation, got %d", code)
+	}
+	if code := rbacRequest(server, http.MethodGet, "/api/conversations/"+conversation.ID+"/export", "op-a-key", "").Code; code != http.StatusOK {
+		t.Errorf("Expected the owner's operator to export, got %d", code)
+	}
+}
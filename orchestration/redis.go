@@ -0,0 +1,418 @@
+package orchestration
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// redisClient is a minimal RESP client covering the small command surface the
+// queue and lock implementations need. Using a thin client keeps the module
+// free of an external Redis dependency.
+type redisClient struct {
+	addr   string
+	conn   net.Conn
+	reader *bufio.Reader
+	mu     sync.Mutex
+}
+
+// newRedisClient connects to a Redis server at the given address.
+func newRedisClient(addr string) (*redisClient, error) {
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", addr, err)
+	}
+
+	return &redisClient{
+		addr:   addr,
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+	}, nil
+}
+
+// Close closes the underlying connection.
+func (c *redisClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn.Close()
+}
+
+// Do sends a command and returns the parsed reply.
+func (c *redisClient) Do(ctx context.Context, args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		c.conn.SetDeadline(deadline)
+	} else {
+		c.conn.SetDeadline(time.Now().Add(10 * time.Second))
+	}
+
+	// Encode the command as a RESP array of bulk strings.
+	buf := fmt.Sprintf("*%d\r\n", len(args))
+	for _, arg := range args {
+		buf += fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg)
+	}
+
+	if _, err := c.conn.Write([]byte(buf)); err != nil {
+		return nil, fmt.Errorf("redis write failed: %w", err)
+	}
+
+	return c.readReply()
+}
+
+// readReply parses a single RESP reply from the connection.
+func (c *redisClient) readReply() (interface{}, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return nil, fmt.Errorf("redis read failed: %w", err)
+	}
+	if len(line) < 3 {
+		return nil, fmt.Errorf("malformed redis reply: %q", line)
+	}
+
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, fmt.Errorf("redis error: %s", payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		length, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed bulk length: %q", payload)
+		}
+		if length < 0 {
+			return nil, nil // Null bulk string
+		}
+		data := make([]byte, length+2)
+		if _, err := readFull(c.reader, data); err != nil {
+			return nil, fmt.Errorf("redis bulk read failed: %w", err)
+		}
+		return string(data[:length]), nil
+	case '*':
+		count, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, fmt.Errorf("malformed array length: %q", payload)
+		}
+		if count < 0 {
+			return nil, nil // Null array
+		}
+		elements := make([]interface{}, count)
+		for i := 0; i < count; i++ {
+			element, err := c.readReply()
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = element
+		}
+		return elements, nil
+	default:
+		return nil, fmt.Errorf("unsupported redis reply type: %q", kind)
+	}
+}
+
+// readFull reads len(buf) bytes from the reader.
+func readFull(r *bufio.Reader, buf []byte) (int, error) {
+	total := 0
+	for total < len(buf) {
+		n, err := r.Read(buf[total:])
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// RedisTaskQueue is a Redis-backed TaskQueue that multiple engine instances
+// can share. Pending tasks live in a list, in-flight tasks in a sorted set
+// keyed by visibility deadline, and exhausted tasks in a dead-letter list.
+type RedisTaskQueue struct {
+	client            *redisClient
+	name              string
+	visibilityTimeout time.Duration
+	maxAttempts       int
+}
+
+// NewRedisTaskQueue connects to Redis and creates a shared task queue with
+// the given name, visibility timeout, and maximum delivery attempts.
+func NewRedisTaskQueue(addr, name string, visibilityTimeout time.Duration, maxAttempts int) (*RedisTaskQueue, error) {
+	client, err := newRedisClient(addr)
+	if err != nil {
+		return nil, err
+	}
+	if visibilityTimeout <= 0 {
+		visibilityTimeout = 30 * time.Second
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+
+	return &RedisTaskQueue{
+		client:            client,
+		name:              name,
+		visibilityTimeout: visibilityTimeout,
+		maxAttempts:       maxAttempts,
+	}, nil
+}
+
+// Close releases the Redis connection.
+func (q *RedisTaskQueue) Close() error {
+	return q.client.Close()
+}
+
+func (q *RedisTaskQueue) pendingKey() string    { return fmt.Sprintf("echollama:queue:%s:pending", q.name) }
+func (q *RedisTaskQueue) inflightKey() string   { return fmt.Sprintf("echollama:queue:%s:inflight", q.name) }
+func (q *RedisTaskQueue) deadLetterKey() string { return fmt.Sprintf("echollama:queue:%s:dead", q.name) }
+
+// Enqueue adds a task to the shared pending queue.
+func (q *RedisTaskQueue) Enqueue(ctx context.Context, task *Task) error {
+	queued := &QueuedTask{Task: task, EnqueuedAt: time.Now()}
+	payload, err := json.Marshal(queued)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	// The consumer pops from the right, so interactive tasks RPUSH to jump
+	// ahead of queued batch work.
+	op := "LPUSH"
+	if queued.interactive() {
+		op = "RPUSH"
+	}
+	_, err = q.client.Do(ctx, op, q.pendingKey(), string(payload))
+	return err
+}
+
+// Dequeue claims the next available task from the shared queue.
+func (q *RedisTaskQueue) Dequeue(ctx context.Context) (*QueuedTask, error) {
+	if err := q.reapExpired(ctx); err != nil {
+		return nil, err
+	}
+
+	reply, err := q.client.Do(ctx, "RPOP", q.pendingKey())
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrQueueEmpty
+	}
+
+	var queued QueuedTask
+	if err := json.Unmarshal([]byte(reply.(string)), &queued); err != nil {
+		return nil, fmt.Errorf("failed to decode task: %w", err)
+	}
+
+	queued.Receipt = uuid.New().String()
+	queued.Attempts++
+	queued.VisibleAt = time.Now().Add(q.visibilityTimeout)
+
+	payload, err := json.Marshal(&queued)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	deadline := strconv.FormatInt(queued.VisibleAt.Unix(), 10)
+	if _, err := q.client.Do(ctx, "ZADD", q.inflightKey(), deadline, string(payload)); err != nil {
+		return nil, err
+	}
+
+	return &queued, nil
+}
+
+// Ack acknowledges a delivery, removing the task from the in-flight set.
+func (q *RedisTaskQueue) Ack(ctx context.Context, receipt string) error {
+	queued, payload, err := q.findInflight(ctx, receipt)
+	if err != nil {
+		return err
+	}
+	if queued == nil {
+		return fmt.Errorf("unknown or expired receipt: %s", receipt)
+	}
+
+	_, err = q.client.Do(ctx, "ZREM", q.inflightKey(), payload)
+	return err
+}
+
+// Nack returns a delivery to the pending queue or dead-letters it.
+func (q *RedisTaskQueue) Nack(ctx context.Context, receipt string) error {
+	queued, payload, err := q.findInflight(ctx, receipt)
+	if err != nil {
+		return err
+	}
+	if queued == nil {
+		return fmt.Errorf("unknown or expired receipt: %s", receipt)
+	}
+
+	if _, err := q.client.Do(ctx, "ZREM", q.inflightKey(), payload); err != nil {
+		return err
+	}
+	return q.requeue(ctx, queued)
+}
+
+// DeadLetters returns tasks that exhausted their delivery attempts.
+func (q *RedisTaskQueue) DeadLetters(ctx context.Context) ([]*QueuedTask, error) {
+	reply, err := q.client.Do(ctx, "LRANGE", q.deadLetterKey(), "0", "-1")
+	if err != nil {
+		return nil, err
+	}
+
+	elements, _ := reply.([]interface{})
+	letters := make([]*QueuedTask, 0, len(elements))
+	for _, element := range elements {
+		var queued QueuedTask
+		if err := json.Unmarshal([]byte(element.(string)), &queued); err != nil {
+			continue
+		}
+		letters = append(letters, &queued)
+	}
+	return letters, nil
+}
+
+// Depth returns how many tasks are pending or in flight.
+func (q *RedisTaskQueue) Depth(ctx context.Context) (int, error) {
+	depth := 0
+	pending, err := q.client.Do(ctx, "LLEN", q.pendingKey())
+	if err != nil {
+		return 0, err
+	}
+	if count, ok := pending.(int64); ok {
+		depth += int(count)
+	}
+	inflight, err := q.client.Do(ctx, "ZCARD", q.inflightKey())
+	if err != nil {
+		return 0, err
+	}
+	if count, ok := inflight.(int64); ok {
+		depth += int(count)
+	}
+	return depth, nil
+}
+
+// findInflight scans the in-flight set for a delivery with the given receipt.
+func (q *RedisTaskQueue) findInflight(ctx context.Context, receipt string) (*QueuedTask, string, error) {
+	reply, err := q.client.Do(ctx, "ZRANGE", q.inflightKey(), "0", "-1")
+	if err != nil {
+		return nil, "", err
+	}
+
+	elements, _ := reply.([]interface{})
+	for _, element := range elements {
+		payload := element.(string)
+		var queued QueuedTask
+		if err := json.Unmarshal([]byte(payload), &queued); err != nil {
+			continue
+		}
+		if queued.Receipt == receipt {
+			return &queued, payload, nil
+		}
+	}
+	return nil, "", nil
+}
+
+// reapExpired moves in-flight tasks whose visibility deadline has passed back
+// to the pending queue, or to the dead-letter queue when exhausted.
+func (q *RedisTaskQueue) reapExpired(ctx context.Context) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	reply, err := q.client.Do(ctx, "ZRANGEBYSCORE", q.inflightKey(), "-inf", now)
+	if err != nil {
+		return err
+	}
+
+	elements, _ := reply.([]interface{})
+	for _, element := range elements {
+		payload := element.(string)
+		if _, err := q.client.Do(ctx, "ZREM", q.inflightKey(), payload); err != nil {
+			return err
+		}
+
+		var queued QueuedTask
+		if err := json.Unmarshal([]byte(payload), &queued); err != nil {
+			continue
+		}
+		if err := q.requeue(ctx, &queued); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requeue places a task back on the pending queue, or dead-letters it when
+// the delivery limit has been reached.
+func (q *RedisTaskQueue) requeue(ctx context.Context, queued *QueuedTask) error {
+	queued.Receipt = ""
+	payload, err := json.Marshal(queued)
+	if err != nil {
+		return fmt.Errorf("failed to encode task: %w", err)
+	}
+
+	key := q.pendingKey()
+	if queued.Attempts >= q.maxAttempts {
+		key = q.deadLetterKey()
+	}
+	_, err = q.client.Do(ctx, "LPUSH", key, string(payload))
+	return err
+}
+
+// RedisLock is a Redis-backed DistributedLock for coordinating agent state
+// updates across engine instances.
+type RedisLock struct {
+	client *redisClient
+}
+
+// NewRedisLock connects to Redis and creates a distributed lock manager.
+func NewRedisLock(addr string) (*RedisLock, error) {
+	client, err := newRedisClient(addr)
+	if err != nil {
+		return nil, err
+	}
+	return &RedisLock{client: client}, nil
+}
+
+// Close releases the Redis connection.
+func (l *RedisLock) Close() error {
+	return l.client.Close()
+}
+
+func lockKey(name string) string {
+	return fmt.Sprintf("echollama:lock:%s", name)
+}
+
+// Acquire attempts to take the named lock using SET NX with an expiry.
+func (l *RedisLock) Acquire(ctx context.Context, name string, ttl time.Duration) (string, bool, error) {
+	token := uuid.New().String()
+	ttlMillis := strconv.FormatInt(ttl.Milliseconds(), 10)
+
+	reply, err := l.client.Do(ctx, "SET", lockKey(name), token, "NX", "PX", ttlMillis)
+	if err != nil {
+		return "", false, err
+	}
+	if reply == nil {
+		return "", false, nil // Lock held by another instance
+	}
+	return token, true, nil
+}
+
+// Release releases the named lock only if the token still owns it, using a
+// compare-and-delete script to avoid releasing another holder's lock.
+func (l *RedisLock) Release(ctx context.Context, name string, token string) error {
+	script := `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) else return 0 end`
+	reply, err := l.client.Do(ctx, "EVAL", script, "1", lockKey(name), token)
+	if err != nil {
+		return err
+	}
+	if deleted, ok := reply.(int64); !ok || deleted == 0 {
+		return fmt.Errorf("lock not held with given token: %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// reflectionJob tracks one agent's periodic reflection loop.
+type reflectionJob struct {
+	agentID  string
+	interval time.Duration
+	stop     chan struct{}
+}
+
+// ReflectionScheduler runs periodic reflect tasks for agents, summarizing
+// their recent work and folding the outcomes back into their goals and
+// capabilities. This is what makes AgentTypeReflective agents actually
+// self-improve over time.
+type ReflectionScheduler struct {
+	engine *Engine
+	jobs   map[string]*reflectionJob
+	mu     sync.Mutex
+}
+
+// NewReflectionScheduler creates a reflection scheduler for an engine.
+func NewReflectionScheduler(engine *Engine) *ReflectionScheduler {
+	return &ReflectionScheduler{
+		engine: engine,
+		jobs:   make(map[string]*reflectionJob),
+	}
+}
+
+// Start schedules periodic reflection for an agent. Starting an agent that
+// already has a job replaces its interval.
+func (rs *ReflectionScheduler) Start(ctx context.Context, agentID string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("reflection interval must be positive")
+	}
+
+	if _, err := rs.engine.GetAgent(ctx, agentID); err != nil {
+		return err
+	}
+
+	rs.mu.Lock()
+	if existing, ok := rs.jobs[agentID]; ok {
+		close(existing.stop)
+	}
+	job := &reflectionJob{
+		agentID:  agentID,
+		interval: interval,
+		stop:     make(chan struct{}),
+	}
+	rs.jobs[agentID] = job
+	rs.mu.Unlock()
+
+	// Detach from the caller's context (e.g. an HTTP request) so the job
+	// outlives it; the stop channel remains the cancellation mechanism.
+	go rs.run(context.WithoutCancel(ctx), job)
+
+	clog(ctx).Info("Started scheduled reflection", "agent_id", agentID, "interval", interval)
+	return nil
+}
+
+// Stop cancels an agent's reflection job.
+func (rs *ReflectionScheduler) Stop(agentID string) {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if job, ok := rs.jobs[agentID]; ok {
+		close(job.stop)
+		delete(rs.jobs, agentID)
+	}
+}
+
+// ScheduledAgents returns the agent IDs with active reflection jobs.
+func (rs *ReflectionScheduler) ScheduledAgents() []string {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	agents := make([]string, 0, len(rs.jobs))
+	for agentID := range rs.jobs {
+		agents = append(agents, agentID)
+	}
+	return agents
+}
+
+// run executes the reflection loop for one job until stopped.
+func (rs *ReflectionScheduler) run(ctx context.Context, job *reflectionJob) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-job.stop:
+			return
+		case <-ticker.C:
+			// In multi-instance deployments only the leader reflects,
+			// mirroring how queued task processing is gated.
+			if !rs.engine.isSchedulingActive() {
+				continue
+			}
+			if err := rs.engine.ReflectOnAgent(ctx, job.agentID); err != nil {
+				clog(ctx).Error("Scheduled reflection failed", "error", err, "agent_id", job.agentID)
+			}
+		}
+	}
+}
+
+// ReflectOnAgent runs one reflection cycle for an agent: it summarizes the
+// agent's recent task outcomes, executes a reflect task over that summary,
+// updates the agent's goals and capabilities from the outcomes, and stores
+// the reflection in the agent's persistent memory.
+func (e *Engine) ReflectOnAgent(ctx context.Context, agentID string) error {
+	agent, err := e.GetAgent(ctx, agentID)
+	if err != nil {
+		return err
+	}
+
+	history := e.learningSystem.performanceHistory[agentID]
+	summary := summarizeRecentTasks(history)
+
+	task := &Task{
+		ID:        uuid.New().String(),
+		Type:      TaskTypeReflect,
+		Input:     summary,
+		Status:    TaskStatusPending,
+		AgentID:   agentID,
+		CreatedAt: time.Now(),
+	}
+
+	ctx = WithCorrelation(ctx, Correlation{RequestID: NewRequestID()})
+	result, err := e.ExecuteTask(ctx, task, agent)
+	if err != nil {
+		return fmt.Errorf("reflect task failed: %w", err)
+	}
+
+	e.mu.Lock()
+	e.reflectionRuns++
+	e.mu.Unlock()
+
+	e.applyReflectionOutcomes(agent, history)
+
+	// Store the reflection in the agent's persistent memory
+	if agent.State == nil {
+		agent.State = &AgentState{}
+	}
+	if agent.State.Memory == nil {
+		agent.State.Memory = make(map[string]interface{})
+	}
+	agent.State.Memory["last_reflection"] = result.Output
+	agent.State.Memory["last_reflection_at"] = time.Now().Format(time.RFC3339)
+
+	if e.identityBridge != nil {
+		e.identityBridge.ObserveReflection(agentID, result.Output)
+	}
+	if bridge := e.GetAgentIdentity(agentID); bridge != nil {
+		bridge.ObserveReflection(agentID, result.Output)
+	}
+
+	return e.UpdateAgent(ctx, agent)
+}
+
+// applyReflectionOutcomes adjusts an agent's goals and capabilities based on
+// its recent per-task-type success rates: consistently strong task types
+// become capabilities, weak ones become improvement goals.
+func (e *Engine) applyReflectionOutcomes(agent *Agent, history []*TaskPerformance) {
+	if agent.State == nil {
+		agent.State = &AgentState{}
+	}
+
+	type outcome struct {
+		total     int
+		successes int
+	}
+	outcomes := make(map[string]*outcome)
+	for _, performance := range history {
+		if outcomes[performance.TaskType] == nil {
+			outcomes[performance.TaskType] = &outcome{}
+		}
+		outcomes[performance.TaskType].total++
+		if performance.Success {
+			outcomes[performance.TaskType].successes++
+		}
+	}
+
+	for taskType, o := range outcomes {
+		if o.total < 3 {
+			continue // Not enough signal to act on
+		}
+
+		rate := float64(o.successes) / float64(o.total)
+		capability := fmt.Sprintf("proficient_%s", taskType)
+		goal := fmt.Sprintf("improve %s task outcomes", taskType)
+
+		if rate >= 0.8 {
+			agent.State.Capabilities = appendUnique(agent.State.Capabilities, capability)
+			agent.State.Goals = removeString(agent.State.Goals, goal)
+		} else if rate < 0.5 {
+			agent.State.Goals = appendUnique(agent.State.Goals, goal)
+			agent.State.Capabilities = removeString(agent.State.Capabilities, capability)
+		}
+	}
+}
+
+// summarizeRecentTasks builds a compact textual summary of recent task
+// performance, used as the input to the reflect task.
+func summarizeRecentTasks(history []*TaskPerformance) string {
+	if len(history) == 0 {
+		return "No recent task activity to reflect on."
+	}
+
+	recent := history
+	if len(recent) > 20 {
+		recent = recent[len(recent)-20:]
+	}
+
+	successes := 0
+	byType := make(map[string]int)
+	var totalDuration time.Duration
+	for _, performance := range recent {
+		if performance.Success {
+			successes++
+		}
+		byType[performance.TaskType]++
+		totalDuration += performance.Duration
+	}
+
+	parts := make([]string, 0, len(byType))
+	for taskType, count := range byType {
+		parts = append(parts, fmt.Sprintf("%s x%d", taskType, count))
+	}
+
+	return fmt.Sprintf(
+		"Recent activity: %d tasks (%s), %d succeeded, average duration %s.",
+		len(recent),
+		strings.Join(parts, ", "),
+		successes,
+		(totalDuration / time.Duration(len(recent))).Round(time.Millisecond),
+	)
+}
+
+// appendUnique appends a value to a slice if not already present.
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// removeString removes all occurrences of a value from a slice.
+func removeString(values []string, value string) []string {
+	kept := values[:0]
+	for _, existing := range values {
+		if existing != value {
+			kept = append(kept, existing)
+		}
+	}
+	return kept
+}
+
+// GetReflectionScheduler returns the engine's reflection scheduler.
+func (e *Engine) GetReflectionScheduler() *ReflectionScheduler {
+	return e.reflectionScheduler
+}
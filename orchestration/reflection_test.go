@@ -0,0 +1,113 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestReflectOnAgentUpdatesStateAndMemory(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "Reflective Agent", Type: AgentTypeReflective}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Seed performance history: custom tasks succeed, generate tasks fail
+	for i := 0; i < 4; i++ {
+		engine.learningSystem.RecordTaskPerformance(&TaskPerformance{
+			TaskType: TaskTypeCustom, AgentID: agent.ID, Success: true, Quality: 0.9,
+			Duration: 100 * time.Millisecond,
+		})
+		engine.learningSystem.RecordTaskPerformance(&TaskPerformance{
+			TaskType: TaskTypeGenerate, AgentID: agent.ID, Success: false, Quality: 0.2,
+			Duration: 100 * time.Millisecond,
+		})
+	}
+
+	if err := engine.ReflectOnAgent(ctx, agent.ID); err != nil {
+		t.Fatalf("Reflection failed: %v", err)
+	}
+
+	updated, err := engine.GetAgent(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("Failed to get agent: %v", err)
+	}
+
+	if updated.State == nil {
+		t.Fatal("Expected agent state after reflection")
+	}
+
+	if _, ok := updated.State.Memory["last_reflection"]; !ok {
+		t.Error("Expected reflection stored in agent memory")
+	}
+
+	foundCapability := false
+	for _, capability := range updated.State.Capabilities {
+		if capability == "proficient_"+TaskTypeCustom {
+			foundCapability = true
+		}
+	}
+	if !foundCapability {
+		t.Errorf("Expected proficiency capability for successful task type, got %v", updated.State.Capabilities)
+	}
+
+	foundGoal := false
+	for _, goal := range updated.State.Goals {
+		if strings.Contains(goal, TaskTypeGenerate) {
+			foundGoal = true
+		}
+	}
+	if !foundGoal {
+		t.Errorf("Expected improvement goal for failing task type, got %v", updated.State.Goals)
+	}
+}
+
+func TestReflectionSchedulerLifecycle(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "Scheduled Agent"}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	scheduler := engine.GetReflectionScheduler()
+
+	if err := scheduler.Start(ctx, agent.ID, 50*time.Millisecond); err != nil {
+		t.Fatalf("Failed to start reflection schedule: %v", err)
+	}
+
+	if agents := scheduler.ScheduledAgents(); len(agents) != 1 || agents[0] != agent.ID {
+		t.Errorf("Expected one scheduled agent, got %v", agents)
+	}
+
+	// Wait for at least one reflection cycle to run
+	time.Sleep(150 * time.Millisecond)
+
+	updated, err := engine.GetAgent(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("Failed to get agent: %v", err)
+	}
+	if updated.State == nil || updated.State.Memory["last_reflection"] == nil {
+		t.Error("Expected scheduled reflection to have run")
+	}
+
+	scheduler.Stop(agent.ID)
+	if agents := scheduler.ScheduledAgents(); len(agents) != 0 {
+		t.Errorf("Expected no scheduled agents after stop, got %v", agents)
+	}
+}
+
+func TestReflectionSchedulerRejectsUnknownAgent(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	if err := engine.GetReflectionScheduler().Start(context.Background(), "missing", time.Second); err == nil {
+		t.Error("Expected error scheduling reflection for unknown agent")
+	}
+}
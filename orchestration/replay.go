@@ -0,0 +1,110 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ReplayTaskComparison is the side-by-side outcome of one task executed in
+// the original run and its replay.
+type ReplayTaskComparison struct {
+	Index            int           `json:"index"`
+	Input            string        `json:"input"`
+	OriginalModel    string        `json:"original_model,omitempty"`
+	ReplayModel      string        `json:"replay_model,omitempty"`
+	OriginalOutput   string        `json:"original_output"`
+	ReplayOutput     string        `json:"replay_output"`
+	OutputChanged    bool          `json:"output_changed"`
+	OriginalDuration time.Duration `json:"original_duration"`
+	ReplayDuration   time.Duration `json:"replay_duration"`
+	OriginalTokens   int           `json:"original_tokens"`
+	ReplayTokens     int           `json:"replay_tokens"`
+	OriginalCost     float64       `json:"original_cost"`
+	ReplayCost       float64       `json:"replay_cost"`
+}
+
+// ReplayReport compares a recorded orchestration run with its replay against
+// a different model: per-task diffs plus aggregate latency, token, and cost
+// totals.
+type ReplayReport struct {
+	OriginalRunID    string                 `json:"original_run_id"`
+	ReplayRunID      string                 `json:"replay_run_id"`
+	Model            string                 `json:"model"`
+	Tasks            []ReplayTaskComparison `json:"tasks"`
+	ChangedOutputs   int                    `json:"changed_outputs"`
+	OriginalDuration time.Duration          `json:"original_duration"`
+	ReplayDuration   time.Duration          `json:"replay_duration"`
+	OriginalTokens   int                    `json:"original_tokens"`
+	ReplayTokens     int                    `json:"replay_tokens"`
+	OriginalCost     float64                `json:"original_cost"`
+	ReplayCost       float64                `json:"replay_cost"`
+}
+
+// ReplayOrchestration re-executes a recorded run's inputs against a different
+// model and reports a side-by-side diff of outputs, latency, and cost. The
+// replayed run is recorded like any other, referencing the original.
+func (e *Engine) ReplayOrchestration(ctx context.Context, id, model string) (*ReplayReport, error) {
+	if model == "" {
+		return nil, fmt.Errorf("replay requires a model to compare against")
+	}
+
+	original, err := e.GetOrchestrationRun(id)
+	if err != nil {
+		return nil, err
+	}
+
+	request := cloneOrchestrationRequest(original.Request)
+	for i := range request.Tasks {
+		request.Tasks[i].ModelName = model
+	}
+
+	response, err := e.OrchestrateTasks(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("replay execution failed: %w", err)
+	}
+	if run, getErr := e.GetOrchestrationRun(response.ID); getErr == nil {
+		run.RerunOf = original.ID
+	}
+
+	report := &ReplayReport{
+		OriginalRunID: original.ID,
+		ReplayRunID:   response.ID,
+		Model:         model,
+	}
+	for i := range original.Request.Tasks {
+		comparison := ReplayTaskComparison{
+			Index: i,
+			Input: original.Request.Tasks[i].Input,
+		}
+		if original.Response != nil && i < len(original.Response.Results) {
+			result := &original.Response.Results[i]
+			comparison.OriginalModel = result.ModelUsed
+			comparison.OriginalOutput = result.Output
+			comparison.OriginalDuration = result.Metrics.Duration
+			comparison.OriginalTokens = result.Metrics.TokensUsed
+			comparison.OriginalCost = e.usage.EstimateCost(result.ModelUsed, result.Metrics.PromptTokens, result.Metrics.OutputTokens)
+		}
+		if i < len(response.Results) {
+			result := &response.Results[i]
+			comparison.ReplayModel = result.ModelUsed
+			comparison.ReplayOutput = result.Output
+			comparison.ReplayDuration = result.Metrics.Duration
+			comparison.ReplayTokens = result.Metrics.TokensUsed
+			comparison.ReplayCost = e.usage.EstimateCost(result.ModelUsed, result.Metrics.PromptTokens, result.Metrics.OutputTokens)
+		}
+		comparison.OutputChanged = comparison.OriginalOutput != comparison.ReplayOutput
+
+		report.Tasks = append(report.Tasks, comparison)
+		if comparison.OutputChanged {
+			report.ChangedOutputs++
+		}
+		report.OriginalDuration += comparison.OriginalDuration
+		report.ReplayDuration += comparison.ReplayDuration
+		report.OriginalTokens += comparison.OriginalTokens
+		report.ReplayTokens += comparison.ReplayTokens
+		report.OriginalCost += comparison.OriginalCost
+		report.ReplayCost += comparison.ReplayCost
+	}
+	return report, nil
+}
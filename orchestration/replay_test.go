@@ -0,0 +1,78 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestReplayOrchestration(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "replayer", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	response, err := engine.OrchestrateTasks(ctx, &OrchestrationRequest{
+		AgentID: agent.ID,
+		Tasks: []TaskRequest{
+			{Type: TaskTypeCustom, Input: "first"},
+			{Type: TaskTypeCustom, Input: "second"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to orchestrate: %v", err)
+	}
+
+	report, err := engine.ReplayOrchestration(ctx, response.ID, "mistral")
+	if err != nil {
+		t.Fatalf("Failed to replay: %v", err)
+	}
+
+	if report.OriginalRunID != response.ID {
+		t.Errorf("Expected original run ID %s, got %s", response.ID, report.OriginalRunID)
+	}
+	if report.ReplayRunID == "" || report.ReplayRunID == response.ID {
+		t.Errorf("Expected a distinct replay run ID, got %s", report.ReplayRunID)
+	}
+	if len(report.Tasks) != 2 {
+		t.Fatalf("Expected 2 task comparisons, got %d", len(report.Tasks))
+	}
+	// Custom tasks are deterministic, so outputs should match
+	if report.ChangedOutputs != 0 {
+		t.Errorf("Expected no changed outputs, got %d", report.ChangedOutputs)
+	}
+
+	replayRun, err := engine.GetOrchestrationRun(report.ReplayRunID)
+	if err != nil {
+		t.Fatalf("Failed to get replay run: %v", err)
+	}
+	if replayRun.RerunOf != response.ID {
+		t.Errorf("Expected replay run to reference the original, got %s", replayRun.RerunOf)
+	}
+}
+
+func TestReplayRequiresModel(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	if _, err := engine.ReplayOrchestration(context.Background(), "any", ""); err == nil {
+		t.Error("Expected error for replay without a model")
+	}
+	if _, err := engine.ReplayOrchestration(context.Background(), "missing", "mistral"); err == nil {
+		t.Error("Expected error for unknown run")
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	usage := NewUsageTracker()
+	usage.SetModelPricing("llama3.2", ModelPricing{PromptPer1K: 1, CompletionPer1K: 2})
+
+	if cost := usage.EstimateCost("llama3.2", 1000, 500); cost != 2 {
+		t.Errorf("Expected cost 2, got %f", cost)
+	}
+	if cost := usage.EstimateCost("unpriced", 1000, 1000); cost != 0 {
+		t.Errorf("Expected zero cost for unpriced model, got %f", cost)
+	}
+}
@@ -0,0 +1,81 @@
+package orchestration
+
+import (
+	"context"
+	"time"
+)
+
+// TaskResumePolicy decides what happens to tasks found pending or running
+// when an engine starts — the residue of a process that died
+// mid-orchestration. The zero value retries pending tasks and marks
+// interrupted running tasks failed.
+type TaskResumePolicy struct {
+	// RetryRunning re-executes tasks that were mid-run when the process
+	// died instead of marking them failed. Only safe for idempotent tasks.
+	RetryRunning bool
+
+	// FailPending marks pending tasks failed instead of re-queueing them.
+	FailPending bool
+
+	// MaxAge fails any interrupted task older than this outright, on the
+	// grounds that its result is no longer wanted. Zero means no limit.
+	MaxAge time.Duration
+}
+
+// ResumePendingTasks scans the store for tasks left pending or running by a
+// previous instance and re-executes or fails them according to the policy.
+// It returns how many tasks were resumed and how many were marked failed.
+// Resumed tasks run synchronously; call from a goroutine if startup latency
+// matters.
+func (e *Engine) ResumePendingTasks(ctx context.Context, policy *TaskResumePolicy) (resumed, failed int, err error) {
+	if policy == nil {
+		policy = &TaskResumePolicy{}
+	}
+
+	interrupted := make([]*Task, 0)
+	for _, status := range []string{TaskStatusPending, TaskStatusRunning} {
+		tasks, listErr := e.ListTasks(ctx, TaskFilter{Status: status})
+		if listErr != nil {
+			return 0, 0, listErr
+		}
+		interrupted = append(interrupted, tasks...)
+	}
+
+	now := time.Now()
+	for _, task := range interrupted {
+		retry := task.Status == TaskStatusPending && !policy.FailPending ||
+			task.Status == TaskStatusRunning && policy.RetryRunning
+		if policy.MaxAge > 0 && !task.CreatedAt.IsZero() && now.Sub(task.CreatedAt) > policy.MaxAge {
+			retry = false
+		}
+
+		if !retry {
+			task.Status = TaskStatusFailed
+			task.Error = "interrupted by engine restart"
+			e.persistTask(ctx, task)
+			failed++
+			continue
+		}
+
+		agent, agentErr := e.GetAgent(ctx, task.AgentID)
+		if agentErr != nil {
+			task.Status = TaskStatusFailed
+			task.Error = agentErr.Error()
+			e.persistTask(ctx, task)
+			failed++
+			continue
+		}
+
+		task.Status = TaskStatusPending
+		task.Error = ""
+		if _, execErr := e.ExecuteTask(ctx, task, agent); execErr != nil {
+			clog(ctx).Error("Resumed task failed", "error", execErr, "task_id", task.ID)
+		}
+		resumed++
+	}
+
+	if resumed > 0 || failed > 0 {
+		clog(ctx).Info("Resumed interrupted tasks", "resumed", resumed, "failed", failed)
+	}
+	return resumed, failed, nil
+}
@@ -0,0 +1,99 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestResumePendingTasksDefaultPolicy(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "survivor", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Residue of a crashed instance: one task never started, one was mid-run
+	pending := &Task{ID: "resume-pending", Type: TaskTypeCustom, Input: "work", AgentID: agent.ID, Status: TaskStatusPending, CreatedAt: time.Now()}
+	running := &Task{ID: "resume-running", Type: TaskTypeCustom, Input: "work", AgentID: agent.ID, Status: TaskStatusRunning, CreatedAt: time.Now()}
+	engine.persistTask(ctx, pending)
+	engine.persistTask(ctx, running)
+
+	resumed, failed, err := engine.ResumePendingTasks(ctx, nil)
+	if err != nil {
+		t.Fatalf("ResumePendingTasks failed: %v", err)
+	}
+	if resumed != 1 || failed != 1 {
+		t.Fatalf("Expected 1 resumed and 1 failed, got %d and %d", resumed, failed)
+	}
+
+	restored, err := engine.store.GetTask(ctx, pending.ID)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if restored.Status != TaskStatusCompleted {
+		t.Errorf("Expected resumed pending task to complete, got %s", restored.Status)
+	}
+
+	interrupted, err := engine.store.GetTask(ctx, running.ID)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if interrupted.Status != TaskStatusFailed || interrupted.Error == "" {
+		t.Errorf("Expected interrupted running task to be marked failed, got %+v", interrupted)
+	}
+}
+
+func TestResumePendingTasksRetryRunning(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "retrier", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	running := &Task{ID: "retry-running", Type: TaskTypeCustom, Input: "work", AgentID: agent.ID, Status: TaskStatusRunning, CreatedAt: time.Now()}
+	engine.persistTask(ctx, running)
+
+	resumed, failed, err := engine.ResumePendingTasks(ctx, &TaskResumePolicy{RetryRunning: true})
+	if err != nil {
+		t.Fatalf("ResumePendingTasks failed: %v", err)
+	}
+	if resumed != 1 || failed != 0 {
+		t.Fatalf("Expected the running task to be retried, got resumed=%d failed=%d", resumed, failed)
+	}
+
+	restored, err := engine.store.GetTask(ctx, running.ID)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if restored.Status != TaskStatusCompleted {
+		t.Errorf("Expected retried task to complete, got %s", restored.Status)
+	}
+}
+
+func TestResumePendingTasksMaxAge(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "ager", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	stale := &Task{ID: "resume-stale", Type: TaskTypeCustom, Input: "work", AgentID: agent.ID, Status: TaskStatusPending, CreatedAt: time.Now().Add(-2 * time.Hour)}
+	engine.persistTask(ctx, stale)
+
+	resumed, failed, err := engine.ResumePendingTasks(ctx, &TaskResumePolicy{MaxAge: time.Hour})
+	if err != nil {
+		t.Fatalf("ResumePendingTasks failed: %v", err)
+	}
+	if resumed != 0 || failed != 1 {
+		t.Errorf("Expected the stale task to be failed, got resumed=%d failed=%d", resumed, failed)
+	}
+}
@@ -0,0 +1,173 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParticipantRole defines what a participant may do in a conversation.
+type ParticipantRole string
+
+const (
+	// RoleModerator may post, close the conversation, and steers the
+	// discussion back to its declared topic.
+	RoleModerator ParticipantRole = "moderator"
+	// RoleContributor may post messages; this is the default role.
+	RoleContributor ParticipantRole = "contributor"
+	// RoleObserver receives the conversation but may not post.
+	RoleObserver ParticipantRole = "observer"
+)
+
+// validParticipantRole reports whether a role is one of the known roles.
+func validParticipantRole(role ParticipantRole) bool {
+	switch role {
+	case RoleModerator, RoleContributor, RoleObserver:
+		return true
+	}
+	return false
+}
+
+// participantRole returns an agent's role in a conversation. Participants
+// without an explicit assignment are contributors.
+func participantRole(conversation *Conversation, agentID string) ParticipantRole {
+	if role, exists := conversation.Roles[agentID]; exists {
+		return role
+	}
+	return RoleContributor
+}
+
+// hasModerator reports whether any participant holds the moderator role.
+func hasModerator(conversation *Conversation) bool {
+	for _, role := range conversation.Roles {
+		if role == RoleModerator {
+			return true
+		}
+	}
+	return false
+}
+
+// SetParticipantRole assigns a conversation role to a participant.
+func (e *Engine) SetParticipantRole(ctx context.Context, conversationID, agentID string, role ParticipantRole) error {
+	if !validParticipantRole(role) {
+		return fmt.Errorf("unknown participant role: %s", role)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	conversation, exists := e.conversations[conversationID]
+	if !exists {
+		stored, err := e.store.GetConversation(ctx, conversationID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrConversationNotFound, conversationID)
+		}
+		conversation = stored
+		e.conversations[conversationID] = conversation
+	}
+
+	participant := false
+	for _, id := range conversation.Participants {
+		if id == agentID {
+			participant = true
+			break
+		}
+	}
+	if !participant {
+		return fmt.Errorf("%w: %s is not a participant of %s", ErrAgentNotFound, agentID, conversationID)
+	}
+
+	if conversation.Roles == nil {
+		conversation.Roles = make(map[string]ParticipantRole)
+	}
+	conversation.Roles[agentID] = role
+	conversation.UpdatedAt = time.Now()
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		return fmt.Errorf("failed to persist conversation: %w", err)
+	}
+
+	clog(ctx).Info("Set participant role", "conversation_id", conversationID, "agent_id", agentID, "role", role)
+	return nil
+}
+
+// CloseConversationAs closes a conversation on behalf of a participant,
+// enforcing that only a moderator may close moderated conversations.
+func (e *Engine) CloseConversationAs(ctx context.Context, conversationID, agentID string) error {
+	conversation, err := e.GetConversation(ctx, conversationID)
+	if err != nil {
+		return err
+	}
+
+	e.mu.RLock()
+	moderated := hasModerator(conversation)
+	role := participantRole(conversation, agentID)
+	e.mu.RUnlock()
+
+	if moderated && role != RoleModerator {
+		return fmt.Errorf("%w: only a moderator may close conversation %s", ErrRoleForbidden, conversationID)
+	}
+	return e.CloseConversation(ctx, conversationID)
+}
+
+// checkPostPermission rejects messages from observers. The caller must hold
+// e.mu.
+func checkPostPermission(conversation *Conversation, message *Message) error {
+	if participantRole(conversation, message.FromAgentID) == RoleObserver {
+		return fmt.Errorf("%w: observer %s cannot post", ErrRoleForbidden, message.FromAgentID)
+	}
+	return nil
+}
+
+// moderateTopicDrift posts a moderator reminder when a contributor's message
+// shares no vocabulary with the conversation's declared topic. It only acts
+// in moderated conversations with a topic. The caller must hold e.mu.
+func (e *Engine) moderateTopicDrift(ctx context.Context, conversation *Conversation, message *Message) {
+	if conversation.Topic == "" || !hasModerator(conversation) {
+		return
+	}
+	if participantRole(conversation, message.FromAgentID) == RoleModerator {
+		return
+	}
+	if onTopic(message.Content, conversation.Topic) {
+		return
+	}
+
+	reminder := Message{
+		ID:      uuid.New().String(),
+		Content: fmt.Sprintf("Moderator reminder: please keep the discussion on topic (%s).", conversation.Topic),
+		Type:    MessageTypeNotification,
+		Context: map[string]interface{}{
+			"intervention":        "topic_reminder",
+			"original_message_id": message.ID,
+		},
+		Timestamp: time.Now(),
+	}
+	conversation.Messages = append(conversation.Messages, reminder)
+	conversation.UpdatedAt = time.Now()
+	if err := e.store.SaveConversation(ctx, conversation); err != nil {
+		clog(ctx).Error("Failed to persist topic reminder", "error", err)
+	}
+
+	clog(ctx).Info("Posted topic reminder", "conversation_id", conversation.ID, "from", message.FromAgentID)
+}
+
+// minTopicCheckWords is the smallest message (in distinct words) considered
+// substantial enough to check for topic drift.
+const minTopicCheckWords = 4
+
+// onTopic reports whether a message plausibly relates to the topic: short
+// messages pass, longer ones must share at least one topic word.
+func onTopic(content, topic string) bool {
+	words := wordSet(content)
+	if len(words) < minTopicCheckWords {
+		return true
+	}
+	for word := range wordSet(topic) {
+		if words[word] {
+			return true
+		}
+	}
+	return false
+}
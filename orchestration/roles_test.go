@@ -0,0 +1,149 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// startRoleConversation creates three agents and a conversation for role
+// tests, returning the conversation and the agents in declaration order.
+func startRoleConversation(t *testing.T, engine *Engine, topic string) (*Conversation, []*Agent) {
+	t.Helper()
+	ctx := context.Background()
+
+	agents := []*Agent{
+		{Name: "mod", Models: []string{"llama3.2"}},
+		{Name: "worker", Models: []string{"llama3.2"}},
+		{Name: "watcher", Models: []string{"llama3.2"}},
+	}
+	participants := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+		participants = append(participants, agent.ID)
+	}
+
+	conversation, err := engine.StartConversation(ctx, participants, topic)
+	if err != nil {
+		t.Fatalf("Failed to start conversation: %v", err)
+	}
+	return conversation, agents
+}
+
+func TestObserverCannotPost(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "release planning")
+
+	if err := engine.SetParticipantRole(ctx, conversation.ID, agents[2].ID, RoleObserver); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	err := engine.SendMessage(ctx, conversation.ID, &Message{
+		FromAgentID: agents[2].ID,
+		Content:     "let me chime in",
+		Type:        MessageTypeNotification,
+	})
+	if !errors.Is(err, ErrRoleForbidden) {
+		t.Errorf("Expected ErrRoleForbidden for observer post, got %v", err)
+	}
+
+	// Contributors still post freely
+	err = engine.SendMessage(ctx, conversation.ID, &Message{
+		FromAgentID: agents[1].ID,
+		Content:     "release planning update",
+		Type:        MessageTypeNotification,
+	})
+	if err != nil {
+		t.Errorf("Expected contributor post to succeed, got %v", err)
+	}
+}
+
+func TestOnlyModeratorCloses(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "release planning")
+
+	if err := engine.SetParticipantRole(ctx, conversation.ID, agents[0].ID, RoleModerator); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	if err := engine.CloseConversationAs(ctx, conversation.ID, agents[1].ID); !errors.Is(err, ErrRoleForbidden) {
+		t.Errorf("Expected ErrRoleForbidden for contributor close, got %v", err)
+	}
+	if err := engine.CloseConversationAs(ctx, conversation.ID, agents[0].ID); err != nil {
+		t.Errorf("Expected moderator close to succeed, got %v", err)
+	}
+
+	updated, err := engine.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("Failed to get conversation: %v", err)
+	}
+	if updated.Status != ConversationStatusClosed {
+		t.Errorf("Expected closed conversation, got %s", updated.Status)
+	}
+}
+
+func TestModeratorTopicReminder(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+	conversation, agents := startRoleConversation(t, engine, "release planning")
+
+	if err := engine.SetParticipantRole(ctx, conversation.ID, agents[0].ID, RoleModerator); err != nil {
+		t.Fatalf("Failed to set role: %v", err)
+	}
+
+	err := engine.SendMessage(ctx, conversation.ID, &Message{
+		FromAgentID: agents[1].ID,
+		Content:     "anyone watched the game last night at the stadium",
+		Type:        MessageTypeNotification,
+	})
+	if err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+
+	updated, err := engine.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("Failed to get conversation: %v", err)
+	}
+	found := false
+	for _, msg := range updated.Messages {
+		if msg.Type == MessageTypeNotification && msg.Context["intervention"] == "topic_reminder" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Expected a moderator topic reminder in the conversation")
+	}
+
+	// On-topic messages draw no reminder
+	before := len(updated.Messages)
+	err = engine.SendMessage(ctx, conversation.ID, &Message{
+		FromAgentID: agents[1].ID,
+		Content:     "the release planning milestones look achievable this quarter",
+		Type:        MessageTypeNotification,
+	})
+	if err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+	updated, _ = engine.GetConversation(ctx, conversation.ID)
+	if len(updated.Messages) != before+1 {
+		t.Errorf("Expected no reminder for on-topic message, got %d messages (was %d)", len(updated.Messages), before)
+	}
+}
+
+func TestOnTopic(t *testing.T) {
+	if onTopic("completely unrelated chatter about lunch menus", "release planning") {
+		t.Error("Expected off-topic message to be flagged")
+	}
+	if !onTopic("ok", "release planning") {
+		t.Error("Expected short message to pass")
+	}
+	if !onTopic("the planning doc needs another pass before review", "release planning") {
+		t.Error("Expected message sharing topic words to pass")
+	}
+}
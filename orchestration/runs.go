@@ -0,0 +1,129 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OrchestrationRun pairs an orchestration request with its response so past
+// runs can be inspected and re-run.
+type OrchestrationRun struct {
+	ID        string                 `json:"id"`
+	Request   *OrchestrationRequest  `json:"request"`
+	Response  *OrchestrationResponse `json:"response"`
+	CreatedAt time.Time              `json:"created_at"`
+	// RerunOf references the run this one was re-executed from, if any.
+	RerunOf string `json:"rerun_of,omitempty"`
+}
+
+// maxRetainedRuns bounds how many completed runs the engine keeps in memory.
+const maxRetainedRuns = 500
+
+// recordRun stores a completed orchestration run, evicting the oldest run
+// beyond the retention bound.
+func (e *Engine) recordRun(run *OrchestrationRun) {
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+
+	e.runs[run.ID] = run
+	if len(e.runs) <= maxRetainedRuns {
+		return
+	}
+
+	var oldestID string
+	var oldest time.Time
+	for id, candidate := range e.runs {
+		if oldestID == "" || candidate.CreatedAt.Before(oldest) {
+			oldestID = id
+			oldest = candidate.CreatedAt
+		}
+	}
+	delete(e.runs, oldestID)
+}
+
+// GetOrchestrationRun returns a past orchestration run by ID.
+func (e *Engine) GetOrchestrationRun(id string) (*OrchestrationRun, error) {
+	e.runMu.RLock()
+	defer e.runMu.RUnlock()
+
+	run, exists := e.runs[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: orchestration run %s", ErrNotFound, id)
+	}
+	return run, nil
+}
+
+// ListOrchestrationRuns returns past runs, newest first, optionally filtered
+// by agent.
+func (e *Engine) ListOrchestrationRuns(agentID string) []*OrchestrationRun {
+	e.runMu.RLock()
+	defer e.runMu.RUnlock()
+
+	runs := make([]*OrchestrationRun, 0, len(e.runs))
+	for _, run := range e.runs {
+		if agentID != "" && run.Request.AgentID != agentID {
+			continue
+		}
+		runs = append(runs, run)
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].CreatedAt.After(runs[j].CreatedAt) })
+	return runs
+}
+
+// RerunOrchestration re-executes a past run's request with parameter
+// overrides merged into the request and every task. The new run references
+// the original via RerunOf.
+func (e *Engine) RerunOrchestration(ctx context.Context, id string, overrides map[string]interface{}) (*OrchestrationResponse, error) {
+	original, err := e.GetOrchestrationRun(id)
+	if err != nil {
+		return nil, err
+	}
+
+	request := cloneOrchestrationRequest(original.Request)
+	for key, value := range overrides {
+		request.Parameters[key] = value
+	}
+	for i := range request.Tasks {
+		if request.Tasks[i].Parameters == nil {
+			request.Tasks[i].Parameters = make(map[string]interface{})
+		}
+		for key, value := range overrides {
+			request.Tasks[i].Parameters[key] = value
+		}
+	}
+
+	response, err := e.OrchestrateTasks(ctx, request)
+	if response != nil {
+		if run, getErr := e.GetOrchestrationRun(response.ID); getErr == nil {
+			run.RerunOf = original.ID
+		}
+	}
+	return response, err
+}
+
+// cloneOrchestrationRequest deep-copies a request so re-runs cannot mutate
+// the recorded original.
+func cloneOrchestrationRequest(request *OrchestrationRequest) *OrchestrationRequest {
+	clone := &OrchestrationRequest{
+		AgentID:    request.AgentID,
+		Sequential: request.Sequential,
+		Stream:     request.Stream,
+		KeepAlive:  request.KeepAlive,
+		Parameters: make(map[string]interface{}, len(request.Parameters)),
+		Tasks:      make([]TaskRequest, len(request.Tasks)),
+	}
+	for key, value := range request.Parameters {
+		clone.Parameters[key] = value
+	}
+	for i, task := range request.Tasks {
+		copied := task
+		copied.Parameters = make(map[string]interface{}, len(task.Parameters))
+		for key, value := range task.Parameters {
+			copied.Parameters[key] = value
+		}
+		clone.Tasks[i] = copied
+	}
+	return clone
+}
@@ -0,0 +1,90 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestOrchestrationRunPersistence(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "run-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	req := &OrchestrationRequest{
+		AgentID: agent.ID,
+		Tasks:   []TaskRequest{{Type: TaskTypeCustom, Input: "noop"}},
+	}
+	response, err := engine.OrchestrateTasks(ctx, req)
+	if err != nil {
+		t.Fatalf("OrchestrateTasks failed: %v", err)
+	}
+
+	run, err := engine.GetOrchestrationRun(response.ID)
+	if err != nil {
+		t.Fatalf("Expected run to be persisted: %v", err)
+	}
+	if run.Request.AgentID != agent.ID || len(run.Response.Results) != 1 {
+		t.Errorf("Unexpected recorded run: %+v", run)
+	}
+
+	runs := engine.ListOrchestrationRuns(agent.ID)
+	if len(runs) != 1 {
+		t.Errorf("Expected 1 run listed for agent, got %d", len(runs))
+	}
+	if runs := engine.ListOrchestrationRuns("other-agent"); len(runs) != 0 {
+		t.Errorf("Expected no runs for other agent, got %d", len(runs))
+	}
+
+	if _, err := engine.GetOrchestrationRun("missing"); err == nil {
+		t.Error("Expected error for unknown run")
+	}
+}
+
+func TestRerunOrchestration(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "rerun-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	original, err := engine.OrchestrateTasks(ctx, &OrchestrationRequest{
+		AgentID: agent.ID,
+		Tasks:   []TaskRequest{{Type: TaskTypeCustom, Input: "noop"}},
+	})
+	if err != nil {
+		t.Fatalf("OrchestrateTasks failed: %v", err)
+	}
+
+	rerun, err := engine.RerunOrchestration(ctx, original.ID, map[string]interface{}{"variant": "b"})
+	if err != nil {
+		t.Fatalf("RerunOrchestration failed: %v", err)
+	}
+	if rerun.ID == original.ID {
+		t.Error("Expected re-run to produce a new run ID")
+	}
+
+	rerunRecord, err := engine.GetOrchestrationRun(rerun.ID)
+	if err != nil {
+		t.Fatalf("Expected re-run to be recorded: %v", err)
+	}
+	if rerunRecord.RerunOf != original.ID {
+		t.Errorf("Expected rerun_of %s, got %s", original.ID, rerunRecord.RerunOf)
+	}
+	if rerunRecord.Request.Tasks[0].Parameters["variant"] != "b" {
+		t.Errorf("Expected override applied to task parameters: %v", rerunRecord.Request.Tasks[0].Parameters)
+	}
+
+	// The original request is not mutated by the re-run overrides
+	originalRecord, _ := engine.GetOrchestrationRun(original.ID)
+	if _, exists := originalRecord.Request.Tasks[0].Parameters["variant"]; exists {
+		t.Error("Expected original run request to remain unchanged")
+	}
+}
@@ -0,0 +1,383 @@
+package orchestration
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretSource resolves named secrets from a backing store. Implementations
+// must be safe for concurrent use.
+type SecretSource interface {
+	// Name identifies the source, used as the prefix in secret references.
+	Name() string
+	// GetSecret returns the secret value for a key.
+	GetSecret(ctx context.Context, key string) (string, error)
+}
+
+// SecretManager resolves secret references of the form "source:key"
+// (e.g. "env:OPENAI_API_KEY", "vault:secret/data/echollama#api_key") so
+// credentials for providers and tools never appear in plain configuration.
+// Values without a registered source prefix are returned unchanged.
+type SecretManager struct {
+	sources map[string]SecretSource
+	mu      sync.RWMutex
+}
+
+// NewSecretManager creates a secret manager with the environment source
+// registered by default.
+func NewSecretManager() *SecretManager {
+	manager := &SecretManager{sources: make(map[string]SecretSource)}
+	manager.Register(&EnvSecretSource{})
+	return manager
+}
+
+// Register adds a secret source, replacing any source with the same name.
+func (m *SecretManager) Register(source SecretSource) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sources[source.Name()] = source
+}
+
+// Resolve expands a secret reference. Values without a registered source
+// prefix — including "source:key" forms whose source is not registered —
+// pass through untouched, so plain credentials that happen to contain a
+// colon keep working. A mistyped source name is therefore used literally;
+// the downstream provider rejecting it is what surfaces the typo.
+func (m *SecretManager) Resolve(ctx context.Context, reference string) (string, error) {
+	name, key, found := strings.Cut(reference, ":")
+	if !found {
+		return reference, nil
+	}
+
+	m.mu.RLock()
+	source, exists := m.sources[name]
+	m.mu.RUnlock()
+
+	if !exists {
+		return reference, nil
+	}
+
+	value, err := source.GetSecret(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve secret %q from %s: %w", key, name, err)
+	}
+	return value, nil
+}
+
+// Sources returns the names of registered sources.
+func (m *SecretManager) Sources() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	names := make([]string, 0, len(m.sources))
+	for name := range m.sources {
+		names = append(names, name)
+	}
+	return names
+}
+
+// EnvSecretSource reads secrets from process environment variables.
+type EnvSecretSource struct{}
+
+// Name implements SecretSource.
+func (s *EnvSecretSource) Name() string { return "env" }
+
+// GetSecret implements SecretSource.
+func (s *EnvSecretSource) GetSecret(ctx context.Context, key string) (string, error) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s not set", key)
+	}
+	return value, nil
+}
+
+// EncryptedFileSecretSource reads secrets from an AES-GCM encrypted JSON
+// file of key/value pairs. The encryption key is derived from a passphrase
+// with SHA-256.
+type EncryptedFileSecretSource struct {
+	path       string
+	passphrase string
+}
+
+// NewEncryptedFileSecretSource creates a source reading the encrypted
+// secrets file at path.
+func NewEncryptedFileSecretSource(path, passphrase string) *EncryptedFileSecretSource {
+	return &EncryptedFileSecretSource{path: path, passphrase: passphrase}
+}
+
+// Name implements SecretSource.
+func (s *EncryptedFileSecretSource) Name() string { return "file" }
+
+// GetSecret implements SecretSource.
+func (s *EncryptedFileSecretSource) GetSecret(ctx context.Context, key string) (string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	plaintext, err := decryptSecrets(data, s.passphrase)
+	if err != nil {
+		return "", err
+	}
+
+	var secrets map[string]string
+	if err := json.Unmarshal(plaintext, &secrets); err != nil {
+		return "", fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+
+	value, ok := secrets[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s not present in file", key)
+	}
+	return value, nil
+}
+
+// WriteEncryptedSecretsFile encrypts a secrets map with AES-GCM and writes
+// it to path, for provisioning EncryptedFileSecretSource files.
+func WriteEncryptedSecretsFile(path, passphrase string, secrets map[string]string) error {
+	plaintext, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, plaintext, nil)
+	return os.WriteFile(path, ciphertext, 0o600)
+}
+
+// decryptSecrets reverses WriteEncryptedSecretsFile.
+func decryptSecrets(data []byte, passphrase string) ([]byte, error) {
+	key := sha256.Sum256([]byte(passphrase))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("secrets file too short")
+	}
+
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file (wrong passphrase?): %w", err)
+	}
+	return plaintext, nil
+}
+
+// VaultSecretSource reads secrets from a HashiCorp Vault KV v2 mount over
+// its HTTP API. Keys use the form "path#field", e.g.
+// "secret/data/echollama#api_key".
+type VaultSecretSource struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+// NewVaultSecretSource creates a Vault source for the given server address
+// and token.
+func NewVaultSecretSource(address, token string) *VaultSecretSource {
+	return &VaultSecretSource{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name implements SecretSource.
+func (s *VaultSecretSource) Name() string { return "vault" }
+
+// GetSecret implements SecretSource.
+func (s *VaultSecretSource) GetSecret(ctx context.Context, key string) (string, error) {
+	path, field, found := strings.Cut(key, "#")
+	if !found {
+		return "", fmt.Errorf("vault key must be path#field, got %q", key)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.address+"/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault returned status %d for %s", resp.StatusCode, path)
+	}
+
+	var payload struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("failed to decode vault response: %w", err)
+	}
+
+	if value, ok := payload.Data.Data[field].(string); ok {
+		return value, nil
+	}
+	return "", fmt.Errorf("field %s not found at %s", field, path)
+}
+
+// AWSSecretsManagerSource reads secrets from AWS Secrets Manager using the
+// GetSecretValue API with SigV4 request signing, avoiding an SDK dependency.
+type AWSSecretsManagerSource struct {
+	region    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	// endpoint overrides the service URL, for tests
+	endpoint string
+}
+
+// NewAWSSecretsManagerSource creates an AWS Secrets Manager source for the
+// given region and static credentials.
+func NewAWSSecretsManagerSource(region, accessKey, secretKey string) *AWSSecretsManagerSource {
+	return &AWSSecretsManagerSource{
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name implements SecretSource.
+func (s *AWSSecretsManagerSource) Name() string { return "aws" }
+
+// GetSecret implements SecretSource.
+func (s *AWSSecretsManagerSource) GetSecret(ctx context.Context, key string) (string, error) {
+	host := fmt.Sprintf("secretsmanager.%s.amazonaws.com", s.region)
+	endpoint := "https://" + host + "/"
+	if s.endpoint != "" {
+		endpoint = s.endpoint
+	}
+
+	body := fmt.Sprintf(`{"SecretId":%q}`, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	req.Header.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	req.Header.Set("Host", req.URL.Host)
+	s.sign(req, []byte(body), now)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("secrets manager request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("secrets manager returned status %d for %s", resp.StatusCode, key)
+	}
+
+	var payload struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return "", fmt.Errorf("failed to decode secrets manager response: %w", err)
+	}
+	return payload.SecretString, nil
+}
+
+// sign applies AWS Signature Version 4 to the request.
+func (s *AWSSecretsManagerSource) sign(req *http.Request, body []byte, now time.Time) {
+	const service = "secretsmanager"
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+
+	payloadHash := sha256.Sum256(body)
+	canonicalHeaders := fmt.Sprintf("content-type:%s\nhost:%s\nx-amz-date:%s\nx-amz-target:%s\n",
+		req.Header.Get("Content-Type"), req.URL.Host, amzDate, req.Header.Get("X-Amz-Target"))
+	signedHeaders := "content-type;host;x-amz-date;x-amz-target"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method, "/", "",
+		canonicalHeaders, signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := strings.Join([]string{dateStamp, s.region, service, "aws4_request"}, "/")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	kDate := hmacSHA256([]byte("AWS4"+s.secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, s.region)
+	kService := hmacSHA256(kRegion, service)
+	kSigning := hmacSHA256(kService, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(kSigning, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+// hmacSHA256 computes HMAC-SHA256 of a message with a key.
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+// SetSecretManager configures the engine's secret manager.
+func (e *Engine) SetSecretManager(manager *SecretManager) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.secrets = manager
+}
+
+// GetSecretManager returns the engine's secret manager.
+func (e *Engine) GetSecretManager() *SecretManager {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.secrets
+}
+
+// ResolveSecret expands a secret reference using the engine's secret
+// manager, for tools and providers that take credentials from configuration.
+func (e *Engine) ResolveSecret(ctx context.Context, reference string) (string, error) {
+	return e.GetSecretManager().Resolve(ctx, reference)
+}
@@ -0,0 +1,135 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestSecretManagerEnvSource(t *testing.T) {
+	t.Setenv("TEST_SECRET_VALUE", "hunter2")
+
+	manager := NewSecretManager()
+	ctx := context.Background()
+
+	value, err := manager.Resolve(ctx, "env:TEST_SECRET_VALUE")
+	if err != nil {
+		t.Fatalf("Failed to resolve env secret: %v", err)
+	}
+	if value != "hunter2" {
+		t.Errorf("Expected hunter2, got %q", value)
+	}
+
+	if _, err := manager.Resolve(ctx, "env:DEFINITELY_NOT_SET_ANYWHERE"); err == nil {
+		t.Error("Expected error for unset environment variable")
+	}
+}
+
+func TestSecretManagerPassthrough(t *testing.T) {
+	manager := NewSecretManager()
+	ctx := context.Background()
+
+	// Plain values and unknown prefixes pass through untouched
+	for _, value := range []string{"plain-value", "unknown:key", "http://example.com"} {
+		resolved, err := manager.Resolve(ctx, value)
+		if err != nil {
+			t.Fatalf("Unexpected error resolving %q: %v", value, err)
+		}
+		if resolved != value {
+			t.Errorf("Expected %q to pass through, got %q", value, resolved)
+		}
+	}
+}
+
+func TestEncryptedFileSecretSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.enc")
+
+	err := WriteEncryptedSecretsFile(path, "correct horse", map[string]string{
+		"api_key": "sk-12345",
+	})
+	if err != nil {
+		t.Fatalf("Failed to write encrypted secrets: %v", err)
+	}
+
+	manager := NewSecretManager()
+	manager.Register(NewEncryptedFileSecretSource(path, "correct horse"))
+	ctx := context.Background()
+
+	value, err := manager.Resolve(ctx, "file:api_key")
+	if err != nil {
+		t.Fatalf("Failed to resolve file secret: %v", err)
+	}
+	if value != "sk-12345" {
+		t.Errorf("Expected sk-12345, got %q", value)
+	}
+
+	// Wrong passphrase must fail decryption
+	wrong := NewEncryptedFileSecretSource(path, "battery staple")
+	if _, err := wrong.GetSecret(ctx, "api_key"); err == nil {
+		t.Error("Expected decryption failure with wrong passphrase")
+	}
+}
+
+func TestVaultSecretSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.URL.Path != "/v1/secret/data/echollama" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{
+				"data": map[string]interface{}{"api_key": "vault-secret"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	source := NewVaultSecretSource(server.URL, "test-token")
+	ctx := context.Background()
+
+	value, err := source.GetSecret(ctx, "secret/data/echollama#api_key")
+	if err != nil {
+		t.Fatalf("Failed to get vault secret: %v", err)
+	}
+	if value != "vault-secret" {
+		t.Errorf("Expected vault-secret, got %q", value)
+	}
+
+	if _, err := source.GetSecret(ctx, "secret/data/echollama#missing"); err == nil {
+		t.Error("Expected error for missing field")
+	}
+	if _, err := source.GetSecret(ctx, "no-field-separator"); err == nil {
+		t.Error("Expected error for malformed key")
+	}
+}
+
+func TestAWSSecretsManagerSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if auth == "" || r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"SecretString": "aws-secret"})
+	}))
+	defer server.Close()
+
+	source := NewAWSSecretsManagerSource("us-east-1", "AKIATEST", "secret")
+	source.endpoint = server.URL
+	ctx := context.Background()
+
+	value, err := source.GetSecret(ctx, "prod/echollama/api-key")
+	if err != nil {
+		t.Fatalf("Failed to get AWS secret: %v", err)
+	}
+	if value != "aws-secret" {
+		t.Errorf("Expected aws-secret, got %q", value)
+	}
+}
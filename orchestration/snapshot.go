@@ -0,0 +1,47 @@
+package orchestration
+
+// Copy-on-read snapshots for the engine's cached maps. Heavy read paths
+// clone the records under a brief RLock and do all response building outside
+// the lock, so metrics and list endpoints don't block task execution under
+// load. Cloning matters as much as the lock scope: task execution and
+// conversation writes mutate the cached records in place, so a snapshot of
+// live pointers would race with them.
+
+// agentsSnapshot returns a point-in-time copy of the agent cache. The lock
+// is held only long enough to clone the records.
+func (e *Engine) agentsSnapshot() []*Agent {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	agents := make([]*Agent, 0, len(e.agents))
+	for _, agent := range e.agents {
+		agents = append(agents, agent.Clone())
+	}
+	return agents
+}
+
+// conversationsSnapshot returns a point-in-time copy of the conversation
+// cache. The lock is held only long enough to clone the records.
+func (e *Engine) conversationsSnapshot() []*Conversation {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	conversations := make([]*Conversation, 0, len(e.conversations))
+	for _, conversation := range e.conversations {
+		conversations = append(conversations, conversation.Clone())
+	}
+	return conversations
+}
+
+// tasksSnapshot returns a point-in-time copy of the task cache. The lock is
+// held only long enough to clone the records.
+func (e *Engine) tasksSnapshot() []*Task {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	tasks := make([]*Task, 0, len(e.tasks))
+	for _, task := range e.tasks {
+		tasks = append(tasks, task.Clone())
+	}
+	return tasks
+}
@@ -0,0 +1,83 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestSnapshotsAreIsolatedFromLaterWrites(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "snapshotted", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	agents := engine.agentsSnapshot()
+	conversations := engine.conversationsSnapshot()
+
+	other := &Agent{Name: "later", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, other); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.conversations["after"] = &Conversation{ID: "after"}
+
+	if len(agents) != 1 {
+		t.Errorf("Expected the snapshot to keep its point-in-time view, got %d agents", len(agents))
+	}
+	if len(conversations) != 0 {
+		t.Errorf("Expected an empty conversation snapshot, got %d", len(conversations))
+	}
+
+	// Snapshots copy the records, not just the pointer slice: in-place
+	// writes to the cached record don't bleed into an earlier snapshot
+	engine.mu.Lock()
+	engine.agents[agent.ID].Name = "renamed"
+	engine.mu.Unlock()
+	if agents[0].Name != "snapshotted" {
+		t.Errorf("Expected the snapshot isolated from later writes, got %q", agents[0].Name)
+	}
+}
+
+func TestConversationMetricsDoNotBlockExecution(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "busy", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.conversations["c1"] = &Conversation{
+		ID:           "c1",
+		Status:       ConversationStatusActive,
+		Participants: []string{agent.ID},
+		Messages:     []Message{{Content: "hi", Type: MessageTypeRequest}},
+	}
+
+	// Metrics reads and task execution run concurrently without deadlocking.
+	var wg sync.WaitGroup
+	for n := 0; n < 4; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			task := &Task{ID: fmt.Sprintf("contention-%d", n), Type: TaskTypeCustom, Input: "work", AgentID: agent.ID}
+			if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+				t.Errorf("ExecuteTask failed: %v", err)
+			}
+		}(n)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			metrics := engine.GetConversationMetrics(ctx)
+			if metrics["total_conversations"].(int) < 1 {
+				t.Error("Expected metrics to see the seeded conversation")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,176 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// SpatialPosition is a point in the engine's shared cognitive coordinate
+// space, the orchestration-level view of the identity's SpatialContext.
+type SpatialPosition struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// Distance returns the Euclidean distance to another position.
+func (p SpatialPosition) Distance(other SpatialPosition) float64 {
+	dx, dy, dz := p.X-other.X, p.Y-other.Y, p.Z-other.Z
+	return math.Sqrt(dx*dx + dy*dy + dz*dz)
+}
+
+// Spatial entity kinds.
+const (
+	SpatialKindAgent  = "agent"
+	SpatialKindMemory = "memory"
+)
+
+// SpatialNeighbor is one entity returned by a neighborhood query, ordered by
+// distance from the query point.
+type SpatialNeighbor struct {
+	ID       string          `json:"id"`
+	Kind     string          `json:"kind"`
+	Position SpatialPosition `json:"position"`
+	Distance float64         `json:"distance"`
+}
+
+// SpatialMap organizes agents and memories in cognitive space so that
+// proximity is meaningful: entities placed near the same topic region are
+// candidates for collaboration. Placement is explicit or derived from topic
+// text via the identity's embeddings.
+type SpatialMap struct {
+	mu       sync.RWMutex
+	agents   map[string]SpatialPosition
+	memories map[string]SpatialPosition
+}
+
+// NewSpatialMap creates an empty spatial map.
+func NewSpatialMap() *SpatialMap {
+	return &SpatialMap{
+		agents:   make(map[string]SpatialPosition),
+		memories: make(map[string]SpatialPosition),
+	}
+}
+
+// PlaceAgent positions an agent in cognitive space, replacing any previous
+// position.
+func (m *SpatialMap) PlaceAgent(agentID string, position SpatialPosition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.agents[agentID] = position
+}
+
+// RemoveAgent removes an agent from cognitive space.
+func (m *SpatialMap) RemoveAgent(agentID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.agents, agentID)
+}
+
+// AgentPosition returns an agent's position and whether it has been placed.
+func (m *SpatialMap) AgentPosition(agentID string) (SpatialPosition, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	position, ok := m.agents[agentID]
+	return position, ok
+}
+
+// PlaceMemory positions a memory key in cognitive space.
+func (m *SpatialMap) PlaceMemory(key string, position SpatialPosition) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.memories[key] = position
+}
+
+// Near returns the entities within radius of a position, nearest first. A
+// non-empty kind restricts results to agents or memories.
+func (m *SpatialMap) Near(position SpatialPosition, radius float64, kind string) []SpatialNeighbor {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	neighbors := []SpatialNeighbor{}
+	collect := func(entities map[string]SpatialPosition, entityKind string) {
+		if kind != "" && kind != entityKind {
+			return
+		}
+		for id, entityPosition := range entities {
+			if distance := position.Distance(entityPosition); distance <= radius {
+				neighbors = append(neighbors, SpatialNeighbor{
+					ID:       id,
+					Kind:     entityKind,
+					Position: entityPosition,
+					Distance: distance,
+				})
+			}
+		}
+	}
+	collect(m.agents, SpatialKindAgent)
+	collect(m.memories, SpatialKindMemory)
+
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].Distance < neighbors[j].Distance })
+	return neighbors
+}
+
+// GetSpatialMap returns the engine's cognitive coordinate space.
+func (e *Engine) GetSpatialMap() *SpatialMap {
+	return e.spatial
+}
+
+// PositionForTopic derives a position in cognitive space from topic text by
+// projecting the identity's embedding of it down to three coordinates. The
+// same topic always maps to the same region, so related placements cluster.
+func (e *Engine) PositionForTopic(topic string) SpatialPosition {
+	embedding := e.identityBridge.Identity().EncodeText(topic)
+
+	var sums [3]float64
+	for j, value := range embedding {
+		sums[j%3] += value
+	}
+	scale := 3.0 / float64(len(embedding))
+	return SpatialPosition{
+		X: sums[0] * scale,
+		Y: sums[1] * scale,
+		Z: sums[2] * scale,
+	}
+}
+
+// PlaceAgentAtTopic positions an agent at the region derived from topic
+// text.
+func (e *Engine) PlaceAgentAtTopic(ctx context.Context, agentID, topic string) (SpatialPosition, error) {
+	if _, err := e.GetAgent(ctx, agentID); err != nil {
+		return SpatialPosition{}, err
+	}
+	position := e.PositionForTopic(topic)
+	e.spatial.PlaceAgent(agentID, position)
+	clog(ctx).Info("Placed agent in cognitive space", "agent_id", agentID, "topic", topic)
+	return position, nil
+}
+
+// AgentsNearTopic returns the agents within radius of a topic's region,
+// nearest first, capped at limit when limit is positive.
+func (e *Engine) AgentsNearTopic(topic string, radius float64, limit int) []SpatialNeighbor {
+	neighbors := e.spatial.Near(e.PositionForTopic(topic), radius, SpatialKindAgent)
+	if limit > 0 && len(neighbors) > limit {
+		neighbors = neighbors[:limit]
+	}
+	return neighbors
+}
+
+// StartConversationNearTopic starts a conversation whose participants are
+// selected by spatial proximity to the topic's region rather than named
+// explicitly. At least two agents must be placed within radius.
+func (e *Engine) StartConversationNearTopic(ctx context.Context, topic string, radius float64, limit int) (*Conversation, error) {
+	neighbors := e.AgentsNearTopic(topic, radius, limit)
+	if len(neighbors) < 2 {
+		return nil, fmt.Errorf("need at least 2 agents within radius %g of topic %q, found %d", radius, topic, len(neighbors))
+	}
+
+	participants := make([]string, 0, len(neighbors))
+	for _, neighbor := range neighbors {
+		participants = append(participants, neighbor.ID)
+	}
+	return e.StartConversation(ctx, participants, topic)
+}
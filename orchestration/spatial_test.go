@@ -0,0 +1,100 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestSpatialMapNeighborQueries(t *testing.T) {
+	spatial := NewSpatialMap()
+	spatial.PlaceAgent("close", SpatialPosition{X: 0.1})
+	spatial.PlaceAgent("far", SpatialPosition{X: 10})
+	spatial.PlaceMemory("note", SpatialPosition{Y: 0.2})
+
+	neighbors := spatial.Near(SpatialPosition{}, 1.0, "")
+	if len(neighbors) != 2 {
+		t.Fatalf("Expected 2 neighbors within radius, got %d", len(neighbors))
+	}
+	if neighbors[0].ID != "close" || neighbors[0].Kind != SpatialKindAgent {
+		t.Errorf("Expected nearest neighbor to be the close agent, got %s/%s", neighbors[0].ID, neighbors[0].Kind)
+	}
+
+	agentsOnly := spatial.Near(SpatialPosition{}, 1.0, SpatialKindAgent)
+	if len(agentsOnly) != 1 || agentsOnly[0].ID != "close" {
+		t.Errorf("Expected agent-only query to return the close agent, got %v", agentsOnly)
+	}
+}
+
+func TestPositionForTopicIsStable(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	first := engine.PositionForTopic("distributed systems")
+	second := engine.PositionForTopic("distributed systems")
+	if first != second {
+		t.Errorf("Expected the same topic to map to the same position, got %v and %v", first, second)
+	}
+}
+
+func TestStartConversationNearTopic(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	topic := "reservoir computing"
+	var placed []string
+	for _, name := range []string{"alpha", "beta"} {
+		agent := &Agent{Name: name, Models: []string{"llama3.2"}}
+		if err := engine.CreateAgent(ctx, agent); err != nil {
+			t.Fatalf("Failed to create agent: %v", err)
+		}
+		if _, err := engine.PlaceAgentAtTopic(ctx, agent.ID, topic); err != nil {
+			t.Fatalf("Failed to place agent: %v", err)
+		}
+		placed = append(placed, agent.ID)
+	}
+
+	outsider := &Agent{Name: "gamma", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, outsider); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.GetSpatialMap().PlaceAgent(outsider.ID, SpatialPosition{X: 100})
+
+	conversation, err := engine.StartConversationNearTopic(ctx, topic, 1.0, 0)
+	if err != nil {
+		t.Fatalf("Failed to start spatial conversation: %v", err)
+	}
+	if len(conversation.Participants) != 2 {
+		t.Fatalf("Expected 2 participants near the topic, got %d", len(conversation.Participants))
+	}
+	for _, participant := range conversation.Participants {
+		if participant != placed[0] && participant != placed[1] {
+			t.Errorf("Unexpected participant %s outside the topic region", participant)
+		}
+	}
+}
+
+func TestStartConversationNearTopicRequiresNeighbors(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	if _, err := engine.StartConversationNearTopic(context.Background(), "empty space", 1.0, 0); err == nil {
+		t.Error("Expected an error with no agents placed near the topic")
+	}
+}
+
+func TestDeleteAgentRemovesSpatialPlacement(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "transient", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	engine.GetSpatialMap().PlaceAgent(agent.ID, SpatialPosition{})
+	if err := engine.DeleteAgent(ctx, agent.ID); err != nil {
+		t.Fatalf("Failed to delete agent: %v", err)
+	}
+	if _, ok := engine.GetSpatialMap().AgentPosition(agent.ID); ok {
+		t.Error("Expected spatial placement removed with the agent")
+	}
+}
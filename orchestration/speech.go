@@ -0,0 +1,241 @@
+package orchestration
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// Transcriber converts recorded audio into text. Implementations wrap
+// Whisper-compatible endpoints or local models.
+type Transcriber interface {
+	Transcribe(ctx context.Context, audio []byte, language string) (string, error)
+}
+
+// SpeechSynthesizer converts text into audio, enabling spoken agent output.
+type SpeechSynthesizer interface {
+	Synthesize(ctx context.Context, text, voice string) ([]byte, error)
+}
+
+// SetTranscriber configures the provider used by transcribe tasks.
+func (e *Engine) SetTranscriber(transcriber Transcriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.transcriber = transcriber
+	slog.Info("Configured speech transcriber")
+}
+
+// SetSpeechSynthesizer configures the provider used by speak tasks.
+func (e *Engine) SetSpeechSynthesizer(synthesizer SpeechSynthesizer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.synthesizer = synthesizer
+	slog.Info("Configured speech synthesizer")
+}
+
+// executeTranscribeTask converts audio to text. Audio is supplied via
+// Parameters["audio_path"] or Parameters["audio_base64"]; an optional
+// language parameter hints the source language.
+func (e *Engine) executeTranscribeTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	e.mu.RLock()
+	transcriber := e.transcriber
+	e.mu.RUnlock()
+	if transcriber == nil {
+		return nil, fmt.Errorf("%w: no transcriber configured", ErrModelUnavailable)
+	}
+
+	audio, err := transcribeTaskAudio(task)
+	if err != nil {
+		return nil, err
+	}
+
+	language, _ := task.Parameters["language"].(string)
+	text, err := transcriber.Transcribe(ctx, audio, language)
+	if err != nil {
+		return nil, fmt.Errorf("transcription failed: %w", err)
+	}
+
+	return &TaskResult{
+		TaskID: task.ID,
+		Output: text,
+	}, nil
+}
+
+// executeSpeakTask synthesizes speech from the task input. The audio is
+// written to Parameters["output_path"] when set, otherwise returned
+// base64-encoded as the task output.
+func (e *Engine) executeSpeakTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	e.mu.RLock()
+	synthesizer := e.synthesizer
+	e.mu.RUnlock()
+	if synthesizer == nil {
+		return nil, fmt.Errorf("%w: no speech synthesizer configured", ErrModelUnavailable)
+	}
+
+	text := task.InputText()
+	if text == "" {
+		return nil, fmt.Errorf("speak task has no input text")
+	}
+
+	voice, _ := task.Parameters["voice"].(string)
+	audio, err := synthesizer.Synthesize(ctx, text, voice)
+	if err != nil {
+		return nil, fmt.Errorf("speech synthesis failed: %w", err)
+	}
+
+	if outputPath, ok := task.Parameters["output_path"].(string); ok && outputPath != "" {
+		if err := os.WriteFile(outputPath, audio, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write audio output: %w", err)
+		}
+		return &TaskResult{
+			TaskID: task.ID,
+			Output: outputPath,
+		}, nil
+	}
+
+	return &TaskResult{
+		TaskID: task.ID,
+		Output: base64.StdEncoding.EncodeToString(audio),
+	}, nil
+}
+
+// transcribeTaskAudio loads the audio a transcribe task should process.
+func transcribeTaskAudio(task *Task) ([]byte, error) {
+	if encoded, ok := task.Parameters["audio_base64"].(string); ok && encoded != "" {
+		audio, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 audio: %v", err)
+		}
+		return audio, nil
+	}
+	if path, ok := task.Parameters["audio_path"].(string); ok && path != "" {
+		audio, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load audio from %s: %w", path, err)
+		}
+		return audio, nil
+	}
+	return nil, fmt.Errorf("transcribe task requires an audio_path or audio_base64 parameter")
+}
+
+// WhisperTranscriber calls a Whisper-compatible transcription endpoint
+// (POST /v1/audio/transcriptions with multipart form data).
+type WhisperTranscriber struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Client  *http.Client
+}
+
+// Transcribe sends audio to the provider and returns the recognized text.
+func (w *WhisperTranscriber) Transcribe(ctx context.Context, audio []byte, language string) (string, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	part, err := form.CreateFormFile("file", "audio.wav")
+	if err != nil {
+		return "", err
+	}
+	if _, err := part.Write(audio); err != nil {
+		return "", err
+	}
+	if err := form.WriteField("model", w.Model); err != nil {
+		return "", err
+	}
+	if language != "" {
+		if err := form.WriteField("language", language); err != nil {
+			return "", err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.BaseURL+"/v1/audio/transcriptions", &body)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if w.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+w.APIKey)
+	}
+
+	resp, err := w.httpClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("transcription provider returned %d: %s", resp.StatusCode, message)
+	}
+
+	var decoded struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return "", fmt.Errorf("invalid transcription response: %v", err)
+	}
+	return decoded.Text, nil
+}
+
+func (w *WhisperTranscriber) httpClient() *http.Client {
+	if w.Client != nil {
+		return w.Client
+	}
+	return http.DefaultClient
+}
+
+// HTTPSpeechSynthesizer calls an OpenAI-compatible text-to-speech endpoint
+// (POST /v1/audio/speech) and returns the raw audio bytes.
+type HTTPSpeechSynthesizer struct {
+	BaseURL string
+	APIKey  string
+	Model   string
+	Client  *http.Client
+}
+
+// Synthesize renders text as audio using the configured voice.
+func (s *HTTPSpeechSynthesizer) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	payload, err := json.Marshal(map[string]string{
+		"model": s.Model,
+		"input": text,
+		"voice": voice,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.BaseURL+"/v1/audio/speech", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIKey)
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("speech provider returned %d: %s", resp.StatusCode, message)
+	}
+	return io.ReadAll(resp.Body)
+}
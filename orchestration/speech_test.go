@@ -0,0 +1,145 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+type fakeTranscriber struct {
+	text string
+}
+
+func (f *fakeTranscriber) Transcribe(ctx context.Context, audio []byte, language string) (string, error) {
+	return f.text, nil
+}
+
+type fakeSynthesizer struct {
+	audio []byte
+}
+
+func (f *fakeSynthesizer) Synthesize(ctx context.Context, text, voice string) ([]byte, error) {
+	return f.audio, nil
+}
+
+func TestExecuteTranscribeTask(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	agent := &Agent{Models: []string{"llama3.2"}}
+	ctx := context.Background()
+
+	task := &Task{ID: "stt-1", Type: TaskTypeTranscribe, Parameters: map[string]interface{}{
+		"audio_base64": base64.StdEncoding.EncodeToString([]byte("audio-bytes")),
+	}}
+
+	// No transcriber configured yet
+	if _, err := engine.executeTranscribeTask(ctx, task, agent); err == nil {
+		t.Error("Expected error without a configured transcriber")
+	}
+
+	engine.SetTranscriber(&fakeTranscriber{text: "hello world"})
+	result, err := engine.executeTranscribeTask(ctx, task, agent)
+	if err != nil {
+		t.Fatalf("Transcribe task failed: %v", err)
+	}
+	if result.Output != "hello world" {
+		t.Errorf("Expected transcription output, got %q", result.Output)
+	}
+
+	// Missing audio parameter
+	if _, err := engine.executeTranscribeTask(ctx, &Task{ID: "stt-2", Parameters: map[string]interface{}{}}, agent); err == nil {
+		t.Error("Expected error for missing audio")
+	}
+}
+
+func TestExecuteSpeakTask(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	agent := &Agent{Models: []string{"llama3.2"}}
+	ctx := context.Background()
+
+	task := &Task{ID: "tts-1", Type: TaskTypeSpeak, Input: "say this"}
+
+	if _, err := engine.executeSpeakTask(ctx, task, agent); err == nil {
+		t.Error("Expected error without a configured synthesizer")
+	}
+
+	audio := []byte{0x52, 0x49, 0x46, 0x46}
+	engine.SetSpeechSynthesizer(&fakeSynthesizer{audio: audio})
+
+	result, err := engine.executeSpeakTask(ctx, task, agent)
+	if err != nil {
+		t.Fatalf("Speak task failed: %v", err)
+	}
+	if result.Output != base64.StdEncoding.EncodeToString(audio) {
+		t.Errorf("Expected base64 audio output, got %q", result.Output)
+	}
+
+	// With output_path the audio is written to disk
+	outputPath := filepath.Join(t.TempDir(), "speech.wav")
+	task.Parameters = map[string]interface{}{"output_path": outputPath}
+	result, err = engine.executeSpeakTask(ctx, task, agent)
+	if err != nil {
+		t.Fatalf("Speak task failed: %v", err)
+	}
+	if result.Output != outputPath {
+		t.Errorf("Expected output path, got %q", result.Output)
+	}
+	written, err := os.ReadFile(outputPath)
+	if err != nil || string(written) != string(audio) {
+		t.Errorf("Expected audio written to %s: %v", outputPath, err)
+	}
+}
+
+func TestWhisperTranscriber(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/transcriptions" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.FormValue("model") != "whisper-1" {
+			http.Error(w, "wrong model", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"text": "transcribed"}`))
+	}))
+	defer server.Close()
+
+	transcriber := &WhisperTranscriber{BaseURL: server.URL, Model: "whisper-1"}
+	text, err := transcriber.Transcribe(context.Background(), []byte("audio"), "en")
+	if err != nil {
+		t.Fatalf("Transcribe failed: %v", err)
+	}
+	if text != "transcribed" {
+		t.Errorf("Expected transcribed text, got %q", text)
+	}
+}
+
+func TestHTTPSpeechSynthesizer(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("audio-data"))
+	}))
+	defer server.Close()
+
+	synthesizer := &HTTPSpeechSynthesizer{BaseURL: server.URL, Model: "tts-1"}
+	audio, err := synthesizer.Synthesize(context.Background(), "hello", "alloy")
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+	if string(audio) != "audio-data" {
+		t.Errorf("Expected audio bytes, got %q", audio)
+	}
+}
@@ -0,0 +1,178 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Store abstracts persistence for the engine's mutable state (agents, tasks,
+// conversations). The engine treats its internal maps as a cache in front of
+// the store, so multiple API replicas configured with a shared backend see
+// the same state without sticky sessions.
+type Store interface {
+	SaveAgent(ctx context.Context, agent *Agent) error
+	GetAgent(ctx context.Context, id string) (*Agent, error)
+	ListAgents(ctx context.Context) ([]*Agent, error)
+	DeleteAgent(ctx context.Context, id string) error
+
+	SaveTask(ctx context.Context, task *Task) error
+	GetTask(ctx context.Context, id string) (*Task, error)
+
+	SaveConversation(ctx context.Context, conversation *Conversation) error
+	GetConversation(ctx context.Context, id string) (*Conversation, error)
+	ListConversations(ctx context.Context, agentID string) ([]*Conversation, error)
+}
+
+// ErrNotFound is returned by stores when the requested record does not exist.
+var ErrNotFound = fmt.Errorf("record not found")
+
+// InMemoryStore is the default Store implementation for single-instance
+// deployments. Shared backends implement the same interface.
+type InMemoryStore struct {
+	agents        map[string]*Agent
+	tasks         map[string]*Task
+	conversations map[string]*Conversation
+	mu            sync.RWMutex
+}
+
+// NewInMemoryStore creates an empty in-memory store.
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{
+		agents:        make(map[string]*Agent),
+		tasks:         make(map[string]*Task),
+		conversations: make(map[string]*Conversation),
+	}
+}
+
+// SaveAgent creates or updates an agent record.
+func (s *InMemoryStore) SaveAgent(ctx context.Context, agent *Agent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.agents[agent.ID] = agent
+	return nil
+}
+
+// GetAgent retrieves an agent record by ID.
+func (s *InMemoryStore) GetAgent(ctx context.Context, id string) (*Agent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	agent, exists := s.agents[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return agent, nil
+}
+
+// ListAgents returns all stored agents.
+func (s *InMemoryStore) ListAgents(ctx context.Context) ([]*Agent, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	agents := make([]*Agent, 0, len(s.agents))
+	for _, agent := range s.agents {
+		agents = append(agents, agent)
+	}
+	return agents, nil
+}
+
+// DeleteAgent removes an agent record.
+func (s *InMemoryStore) DeleteAgent(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.agents[id]; !exists {
+		return ErrNotFound
+	}
+	delete(s.agents, id)
+	return nil
+}
+
+// SaveTask creates or updates a task record.
+func (s *InMemoryStore) SaveTask(ctx context.Context, task *Task) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+// GetTask retrieves a task record by ID.
+func (s *InMemoryStore) GetTask(ctx context.Context, id string) (*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	task, exists := s.tasks[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return task, nil
+}
+
+// ListTasks returns stored tasks matching the filter.
+func (s *InMemoryStore) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tasks := make([]*Task, 0)
+	for _, task := range s.tasks {
+		if filter.matches(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
+// DeleteTask removes a task record.
+func (s *InMemoryStore) DeleteTask(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.tasks[id]; !exists {
+		return ErrNotFound
+	}
+	delete(s.tasks, id)
+	return nil
+}
+
+// SaveConversation creates or updates a conversation record.
+func (s *InMemoryStore) SaveConversation(ctx context.Context, conversation *Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conversations[conversation.ID] = conversation
+	return nil
+}
+
+// GetConversation retrieves a conversation record by ID.
+func (s *InMemoryStore) GetConversation(ctx context.Context, id string) (*Conversation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conversation, exists := s.conversations[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return conversation, nil
+}
+
+// ListConversations returns conversations involving the given agent, or all
+// conversations when agentID is empty.
+func (s *InMemoryStore) ListConversations(ctx context.Context, agentID string) ([]*Conversation, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	conversations := make([]*Conversation, 0)
+	for _, conversation := range s.conversations {
+		if agentID == "" {
+			conversations = append(conversations, conversation)
+			continue
+		}
+		for _, participant := range conversation.Participants {
+			if participant == agentID {
+				conversations = append(conversations, conversation)
+				break
+			}
+		}
+	}
+	return conversations, nil
+}
@@ -0,0 +1,80 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestInMemoryStoreAgentCRUD(t *testing.T) {
+	store := NewInMemoryStore()
+	ctx := context.Background()
+
+	agent := &Agent{ID: "a1", Name: "stored-agent"}
+	if err := store.SaveAgent(ctx, agent); err != nil {
+		t.Fatalf("SaveAgent failed: %v", err)
+	}
+
+	loaded, err := store.GetAgent(ctx, "a1")
+	if err != nil {
+		t.Fatalf("GetAgent failed: %v", err)
+	}
+	if loaded.Name != "stored-agent" {
+		t.Errorf("Expected stored-agent, got %s", loaded.Name)
+	}
+
+	agents, err := store.ListAgents(ctx)
+	if err != nil {
+		t.Fatalf("ListAgents failed: %v", err)
+	}
+	if len(agents) != 1 {
+		t.Errorf("Expected 1 agent, got %d", len(agents))
+	}
+
+	if err := store.DeleteAgent(ctx, "a1"); err != nil {
+		t.Fatalf("DeleteAgent failed: %v", err)
+	}
+
+	if _, err := store.GetAgent(ctx, "a1"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestEngineReplicasShareStore(t *testing.T) {
+	client := api.Client{}
+	store := NewInMemoryStore()
+	ctx := context.Background()
+
+	// Two engine replicas sharing one storage backend
+	primary := NewEngineWithStore(client, store)
+	replica := NewEngineWithStore(client, store)
+
+	agent := &Agent{Name: "shared-agent", Models: []string{"llama2"}}
+	if err := primary.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("CreateAgent failed: %v", err)
+	}
+
+	// The replica should see the agent through the shared store
+	loaded, err := replica.GetAgent(ctx, agent.ID)
+	if err != nil {
+		t.Fatalf("Replica GetAgent failed: %v", err)
+	}
+	if loaded.Name != "shared-agent" {
+		t.Errorf("Expected shared-agent, got %s", loaded.Name)
+	}
+
+	// Conversations started on one replica are visible on the other
+	conversation, err := primary.StartConversation(ctx, []string{agent.ID}, "shared topic")
+	if err != nil {
+		t.Fatalf("StartConversation failed: %v", err)
+	}
+
+	fetched, err := replica.GetConversation(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("Replica GetConversation failed: %v", err)
+	}
+	if fetched.Topic != "shared topic" {
+		t.Errorf("Expected shared topic, got %s", fetched.Topic)
+	}
+}
@@ -0,0 +1,229 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// maxSchemaRepairAttempts is how many corrective generations are attempted
+// when model output does not satisfy a task's output schema, unless the task
+// sets a max_repair_attempts parameter of its own.
+const maxSchemaRepairAttempts = 2
+
+// jsonFormat is the request format that asks providers for JSON-mode output.
+var jsonFormat = json.RawMessage(`"json"`)
+
+// InputText returns the textual input for a task. Tasks with only a
+// structured input have it marshaled to JSON.
+func (t *Task) InputText() string {
+	if t.Input != "" || t.StructuredInput == nil {
+		return t.Input
+	}
+	data, err := json.Marshal(t.StructuredInput)
+	if err != nil {
+		return t.Input
+	}
+	return string(data)
+}
+
+// schemaInstruction renders the prompt suffix that constrains output to the
+// task's declared schema.
+func schemaInstruction(schema map[string]interface{}) string {
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("\n\nRespond with a single JSON value matching this schema, and nothing else:\n%s", data)
+}
+
+// extractJSON pulls the first JSON value out of model output, tolerating
+// surrounding prose and markdown fences.
+func extractJSON(output string) (interface{}, error) {
+	trimmed := strings.TrimSpace(output)
+	if start := strings.Index(trimmed, "```"); start >= 0 {
+		trimmed = strings.TrimSpace(strings.Trim(trimmed[start:], "`"))
+		trimmed = strings.TrimPrefix(trimmed, "json")
+		trimmed = strings.TrimSpace(trimmed)
+	}
+
+	first := strings.IndexAny(trimmed, "{[")
+	if first < 0 {
+		return nil, fmt.Errorf("output contains no JSON value")
+	}
+	last := strings.LastIndexAny(trimmed, "}]")
+	if last < first {
+		return nil, fmt.Errorf("output contains unterminated JSON")
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(trimmed[first:last+1]), &value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return value, nil
+}
+
+// ValidateJSONSchema checks a decoded JSON value against a schema supporting
+// the subset of JSON Schema the engine declares: type, properties, required,
+// items, and enum.
+func ValidateJSONSchema(value interface{}, schema map[string]interface{}) error {
+	return validateSchema(value, schema, "$")
+}
+
+func validateSchema(value interface{}, schema map[string]interface{}, path string) error {
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		for _, allowed := range enum {
+			if fmt.Sprintf("%v", allowed) == fmt.Sprintf("%v", value) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: value %v not in enum", path, value)
+	}
+
+	schemaType, _ := schema["type"].(string)
+	switch schemaType {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %T", path, value)
+		}
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				key, _ := name.(string)
+				if _, present := object[key]; !present {
+					return fmt.Errorf("%s: missing required property %q", path, key)
+				}
+			}
+		}
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			for key, raw := range properties {
+				propertySchema, ok := raw.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				property, present := object[key]
+				if !present {
+					continue
+				}
+				if err := validateSchema(property, propertySchema, path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, value)
+		}
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range items {
+				if err := validateSchema(item, itemSchema, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("%s: expected string, got %T", path, value)
+		}
+	case "number":
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("%s: expected number, got %T", path, value)
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return fmt.Errorf("%s: expected integer, got %v", path, value)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %T", path, value)
+		}
+	case "null":
+		if value != nil {
+			return fmt.Errorf("%s: expected null, got %T", path, value)
+		}
+	case "":
+		// No type constraint
+	default:
+		return fmt.Errorf("%s: unsupported schema type %q", path, schemaType)
+	}
+	return nil
+}
+
+// ensureStructuredOutput validates model output against the task's schema,
+// asking the model to repair invalid output up to maxSchemaRepairAttempts
+// times. It returns the decoded value and its canonical JSON encoding.
+func (e *Engine) ensureStructuredOutput(ctx context.Context, modelName string, task *Task, output string) (interface{}, string, error) {
+	limit := taskRepairAttempts(task)
+	var lastErr error
+	for attempt := 0; attempt <= limit; attempt++ {
+		value, err := extractJSON(output)
+		if err == nil {
+			err = ValidateJSONSchema(value, task.OutputSchema)
+		}
+		if err == nil {
+			canonical, marshalErr := json.Marshal(value)
+			if marshalErr != nil {
+				return nil, "", marshalErr
+			}
+			return value, string(canonical), nil
+		}
+		lastErr = err
+		if attempt == limit {
+			break
+		}
+
+		output, err = e.repairStructuredOutput(ctx, modelName, task, output, lastErr)
+		if err != nil {
+			return nil, "", fmt.Errorf("schema repair request failed: %w", err)
+		}
+	}
+	return nil, "", fmt.Errorf("output failed schema validation after %d repair attempts: %w",
+		limit, lastErr)
+}
+
+// taskRepairAttempts reads a task's schema-repair patience, tolerating the
+// float form a JSON round-trip produces. Unset falls back to the default.
+func taskRepairAttempts(task *Task) int {
+	if task.Parameters != nil {
+		switch v := task.Parameters["max_repair_attempts"].(type) {
+		case int:
+			if v >= 0 {
+				return v
+			}
+		case float64:
+			if v >= 0 {
+				return int(v)
+			}
+		}
+	}
+	return maxSchemaRepairAttempts
+}
+
+// repairStructuredOutput asks the model to correct output that failed schema
+// validation.
+func (e *Engine) repairStructuredOutput(ctx context.Context, modelName string, task *Task, output string, validationErr error) (string, error) {
+	prompt := fmt.Sprintf(
+		"The following output failed validation (%v):\n%s%s",
+		validationErr, output, schemaInstruction(task.OutputSchema))
+
+	req := &api.GenerateRequest{
+		Model:  modelName,
+		Prompt: prompt,
+		Format: jsonFormat,
+	}
+
+	var repaired string
+	err := e.client.Generate(ctx, req, func(resp api.GenerateResponse) error {
+		repaired += resp.Response
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return repaired, nil
+}
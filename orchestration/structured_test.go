@@ -0,0 +1,105 @@
+package orchestration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		output string
+		valid  bool
+	}{
+		{"bare object", `{"a": 1}`, true},
+		{"fenced", "```json\n{\"a\": 1}\n```", true},
+		{"surrounding prose", `Here you go: {"a": 1} hope that helps`, true},
+		{"array", `[1, 2, 3]`, true},
+		{"no json", "just some text", false},
+		{"truncated", `{"a": `, false},
+	}
+
+	for _, tc := range cases {
+		value, err := extractJSON(tc.output)
+		if tc.valid && err != nil {
+			t.Errorf("%s: expected success, got %v", tc.name, err)
+		}
+		if !tc.valid && err == nil {
+			t.Errorf("%s: expected error, got %v", tc.name, value)
+		}
+	}
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"name", "count"},
+		"properties": map[string]interface{}{
+			"name":  map[string]interface{}{"type": "string"},
+			"count": map[string]interface{}{"type": "integer"},
+			"tags": map[string]interface{}{
+				"type":  "array",
+				"items": map[string]interface{}{"type": "string"},
+			},
+			"status": map[string]interface{}{"enum": []interface{}{"open", "closed"}},
+		},
+	}
+
+	valid := map[string]interface{}{
+		"name":   "widget",
+		"count":  float64(3),
+		"tags":   []interface{}{"a", "b"},
+		"status": "open",
+	}
+	if err := ValidateJSONSchema(valid, schema); err != nil {
+		t.Errorf("Expected valid value to pass: %v", err)
+	}
+
+	missing := map[string]interface{}{"name": "widget"}
+	if err := ValidateJSONSchema(missing, schema); err == nil {
+		t.Error("Expected missing required property to fail")
+	}
+
+	wrongType := map[string]interface{}{"name": "widget", "count": float64(3.5)}
+	if err := ValidateJSONSchema(wrongType, schema); err == nil {
+		t.Error("Expected non-integer count to fail")
+	}
+
+	badEnum := map[string]interface{}{"name": "widget", "count": float64(1), "status": "pending"}
+	if err := ValidateJSONSchema(badEnum, schema); err == nil {
+		t.Error("Expected out-of-enum status to fail")
+	}
+
+	badItems := map[string]interface{}{"name": "widget", "count": float64(1), "tags": []interface{}{1}}
+	if err := ValidateJSONSchema(badItems, schema); err == nil {
+		t.Error("Expected non-string tag to fail")
+	}
+}
+
+func TestTaskInputText(t *testing.T) {
+	task := &Task{Input: "plain"}
+	if task.InputText() != "plain" {
+		t.Errorf("Expected textual input preserved, got %q", task.InputText())
+	}
+
+	structured := &Task{StructuredInput: map[string]interface{}{"city": "Berlin"}}
+	if structured.InputText() != `{"city":"Berlin"}` {
+		t.Errorf("Expected marshaled structured input, got %q", structured.InputText())
+	}
+
+	// Textual input wins when both are present
+	both := &Task{Input: "text", StructuredInput: map[string]interface{}{"a": 1}}
+	if both.InputText() != "text" {
+		t.Errorf("Expected textual input precedence, got %q", both.InputText())
+	}
+}
+
+func TestSchemaInstruction(t *testing.T) {
+	instruction := schemaInstruction(map[string]interface{}{"type": "object"})
+	if instruction == "" {
+		t.Fatal("Expected non-empty schema instruction")
+	}
+	if !strings.Contains(instruction, "JSON") || !strings.Contains(instruction, "object") {
+		t.Errorf("Unexpected instruction: %s", instruction)
+	}
+}
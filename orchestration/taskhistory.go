@@ -0,0 +1,146 @@
+package orchestration
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// TaskFilter selects tasks from history. Zero-valued fields match
+// everything; Since and Until bound the creation time.
+type TaskFilter struct {
+	Status  string    `json:"status,omitempty"`
+	AgentID string    `json:"agent_id,omitempty"`
+	Type    string    `json:"type,omitempty"`
+	Since   time.Time `json:"since,omitempty"`
+	Until   time.Time `json:"until,omitempty"`
+	// Limit caps the number of tasks returned, newest first. Zero means no
+	// cap.
+	Limit int `json:"limit,omitempty"`
+}
+
+// matches reports whether a task satisfies the filter, ignoring Limit.
+func (f TaskFilter) matches(task *Task) bool {
+	if f.Status != "" && task.Status != f.Status {
+		return false
+	}
+	if f.AgentID != "" && task.AgentID != f.AgentID {
+		return false
+	}
+	if f.Type != "" && task.Type != f.Type {
+		return false
+	}
+	if !f.Since.IsZero() && task.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && task.CreatedAt.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+// TaskLister is an optional Store capability: backends that can enumerate
+// tasks serve history queries directly, so replicas see tasks they did not
+// execute themselves.
+type TaskLister interface {
+	ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error)
+}
+
+// TaskDeleter is an optional Store capability used by retention to drop
+// expired task records.
+type TaskDeleter interface {
+	DeleteTask(ctx context.Context, id string) error
+}
+
+// ListTasks returns task history matching the filter, newest first. Stores
+// that implement TaskLister are queried directly; otherwise the engine's
+// cache serves the query.
+func (e *Engine) ListTasks(ctx context.Context, filter TaskFilter) ([]*Task, error) {
+	var tasks []*Task
+	if lister, ok := e.store.(TaskLister); ok {
+		listed, err := lister.ListTasks(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		tasks = listed
+	} else {
+		for _, task := range e.tasksSnapshot() {
+			if filter.matches(task) {
+				tasks = append(tasks, task)
+			}
+		}
+	}
+
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].CreatedAt.After(tasks[j].CreatedAt) })
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
+	}
+	return tasks, nil
+}
+
+// TaskRetentionPolicy bounds task history: finished tasks older than MaxAge
+// or beyond the MaxCount newest are pruned. Pending and running tasks are
+// never pruned.
+type TaskRetentionPolicy struct {
+	MaxAge   time.Duration `json:"max_age,omitempty"`
+	MaxCount int           `json:"max_count,omitempty"`
+}
+
+// SetTaskRetentionPolicy installs (or with nil, removes) the engine's task
+// retention policy. Retention is enforced as tasks are persisted.
+func (e *Engine) SetTaskRetentionPolicy(policy *TaskRetentionPolicy) {
+	e.retentionMu.Lock()
+	defer e.retentionMu.Unlock()
+	e.taskRetention = policy
+}
+
+// GetTaskRetentionPolicy returns the current retention policy, or nil when
+// history is unbounded.
+func (e *Engine) GetTaskRetentionPolicy() *TaskRetentionPolicy {
+	e.retentionMu.Lock()
+	defer e.retentionMu.Unlock()
+	return e.taskRetention
+}
+
+// ApplyTaskRetention prunes finished tasks that fall outside the retention
+// policy, returning how many were removed. It is called automatically as
+// tasks persist and may be invoked directly after changing the policy.
+func (e *Engine) ApplyTaskRetention(ctx context.Context) int {
+	policy := e.GetTaskRetentionPolicy()
+	if policy == nil {
+		return 0
+	}
+
+	e.mu.Lock()
+	finished := make([]*Task, 0, len(e.tasks))
+	for _, task := range e.tasks {
+		if task.Status == TaskStatusCompleted || task.Status == TaskStatusFailed {
+			finished = append(finished, task)
+		}
+	}
+	sort.Slice(finished, func(i, j int) bool { return finished[i].CreatedAt.After(finished[j].CreatedAt) })
+
+	var victims []string
+	cutoff := time.Time{}
+	if policy.MaxAge > 0 {
+		cutoff = time.Now().Add(-policy.MaxAge)
+	}
+	for index, task := range finished {
+		tooOld := !cutoff.IsZero() && task.CreatedAt.Before(cutoff)
+		tooMany := policy.MaxCount > 0 && index >= policy.MaxCount
+		if tooOld || tooMany {
+			victims = append(victims, task.ID)
+			delete(e.tasks, task.ID)
+		}
+	}
+	e.mu.Unlock()
+
+	if deleter, ok := e.store.(TaskDeleter); ok {
+		for _, id := range victims {
+			if err := deleter.DeleteTask(ctx, id); err != nil && err != ErrNotFound {
+				clog(ctx).Error("Failed to prune task from store", "error", err, "task_id", id)
+			}
+		}
+	}
+	return len(victims)
+}
@@ -0,0 +1,128 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestListTasksFiltering(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	agent := &Agent{Name: "historian", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	other := &Agent{Name: "bystander", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, other); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		task := &Task{ID: fmt.Sprintf("history-ok-%d", i), Type: TaskTypeCustom, Input: "succeed", AgentID: agent.ID}
+		if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+			t.Fatalf("Failed to execute task: %v", err)
+		}
+	}
+	failing := &Task{ID: "history-fail", Type: TaskTypeGenerate, Input: "fail", AgentID: other.ID}
+	if _, err := engine.ExecuteTask(ctx, failing, other); err == nil {
+		t.Fatal("Expected generate task to fail against unreachable provider")
+	}
+
+	all, err := engine.ListTasks(ctx, TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(all) != 4 {
+		t.Errorf("Expected 4 tasks in history, got %d", len(all))
+	}
+
+	completed, err := engine.ListTasks(ctx, TaskFilter{Status: TaskStatusCompleted})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(completed) != 3 {
+		t.Errorf("Expected 3 completed tasks, got %d", len(completed))
+	}
+
+	byAgent, err := engine.ListTasks(ctx, TaskFilter{AgentID: other.ID})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(byAgent) != 1 || byAgent[0].ID != "history-fail" {
+		t.Errorf("Expected only the failing task for the other agent, got %v", byAgent)
+	}
+
+	limited, err := engine.ListTasks(ctx, TaskFilter{Limit: 2})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("Expected limit to cap results at 2, got %d", len(limited))
+	}
+
+	none, err := engine.ListTasks(ctx, TaskFilter{Until: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("Expected no tasks before the cutoff, got %d", len(none))
+	}
+}
+
+func TestTaskRetentionPrunesFinishedTasks(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "bounded-history", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	engine.SetTaskRetentionPolicy(&TaskRetentionPolicy{MaxCount: 2})
+
+	for i := 0; i < 5; i++ {
+		task := &Task{ID: fmt.Sprintf("retained-%d", i), Type: TaskTypeCustom, Input: "work", AgentID: agent.ID}
+		if _, err := engine.ExecuteTask(ctx, task, agent); err != nil {
+			t.Fatalf("Failed to execute task: %v", err)
+		}
+	}
+
+	tasks, err := engine.ListTasks(ctx, TaskFilter{})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("Expected retention to keep 2 tasks, got %d", len(tasks))
+	}
+
+	// The store was pruned too, not just the cache.
+	if _, err := engine.store.GetTask(ctx, "retained-0"); err != ErrNotFound {
+		t.Errorf("Expected the oldest task to be pruned from the store, got %v", err)
+	}
+}
+
+func TestTaskRetentionKeepsUnfinishedTasks(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	pending := &Task{ID: "still-pending", Type: TaskTypeCustom, Status: TaskStatusPending, CreatedAt: time.Now().Add(-time.Hour)}
+	engine.persistTask(ctx, pending)
+
+	engine.SetTaskRetentionPolicy(&TaskRetentionPolicy{MaxAge: time.Minute})
+	if removed := engine.ApplyTaskRetention(ctx); removed != 0 {
+		t.Errorf("Expected pending tasks to survive retention, pruned %d", removed)
+	}
+
+	tasks, err := engine.ListTasks(ctx, TaskFilter{Status: TaskStatusPending})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(tasks) != 1 {
+		t.Errorf("Expected the pending task to remain, got %d tasks", len(tasks))
+	}
+}
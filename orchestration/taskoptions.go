@@ -0,0 +1,138 @@
+package orchestration
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// TaskOptions are first-class, validated generation options for a task
+// request. They map onto api.Options keys consistently instead of callers
+// hand-building the raw "options" map.
+type TaskOptions struct {
+	Temperature *float64      `json:"temperature,omitempty"`
+	TopP        *float64      `json:"top_p,omitempty"`
+	NumCtx      *int          `json:"num_ctx,omitempty"`
+	Seed        *int          `json:"seed,omitempty"`
+	Stop        []string      `json:"stop,omitempty"`
+	KeepAlive   *api.Duration `json:"keep_alive,omitempty"`
+}
+
+// maxStopSequences bounds the number of stop sequences a task may declare.
+const maxStopSequences = 8
+
+// Validate checks option values against the ranges the providers accept.
+func (o *TaskOptions) Validate() error {
+	if o == nil {
+		return nil
+	}
+	if o.Temperature != nil && (*o.Temperature < 0 || *o.Temperature > 2) {
+		return fmt.Errorf("temperature must be between 0 and 2, got %g", *o.Temperature)
+	}
+	if o.TopP != nil && (*o.TopP <= 0 || *o.TopP > 1) {
+		return fmt.Errorf("top_p must be in (0, 1], got %g", *o.TopP)
+	}
+	if o.NumCtx != nil && *o.NumCtx <= 0 {
+		return fmt.Errorf("num_ctx must be positive, got %d", *o.NumCtx)
+	}
+	if len(o.Stop) > maxStopSequences {
+		return fmt.Errorf("at most %d stop sequences are allowed, got %d", maxStopSequences, len(o.Stop))
+	}
+	for _, stop := range o.Stop {
+		if stop == "" {
+			return fmt.Errorf("stop sequences must be non-empty")
+		}
+	}
+	return nil
+}
+
+// apply merges the options into a raw options map using the api.Options key
+// names. Existing entries set explicitly by the caller are preserved.
+func (o *TaskOptions) apply(options map[string]interface{}) map[string]interface{} {
+	if o == nil {
+		return options
+	}
+	if options == nil {
+		options = make(map[string]interface{})
+	}
+
+	set := func(key string, value interface{}) {
+		if _, exists := options[key]; !exists {
+			options[key] = value
+		}
+	}
+	if o.Temperature != nil {
+		set("temperature", *o.Temperature)
+	}
+	if o.TopP != nil {
+		set("top_p", *o.TopP)
+	}
+	if o.NumCtx != nil {
+		set("num_ctx", *o.NumCtx)
+	}
+	if o.Seed != nil {
+		set("seed", *o.Seed)
+	}
+	if len(o.Stop) > 0 {
+		set("stop", o.Stop)
+	}
+	return options
+}
+
+// taskKeepAlive reads a task's keep_alive parameter, tolerating both native
+// api.Duration values and the string/number forms a JSON round-trip produces.
+func taskKeepAlive(task *Task) *api.Duration {
+	if task.Parameters == nil {
+		return nil
+	}
+	switch v := task.Parameters["keep_alive"].(type) {
+	case *api.Duration:
+		return v
+	case api.Duration:
+		return &v
+	case string:
+		if d, err := time.ParseDuration(v); err == nil {
+			return &api.Duration{Duration: d}
+		}
+	case float64:
+		return &api.Duration{Duration: time.Duration(v) * time.Second}
+	case int:
+		return &api.Duration{Duration: time.Duration(v) * time.Second}
+	}
+	return nil
+}
+
+// taskStream reads a task's stream parameter, nil when unset.
+func taskStream(task *Task) *bool {
+	if task.Parameters == nil {
+		return nil
+	}
+	if stream, ok := task.Parameters["stream"].(bool); ok {
+		return &stream
+	}
+	return nil
+}
+
+// applyTaskOptions validates a task request's options and folds them into
+// the task's parameters under the "options" key the execution paths read.
+// The keep_alive option is carried separately since it is a request field,
+// not a sampling option.
+func applyTaskOptions(task *Task, options *TaskOptions) error {
+	if options == nil {
+		return nil
+	}
+	if err := options.Validate(); err != nil {
+		return fmt.Errorf("invalid task options: %w", err)
+	}
+
+	if task.Parameters == nil {
+		task.Parameters = make(map[string]interface{})
+	}
+	raw, _ := task.Parameters["options"].(map[string]interface{})
+	task.Parameters["options"] = options.apply(raw)
+	if options.KeepAlive != nil {
+		task.Parameters["keep_alive"] = options.KeepAlive
+	}
+	return nil
+}
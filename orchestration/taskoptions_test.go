@@ -0,0 +1,158 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+func intPtr(v int) *int           { return &v }
+
+func TestTaskOptionsValidate(t *testing.T) {
+	valid := &TaskOptions{
+		Temperature: floatPtr(0.7),
+		TopP:        floatPtr(0.9),
+		NumCtx:      intPtr(4096),
+		Seed:        intPtr(42),
+		Stop:        []string{"\n\n"},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid options to pass: %v", err)
+	}
+
+	var nilOptions *TaskOptions
+	if err := nilOptions.Validate(); err != nil {
+		t.Errorf("Expected nil options to pass: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		options *TaskOptions
+	}{
+		{"temperature too high", &TaskOptions{Temperature: floatPtr(2.5)}},
+		{"negative temperature", &TaskOptions{Temperature: floatPtr(-0.1)}},
+		{"top_p zero", &TaskOptions{TopP: floatPtr(0)}},
+		{"top_p above one", &TaskOptions{TopP: floatPtr(1.1)}},
+		{"num_ctx zero", &TaskOptions{NumCtx: intPtr(0)}},
+		{"empty stop sequence", &TaskOptions{Stop: []string{""}}},
+		{"too many stops", &TaskOptions{Stop: make([]string, maxStopSequences+1)}},
+	}
+	for _, tc := range cases {
+		if err := tc.options.Validate(); err == nil {
+			t.Errorf("%s: expected validation error", tc.name)
+		}
+	}
+}
+
+func TestApplyTaskOptions(t *testing.T) {
+	task := &Task{}
+	options := &TaskOptions{
+		Temperature: floatPtr(0.2),
+		Seed:        intPtr(7),
+		Stop:        []string{"END"},
+	}
+	if err := applyTaskOptions(task, options); err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+
+	raw, ok := task.Parameters["options"].(map[string]interface{})
+	if !ok {
+		t.Fatal("Expected options map in parameters")
+	}
+	if raw["temperature"] != 0.2 || raw["seed"] != 7 {
+		t.Errorf("Unexpected mapped options: %v", raw)
+	}
+	if stop, ok := raw["stop"].([]string); !ok || stop[0] != "END" {
+		t.Errorf("Expected stop sequences mapped, got %v", raw["stop"])
+	}
+
+	// Explicit raw entries are preserved over first-class fields
+	task = &Task{Parameters: map[string]interface{}{
+		"options": map[string]interface{}{"temperature": 1.5},
+	}}
+	if err := applyTaskOptions(task, options); err != nil {
+		t.Fatalf("Failed to apply options: %v", err)
+	}
+	raw = task.Parameters["options"].(map[string]interface{})
+	if raw["temperature"] != 1.5 {
+		t.Errorf("Expected raw temperature preserved, got %v", raw["temperature"])
+	}
+	if raw["seed"] != 7 {
+		t.Errorf("Expected seed merged in, got %v", raw["seed"])
+	}
+
+	// Invalid options are rejected before dispatch
+	if err := applyTaskOptions(&Task{}, &TaskOptions{NumCtx: intPtr(-1)}); err == nil {
+		t.Error("Expected invalid options to be rejected")
+	}
+}
+
+func TestTaskKeepAlive(t *testing.T) {
+	if taskKeepAlive(&Task{}) != nil {
+		t.Error("Expected nil keep_alive for bare task")
+	}
+
+	native := &api.Duration{Duration: 5 * time.Minute}
+	task := &Task{Parameters: map[string]interface{}{"keep_alive": native}}
+	if taskKeepAlive(task) != native {
+		t.Error("Expected native duration passed through")
+	}
+
+	// JSON round-trips produce strings or numbers
+	task = &Task{Parameters: map[string]interface{}{"keep_alive": "10m"}}
+	if d := taskKeepAlive(task); d == nil || d.Duration != 10*time.Minute {
+		t.Errorf("Expected 10m from string, got %v", d)
+	}
+	task = &Task{Parameters: map[string]interface{}{"keep_alive": float64(30)}}
+	if d := taskKeepAlive(task); d == nil || d.Duration != 30*time.Second {
+		t.Errorf("Expected 30s from number, got %v", d)
+	}
+}
+
+func TestTaskStream(t *testing.T) {
+	if taskStream(&Task{}) != nil {
+		t.Error("Expected nil stream for bare task")
+	}
+	task := &Task{Parameters: map[string]interface{}{"stream": false}}
+	if stream := taskStream(task); stream == nil || *stream {
+		t.Errorf("Expected stream=false, got %v", stream)
+	}
+}
+
+func TestOrchestrationRequestKeepAlivePropagation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent := &Agent{Name: "keepalive-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	stream := false
+	req := &OrchestrationRequest{
+		AgentID:   agent.ID,
+		Stream:    &stream,
+		KeepAlive: &api.Duration{Duration: time.Minute},
+		// Custom tasks complete without touching the model API
+		Tasks: []TaskRequest{{Type: TaskTypeCustom, Input: "noop"}},
+	}
+
+	resp, err := engine.OrchestrateTasks(ctx, req)
+	if err != nil {
+		t.Fatalf("OrchestrateTasks failed: %v", err)
+	}
+	if len(resp.Tasks) != 1 {
+		t.Fatalf("Expected 1 task, got %d", len(resp.Tasks))
+	}
+
+	task := resp.Tasks[0]
+	if d := taskKeepAlive(&task); d == nil || d.Duration != time.Minute {
+		t.Errorf("Expected keep_alive propagated to task, got %v", d)
+	}
+	if s := taskStream(&task); s == nil || *s {
+		t.Errorf("Expected stream=false propagated to task, got %v", s)
+	}
+}
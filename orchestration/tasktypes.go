@@ -0,0 +1,128 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// defaultSummaryWords is the summary length used when a summarize task does
+// not set max_words.
+const defaultSummaryWords = 150
+
+// executeSummarizeTask executes a built-in summarization task. Parameters:
+// max_words bounds the summary length, style selects "paragraph" (default)
+// or "bullets", and focus optionally names an aspect to emphasize.
+func (e *Engine) executeSummarizeTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	input := task.InputText()
+	if input == "" {
+		return nil, fmt.Errorf("summarize task has no input text")
+	}
+
+	derived := *task
+	derived.Input = buildSummarizePrompt(input, task.Parameters)
+	derived.StructuredInput = nil
+	return e.executeGenerateTask(ctx, &derived, agent)
+}
+
+// buildSummarizePrompt renders the summarization prompt from the task's
+// length, style, and focus parameters.
+func buildSummarizePrompt(input string, params map[string]interface{}) string {
+	maxWords := defaultSummaryWords
+	if words, ok := toInt(params["max_words"]); ok && words > 0 {
+		maxWords = words
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Summarize the following text in at most %d words", maxWords)
+	if style, ok := params["style"].(string); ok && style == "bullets" {
+		builder.WriteString(" as a bulleted list")
+	}
+	if focus, ok := params["focus"].(string); ok && focus != "" {
+		fmt.Fprintf(&builder, ", focusing on %s", focus)
+	}
+	builder.WriteString(". Respond with only the summary.\n\n")
+	builder.WriteString(input)
+	return builder.String()
+}
+
+// executeClassifyTask executes a built-in classification task. The labels
+// parameter lists the allowed classes; multi_label allows assigning several.
+// Output is structured JSON validated against the label set, with a
+// confidence score and brief reasoning.
+func (e *Engine) executeClassifyTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	input := task.InputText()
+	if input == "" {
+		return nil, fmt.Errorf("classify task has no input text")
+	}
+
+	labels := classifyLabels(task)
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("classify task requires a labels parameter")
+	}
+	multiLabel, _ := task.Parameters["multi_label"].(bool)
+
+	derived := *task
+	derived.Input = buildClassifyPrompt(input, labels, multiLabel)
+	derived.StructuredInput = nil
+	if derived.OutputSchema == nil {
+		derived.OutputSchema = classificationSchema(labels, multiLabel)
+	}
+	return e.executeGenerateTask(ctx, &derived, agent)
+}
+
+// classifyLabels extracts the allowed class labels from task parameters.
+func classifyLabels(task *Task) []string {
+	raw, ok := task.Parameters["labels"].([]interface{})
+	if !ok {
+		return nil
+	}
+	labels := make([]string, 0, len(raw))
+	for _, entry := range raw {
+		if label, ok := entry.(string); ok && label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// buildClassifyPrompt renders the classification prompt for the given labels.
+func buildClassifyPrompt(input string, labels []string, multiLabel bool) string {
+	noun := "exactly one"
+	if multiLabel {
+		noun = "one or more"
+	}
+	return fmt.Sprintf(
+		"Classify the following text into %s of these labels: %s.\n\n%s",
+		noun, strings.Join(labels, ", "), input)
+}
+
+// classificationSchema declares the structured output shape for classify
+// tasks: the chosen label(s) constrained to the allowed set, a confidence
+// score, and brief reasoning.
+func classificationSchema(labels []string, multiLabel bool) map[string]interface{} {
+	enum := make([]interface{}, len(labels))
+	for i, label := range labels {
+		enum[i] = label
+	}
+
+	var labelSchema map[string]interface{}
+	if multiLabel {
+		labelSchema = map[string]interface{}{
+			"type":  "array",
+			"items": map[string]interface{}{"enum": enum},
+		}
+	} else {
+		labelSchema = map[string]interface{}{"enum": enum}
+	}
+
+	return map[string]interface{}{
+		"type":     "object",
+		"required": []interface{}{"label"},
+		"properties": map[string]interface{}{
+			"label":      labelSchema,
+			"confidence": map[string]interface{}{"type": "number"},
+			"reasoning":  map[string]interface{}{"type": "string"},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestBuildSummarizePrompt(t *testing.T) {
+	prompt := buildSummarizePrompt("long article text", nil)
+	if !strings.Contains(prompt, "at most 150 words") {
+		t.Errorf("Expected default length, got %q", prompt)
+	}
+	if !strings.Contains(prompt, "long article text") {
+		t.Error("Expected input included in prompt")
+	}
+
+	prompt = buildSummarizePrompt("text", map[string]interface{}{
+		"max_words": 50,
+		"style":     "bullets",
+		"focus":     "performance",
+	})
+	if !strings.Contains(prompt, "at most 50 words") ||
+		!strings.Contains(prompt, "bulleted list") ||
+		!strings.Contains(prompt, "focusing on performance") {
+		t.Errorf("Expected parameters reflected in prompt, got %q", prompt)
+	}
+}
+
+func TestBuildClassifyPrompt(t *testing.T) {
+	prompt := buildClassifyPrompt("some text", []string{"bug", "feature"}, false)
+	if !strings.Contains(prompt, "exactly one") || !strings.Contains(prompt, "bug, feature") {
+		t.Errorf("Unexpected prompt: %q", prompt)
+	}
+
+	prompt = buildClassifyPrompt("some text", []string{"a", "b"}, true)
+	if !strings.Contains(prompt, "one or more") {
+		t.Errorf("Expected multi-label wording, got %q", prompt)
+	}
+}
+
+func TestClassificationSchema(t *testing.T) {
+	schema := classificationSchema([]string{"spam", "ham"}, false)
+
+	valid := map[string]interface{}{"label": "spam", "confidence": 0.9}
+	if err := ValidateJSONSchema(valid, schema); err != nil {
+		t.Errorf("Expected valid classification, got %v", err)
+	}
+
+	invalid := map[string]interface{}{"label": "other"}
+	if err := ValidateJSONSchema(invalid, schema); err == nil {
+		t.Error("Expected out-of-set label rejected")
+	}
+
+	multi := classificationSchema([]string{"spam", "ham"}, true)
+	validMulti := map[string]interface{}{"label": []interface{}{"spam", "ham"}}
+	if err := ValidateJSONSchema(validMulti, multi); err != nil {
+		t.Errorf("Expected valid multi-label classification, got %v", err)
+	}
+}
+
+func TestClassifyTaskValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	agent := &Agent{Models: []string{"llama3.2"}}
+
+	task := &Task{ID: "cls-1", Type: TaskTypeClassify, Input: "text"}
+	if _, err := engine.executeClassifyTask(context.Background(), task, agent); err == nil {
+		t.Error("Expected error for missing labels")
+	}
+
+	task = &Task{ID: "cls-2", Type: TaskTypeClassify, Parameters: map[string]interface{}{
+		"labels": []interface{}{"a"},
+	}}
+	if _, err := engine.executeClassifyTask(context.Background(), task, agent); err == nil {
+		t.Error("Expected error for missing input")
+	}
+}
+
+func TestSummarizeTaskValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	agent := &Agent{Models: []string{"llama3.2"}}
+
+	task := &Task{ID: "sum-1", Type: TaskTypeSummarize}
+	if _, err := engine.executeSummarizeTask(context.Background(), task, agent); err == nil {
+		t.Error("Expected error for missing input")
+	}
+}
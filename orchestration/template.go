@@ -0,0 +1,70 @@
+package orchestration
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// templateFuncs are the helper functions available inside message, workflow,
+// and prompt templates. text/template is already sandboxed: templates can
+// only read the data they are given and call these helpers, with no file or
+// network access.
+var templateFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"trim":  strings.TrimSpace,
+	"truncate": func(n int, s string) string {
+		if n < 0 || len(s) <= n {
+			return s
+		}
+		return s[:n] + "..."
+	},
+	"json": func(v interface{}) (string, error) {
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encoded), nil
+	},
+}
+
+// legacyPlaceholderPattern matches the repo's historical bare {{variable}}
+// placeholders, which predate text/template.
+var legacyPlaceholderPattern = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// normalizeTemplate rewrites legacy {{variable}} placeholders to
+// {{.variable}} field references so existing templates keep working under
+// text/template. Actions that already use dots, pipes, or helpers are left
+// untouched.
+func normalizeTemplate(text string) string {
+	return legacyPlaceholderPattern.ReplaceAllString(text, "{{.$1}}")
+}
+
+// renderTemplate renders text with text/template and the shared helper set.
+// Missing variables are an error so typos surface instead of silently
+// rendering empty strings.
+func renderTemplate(text string, data map[string]interface{}) (string, error) {
+	tmpl, err := template.New("template").Funcs(templateFuncs).Option("missingkey=error").Parse(normalizeTemplate(text))
+	if err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("template execution failed: %w", err)
+	}
+	return rendered.String(), nil
+}
+
+// templateData widens string variables into the interface map text/template
+// expects.
+func templateData(vars map[string]string) map[string]interface{} {
+	data := make(map[string]interface{}, len(vars))
+	for key, value := range vars {
+		data[key] = value
+	}
+	return data
+}
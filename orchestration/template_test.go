@@ -0,0 +1,82 @@
+package orchestration
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTemplateLegacyPlaceholders(t *testing.T) {
+	rendered, err := renderTemplate("Hello {{name}}, welcome to {{place}}",
+		map[string]interface{}{"name": "Echo", "place": "the grove"})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if rendered != "Hello Echo, welcome to the grove" {
+		t.Errorf("Unexpected rendering: %s", rendered)
+	}
+}
+
+func TestRenderTemplateHelpers(t *testing.T) {
+	rendered, err := renderTemplate("{{upper .name}}: {{truncate 5 .body}}",
+		map[string]interface{}{"name": "echo", "body": "a very long message"})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if rendered != "ECHO: a ver..." {
+		t.Errorf("Unexpected rendering: %s", rendered)
+	}
+
+	rendered, err = renderTemplate("{{json .payload}}",
+		map[string]interface{}{"payload": map[string]interface{}{"ok": true}})
+	if err != nil {
+		t.Fatalf("Failed to render: %v", err)
+	}
+	if rendered != `{"ok":true}` {
+		t.Errorf("Unexpected JSON rendering: %s", rendered)
+	}
+}
+
+func TestRenderTemplateMissingVariable(t *testing.T) {
+	if _, err := renderTemplate("Hello {{name}}", map[string]interface{}{}); err == nil {
+		t.Error("Expected an error for a missing variable")
+	}
+	if _, err := renderTemplate("Hello {{name", map[string]interface{}{"name": "x"}); err == nil {
+		t.Error("Expected an error for a malformed template")
+	}
+}
+
+func TestPromptTemplateRenderFallback(t *testing.T) {
+	template := &PromptTemplate{Template: "Agent {{agent_name}} on {{topic}}"}
+
+	// Full variables render via text/template
+	rendered := template.Render(map[string]string{"agent_name": "Echo", "topic": "planning"})
+	if rendered != "Agent Echo on planning" {
+		t.Errorf("Unexpected rendering: %s", rendered)
+	}
+
+	// Missing variables fall back, leaving the placeholder visible
+	rendered = template.Render(map[string]string{"agent_name": "Echo"})
+	if !strings.Contains(rendered, "{{topic}}") {
+		t.Errorf("Expected missing placeholder left intact, got: %s", rendered)
+	}
+
+	// Strict rendering surfaces the missing variable instead
+	if _, err := template.RenderStrict(map[string]string{"agent_name": "Echo"}); err == nil {
+		t.Error("Expected RenderStrict to fail on a missing variable")
+	}
+}
+
+func TestProcessMessageTemplateHelpers(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	rendered := engine.processMessageTemplate("Review {{upper .component}} build {{build}}",
+		map[string]interface{}{"component": "parser", "build": 42})
+	if rendered != "Review PARSER build 42" {
+		t.Errorf("Unexpected rendering: %s", rendered)
+	}
+
+	// Malformed templates still yield a best-effort message
+	rendered = engine.processMessageTemplate("Review {{component", map[string]interface{}{"component": "parser"})
+	if rendered != "Review {{component" {
+		t.Errorf("Unexpected fallback rendering: %s", rendered)
+	}
+}
@@ -0,0 +1,196 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SpanKind classifies a timeline span.
+type SpanKind string
+
+const (
+	SpanKindTask  SpanKind = "task"
+	SpanKindTool  SpanKind = "tool"
+	SpanKindStage SpanKind = "stage"
+)
+
+// TimelineSpan records the precise start and end of one unit of work — a
+// task execution, a tool call, or a workflow stage — so multi-agent
+// workflows can be profiled after the fact.
+type TimelineSpan struct {
+	ID         string    `json:"id"`
+	Kind       SpanKind  `json:"kind"`
+	Name       string    `json:"name"`
+	WorkflowID string    `json:"workflow_id,omitempty"`
+	TaskID     string    `json:"task_id,omitempty"`
+	AgentID    string    `json:"agent_id,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	EndedAt    time.Time `json:"ended_at,omitempty"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Duration returns the span length, or the elapsed time so far for spans
+// that have not ended.
+func (s *TimelineSpan) Duration() time.Duration {
+	if s.EndedAt.IsZero() {
+		return time.Since(s.StartedAt)
+	}
+	return s.EndedAt.Sub(s.StartedAt)
+}
+
+// Timeline collects execution spans in memory, retaining the most recent
+// entries. It is safe for concurrent use.
+type Timeline struct {
+	spans    []*TimelineSpan
+	maxSpans int
+	mu       sync.RWMutex
+}
+
+// NewTimeline creates a timeline retaining the most recent spans.
+func NewTimeline() *Timeline {
+	return &Timeline{
+		spans:    make([]*TimelineSpan, 0),
+		maxSpans: 5000,
+	}
+}
+
+// Begin opens a span and records it. The returned span should be completed
+// with End once the work finishes.
+func (t *Timeline) Begin(kind SpanKind, name string, workflowID, taskID, agentID string) *TimelineSpan {
+	span := &TimelineSpan{
+		ID:         uuid.New().String(),
+		Kind:       kind,
+		Name:       name,
+		WorkflowID: workflowID,
+		TaskID:     taskID,
+		AgentID:    agentID,
+		StartedAt:  time.Now(),
+	}
+
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	if len(t.spans) > t.maxSpans {
+		t.spans = t.spans[len(t.spans)-t.maxSpans:]
+	}
+	t.mu.Unlock()
+
+	return span
+}
+
+// End closes a span, recording its outcome.
+func (t *Timeline) End(span *TimelineSpan, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	span.EndedAt = time.Now()
+	span.Success = err == nil
+	if err != nil {
+		span.Error = err.Error()
+	}
+}
+
+// Spans returns spans matching the given filters. Empty filter values match
+// everything; results are ordered by start time.
+func (t *Timeline) Spans(workflowID, taskID string) []*TimelineSpan {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	spans := make([]*TimelineSpan, 0)
+	for _, span := range t.spans {
+		if workflowID != "" && span.WorkflowID != workflowID {
+			continue
+		}
+		if taskID != "" && span.TaskID != taskID {
+			continue
+		}
+		spans = append(spans, span)
+	}
+
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].StartedAt.Before(spans[j].StartedAt)
+	})
+	return spans
+}
+
+// MermaidGantt renders the matching spans as a Mermaid gantt chart, grouped
+// into sections by span kind, for embedding in dashboards and reports.
+func (t *Timeline) MermaidGantt(workflowID, taskID string) string {
+	spans := t.Spans(workflowID, taskID)
+
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	if workflowID != "" {
+		fmt.Fprintf(&b, "    title Workflow %s\n", workflowID)
+	} else {
+		b.WriteString("    title Task Execution Timeline\n")
+	}
+	b.WriteString("    dateFormat x\n")
+	b.WriteString("    axisFormat %H:%M:%S\n")
+
+	for _, kind := range []SpanKind{SpanKindStage, SpanKindTask, SpanKindTool} {
+		wroteSection := false
+		for _, span := range spans {
+			if span.Kind != kind {
+				continue
+			}
+			if !wroteSection {
+				fmt.Fprintf(&b, "    section %s\n", kind)
+				wroteSection = true
+			}
+
+			end := span.EndedAt
+			if end.IsZero() {
+				end = time.Now()
+			}
+			marker := ""
+			if !span.Success && !span.EndedAt.IsZero() {
+				marker = "crit, "
+			}
+			fmt.Fprintf(&b, "    %s :%s%d, %d\n",
+				sanitizeGanttName(span.Name),
+				marker,
+				span.StartedAt.UnixMilli(),
+				end.UnixMilli())
+		}
+	}
+
+	return b.String()
+}
+
+// sanitizeGanttName strips characters that would break Mermaid gantt syntax.
+func sanitizeGanttName(name string) string {
+	name = strings.ReplaceAll(name, ":", "-")
+	name = strings.ReplaceAll(name, "\n", " ")
+	if name == "" {
+		name = "unnamed"
+	}
+	return name
+}
+
+type workflowIDKey struct{}
+
+// withWorkflowID annotates a context with the workflow a span belongs to,
+// so task and tool spans recorded deeper in the call chain attach to it.
+func withWorkflowID(ctx context.Context, workflowID string) context.Context {
+	return context.WithValue(ctx, workflowIDKey{}, workflowID)
+}
+
+// workflowIDFrom extracts the workflow ID from a context, if present.
+func workflowIDFrom(ctx context.Context) string {
+	if id, ok := ctx.Value(workflowIDKey{}).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// GetTimeline returns the engine's execution timeline.
+func (e *Engine) GetTimeline() *Timeline {
+	return e.timeline
+}
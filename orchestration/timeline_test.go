@@ -0,0 +1,77 @@
+package orchestration
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTimelineRecordsSpans(t *testing.T) {
+	timeline := NewTimeline()
+
+	span := timeline.Begin(SpanKindTask, "generate", "wf-1", "task-1", "agent-1")
+	timeline.End(span, nil)
+
+	failed := timeline.Begin(SpanKindTool, "web_search", "wf-1", "task-1", "agent-1")
+	timeline.End(failed, errors.New("connection refused"))
+
+	spans := timeline.Spans("wf-1", "")
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	if !spans[0].Success {
+		t.Error("Expected first span to be successful")
+	}
+	if spans[1].Success {
+		t.Error("Expected second span to have failed")
+	}
+	if spans[1].Error != "connection refused" {
+		t.Errorf("Expected span error recorded, got %q", spans[1].Error)
+	}
+	if spans[0].EndedAt.IsZero() {
+		t.Error("Expected ended span to have an end time")
+	}
+}
+
+func TestTimelineSpanFiltering(t *testing.T) {
+	timeline := NewTimeline()
+
+	timeline.End(timeline.Begin(SpanKindTask, "a", "wf-1", "task-1", ""), nil)
+	timeline.End(timeline.Begin(SpanKindTask, "b", "wf-2", "task-2", ""), nil)
+
+	if got := len(timeline.Spans("wf-2", "")); got != 1 {
+		t.Errorf("Expected 1 span for wf-2, got %d", got)
+	}
+	if got := len(timeline.Spans("", "task-1")); got != 1 {
+		t.Errorf("Expected 1 span for task-1, got %d", got)
+	}
+	if got := len(timeline.Spans("", "")); got != 2 {
+		t.Errorf("Expected 2 spans unfiltered, got %d", got)
+	}
+}
+
+func TestTimelineMermaidGantt(t *testing.T) {
+	timeline := NewTimeline()
+
+	timeline.End(timeline.Begin(SpanKindStage, "research: phase one", "wf-1", "", "agent-1"), nil)
+	timeline.End(timeline.Begin(SpanKindTask, "generate", "wf-1", "task-1", "agent-1"), nil)
+
+	gantt := timeline.MermaidGantt("wf-1", "")
+
+	if !strings.HasPrefix(gantt, "gantt\n") {
+		t.Error("Expected output to start with gantt header")
+	}
+	if !strings.Contains(gantt, "title Workflow wf-1") {
+		t.Error("Expected workflow title in gantt output")
+	}
+	if !strings.Contains(gantt, "section stage") || !strings.Contains(gantt, "section task") {
+		t.Error("Expected sections per span kind")
+	}
+	if strings.Contains(gantt, "research: phase one") {
+		t.Error("Expected colons in span names to be sanitized")
+	}
+	if !strings.Contains(gantt, "research- phase one") {
+		t.Error("Expected sanitized span name in gantt output")
+	}
+}
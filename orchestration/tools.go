@@ -3,6 +3,7 @@ package orchestration
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -135,10 +136,59 @@ func (p *ExampleDataAnalysisPlugin) Execute(ctx context.Context, input string, p
 	}
 }
 
+// KnowledgeSearchTool retrieves relevant document chunks from the engine's
+// knowledge base, grounding agent responses on ingested documents.
+type KnowledgeSearchTool struct {
+	engine *Engine
+}
+
+func (t *KnowledgeSearchTool) Name() string {
+	return "knowledge_search"
+}
+
+func (t *KnowledgeSearchTool) Description() string {
+	return "Retrieves relevant passages from ingested documents for a query"
+}
+
+func (t *KnowledgeSearchTool) Call(ctx context.Context, params map[string]interface{}) (*ToolResult, error) {
+	query, ok := params["query"].(string)
+	if !ok {
+		return &ToolResult{
+			Success: false,
+			Error:   "query parameter required",
+		}, nil
+	}
+
+	model, _ := params["model"].(string)
+	topK := 5
+	if k, ok := params["top_k"].(float64); ok && k > 0 {
+		topK = int(k)
+	}
+
+	results, err := t.engine.GetKnowledgeBase().Search(ctx, query, model, topK)
+	if err != nil {
+		return &ToolResult{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	passages := make([]string, 0, len(results))
+	for _, result := range results {
+		passages = append(passages, result.Chunk.Content)
+	}
+
+	return &ToolResult{
+		Success: true,
+		Output:  strings.Join(passages, "\n---\n"),
+	}, nil
+}
+
 // RegisterDefaultTools registers the default set of tools with an engine
 func RegisterDefaultTools(engine *Engine) {
 	engine.RegisterTool(&ExampleWebSearchTool{})
 	engine.RegisterTool(&ExampleCalculatorTool{})
+	engine.RegisterTool(&KnowledgeSearchTool{engine: engine})
 }
 
 // RegisterDefaultPlugins registers the default set of plugins with an engine
@@ -17,27 +17,35 @@ type Agent struct {
 	Type        AgentType              `json:"type"`
 	State       *AgentState            `json:"state,omitempty"`
 	Tools       []string               `json:"tools,omitempty"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	// Persona is a system prompt prepended to chat/generate requests. It
+	// supports {{agent_name}}, {{agent_description}}, {{goals}}, and
+	// {{capabilities}} placeholders and takes precedence over "system"
+	// templates from the prompt library.
+	Persona string `json:"persona,omitempty"`
+	// Owner is the tenant the agent belongs to. Operator API keys may only
+	// manage agents that are unowned or owned by their own tenant.
+	Owner     string    `json:"owner,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // AgentType defines different types of agents with specialized behaviors
 type AgentType string
 
 const (
-	AgentTypeGeneral     AgentType = "general"     // General purpose agent
-	AgentTypeSpecialist  AgentType = "specialist"  // Specialized for specific domains
+	AgentTypeGeneral      AgentType = "general"      // General purpose agent
+	AgentTypeSpecialist   AgentType = "specialist"   // Specialized for specific domains
 	AgentTypeOrchestrator AgentType = "orchestrator" // Coordinates other agents
-	AgentTypeReflective  AgentType = "reflective"  // Self-analyzing and improving
+	AgentTypeReflective   AgentType = "reflective"   // Self-analyzing and improving
 )
 
 // AgentState maintains persistent state and memory for agents
 type AgentState struct {
-	Memory         map[string]interface{} `json:"memory,omitempty"`
-	Context        []ContextItem          `json:"context,omitempty"`
-	Goals          []string               `json:"goals,omitempty"`
-	Capabilities   []string               `json:"capabilities,omitempty"`
-	LastInteraction time.Time             `json:"last_interaction"`
+	Memory          map[string]interface{} `json:"memory,omitempty"`
+	Context         []ContextItem          `json:"context,omitempty"`
+	Goals           []string               `json:"goals,omitempty"`
+	Capabilities    []string               `json:"capabilities,omitempty"`
+	LastInteraction time.Time              `json:"last_interaction"`
 }
 
 // ContextItem represents a piece of contextual information in agent memory
@@ -46,21 +54,33 @@ type ContextItem struct {
 	Value     interface{} `json:"value"`
 	Timestamp time.Time   `json:"timestamp"`
 	Relevance float64     `json:"relevance"`
+	Pinned    bool        `json:"pinned,omitempty"` // Pinned items survive eviction
 }
 
 // Task represents a task that can be executed by an orchestration agent
 type Task struct {
-	ID          string                 `json:"id"`
-	Type        string                 `json:"type"`
-	Input       string                 `json:"input"`
-	Output      string                 `json:"output,omitempty"`
-	Status      string                 `json:"status"`
-	AgentID     string                 `json:"agent_id"`
-	ModelName   string                 `json:"model_name,omitempty"`
-	Parameters  map[string]interface{} `json:"parameters,omitempty"`
-	CreatedAt   time.Time              `json:"created_at"`
-	CompletedAt *time.Time             `json:"completed_at,omitempty"`
-	Error       string                 `json:"error,omitempty"`
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Input     string `json:"input"`
+	Output    string `json:"output,omitempty"`
+	Status    string `json:"status"`
+	AgentID   string `json:"agent_id"`
+	ModelName string `json:"model_name,omitempty"`
+	// StructuredInput is an optional typed payload; tasks without textual
+	// input have it marshaled to JSON as the prompt.
+	StructuredInput map[string]interface{} `json:"structured_input,omitempty"`
+	// OutputSchema declares the JSON shape the model output must satisfy.
+	// When set, generation runs in JSON mode and output is validated (with
+	// automatic repair retries) before the task completes.
+	OutputSchema map[string]interface{} `json:"output_schema,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	// Priority orders queued work: interactive tasks at TaskPriorityHigh or
+	// above jump ahead of (and delay) queued batch tasks. The zero value is
+	// TaskPriorityLow.
+	Priority    TaskPriority `json:"priority,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	CompletedAt *time.Time   `json:"completed_at,omitempty"`
+	Error       string       `json:"error,omitempty"`
 }
 
 // TaskStatus constants
@@ -73,13 +93,20 @@ const (
 
 // TaskType constants
 const (
-	TaskTypeGenerate = "generate"
-	TaskTypeChat     = "chat"
-	TaskTypeEmbed    = "embed"
-	TaskTypeCustom   = "custom"
-	TaskTypeTool     = "tool"     // Call external tools
-	TaskTypeReflect  = "reflect"  // Self-reflection and analysis
-	TaskTypePlugin   = "plugin"   // Custom plugin execution
+	TaskTypeGenerate    = "generate"
+	TaskTypeChat        = "chat"
+	TaskTypeEmbed       = "embed"
+	TaskTypeCustom      = "custom"
+	TaskTypeTool        = "tool"        // Call external tools
+	TaskTypeReflect     = "reflect"     // Self-reflection and analysis
+	TaskTypePlugin      = "plugin"      // Custom plugin execution
+	TaskTypeOrchestrate = "orchestrate" // Nested orchestration request
+	TaskTypeSummarize   = "summarize"   // Built-in summarization
+	TaskTypeClassify    = "classify"    // Built-in label classification
+	TaskTypeVision      = "vision"      // Image analysis via multimodal models
+	TaskTypeTranscribe  = "transcribe"  // Speech-to-text via a configured transcriber
+	TaskTypeSpeak       = "speak"       // Text-to-speech via a configured synthesizer
+	TaskTypeBlackboard  = "blackboard"  // Read/write the conversation's shared blackboard
 )
 
 // ToolCall represents a call to an external tool
@@ -117,39 +144,57 @@ type Tool interface {
 
 // OrchestrationRequest represents a request to orchestrate multiple tasks
 type OrchestrationRequest struct {
-	AgentID     string                 `json:"agent_id"`
-	Tasks       []TaskRequest          `json:"tasks"`
-	Sequential  bool                   `json:"sequential"`
-	Parameters  map[string]interface{} `json:"parameters,omitempty"`
-	Stream      *bool                  `json:"stream,omitempty"`
-	KeepAlive   *api.Duration          `json:"keep_alive,omitempty"`
+	AgentID    string        `json:"agent_id"`
+	Tasks      []TaskRequest `json:"tasks"`
+	Sequential bool          `json:"sequential"`
+	// BestEffort runs every task to completion and reports per-task
+	// success/failure instead of aborting on the first error.
+	BestEffort bool                   `json:"best_effort,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Stream     *bool                  `json:"stream,omitempty"`
+	KeepAlive  *api.Duration          `json:"keep_alive,omitempty"`
 }
 
 // TaskRequest represents a single task within an orchestration request
 type TaskRequest struct {
-	Type       string                 `json:"type"`
-	Input      string                 `json:"input"`
-	ModelName  string                 `json:"model_name,omitempty"`
+	Type      string `json:"type"`
+	Input     string `json:"input"`
+	ModelName string `json:"model_name,omitempty"`
+	// Options are validated, first-class generation options mapped onto
+	// api.Options and merged into Parameters["options"]; entries already
+	// set in the raw map are preserved.
+	Options    *TaskOptions           `json:"options,omitempty"`
 	Parameters map[string]interface{} `json:"parameters,omitempty"`
 }
 
 // OrchestrationResponse represents the response from an orchestration request
 type OrchestrationResponse struct {
-	ID        string `json:"id"`
-	AgentID   string `json:"agent_id"`
-	Status    string `json:"status"`
-	Tasks     []Task `json:"tasks"`
+	ID        string       `json:"id"`
+	AgentID   string       `json:"agent_id"`
+	Status    string       `json:"status"`
+	Tasks     []Task       `json:"tasks"`
 	Results   []TaskResult `json:"results,omitempty"`
-	Error     string `json:"error,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
+	Error     string       `json:"error,omitempty"`
+	CreatedAt time.Time    `json:"created_at"`
 }
 
 // TaskResult represents the result of a completed task
 type TaskResult struct {
-	TaskID    string `json:"task_id"`
-	Output    string `json:"output"`
-	ModelUsed string `json:"model_used,omitempty"`
-	Metrics   TaskMetrics `json:"metrics,omitempty"`
+	TaskID string `json:"task_id"`
+	Output string `json:"output"`
+	// StructuredOutput holds the decoded JSON value when the task declared
+	// an output schema.
+	StructuredOutput interface{} `json:"structured_output,omitempty"`
+	// Embeddings holds the vectors produced by embed tasks, one per input.
+	Embeddings [][]float64 `json:"embeddings,omitempty"`
+	// ContextAccounting reports context-window truncation applied to the
+	// prompt, set only when something was dropped.
+	ContextAccounting *ContextAccounting `json:"context_accounting,omitempty"`
+	ModelUsed         string             `json:"model_used,omitempty"`
+	// EmotionalContext records the identity's emotional state at execution
+	// time when an emotion policy is installed.
+	EmotionalContext *EmotionalContext `json:"emotional_context,omitempty"`
+	Metrics          TaskMetrics       `json:"metrics,omitempty"`
 }
 
 // TaskMetrics contains performance metrics for a completed task
@@ -200,24 +245,26 @@ type Message struct {
 type MessageType string
 
 const (
-	MessageTypeRequest     MessageType = "request"     // Request for action or information
-	MessageTypeResponse    MessageType = "response"    // Response to a request
+	MessageTypeRequest      MessageType = "request"      // Request for action or information
+	MessageTypeResponse     MessageType = "response"     // Response to a request
 	MessageTypeNotification MessageType = "notification" // Informational update
-	MessageTypeTask        MessageType = "task"        // Task delegation
-	MessageTypeReflection  MessageType = "reflection"  // Shared reflection or insight
-	MessageTypeBroadcast   MessageType = "broadcast"   // Message to all agents
+	MessageTypeTask         MessageType = "task"         // Task delegation
+	MessageTypeReflection   MessageType = "reflection"   // Shared reflection or insight
+	MessageTypeBroadcast    MessageType = "broadcast"    // Message to all agents
 )
 
 // Conversation represents a conversation between agents
 type Conversation struct {
-	ID           string                 `json:"id"`
-	Participants []string               `json:"participants"` // Agent IDs
-	Messages     []Message              `json:"messages"`
-	Status       ConversationStatus     `json:"status"`
-	Topic        string                 `json:"topic,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt    time.Time              `json:"created_at"`
-	UpdatedAt    time.Time              `json:"updated_at"`
+	ID           string                     `json:"id"`
+	Participants []string                   `json:"participants"`         // Agent IDs
+	Roles        map[string]ParticipantRole `json:"roles,omitempty"`      // Role per agent ID; absent means contributor
+	Blackboard   *Blackboard                `json:"blackboard,omitempty"` // Shared scratchpad for blackboard-style collaboration
+	Messages     []Message                  `json:"messages"`
+	Status       ConversationStatus         `json:"status"`
+	Topic        string                     `json:"topic,omitempty"`
+	Metadata     map[string]interface{}     `json:"metadata,omitempty"`
+	CreatedAt    time.Time                  `json:"created_at"`
+	UpdatedAt    time.Time                  `json:"updated_at"`
 }
 
 // ConversationStatus defines the status of a conversation
@@ -241,44 +288,44 @@ type ConversationManager interface {
 
 // ConversationWorkflow represents a structured multi-agent conversation workflow
 type ConversationWorkflow struct {
-	ID           string                   `json:"id"`
-	Name         string                   `json:"name"`
-	Description  string                   `json:"description"`
-	Participants []string                 `json:"participants"` // Agent IDs
-	Steps        []ConversationStep       `json:"steps"`
-	Status       ConversationStatus       `json:"status"`
+	ID           string                      `json:"id"`
+	Name         string                      `json:"name"`
+	Description  string                      `json:"description"`
+	Participants []string                    `json:"participants"` // Agent IDs
+	Steps        []ConversationStep          `json:"steps"`
+	Status       ConversationStatus          `json:"status"`
 	Result       *ConversationWorkflowResult `json:"result,omitempty"`
-	CreatedAt    time.Time                `json:"created_at"`
+	CreatedAt    time.Time                   `json:"created_at"`
 }
 
 // ConversationStep represents a step in a conversation workflow
 type ConversationStep struct {
-	ID             string                 `json:"id"`
-	Name           string                 `json:"name"`
-	FromAgentID    string                 `json:"from_agent_id"`
-	ToAgentID      string                 `json:"to_agent_id"`
-	MessageTemplate string                `json:"message_template"`
-	ExpectedResponse string               `json:"expected_response,omitempty"`
-	Timeout         time.Duration          `json:"timeout,omitempty"`
-	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	ID               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	FromAgentID      string                 `json:"from_agent_id"`
+	ToAgentID        string                 `json:"to_agent_id"`
+	MessageTemplate  string                 `json:"message_template"`
+	ExpectedResponse string                 `json:"expected_response,omitempty"`
+	Timeout          time.Duration          `json:"timeout,omitempty"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
 }
 
 // ConversationWorkflowResult represents the result of a conversation workflow
 type ConversationWorkflowResult struct {
-	Success        bool                        `json:"success"`
-	StepResults    []ConversationStepResult    `json:"step_results"`
-	FinalOutcome   string                      `json:"final_outcome"`
-	Insights       []string                    `json:"insights,omitempty"`
-	Duration       time.Duration               `json:"duration"`
-	Error          string                      `json:"error,omitempty"`
+	Success      bool                     `json:"success"`
+	StepResults  []ConversationStepResult `json:"step_results"`
+	FinalOutcome string                   `json:"final_outcome"`
+	Insights     []string                 `json:"insights,omitempty"`
+	Duration     time.Duration            `json:"duration"`
+	Error        string                   `json:"error,omitempty"`
 }
 
 // ConversationStepResult represents the result of a conversation step
 type ConversationStepResult struct {
-	StepID       string        `json:"step_id"`
-	Message      *Message      `json:"message"`
-	Response     *Message      `json:"response,omitempty"`
-	Success      bool          `json:"success"`
-	Duration     time.Duration `json:"duration"`
-	Error        string        `json:"error,omitempty"`
-}
\ No newline at end of file
+	StepID   string        `json:"step_id"`
+	Message  *Message      `json:"message"`
+	Response *Message      `json:"response,omitempty"`
+	Success  bool          `json:"success"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
@@ -0,0 +1,210 @@
+package orchestration
+
+import (
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UsageRecord captures the billable activity of one task execution.
+type UsageRecord struct {
+	Timestamp        time.Time `json:"timestamp"`
+	TenantID         string    `json:"tenant_id,omitempty"`
+	AgentID          string    `json:"agent_id"`
+	TaskID           string    `json:"task_id"`
+	TaskType         string    `json:"task_type"`
+	Model            string    `json:"model,omitempty"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	ToolInvocations  int       `json:"tool_invocations"`
+	Cost             float64   `json:"cost"`
+}
+
+// ModelPricing holds per-1k-token rates for a model, used to attribute
+// provider costs to tenants.
+type ModelPricing struct {
+	PromptPer1K     float64 `json:"prompt_per_1k"`
+	CompletionPer1K float64 `json:"completion_per_1k"`
+}
+
+// UsageReportRow is one aggregated line of a daily usage report, grouped by
+// tenant, agent, and model.
+type UsageReportRow struct {
+	Date             string  `json:"date"`
+	TenantID         string  `json:"tenant_id,omitempty"`
+	AgentID          string  `json:"agent_id"`
+	Model            string  `json:"model,omitempty"`
+	Tasks            int     `json:"tasks"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	ToolInvocations  int     `json:"tool_invocations"`
+	Cost             float64 `json:"cost"`
+}
+
+// UsageTracker aggregates per-tenant and per-agent usage for chargeback in
+// shared deployments.
+type UsageTracker struct {
+	records []UsageRecord
+	pricing map[string]ModelPricing
+	mu      sync.RWMutex
+}
+
+// NewUsageTracker creates an empty usage tracker.
+func NewUsageTracker() *UsageTracker {
+	return &UsageTracker{
+		records: make([]UsageRecord, 0),
+		pricing: make(map[string]ModelPricing),
+	}
+}
+
+// SetModelPricing configures token rates for a model.
+func (u *UsageTracker) SetModelPricing(model string, pricing ModelPricing) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.pricing[model] = pricing
+}
+
+// EstimateCost prices a token count against a model's configured rates,
+// returning 0 for models without pricing.
+func (u *UsageTracker) EstimateCost(model string, promptTokens, completionTokens int) float64 {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	pricing, ok := u.pricing[model]
+	if !ok {
+		return 0
+	}
+	return float64(promptTokens)/1000*pricing.PromptPer1K +
+		float64(completionTokens)/1000*pricing.CompletionPer1K
+}
+
+// Record stores a usage record, computing its cost from configured pricing.
+func (u *UsageTracker) Record(record UsageRecord) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if pricing, ok := u.pricing[record.Model]; ok {
+		record.Cost = float64(record.PromptTokens)/1000*pricing.PromptPer1K +
+			float64(record.CompletionTokens)/1000*pricing.CompletionPer1K
+	}
+	if record.Timestamp.IsZero() {
+		record.Timestamp = time.Now()
+	}
+	u.records = append(u.records, record)
+}
+
+// DailyReport aggregates usage for a calendar day (UTC), grouped by tenant,
+// agent, and model. A zero date reports across all recorded days.
+func (u *UsageTracker) DailyReport(date time.Time) []UsageReportRow {
+	u.mu.RLock()
+	defer u.mu.RUnlock()
+
+	type key struct {
+		date   string
+		tenant string
+		agent  string
+		model  string
+	}
+	grouped := make(map[key]*UsageReportRow)
+
+	for _, record := range u.records {
+		day := record.Timestamp.UTC().Format("2006-01-02")
+		if !date.IsZero() && day != date.UTC().Format("2006-01-02") {
+			continue
+		}
+
+		k := key{date: day, tenant: record.TenantID, agent: record.AgentID, model: record.Model}
+		row, ok := grouped[k]
+		if !ok {
+			row = &UsageReportRow{
+				Date:     day,
+				TenantID: record.TenantID,
+				AgentID:  record.AgentID,
+				Model:    record.Model,
+			}
+			grouped[k] = row
+		}
+
+		row.Tasks++
+		row.PromptTokens += record.PromptTokens
+		row.CompletionTokens += record.CompletionTokens
+		row.ToolInvocations += record.ToolInvocations
+		row.Cost += record.Cost
+	}
+
+	rows := make([]UsageReportRow, 0, len(grouped))
+	for _, row := range grouped {
+		rows = append(rows, *row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Date != rows[j].Date {
+			return rows[i].Date < rows[j].Date
+		}
+		if rows[i].TenantID != rows[j].TenantID {
+			return rows[i].TenantID < rows[j].TenantID
+		}
+		return rows[i].AgentID < rows[j].AgentID
+	})
+	return rows
+}
+
+// ExportCSV renders a daily report as CSV.
+func (u *UsageTracker) ExportCSV(date time.Time) (string, error) {
+	var b strings.Builder
+	writer := csv.NewWriter(&b)
+
+	header := []string{"date", "tenant_id", "agent_id", "model", "tasks",
+		"prompt_tokens", "completion_tokens", "tool_invocations", "cost"}
+	if err := writer.Write(header); err != nil {
+		return "", err
+	}
+
+	for _, row := range u.DailyReport(date) {
+		record := []string{
+			row.Date, row.TenantID, row.AgentID, row.Model,
+			strconv.Itoa(row.Tasks),
+			strconv.Itoa(row.PromptTokens),
+			strconv.Itoa(row.CompletionTokens),
+			strconv.Itoa(row.ToolInvocations),
+			fmt.Sprintf("%.6f", row.Cost),
+		}
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+
+	writer.Flush()
+	return b.String(), writer.Error()
+}
+
+// GetUsageTracker returns the engine's usage tracker.
+func (e *Engine) GetUsageTracker() *UsageTracker {
+	return e.usage
+}
+
+// recordTaskUsage attributes a completed task's usage to its agent and
+// tenant. Tenancy comes from the task's "tenant_id" parameter when present.
+func (e *Engine) recordTaskUsage(task *Task, result *TaskResult) {
+	tenantID, _ := task.Parameters["tenant_id"].(string)
+
+	toolInvocations := 0
+	if task.Type == TaskTypeTool {
+		toolInvocations = 1
+	}
+
+	e.usage.Record(UsageRecord{
+		TenantID:         tenantID,
+		AgentID:          task.AgentID,
+		TaskID:           task.ID,
+		TaskType:         task.Type,
+		Model:            result.ModelUsed,
+		PromptTokens:     result.Metrics.PromptTokens,
+		CompletionTokens: result.Metrics.OutputTokens,
+		ToolInvocations:  toolInvocations,
+	})
+}
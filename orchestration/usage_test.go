@@ -0,0 +1,82 @@
+package orchestration
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUsageTrackerDailyReport(t *testing.T) {
+	tracker := NewUsageTracker()
+	tracker.SetModelPricing("llama3.2", ModelPricing{PromptPer1K: 0.01, CompletionPer1K: 0.03})
+
+	day := time.Date(2026, 8, 27, 10, 0, 0, 0, time.UTC)
+
+	tracker.Record(UsageRecord{
+		Timestamp: day, TenantID: "acme", AgentID: "agent-1", TaskID: "t1",
+		TaskType: TaskTypeGenerate, Model: "llama3.2",
+		PromptTokens: 1000, CompletionTokens: 2000,
+	})
+	tracker.Record(UsageRecord{
+		Timestamp: day.Add(time.Hour), TenantID: "acme", AgentID: "agent-1", TaskID: "t2",
+		TaskType: TaskTypeTool, ToolInvocations: 1,
+	})
+	tracker.Record(UsageRecord{
+		Timestamp: day.Add(24 * time.Hour), TenantID: "other", AgentID: "agent-2", TaskID: "t3",
+		TaskType: TaskTypeGenerate, Model: "llama3.2", PromptTokens: 500,
+	})
+
+	rows := tracker.DailyReport(day)
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows for the day (per model grouping), got %d", len(rows))
+	}
+
+	var modelRow *UsageReportRow
+	for i := range rows {
+		if rows[i].Model == "llama3.2" {
+			modelRow = &rows[i]
+		}
+	}
+	if modelRow == nil {
+		t.Fatal("Expected a row for llama3.2 usage")
+	}
+
+	if modelRow.PromptTokens != 1000 || modelRow.CompletionTokens != 2000 {
+		t.Errorf("Unexpected token totals: %+v", modelRow)
+	}
+
+	expectedCost := 1.0*0.01 + 2.0*0.03
+	if modelRow.Cost < expectedCost-0.0001 || modelRow.Cost > expectedCost+0.0001 {
+		t.Errorf("Expected cost %.4f, got %.4f", expectedCost, modelRow.Cost)
+	}
+
+	// All-days report includes both days
+	if all := tracker.DailyReport(time.Time{}); len(all) != 3 {
+		t.Errorf("Expected 3 rows across all days, got %d", len(all))
+	}
+}
+
+func TestUsageTrackerExportCSV(t *testing.T) {
+	tracker := NewUsageTracker()
+	tracker.Record(UsageRecord{
+		Timestamp: time.Date(2026, 8, 27, 10, 0, 0, 0, time.UTC),
+		TenantID:  "acme", AgentID: "agent-1", TaskID: "t1",
+		TaskType: TaskTypeGenerate, Model: "llama3.2", PromptTokens: 10,
+	})
+
+	report, err := tracker.ExportCSV(time.Time{})
+	if err != nil {
+		t.Fatalf("Failed to export CSV: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(report), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected header plus one row, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "date,tenant_id,agent_id,model") {
+		t.Errorf("Unexpected CSV header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "2026-08-27") || !strings.Contains(lines[1], "acme") {
+		t.Errorf("Unexpected CSV row: %s", lines[1])
+	}
+}
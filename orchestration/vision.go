@@ -0,0 +1,118 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// defaultVisionModel is used for vision tasks when neither the task nor the
+// agent names a multimodal model.
+const defaultVisionModel = "llava"
+
+// defaultVisionPrompt is the instruction used when a vision task provides no
+// input text of its own.
+const defaultVisionPrompt = "Describe this image in detail, including any visible text."
+
+// executeVisionTask executes an image-analysis task against a multimodal
+// model. Images are supplied via Parameters["images"] as file paths or
+// base64-encoded data; the task input, when present, directs the analysis
+// (e.g. "extract the text" or "what is in the foreground?").
+func (e *Engine) executeVisionTask(ctx context.Context, task *Task, agent *Agent) (*TaskResult, error) {
+	modelName := task.ModelName
+	if modelName == "" {
+		modelName = visionModelFor(agent)
+	}
+
+	images, err := visionTaskImages(task)
+	if err != nil {
+		return nil, err
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("vision task requires an images parameter")
+	}
+
+	prompt := task.InputText()
+	if prompt == "" {
+		prompt = defaultVisionPrompt
+	}
+
+	req := &api.GenerateRequest{
+		Model:     modelName,
+		Prompt:    prompt,
+		Images:    images,
+		KeepAlive: taskKeepAlive(task),
+	}
+	if task.Parameters != nil {
+		if opts, ok := task.Parameters["options"].(map[string]interface{}); ok {
+			req.Options = opts
+		}
+	}
+
+	var output string
+	var metrics TaskMetrics
+	err = e.client.Generate(ctx, req, func(resp api.GenerateResponse) error {
+		output += resp.Response
+		if resp.Done {
+			metrics.PromptTokens = resp.PromptEvalCount
+			metrics.OutputTokens = resp.EvalCount
+			metrics.TokensUsed = resp.PromptEvalCount + resp.EvalCount
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskResult{
+		TaskID:    task.ID,
+		Output:    output,
+		ModelUsed: modelName,
+		Metrics:   metrics,
+	}, nil
+}
+
+// visionModelFor picks a multimodal model for an agent: a configured
+// vision_model, else the first agent model, else the default.
+func visionModelFor(agent *Agent) string {
+	if model, ok := agent.Config["vision_model"].(string); ok && model != "" {
+		return model
+	}
+	if len(agent.Models) > 0 {
+		return agent.Models[0]
+	}
+	return defaultVisionModel
+}
+
+// visionTaskImages loads the images referenced by a vision task. Each entry
+// under Parameters["images"] is either a file path or base64-encoded image
+// data.
+func visionTaskImages(task *Task) ([]api.ImageData, error) {
+	raw, ok := task.Parameters["images"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	images := make([]api.ImageData, 0, len(raw))
+	for i, entry := range raw {
+		reference, ok := entry.(string)
+		if !ok || reference == "" {
+			return nil, fmt.Errorf("invalid image reference at index %d", i)
+		}
+
+		if decoded, err := base64.StdEncoding.DecodeString(reference); err == nil && len(decoded) > 0 {
+			images = append(images, api.ImageData(decoded))
+			continue
+		}
+
+		data, err := os.ReadFile(reference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load image %d from %s: %w", i, reference, err)
+		}
+		images = append(images, api.ImageData(data))
+	}
+	return images, nil
+}
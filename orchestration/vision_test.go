@@ -0,0 +1,73 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestVisionTaskImages(t *testing.T) {
+	dir := t.TempDir()
+	imagePath := filepath.Join(dir, "test.png")
+	payload := []byte{0x89, 'P', 'N', 'G'}
+	if err := os.WriteFile(imagePath, payload, 0644); err != nil {
+		t.Fatalf("Failed to write test image: %v", err)
+	}
+
+	task := &Task{Parameters: map[string]interface{}{
+		"images": []interface{}{
+			imagePath,
+			base64.StdEncoding.EncodeToString(payload),
+		},
+	}}
+
+	images, err := visionTaskImages(task)
+	if err != nil {
+		t.Fatalf("visionTaskImages failed: %v", err)
+	}
+	if len(images) != 2 {
+		t.Fatalf("Expected 2 images, got %d", len(images))
+	}
+	for i, image := range images {
+		if string(image) != string(payload) {
+			t.Errorf("Image %d content mismatch", i)
+		}
+	}
+
+	if _, err := visionTaskImages(&Task{Parameters: map[string]interface{}{
+		"images": []interface{}{filepath.Join(dir, "missing.png")},
+	}}); err == nil {
+		t.Error("Expected error for missing image file")
+	}
+}
+
+func TestVisionModelFor(t *testing.T) {
+	agent := &Agent{Config: map[string]interface{}{"vision_model": "llava:13b"}}
+	if model := visionModelFor(agent); model != "llava:13b" {
+		t.Errorf("Expected configured vision model, got %s", model)
+	}
+
+	agent = &Agent{Models: []string{"bakllava"}, Config: map[string]interface{}{}}
+	if model := visionModelFor(agent); model != "bakllava" {
+		t.Errorf("Expected first agent model, got %s", model)
+	}
+
+	agent = &Agent{Config: map[string]interface{}{}}
+	if model := visionModelFor(agent); model != defaultVisionModel {
+		t.Errorf("Expected default vision model, got %s", model)
+	}
+}
+
+func TestVisionTaskRequiresImages(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	agent := &Agent{Models: []string{"llava"}, Config: map[string]interface{}{}}
+
+	task := &Task{ID: "vis-1", Type: TaskTypeVision, Input: "describe"}
+	if _, err := engine.executeVisionTask(context.Background(), task, agent); err == nil {
+		t.Error("Expected error for vision task without images")
+	}
+}
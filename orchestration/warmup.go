@@ -0,0 +1,159 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+// warmKeepAlive is how long warmed models are asked to stay resident.
+const warmKeepAlive = 10 * time.Minute
+
+// warmupJob tracks one agent's keep-alive renewal loop.
+type warmupJob struct {
+	agentID string
+	stop    chan struct{}
+}
+
+// ModelWarmer keeps agent models loaded ahead of scheduled workloads: an
+// explicit warm-up loads each configured model with an empty generate, and a
+// renewal loop re-issues keep-alives while the agent still has pending or
+// running tasks, avoiding cold-start latency spikes between tasks.
+type ModelWarmer struct {
+	engine *Engine
+	jobs   map[string]*warmupJob
+	mu     sync.Mutex
+}
+
+// NewModelWarmer creates a model warmer for an engine.
+func NewModelWarmer(engine *Engine) *ModelWarmer {
+	return &ModelWarmer{
+		engine: engine,
+		jobs:   make(map[string]*warmupJob),
+	}
+}
+
+// WarmUpAgent loads every model an agent is configured with, so the first
+// real task does not pay model load latency. Individual model failures are
+// logged and skipped; an error is returned only when no model could be
+// warmed.
+func (e *Engine) WarmUpAgent(ctx context.Context, agentID string) error {
+	agent, err := e.GetAgent(ctx, agentID)
+	if err != nil {
+		return err
+	}
+	if len(agent.Models) == 0 {
+		return fmt.Errorf("%w: agent %s has no models to warm", ErrModelUnavailable, agentID)
+	}
+
+	warmed := 0
+	var lastErr error
+	for _, model := range agent.Models {
+		if err := e.warmModel(ctx, model); err != nil {
+			slog.Warn("Model warm-up failed", "agent_id", agentID, "model", model, "error", err)
+			lastErr = err
+			continue
+		}
+		warmed++
+	}
+	if warmed == 0 {
+		return fmt.Errorf("failed to warm any model for agent %s: %w", agentID, lastErr)
+	}
+
+	clog(ctx).Info("Warmed agent models", "agent_id", agentID, "warmed", warmed, "total", len(agent.Models))
+	return nil
+}
+
+// warmModel loads a model by issuing an empty generate with a keep-alive,
+// which the daemon treats as a load request without running inference.
+func (e *Engine) warmModel(ctx context.Context, model string) error {
+	keepAlive := &api.Duration{Duration: warmKeepAlive}
+	return e.client.Generate(ctx, &api.GenerateRequest{
+		Model:     model,
+		KeepAlive: keepAlive,
+	}, func(resp api.GenerateResponse) error { return nil })
+}
+
+// StartKeepAlive begins periodic keep-alive renewal for an agent's models.
+// Renewal only fires while the agent has pending or running tasks, so idle
+// agents let their models unload naturally.
+func (mw *ModelWarmer) StartKeepAlive(ctx context.Context, agentID string, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("keep-alive interval must be positive")
+	}
+	if _, err := mw.engine.GetAgent(ctx, agentID); err != nil {
+		return err
+	}
+
+	mw.mu.Lock()
+	if existing, ok := mw.jobs[agentID]; ok {
+		close(existing.stop)
+	}
+	job := &warmupJob{
+		agentID: agentID,
+		stop:    make(chan struct{}),
+	}
+	mw.jobs[agentID] = job
+	mw.mu.Unlock()
+
+	go mw.run(context.WithoutCancel(ctx), job, interval)
+
+	clog(ctx).Info("Started model keep-alive renewal", "agent_id", agentID, "interval", interval)
+	return nil
+}
+
+// StopKeepAlive cancels an agent's keep-alive renewal loop.
+func (mw *ModelWarmer) StopKeepAlive(agentID string) {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	if job, ok := mw.jobs[agentID]; ok {
+		close(job.stop)
+		delete(mw.jobs, agentID)
+	}
+}
+
+// run renews keep-alives on each tick while the agent has active work.
+func (mw *ModelWarmer) run(ctx context.Context, job *warmupJob, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-job.stop:
+			return
+		case <-ticker.C:
+			if !mw.engine.agentHasActiveWork(job.agentID) {
+				continue
+			}
+			if err := mw.engine.WarmUpAgent(ctx, job.agentID); err != nil {
+				slog.Warn("Keep-alive renewal failed", "agent_id", job.agentID, "error", err)
+			}
+		}
+	}
+}
+
+// agentHasActiveWork reports whether an agent has pending or running tasks.
+func (e *Engine) agentHasActiveWork(agentID string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, task := range e.tasks {
+		if task.AgentID != agentID {
+			continue
+		}
+		if task.Status == TaskStatusPending || task.Status == TaskStatusRunning {
+			return true
+		}
+	}
+	return false
+}
+
+// GetModelWarmer returns the engine's model warmer.
+func (e *Engine) GetModelWarmer() *ModelWarmer {
+	return e.warmer
+}
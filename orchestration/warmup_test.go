@@ -0,0 +1,83 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestWarmUpAgentValidation(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	if err := engine.WarmUpAgent(ctx, "missing"); err == nil {
+		t.Error("Expected error for unknown agent")
+	}
+
+	agent := &Agent{Name: "warm-none"}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := engine.WarmUpAgent(ctx, agent.ID); err == nil {
+		t.Error("Expected error for agent without models")
+	}
+
+	// Unreachable daemon: every model fails, so warm-up reports an error
+	agent = &Agent{Name: "warm-fail", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := engine.WarmUpAgent(ctx, agent.ID); err == nil {
+		t.Error("Expected error when no model could be warmed")
+	}
+}
+
+func TestAgentHasActiveWork(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	engine.tasks["p"] = &Task{ID: "p", AgentID: "a", Status: TaskStatusPending}
+	engine.tasks["d"] = &Task{ID: "d", AgentID: "b", Status: TaskStatusCompleted}
+
+	if !engine.agentHasActiveWork("a") {
+		t.Error("Expected agent with pending task to have active work")
+	}
+	if engine.agentHasActiveWork("b") {
+		t.Error("Expected agent with only completed tasks to be idle")
+	}
+}
+
+func TestKeepAliveLifecycle(t *testing.T) {
+	engine := NewEngine(unreachableClient(t))
+	ctx := context.Background()
+
+	agent := &Agent{Name: "keepalive-test", Models: []string{"llama3.2"}}
+	if err := engine.CreateAgent(ctx, agent); err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	warmer := engine.GetModelWarmer()
+	if err := warmer.StartKeepAlive(ctx, agent.ID, 0); err == nil {
+		t.Error("Expected error for non-positive interval")
+	}
+	if err := warmer.StartKeepAlive(ctx, "missing", time.Second); err == nil {
+		t.Error("Expected error for unknown agent")
+	}
+
+	if err := warmer.StartKeepAlive(ctx, agent.ID, time.Hour); err != nil {
+		t.Fatalf("StartKeepAlive failed: %v", err)
+	}
+	// Restarting replaces the existing job rather than erroring
+	if err := warmer.StartKeepAlive(ctx, agent.ID, time.Hour); err != nil {
+		t.Fatalf("Restarting keep-alive failed: %v", err)
+	}
+	warmer.StopKeepAlive(agent.ID)
+
+	warmer.mu.Lock()
+	remaining := len(warmer.jobs)
+	warmer.mu.Unlock()
+	if remaining != 0 {
+		t.Errorf("Expected no keep-alive jobs after stop, got %d", remaining)
+	}
+}
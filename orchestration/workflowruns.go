@@ -0,0 +1,200 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Workflow run lifecycle states for runs submitted over the API.
+const (
+	WorkflowRunRunning   = "running"
+	WorkflowRunCompleted = "completed"
+	WorkflowRunFailed    = "failed"
+	WorkflowRunCancelled = "cancelled"
+)
+
+// WorkflowRun tracks an asynchronously submitted multi-step workflow so
+// callers can poll progress, fetch step results, and cancel it mid-run.
+type WorkflowRun struct {
+	ID             string               `json:"id"`
+	AgentID        string               `json:"agent_id"`
+	Status         string               `json:"status"`
+	StepsTotal     int                  `json:"steps_total"`
+	StepsCompleted int                  `json:"steps_completed"`
+	StepResults    []WorkflowStepResult `json:"step_results,omitempty"`
+	Result         *WorkflowResult      `json:"result,omitempty"`
+	Error          string               `json:"error,omitempty"`
+	StartedAt      time.Time            `json:"started_at"`
+	FinishedAt     *time.Time           `json:"finished_at,omitempty"`
+
+	cancel context.CancelFunc
+	mu     sync.Mutex
+}
+
+// snapshot returns a copy safe to serialize while the run mutates.
+func (r *WorkflowRun) snapshot() *WorkflowRun {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	copied := &WorkflowRun{
+		ID:             r.ID,
+		AgentID:        r.AgentID,
+		Status:         r.Status,
+		StepsTotal:     r.StepsTotal,
+		StepsCompleted: r.StepsCompleted,
+		Result:         r.Result,
+		Error:          r.Error,
+		StartedAt:      r.StartedAt,
+		FinishedAt:     r.FinishedAt,
+	}
+	copied.StepResults = make([]WorkflowStepResult, len(r.StepResults))
+	copy(copied.StepResults, r.StepResults)
+	return copied
+}
+
+// StartWorkflowRun executes a multi-step workflow asynchronously,
+// detached from the caller's context, and returns a run handle
+// immediately. Poll GetWorkflowRun for progress or cancel it with
+// CancelWorkflowRun.
+func (e *Engine) StartWorkflowRun(ctx context.Context, agentID string, steps []WorkflowStep) (*WorkflowRun, error) {
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("workflow has no steps")
+	}
+	agent, err := e.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	run := &WorkflowRun{
+		ID:         uuid.New().String(),
+		AgentID:    agentID,
+		Status:     WorkflowRunRunning,
+		StepsTotal: len(steps),
+		StartedAt:  time.Now(),
+		cancel:     cancel,
+	}
+
+	e.workflowRunMu.Lock()
+	e.workflowRuns[run.ID] = run
+	e.workflowRunMu.Unlock()
+
+	// The run ID doubles as the workflow ID so checkpoint updates land on
+	// this record.
+	state := &WorkflowRunState{
+		WorkflowID: run.ID,
+		AgentID:    agentID,
+		Steps:      steps,
+		Results:    make([]WorkflowStepResult, len(steps)),
+		Context:    make(map[string]string),
+		Visits:     make([]int, len(steps)),
+	}
+	e.checkpointWorkflow(runCtx, state)
+
+	go func() {
+		result, err := e.runWorkflow(withWorkflowID(runCtx, run.ID), agent, state)
+		finished := time.Now()
+
+		run.mu.Lock()
+		defer run.mu.Unlock()
+		run.Result = result
+		run.FinishedAt = &finished
+		switch {
+		case runCtx.Err() != nil:
+			run.Status = WorkflowRunCancelled
+			run.Error = runCtx.Err().Error()
+		case err != nil:
+			run.Status = WorkflowRunFailed
+			run.Error = err.Error()
+		case result != nil && !result.Success:
+			run.Status = WorkflowRunFailed
+			run.Error = result.Error
+		default:
+			run.Status = WorkflowRunCompleted
+		}
+	}()
+
+	return run.snapshot(), nil
+}
+
+// updateWorkflowProgress mirrors a checkpointed state onto its API run
+// record, if the workflow was submitted through StartWorkflowRun.
+func (e *Engine) updateWorkflowProgress(state *WorkflowRunState) {
+	e.workflowRunMu.RLock()
+	run, exists := e.workflowRuns[state.WorkflowID]
+	e.workflowRunMu.RUnlock()
+	if !exists {
+		return
+	}
+
+	completed := 0
+	results := make([]WorkflowStepResult, 0, len(state.Results))
+	for _, result := range state.Results {
+		if result.Name == "" {
+			continue
+		}
+		completed++
+		results = append(results, result)
+	}
+
+	run.mu.Lock()
+	run.StepsCompleted = completed
+	run.StepResults = results
+	run.mu.Unlock()
+}
+
+// GetWorkflowRun returns a point-in-time snapshot of a submitted run.
+func (e *Engine) GetWorkflowRun(id string) (*WorkflowRun, error) {
+	e.workflowRunMu.RLock()
+	run, exists := e.workflowRuns[id]
+	e.workflowRunMu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: workflow run %s", ErrNotFound, id)
+	}
+	return run.snapshot(), nil
+}
+
+// ListWorkflowRuns returns snapshots of every submitted run, newest
+// first.
+func (e *Engine) ListWorkflowRuns() []*WorkflowRun {
+	e.workflowRunMu.RLock()
+	runs := make([]*WorkflowRun, 0, len(e.workflowRuns))
+	for _, run := range e.workflowRuns {
+		runs = append(runs, run)
+	}
+	e.workflowRunMu.RUnlock()
+
+	snapshots := make([]*WorkflowRun, len(runs))
+	for i, run := range runs {
+		snapshots[i] = run.snapshot()
+	}
+	sort.Slice(snapshots, func(a, b int) bool {
+		return snapshots[a].StartedAt.After(snapshots[b].StartedAt)
+	})
+	return snapshots
+}
+
+// CancelWorkflowRun stops a running workflow before its next step. Steps
+// already handed to a model still finish; the run is marked cancelled
+// once the current step returns.
+func (e *Engine) CancelWorkflowRun(id string) error {
+	e.workflowRunMu.RLock()
+	run, exists := e.workflowRuns[id]
+	e.workflowRunMu.RUnlock()
+	if !exists {
+		return fmt.Errorf("%w: workflow run %s", ErrNotFound, id)
+	}
+
+	run.mu.Lock()
+	defer run.mu.Unlock()
+	if run.Status != WorkflowRunRunning {
+		return fmt.Errorf("workflow run %s already %s", id, run.Status)
+	}
+	run.cancel()
+	return nil
+}
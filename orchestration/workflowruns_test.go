@@ -0,0 +1,148 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+	"github.com/gin-gonic/gin"
+)
+
+// waitForRunStatus polls an async workflow run until it leaves the running
+// state or the deadline passes.
+func waitForRunStatus(t *testing.T, engine *Engine, id string) *WorkflowRun {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		run, err := engine.GetWorkflowRun(id)
+		if err != nil {
+			t.Fatalf("GetWorkflowRun failed: %v", err)
+		}
+		if run.Status != WorkflowRunRunning {
+			return run
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Workflow run never finished: %+v", run)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestStartWorkflowRunTracksProgress(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	steps := []WorkflowStep{
+		{Name: "first", Type: TaskTypeCustom, Input: "one"},
+		{Name: "second", Type: TaskTypeCustom, Input: "two"},
+	}
+	run, err := engine.StartWorkflowRun(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("StartWorkflowRun failed: %v", err)
+	}
+	if run.StepsTotal != 2 {
+		t.Errorf("Expected 2 steps total, got %d", run.StepsTotal)
+	}
+
+	finished := waitForRunStatus(t, engine, run.ID)
+	if finished.Status != WorkflowRunCompleted {
+		t.Fatalf("Expected a completed run, got %+v", finished)
+	}
+	if finished.StepsCompleted != 2 || len(finished.StepResults) != 2 {
+		t.Errorf("Expected 2 completed steps, got %+v", finished)
+	}
+	if finished.Result == nil || !finished.Result.Success {
+		t.Errorf("Expected a successful result, got %+v", finished.Result)
+	}
+
+	if runs := engine.ListWorkflowRuns(); len(runs) != 1 || runs[0].ID != run.ID {
+		t.Errorf("Unexpected run listing: %+v", runs)
+	}
+	if _, err := engine.GetWorkflowRun("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown run, got %v", err)
+	}
+}
+
+func TestCancelWorkflowRun(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Stall the run behind a rate limit so cancellation lands mid-workflow
+	engine.SetAgentRateLimit(agent.ID, RateLimitConfig{RequestsPerMinute: 1})
+	steps := []WorkflowStep{
+		{Name: "first", Type: TaskTypeCustom, Input: "one"},
+		{Name: "second", Type: TaskTypeCustom, Input: "two"},
+	}
+	run, err := engine.StartWorkflowRun(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("StartWorkflowRun failed: %v", err)
+	}
+
+	if err := engine.CancelWorkflowRun(run.ID); err != nil {
+		t.Fatalf("CancelWorkflowRun failed: %v", err)
+	}
+	finished := waitForRunStatus(t, engine, run.ID)
+	if finished.Status != WorkflowRunCancelled && finished.Status != WorkflowRunFailed {
+		t.Fatalf("Expected a cancelled or failed run, got %+v", finished)
+	}
+	if err := engine.CancelWorkflowRun(run.ID); err == nil {
+		t.Error("Expected an error cancelling a finished run")
+	}
+}
+
+func TestWorkflowRunEndpoints(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := NewEngine(api.Client{})
+	server := NewAPIServer(engine)
+
+	agent, err := engine.CreateDefaultAgent(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/api/workflows/runs",
+		strings.NewReader(`{"agent_id": "`+agent.ID+`", "steps": [{"name": "only", "type": "custom", "input": "go"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	server.router.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 submitting a workflow, got %d: %s", recorder.Code, recorder.Body)
+	}
+
+	var submitted struct {
+		Data WorkflowRun `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &submitted); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	finished := waitForRunStatus(t, engine, submitted.Data.ID)
+	if finished.Status != WorkflowRunCompleted {
+		t.Fatalf("Expected the submitted run to complete, got %+v", finished)
+	}
+
+	fetched := httptest.NewRecorder()
+	server.router.ServeHTTP(fetched, httptest.NewRequest(http.MethodGet, "/api/workflows/runs/"+submitted.Data.ID, nil))
+	if fetched.Code != http.StatusOK {
+		t.Errorf("Expected 200 fetching the run, got %d", fetched.Code)
+	}
+	if !strings.Contains(fetched.Body.String(), `"steps_completed":1`) {
+		t.Errorf("Expected step progress in the response, got %s", fetched.Body)
+	}
+}
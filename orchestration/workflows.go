@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // CreateDefaultAgent creates a default orchestration agent with common models
@@ -30,60 +32,70 @@ func (e *Engine) CreateDefaultAgent(ctx context.Context) (*Agent, error) {
 	return agent, nil
 }
 
-// CreateSpecializedAgent creates an agent with specialized capabilities
+// CreateSpecializedAgent creates an agent materialized from the capability
+// profile registered for the type and domain. Domain-specific profiles (e.g.
+// a "coding" specialist) take precedence over type-wide ones.
 func (e *Engine) CreateSpecializedAgent(ctx context.Context, agentType AgentType, domain string) (*Agent, error) {
-	var agent *Agent
-	
+	profile, err := e.profiles.Get(agentType, domain)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported agent type: %s", agentType)
+	}
+
+	name := fmt.Sprintf("%s-%s", agentTypeSlug(agentType), domain)
+	if agentType == AgentTypeOrchestrator {
+		name = "orchestrator-coordinator"
+	}
+
+	// Copy profile config so created agents do not share the profile's map
+	config := make(map[string]interface{}, len(profile.Config)+2)
+	for key, value := range profile.Config {
+		config[key] = value
+	}
 	switch agentType {
 	case AgentTypeReflective:
-		agent = &Agent{
-			Name:        fmt.Sprintf("reflective-%s", domain),
-			Description: fmt.Sprintf("Self-reflective agent specialized in %s", domain),
-			Type:        AgentTypeReflective,
-			Models:      []string{"llama3.2", "llama2"},
-			Tools:       []string{"data_analysis"},
-			Config: map[string]interface{}{
-				"reflection_interval": 300,
-				"learning_rate":       0.1,
-				"domain":             domain,
-			},
-		}
-	case AgentTypeOrchestrator:
-		agent = &Agent{
-			Name:        "orchestrator-coordinator",
-			Description: "Orchestrator agent for coordinating multiple agents and complex workflows",
-			Type:        AgentTypeOrchestrator,
-			Models:      []string{"llama3.2"},
-			Tools:       []string{"web_search", "calculator", "data_analysis"},
-			Config: map[string]interface{}{
-				"max_sub_agents":    5,
-				"coordination_mode": "hierarchical",
-			},
-		}
+		config["domain"] = domain
 	case AgentTypeSpecialist:
-		agent = &Agent{
-			Name:        fmt.Sprintf("specialist-%s", domain),
-			Description: fmt.Sprintf("Specialist agent for %s domain tasks", domain),
-			Type:        AgentTypeSpecialist,
-			Models:      []string{"llama3.2", "codellama"},
-			Tools:       []string{"web_search", "data_analysis"},
-			Config: map[string]interface{}{
-				"specialization": domain,
-				"expertise_level": "advanced",
-			},
-		}
-	default:
-		return nil, fmt.Errorf("unsupported agent type: %s", agentType)
+		config["specialization"] = domain
+	}
+	if profile.MemoryLimit > 0 {
+		config["memory_limit"] = profile.MemoryLimit
 	}
 
-	err := e.CreateAgent(ctx, agent)
-	if err != nil {
+	agent := &Agent{
+		Name:        name,
+		Description: strings.ReplaceAll(profile.Description, "{{domain}}", domain),
+		Type:        agentType,
+		Models:      append([]string{}, profile.Models...),
+		Tools:       append([]string{}, profile.Tools...),
+		Config:      config,
+	}
+
+	if err := e.CreateAgent(ctx, agent); err != nil {
 		return nil, err
 	}
 
+	// Register the profile's system prompt as a per-agent override so
+	// chat/generate requests pick it up automatically
+	if profile.SystemPrompt != "" {
+		e.prompts.Put(string(PromptKindSystem), agent.ID, PromptKindSystem,
+			strings.ReplaceAll(profile.SystemPrompt, "{{domain}}", domain))
+	}
+
 	return agent, nil
 }
 
+// agentTypeSlug returns the name prefix used for agents of a type.
+func agentTypeSlug(agentType AgentType) string {
+	switch agentType {
+	case AgentTypeReflective:
+		return "reflective"
+	case AgentTypeSpecialist:
+		return "specialist"
+	default:
+		return string(agentType)
+	}
+}
+
 // SmartRouting intelligently routes tasks to appropriate models based on task type and content
 func (e *Engine) SmartRouting(ctx context.Context, agentID string, input string, taskType string) (*TaskResult, error) {
 	agent, err := e.GetAgent(ctx, agentID)
@@ -103,9 +115,7 @@ func (e *Engine) SmartRouting(ctx context.Context, agentID string, input string,
 	}
 
 	// Store task for tracking
-	e.mu.Lock()
-	e.tasks[task.ID] = task
-	e.mu.Unlock()
+	e.persistTask(ctx, task)
 
 	return e.ExecuteTask(ctx, task, agent)
 }
@@ -158,41 +168,128 @@ func (e *Engine) MultiStepWorkflow(ctx context.Context, agentID string, steps []
 		return nil, err
 	}
 
+	workflowID := uuid.New().String()
+
+	state := &WorkflowRunState{
+		WorkflowID: workflowID,
+		AgentID:    agentID,
+		Steps:      steps,
+		Results:    make([]WorkflowStepResult, len(steps)),
+		Context:    make(map[string]string),
+		Visits:     make([]int, len(steps)),
+	}
+	// Checkpoint the plan up front so a crash during the very first step
+	// still leaves a resumable record.
+	e.checkpointWorkflow(ctx, state)
+
+	return e.runWorkflow(withWorkflowID(ctx, workflowID), agent, state)
+}
+
+// runWorkflow drives a workflow from its run state, checkpointing after
+// every completed step so ResumeWorkflow can continue an interrupted run.
+// The caller must have stamped the workflow ID into the context.
+func (e *Engine) runWorkflow(ctx context.Context, agent *Agent, state *WorkflowRunState) (*WorkflowResult, error) {
+	steps := state.Steps
 	result := &WorkflowResult{
-		Steps:   make([]WorkflowStepResult, len(steps)),
-		Success: true,
+		WorkflowID: state.WorkflowID,
+		Steps:      state.Results,
+		Success:    true,
 	}
 
-	context := make(map[string]string)
+	// Branching on failed assertions may revisit steps; cap visits so a
+	// recovery loop cannot run forever.
+	const maxStepVisits = 3
+
+	for i := state.NextStep; i < len(steps); {
+		step := steps[i]
+		state.Visits[i]++
+		if state.Visits[i] > maxStepVisits {
+			result.Success = false
+			result.Error = fmt.Sprintf("Step %d (%s) exceeded %d visits; aborting recovery loop", i+1, step.Name, maxStepVisits)
+			break
+		}
 
-	for i, step := range steps {
 		// Replace placeholders with previous results
-		input := e.replacePlaceholders(step.Input, context)
+		input := e.replacePlaceholders(step.Input, state.Context)
+
+		attempts := 1
+		if step.OnAssertionFailure == AssertionRetry {
+			if step.MaxRetries > 0 {
+				attempts += step.MaxRetries
+			} else {
+				attempts += defaultAssertionRetries
+			}
+		}
 
-		task := &Task{
-			Type:      step.Type,
-			Input:     input,
-			Status:    TaskStatusPending,
-			AgentID:   agentID,
-			ModelName: step.ModelName,
+		var stepResult *TaskResult
+		var execErr, assertErr error
+		for attempt := 0; attempt < attempts; attempt++ {
+			attemptInput := input
+			if assertErr != nil {
+				attemptInput = assertionFeedbackInput(input, stepResult.Output, assertErr)
+			}
+
+			stageSpan := e.timeline.Begin(SpanKindStage, step.Name, state.WorkflowID, "", state.AgentID)
+
+			task := &Task{
+				Type:      step.Type,
+				Input:     attemptInput,
+				Status:    TaskStatusPending,
+				AgentID:   state.AgentID,
+				ModelName: step.ModelName,
+			}
+
+			if task.ModelName == "" {
+				task.ModelName = e.selectBestModel(agent, step.Type, attemptInput)
+			}
+
+			stepResult, execErr = e.ExecuteTask(ctx, task, agent)
+			e.timeline.End(stageSpan, execErr)
+			if execErr != nil {
+				break
+			}
+
+			assertErr = e.checkStepAssertions(ctx, agent, &step, stepResult.Output)
+			if assertErr == nil {
+				break
+			}
 		}
 
-		if task.ModelName == "" {
-			task.ModelName = e.selectBestModel(agent, step.Type, input)
+		if execErr != nil {
+			result.Success = false
+			result.Error = fmt.Sprintf("Step %d failed: %v", i+1, execErr)
+			break
 		}
 
-		stepResult, err := e.ExecuteTask(ctx, task, agent)
-		if err != nil {
+		if assertErr != nil {
+			state.Results[i] = WorkflowStepResult{
+				Name:    step.Name,
+				Type:    step.Type,
+				Input:   input,
+				Output:  stepResult.Output,
+				Success: false,
+				Error:   assertErr.Error(),
+			}
+
+			if step.OnAssertionFailure == AssertionBranch && step.RecoveryStep != "" {
+				if recovery := stepIndexByName(steps, step.RecoveryStep); recovery >= 0 {
+					i = recovery
+					state.NextStep = i
+					e.checkpointWorkflow(ctx, state)
+					continue
+				}
+			}
+
 			result.Success = false
-			result.Error = fmt.Sprintf("Step %d failed: %v", i+1, err)
+			result.Error = fmt.Sprintf("Step %d (%s) failed assertions: %v", i+1, step.Name, assertErr)
 			break
 		}
 
 		// Store result for future steps
-		context[fmt.Sprintf("step%d", i+1)] = stepResult.Output
-		context[step.Name] = stepResult.Output
+		state.Context[fmt.Sprintf("step%d", i+1)] = stepResult.Output
+		state.Context[step.Name] = stepResult.Output
 
-		result.Steps[i] = WorkflowStepResult{
+		state.Results[i] = WorkflowStepResult{
 			Name:      step.Name,
 			Type:      step.Type,
 			Input:     input,
@@ -200,11 +297,81 @@ func (e *Engine) MultiStepWorkflow(ctx context.Context, agentID string, steps []
 			ModelUsed: stepResult.ModelUsed,
 			Success:   true,
 		}
+		i++
+		state.NextStep = i
+		e.checkpointWorkflow(ctx, state)
+
+		if step.EmitSignal != "" {
+			e.EmitWorkflowSignal(ctx, WorkflowSignal{
+				Name:       step.EmitSignal,
+				Payload:    stepResult.Output,
+				WorkflowID: state.WorkflowID,
+			})
+		}
+	}
+
+	// Saga-style rollback: a failed run compensates its completed steps in
+	// reverse order so side effects do not outlive the workflow.
+	if !result.Success {
+		result.Compensations = e.compensateWorkflow(ctx, agent, state)
+		state.Compensations = result.Compensations
 	}
 
+	// The run is over — successful or not — so a restart must not replay it.
+	state.Completed = true
+	state.Success = result.Success
+	state.Error = result.Error
+	e.checkpointWorkflow(ctx, state)
+
 	return result, nil
 }
 
+// compensateWorkflow runs the compensation task of every completed step,
+// newest first. Compensations are best effort: one failing is recorded in
+// its result but does not stop the remaining rollbacks.
+func (e *Engine) compensateWorkflow(ctx context.Context, agent *Agent, state *WorkflowRunState) []WorkflowStepResult {
+	compensations := make([]WorkflowStepResult, 0)
+	for i := len(state.Steps) - 1; i >= 0; i-- {
+		step := state.Steps[i]
+		if step.CompensationInput == "" || !state.Results[i].Success {
+			continue
+		}
+
+		input := e.replacePlaceholders(step.CompensationInput, state.Context)
+		input = strings.ReplaceAll(input, "{{output}}", state.Results[i].Output)
+
+		taskType := step.CompensationType
+		if taskType == "" {
+			taskType = step.Type
+		}
+
+		task := &Task{
+			Type:    taskType,
+			Input:   input,
+			Status:  TaskStatusPending,
+			AgentID: state.AgentID,
+		}
+		compensation := WorkflowStepResult{
+			Name:  fmt.Sprintf("compensate_%s", step.Name),
+			Type:  taskType,
+			Input: input,
+		}
+
+		taskResult, err := e.ExecuteTask(ctx, task, agent)
+		if err != nil {
+			compensation.Error = err.Error()
+			clog(ctx).Error("Workflow compensation failed",
+				"workflow_id", state.WorkflowID, "step", step.Name, "error", err)
+		} else {
+			compensation.Output = taskResult.Output
+			compensation.ModelUsed = taskResult.ModelUsed
+			compensation.Success = true
+		}
+		compensations = append(compensations, compensation)
+	}
+	return compensations
+}
+
 // EnhancedCoordinatedWorkflow implements advanced agent coordination patterns
 func (e *Engine) EnhancedCoordinatedWorkflow(ctx context.Context, coordinatorID string, tasks []CoordinatedTask) (*CoordinatedWorkflowResult, error) {
 	coordinator, err := e.GetAgent(ctx, coordinatorID)
@@ -216,7 +383,11 @@ func (e *Engine) EnhancedCoordinatedWorkflow(ctx context.Context, coordinatorID
 		return nil, fmt.Errorf("agent must be of type orchestrator for coordinated workflows")
 	}
 
+	workflowID := uuid.New().String()
+	ctx = withWorkflowID(ctx, workflowID)
+
 	result := &CoordinatedWorkflowResult{
+		WorkflowID:    workflowID,
 		CoordinatorID: coordinatorID,
 		Tasks:         make([]CoordinatedTaskResult, len(tasks)),
 		Success:       true,
@@ -234,7 +405,9 @@ func (e *Engine) EnhancedCoordinatedWorkflow(ctx context.Context, coordinatorID
 		}
 
 		// Phase 2: Task Execution with Coordination
+		stageSpan := e.timeline.Begin(SpanKindStage, task.Type, workflowID, task.ID, selectedAgent.ID)
 		executionResult, err := e.executeCoordinatedTask(ctx, task, selectedAgent, coordinator)
+		e.timeline.End(stageSpan, err)
 		if err != nil {
 			result.Success = false
 			result.Error = fmt.Sprintf("Task %d execution failed: %v", i, err)
@@ -242,12 +415,12 @@ func (e *Engine) EnhancedCoordinatedWorkflow(ctx context.Context, coordinatorID
 		}
 
 		result.Tasks[i] = CoordinatedTaskResult{
-			TaskID:      task.ID,
-			AgentID:     selectedAgent.ID,
-			Type:        task.Type,
-			Input:       task.Input,
-			Output:      executionResult.Output,
-			Success:     true,
+			TaskID:       task.ID,
+			AgentID:      selectedAgent.ID,
+			Type:         task.Type,
+			Input:        task.Input,
+			Output:       executionResult.Output,
+			Success:      true,
 			Coordination: fmt.Sprintf("Coordinated by %s", coordinator.Name),
 		}
 
@@ -259,8 +432,8 @@ func (e *Engine) EnhancedCoordinatedWorkflow(ctx context.Context, coordinatorID
 	result.Duration = result.EndTime.Sub(result.StartTime)
 
 	// Phase 3: Post-execution reflection and learning
-	if coordinator.Type == AgentTypeReflective || 
-	   (coordinator.Config != nil && coordinator.Config["enable_reflection"] == true) {
+	if coordinator.Type == AgentTypeReflective ||
+		(coordinator.Config != nil && coordinator.Config["enable_reflection"] == true) {
 		reflection := e.performCoordinationReflection(coordinator, result)
 		e.updateAgentState(coordinator, "workflow_reflection", reflection)
 	}
@@ -375,7 +548,7 @@ func (e *Engine) executeCoordinatedTask(ctx context.Context, task CoordinatedTas
 
 // performCoordinationReflection performs reflection on coordination patterns
 func (e *Engine) performCoordinationReflection(coordinator *Agent, result *CoordinatedWorkflowResult) string {
-	reflection := fmt.Sprintf("Coordination session completed: %d tasks in %v", 
+	reflection := fmt.Sprintf("Coordination session completed: %d tasks in %v",
 		len(result.Tasks), result.Duration)
 
 	successRate := 0.0
@@ -411,13 +584,14 @@ type CoordinatedTask struct {
 
 // CoordinatedWorkflowResult represents the result of a coordinated workflow
 type CoordinatedWorkflowResult struct {
-	CoordinatorID string                   `json:"coordinator_id"`
-	Tasks         []CoordinatedTaskResult  `json:"tasks"`
-	Success       bool                     `json:"success"`
-	Error         string                   `json:"error,omitempty"`
-	StartTime     time.Time                `json:"start_time"`
-	EndTime       time.Time                `json:"end_time"`
-	Duration      time.Duration            `json:"duration"`
+	WorkflowID    string                  `json:"workflow_id,omitempty"`
+	CoordinatorID string                  `json:"coordinator_id"`
+	Tasks         []CoordinatedTaskResult `json:"tasks"`
+	Success       bool                    `json:"success"`
+	Error         string                  `json:"error,omitempty"`
+	StartTime     time.Time               `json:"start_time"`
+	EndTime       time.Time               `json:"end_time"`
+	Duration      time.Duration           `json:"duration"`
 }
 
 // CoordinatedTaskResult represents the result of a single coordinated task
@@ -448,13 +622,40 @@ type WorkflowStep struct {
 	Type      string `json:"type"`
 	Input     string `json:"input"`
 	ModelName string `json:"model_name,omitempty"`
+	// Assertions are checks the step output must pass before the workflow
+	// continues.
+	Assertions []StepAssertion `json:"assertions,omitempty"`
+	// OnAssertionFailure selects how failed assertions are handled: abort
+	// (default), retry, or branch.
+	OnAssertionFailure string `json:"on_assertion_failure,omitempty"`
+	// MaxRetries caps retry attempts when OnAssertionFailure is "retry".
+	MaxRetries int `json:"max_retries,omitempty"`
+	// RecoveryStep names the step to jump to when OnAssertionFailure is
+	// "branch".
+	RecoveryStep string `json:"recovery_step,omitempty"`
+	// CompensationInput declares a compensating task that undoes this
+	// step's side effects (e.g. delete a created file). When a later step
+	// fails, compensations for completed steps run in reverse order. It
+	// supports the same placeholders as Input plus {{output}} for this
+	// step's own output.
+	CompensationInput string `json:"compensation_input,omitempty"`
+	// CompensationType overrides the task type used for the compensation;
+	// it defaults to the step's own type.
+	CompensationType string `json:"compensation_type,omitempty"`
+	// EmitSignal names a workflow signal emitted when this step completes
+	// successfully, with the step's output as payload.
+	EmitSignal string `json:"emit_signal,omitempty"`
 }
 
 // WorkflowResult represents the result of a multi-step workflow
 type WorkflowResult struct {
-	Steps   []WorkflowStepResult `json:"steps"`
-	Success bool                 `json:"success"`
-	Error   string               `json:"error,omitempty"`
+	WorkflowID string               `json:"workflow_id,omitempty"`
+	Steps      []WorkflowStepResult `json:"steps"`
+	Success    bool                 `json:"success"`
+	Error      string               `json:"error,omitempty"`
+	// Compensations are the rollback tasks executed after a failure, in
+	// the order they ran (reverse order of the completed steps).
+	Compensations []WorkflowStepResult `json:"compensations,omitempty"`
 }
 
 // WorkflowStepResult represents the result of a single workflow step
@@ -466,4 +667,4 @@ type WorkflowStepResult struct {
 	ModelUsed string `json:"model_used"`
 	Success   bool   `json:"success"`
 	Error     string `json:"error,omitempty"`
-}
\ No newline at end of file
+}
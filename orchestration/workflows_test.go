@@ -0,0 +1,84 @@
+package orchestration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestWorkflowCompensatesCompletedStepsOnFailure(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	steps := []WorkflowStep{
+		{
+			Name:              "provision",
+			Type:              TaskTypeCustom,
+			Input:             "create the resource",
+			CompensationInput: "delete the resource created by: {{output}}",
+		},
+		{
+			Name:              "configure",
+			Type:              TaskTypeCustom,
+			Input:             "apply configuration",
+			CompensationInput: "revert the configuration",
+		},
+		{
+			Name:       "verify",
+			Type:       TaskTypeCustom,
+			Input:      "check everything",
+			Assertions: []StepAssertion{{Type: "contains", Value: "unicorn"}},
+		},
+	}
+
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("MultiStepWorkflow failed: %v", err)
+	}
+	if result.Success {
+		t.Fatal("Expected the workflow to fail on the verify step")
+	}
+
+	if len(result.Compensations) != 2 {
+		t.Fatalf("Expected both completed steps compensated, got %v", result.Compensations)
+	}
+	if result.Compensations[0].Name != "compensate_configure" || result.Compensations[1].Name != "compensate_provision" {
+		t.Errorf("Expected compensations in reverse order, got %v", result.Compensations)
+	}
+	if !strings.Contains(result.Compensations[1].Input, "created by: Custom task") {
+		t.Errorf("Expected the {{output}} placeholder resolved, got %q", result.Compensations[1].Input)
+	}
+	for _, compensation := range result.Compensations {
+		if !compensation.Success {
+			t.Errorf("Expected compensation to succeed: %+v", compensation)
+		}
+	}
+}
+
+func TestWorkflowSkipsCompensationOnSuccess(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	steps := []WorkflowStep{
+		{Name: "provision", Type: TaskTypeCustom, Input: "create", CompensationInput: "delete"},
+	}
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("MultiStepWorkflow failed: %v", err)
+	}
+	if !result.Success || len(result.Compensations) != 0 {
+		t.Errorf("Expected a clean run without compensations, got %+v", result)
+	}
+}
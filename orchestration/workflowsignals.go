@@ -0,0 +1,181 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkflowSignal is a named event emitted by a workflow — at a specific
+// step or on completion — that other workflows can subscribe to.
+type WorkflowSignal struct {
+	Name       string    `json:"name"`
+	Payload    string    `json:"payload,omitempty"`
+	WorkflowID string    `json:"workflow_id,omitempty"`
+	EmittedAt  time.Time `json:"emitted_at"`
+}
+
+// WorkflowTrigger runs a stored workflow template whenever a matching
+// signal fires, enabling event-driven pipelines without external glue.
+type WorkflowTrigger struct {
+	ID       string `json:"id"`
+	Signal   string `json:"signal"`
+	Template string `json:"template"`
+	AgentID  string `json:"agent_id"`
+	// Inputs are passed to the template on each firing. If the template
+	// declares a "signal_payload" input, the signal's payload is bound to
+	// it automatically.
+	Inputs map[string]interface{} `json:"inputs,omitempty"`
+	// Firings counts how many times the trigger has run.
+	Firings int `json:"firings"`
+}
+
+// maxRetainedSignals bounds the signal history kept for inspection.
+const maxRetainedSignals = 200
+
+// signalChainKey carries the names of the signals that led to the current
+// workflow run, so a trigger cannot re-fire its own signal in a loop.
+type signalChainContextKey struct{}
+
+func signalChain(ctx context.Context) []string {
+	chain, _ := ctx.Value(signalChainContextKey{}).([]string)
+	return chain
+}
+
+func withSignal(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, signalChainContextKey{}, append(signalChain(ctx), name))
+}
+
+// RegisterWorkflowTrigger subscribes a stored template to a signal. The
+// template and agent must exist; missing trigger IDs are generated.
+func (e *Engine) RegisterWorkflowTrigger(ctx context.Context, trigger *WorkflowTrigger) error {
+	if trigger.Signal == "" {
+		return fmt.Errorf("workflow trigger requires a signal name")
+	}
+	if _, err := e.GetWorkflowTemplate(trigger.Template); err != nil {
+		return err
+	}
+	if _, err := e.GetAgent(ctx, trigger.AgentID); err != nil {
+		return err
+	}
+	if trigger.ID == "" {
+		trigger.ID = uuid.New().String()
+	}
+
+	e.signalMu.Lock()
+	defer e.signalMu.Unlock()
+	e.signalTriggers[trigger.ID] = trigger
+	return nil
+}
+
+// DeleteWorkflowTrigger removes a trigger.
+func (e *Engine) DeleteWorkflowTrigger(id string) error {
+	e.signalMu.Lock()
+	defer e.signalMu.Unlock()
+
+	if _, exists := e.signalTriggers[id]; !exists {
+		return fmt.Errorf("%w: workflow trigger %s", ErrNotFound, id)
+	}
+	delete(e.signalTriggers, id)
+	return nil
+}
+
+// ListWorkflowTriggers returns the registered triggers sorted by signal.
+func (e *Engine) ListWorkflowTriggers() []*WorkflowTrigger {
+	e.signalMu.RLock()
+	defer e.signalMu.RUnlock()
+
+	triggers := make([]*WorkflowTrigger, 0, len(e.signalTriggers))
+	for _, trigger := range e.signalTriggers {
+		triggers = append(triggers, trigger)
+	}
+	sort.Slice(triggers, func(a, b int) bool { return triggers[a].Signal < triggers[b].Signal })
+	return triggers
+}
+
+// RecentWorkflowSignals returns the latest emitted signals, newest first,
+// capped at limit.
+func (e *Engine) RecentWorkflowSignals(limit int) []WorkflowSignal {
+	e.signalMu.RLock()
+	defer e.signalMu.RUnlock()
+
+	signals := make([]WorkflowSignal, len(e.recentSignals))
+	copy(signals, e.recentSignals)
+	for i, j := 0, len(signals)-1; i < j; i, j = i+1, j-1 {
+		signals[i], signals[j] = signals[j], signals[i]
+	}
+	if limit > 0 && len(signals) > limit {
+		signals = signals[:limit]
+	}
+	return signals
+}
+
+// EmitWorkflowSignal records a signal and fires every subscribed trigger.
+// Triggered workflows run asynchronously; a signal already present in the
+// run's signal chain is recorded but does not fire triggers again, so
+// trigger cycles cannot run away.
+func (e *Engine) EmitWorkflowSignal(ctx context.Context, signal WorkflowSignal) {
+	if signal.EmittedAt.IsZero() {
+		signal.EmittedAt = time.Now()
+	}
+
+	e.signalMu.Lock()
+	e.recentSignals = append(e.recentSignals, signal)
+	if len(e.recentSignals) > maxRetainedSignals {
+		e.recentSignals = e.recentSignals[len(e.recentSignals)-maxRetainedSignals:]
+	}
+	matched := make([]*WorkflowTrigger, 0)
+	for _, trigger := range e.signalTriggers {
+		if trigger.Signal == signal.Name {
+			matched = append(matched, trigger)
+			trigger.Firings++
+		}
+	}
+	e.signalMu.Unlock()
+
+	if len(matched) == 0 {
+		return
+	}
+	if containsString(signalChain(ctx), signal.Name) {
+		clog(ctx).Warn("Dropping workflow signal already in the trigger chain",
+			"signal", signal.Name, "workflow_id", signal.WorkflowID)
+		return
+	}
+
+	for _, trigger := range matched {
+		trigger := trigger
+		// Detach from the emitting workflow's context so the triggered run
+		// is not cancelled with it; the signal chain rides along.
+		runCtx := withSignal(context.WithoutCancel(ctx), signal.Name)
+		go func() {
+			if _, err := e.runTriggeredWorkflow(runCtx, trigger, signal); err != nil {
+				clog(runCtx).Error("Triggered workflow failed",
+					"error", err, "trigger_id", trigger.ID, "signal", signal.Name)
+			}
+		}()
+	}
+}
+
+// runTriggeredWorkflow executes a trigger's template, binding the signal
+// payload when the template declares a signal_payload input.
+func (e *Engine) runTriggeredWorkflow(ctx context.Context, trigger *WorkflowTrigger, signal WorkflowSignal) (*WorkflowResult, error) {
+	template, err := e.GetWorkflowTemplate(trigger.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{}, len(trigger.Inputs)+1)
+	for key, value := range trigger.Inputs {
+		params[key] = value
+	}
+	for _, input := range template.Inputs {
+		if input.Name == "signal_payload" {
+			params["signal_payload"] = signal.Payload
+		}
+	}
+
+	return e.RunWorkflowTemplate(ctx, trigger.Template, trigger.AgentID, params)
+}
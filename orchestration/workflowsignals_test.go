@@ -0,0 +1,109 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestWorkflowSignalTriggersTemplate(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	downstream := &WorkflowTemplate{
+		Name:   "notify",
+		Inputs: []WorkflowInput{{Name: "signal_payload"}},
+		Steps: []WorkflowStep{
+			{Name: "announce", Type: TaskTypeCustom, Input: "announce {{inputs.signal_payload}}"},
+		},
+		CompletionSignal: "notified",
+	}
+	if err := engine.RegisterWorkflowTemplate(downstream); err != nil {
+		t.Fatalf("RegisterWorkflowTemplate failed: %v", err)
+	}
+
+	trigger := &WorkflowTrigger{Signal: "deployed", Template: "notify", AgentID: agent.ID}
+	if err := engine.RegisterWorkflowTrigger(ctx, trigger); err != nil {
+		t.Fatalf("RegisterWorkflowTrigger failed: %v", err)
+	}
+
+	engine.EmitWorkflowSignal(ctx, WorkflowSignal{Name: "deployed", Payload: "build 42"})
+
+	// The triggered run is asynchronous; its completion signal lands in the
+	// history once it finishes.
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		signals := engine.RecentWorkflowSignals(0)
+		if len(signals) >= 2 {
+			if signals[0].Name != "notified" {
+				t.Fatalf("Expected the completion signal newest, got %q", signals[0].Name)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Triggered workflow never completed; signals: %v", signals)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestEmitWorkflowSignalBreaksTriggerCycles(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// A template that re-emits the same signal it is triggered by.
+	loop := &WorkflowTemplate{
+		Name:             "loop",
+		Steps:            []WorkflowStep{{Name: "spin", Type: TaskTypeCustom, Input: "spin"}},
+		CompletionSignal: "ping",
+	}
+	if err := engine.RegisterWorkflowTemplate(loop); err != nil {
+		t.Fatalf("RegisterWorkflowTemplate failed: %v", err)
+	}
+	trigger := &WorkflowTrigger{Signal: "ping", Template: "loop", AgentID: agent.ID}
+	if err := engine.RegisterWorkflowTrigger(ctx, trigger); err != nil {
+		t.Fatalf("RegisterWorkflowTrigger failed: %v", err)
+	}
+
+	engine.EmitWorkflowSignal(ctx, WorkflowSignal{Name: "ping"})
+
+	// The first emission fires the trigger once; the re-emission from the
+	// triggered run is recorded but must not fire again.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(engine.RecentWorkflowSignals(0)) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("Triggered workflow never re-emitted its signal")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if count := len(engine.RecentWorkflowSignals(0)); count != 2 {
+		t.Errorf("Expected exactly 2 signals after the cycle was broken, got %d", count)
+	}
+}
+
+func TestRegisterWorkflowTriggerValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	if err := engine.RegisterWorkflowTrigger(ctx, &WorkflowTrigger{Template: "x"}); err == nil {
+		t.Error("Expected an error for a trigger without a signal")
+	}
+	if err := engine.RegisterWorkflowTrigger(ctx, &WorkflowTrigger{
+		Signal: "done", Template: "missing",
+	}); err == nil {
+		t.Error("Expected an error for an unknown template")
+	}
+}
@@ -0,0 +1,179 @@
+package orchestration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// WorkflowRunState is the durable progress of a multi-step workflow: the
+// full plan plus everything the loop needs to pick up where it left off.
+// It is checkpointed after every completed step so a process crash loses
+// at most the step that was in flight.
+type WorkflowRunState struct {
+	WorkflowID string               `json:"workflow_id"`
+	AgentID    string               `json:"agent_id"`
+	Steps      []WorkflowStep       `json:"steps"`
+	Results    []WorkflowStepResult `json:"results"`
+	Context    map[string]string    `json:"context"`
+	NextStep   int                  `json:"next_step"`
+	Visits     []int                `json:"visits"`
+	Completed  bool                 `json:"completed"`
+	Success    bool                 `json:"success"`
+	Error      string               `json:"error,omitempty"`
+	// Compensations are the rollback tasks a failed run executed.
+	Compensations []WorkflowStepResult `json:"compensations,omitempty"`
+	UpdatedAt     time.Time            `json:"updated_at"`
+}
+
+// WorkflowCheckpointer persists workflow run state as one JSON file per
+// run. Like the FileStore, every write is atomic (temp file plus rename)
+// so a crash never leaves a half-written checkpoint.
+type WorkflowCheckpointer struct {
+	dir string
+	mu  sync.Mutex
+}
+
+// NewWorkflowCheckpointer opens a checkpoint directory, creating it if
+// needed.
+func NewWorkflowCheckpointer(dir string) (*WorkflowCheckpointer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+	return &WorkflowCheckpointer{dir: dir}, nil
+}
+
+func (w *WorkflowCheckpointer) statePath(workflowID string) string {
+	return filepath.Join(w.dir, workflowID+".json")
+}
+
+// Save writes a run's state atomically, stamping UpdatedAt.
+func (w *WorkflowCheckpointer) Save(state *WorkflowRunState) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	state.UpdatedAt = time.Now()
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode workflow state: %w", err)
+	}
+
+	path := w.statePath(state.WorkflowID)
+	tmp := filepath.Join(w.dir, fmt.Sprintf(".%s.tmp", filepath.Base(path)))
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write workflow checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace workflow checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Load reads a run's checkpointed state by workflow ID.
+func (w *WorkflowCheckpointer) Load(workflowID string) (*WorkflowRunState, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	raw, err := os.ReadFile(w.statePath(workflowID))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: workflow checkpoint %s", ErrNotFound, workflowID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow checkpoint: %w", err)
+	}
+
+	var state WorkflowRunState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("failed to decode workflow checkpoint %s: %w", workflowID, err)
+	}
+	return &state, nil
+}
+
+// List returns every checkpointed run in the directory, including
+// completed ones.
+func (w *WorkflowCheckpointer) List() ([]*WorkflowRunState, error) {
+	w.mu.Lock()
+	entries, err := os.ReadDir(w.dir)
+	w.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint directory: %w", err)
+	}
+
+	states := make([]*WorkflowRunState, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		state, err := w.Load(name[:len(name)-len(".json")])
+		if err != nil {
+			return nil, err
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// SetWorkflowCheckpointer installs durable workflow run state: workflows
+// checkpoint after every step and interrupted runs become resumable via
+// ResumeWorkflow. Passing nil disables checkpointing.
+func (e *Engine) SetWorkflowCheckpointer(checkpointer *WorkflowCheckpointer) {
+	e.checkpointMu.Lock()
+	defer e.checkpointMu.Unlock()
+	e.workflowCheckpoints = checkpointer
+}
+
+// GetWorkflowCheckpointer returns the installed checkpointer, or nil.
+func (e *Engine) GetWorkflowCheckpointer() *WorkflowCheckpointer {
+	e.checkpointMu.RLock()
+	defer e.checkpointMu.RUnlock()
+	return e.workflowCheckpoints
+}
+
+// checkpointWorkflow persists a run's state if a checkpointer is
+// installed. Checkpoint failures are logged rather than failing the
+// workflow itself.
+func (e *Engine) checkpointWorkflow(ctx context.Context, state *WorkflowRunState) {
+	// Keep any API-submitted run record in step with the state, whether or
+	// not durable checkpointing is configured
+	e.updateWorkflowProgress(state)
+
+	checkpointer := e.GetWorkflowCheckpointer()
+	if checkpointer == nil {
+		return
+	}
+	if err := checkpointer.Save(state); err != nil {
+		clog(ctx).Error("Failed to checkpoint workflow", "workflow_id", state.WorkflowID, "error", err)
+	}
+}
+
+// ResumeWorkflow continues an interrupted multi-step workflow from its
+// last checkpoint, re-running nothing that already completed. Call it
+// after a restart for each incomplete run reported by the checkpointer.
+func (e *Engine) ResumeWorkflow(ctx context.Context, workflowID string) (*WorkflowResult, error) {
+	checkpointer := e.GetWorkflowCheckpointer()
+	if checkpointer == nil {
+		return nil, fmt.Errorf("no workflow checkpointer configured")
+	}
+
+	state, err := checkpointer.Load(workflowID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Completed {
+		return nil, fmt.Errorf("workflow %s already completed", workflowID)
+	}
+
+	agent, err := e.GetAgent(ctx, state.AgentID)
+	if err != nil {
+		return nil, err
+	}
+
+	clog(ctx).Info("Resuming workflow from checkpoint",
+		"workflow_id", workflowID, "next_step", state.NextStep, "steps", len(state.Steps))
+	return e.runWorkflow(withWorkflowID(ctx, workflowID), agent, state)
+}
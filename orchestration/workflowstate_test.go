@@ -0,0 +1,109 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestWorkflowCheckpointsEachStep(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	checkpointer, err := NewWorkflowCheckpointer(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWorkflowCheckpointer failed: %v", err)
+	}
+	engine.SetWorkflowCheckpointer(checkpointer)
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	steps := []WorkflowStep{
+		{Name: "gather", Type: TaskTypeCustom, Input: "collect the data"},
+		{Name: "report", Type: TaskTypeCustom, Input: "summarize {{gather}}"},
+	}
+	result, err := engine.MultiStepWorkflow(ctx, agent.ID, steps)
+	if err != nil {
+		t.Fatalf("MultiStepWorkflow failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected workflow success, got %+v", result)
+	}
+
+	state, err := checkpointer.Load(result.WorkflowID)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !state.Completed || !state.Success || state.NextStep != 2 {
+		t.Errorf("Expected a completed checkpoint past both steps, got %+v", state)
+	}
+	if state.Results[0].Output == "" || state.Context["step1"] != state.Results[0].Output {
+		t.Errorf("Expected step context checkpointed for placeholders, got %+v", state)
+	}
+}
+
+func TestResumeWorkflowContinuesFromCheckpoint(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	checkpointer, err := NewWorkflowCheckpointer(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewWorkflowCheckpointer failed: %v", err)
+	}
+	engine.SetWorkflowCheckpointer(checkpointer)
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	// Simulate a crash after the first of two steps: the checkpoint holds
+	// the finished step's result and context, but the run is not complete.
+	steps := []WorkflowStep{
+		{Name: "gather", Type: TaskTypeCustom, Input: "collect the data"},
+		{Name: "report", Type: TaskTypeCustom, Input: "summarize {{gather}}"},
+	}
+	interrupted := &WorkflowRunState{
+		WorkflowID: "wf-interrupted",
+		AgentID:    agent.ID,
+		Steps:      steps,
+		Results:    make([]WorkflowStepResult, len(steps)),
+		Context:    map[string]string{"step1": "the gathered data", "gather": "the gathered data"},
+		NextStep:   1,
+		Visits:     []int{1, 0},
+	}
+	interrupted.Results[0] = WorkflowStepResult{Name: "gather", Type: TaskTypeCustom, Output: "the gathered data", Success: true}
+	if err := checkpointer.Save(interrupted); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	result, err := engine.ResumeWorkflow(ctx, "wf-interrupted")
+	if err != nil {
+		t.Fatalf("ResumeWorkflow failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected the resumed workflow to succeed, got %+v", result)
+	}
+	if result.Steps[0].Output != "the gathered data" {
+		t.Errorf("Expected the completed step preserved, got %+v", result.Steps[0])
+	}
+	if !result.Steps[1].Success {
+		t.Errorf("Expected the remaining step executed, got %+v", result.Steps[1])
+	}
+
+	// Completed runs must not be replayed
+	if _, err := engine.ResumeWorkflow(ctx, "wf-interrupted"); err == nil {
+		t.Error("Expected an error resuming a completed workflow")
+	}
+}
+
+func TestResumeWorkflowRequiresCheckpointer(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	if _, err := engine.ResumeWorkflow(context.Background(), "missing"); err == nil {
+		t.Error("Expected an error without a checkpointer configured")
+	}
+}
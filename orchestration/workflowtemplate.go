@@ -0,0 +1,239 @@
+package orchestration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Workflow input types. Numbers accept any JSON number; booleans accept
+// true/false.
+const (
+	WorkflowInputString = "string"
+	WorkflowInputNumber = "number"
+	WorkflowInputBool   = "bool"
+)
+
+// WorkflowInput declares a typed parameter a workflow template accepts.
+type WorkflowInput struct {
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"` // string (default), number, or bool
+	Description string `json:"description,omitempty"`
+	// Default is used when the caller omits the input. Inputs without a
+	// default and without Required simply render empty.
+	Default  interface{} `json:"default,omitempty"`
+	Required bool        `json:"required,omitempty"`
+	// Enum restricts string inputs to a fixed set of values.
+	Enum []string `json:"enum,omitempty"`
+	// Pattern is a regular expression string inputs must match.
+	Pattern string `json:"pattern,omitempty"`
+}
+
+// WorkflowTemplate is a stored multi-step workflow whose step inputs
+// reference declared parameters as {{inputs.<name>}}, so the same plan
+// can be invoked with different values via the API.
+type WorkflowTemplate struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Inputs      []WorkflowInput `json:"inputs,omitempty"`
+	Steps       []WorkflowStep  `json:"steps"`
+	// CompletionSignal names a workflow signal emitted when a run of this
+	// template succeeds, with the final step's output as payload.
+	CompletionSignal string `json:"completion_signal,omitempty"`
+}
+
+// RegisterWorkflowTemplate stores a template, replacing any template with
+// the same name.
+func (e *Engine) RegisterWorkflowTemplate(template *WorkflowTemplate) error {
+	if template.Name == "" {
+		return fmt.Errorf("workflow template name is required")
+	}
+	if len(template.Steps) == 0 {
+		return fmt.Errorf("workflow template %s has no steps", template.Name)
+	}
+	for _, input := range template.Inputs {
+		if input.Name == "" {
+			return fmt.Errorf("workflow template %s declares an unnamed input", template.Name)
+		}
+		switch input.Type {
+		case "", WorkflowInputString, WorkflowInputNumber, WorkflowInputBool:
+		default:
+			return fmt.Errorf("workflow input %s has unknown type %q", input.Name, input.Type)
+		}
+		if input.Pattern != "" {
+			if _, err := regexp.Compile(input.Pattern); err != nil {
+				return fmt.Errorf("workflow input %s has an invalid pattern: %w", input.Name, err)
+			}
+		}
+		if input.Default != nil {
+			if _, err := renderWorkflowInput(&input, input.Default); err != nil {
+				return fmt.Errorf("workflow input %s has an invalid default: %w", input.Name, err)
+			}
+		}
+	}
+
+	e.templateMu.Lock()
+	defer e.templateMu.Unlock()
+	e.workflowTemplates[template.Name] = template
+	return nil
+}
+
+// GetWorkflowTemplate returns a stored template by name.
+func (e *Engine) GetWorkflowTemplate(name string) (*WorkflowTemplate, error) {
+	e.templateMu.RLock()
+	defer e.templateMu.RUnlock()
+
+	template, exists := e.workflowTemplates[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: workflow template %s", ErrNotFound, name)
+	}
+	return template, nil
+}
+
+// ListWorkflowTemplates returns the stored templates sorted by name.
+func (e *Engine) ListWorkflowTemplates() []*WorkflowTemplate {
+	e.templateMu.RLock()
+	defer e.templateMu.RUnlock()
+
+	templates := make([]*WorkflowTemplate, 0, len(e.workflowTemplates))
+	for _, template := range e.workflowTemplates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(a, b int) bool { return templates[a].Name < templates[b].Name })
+	return templates
+}
+
+// DeleteWorkflowTemplate removes a stored template.
+func (e *Engine) DeleteWorkflowTemplate(name string) error {
+	e.templateMu.Lock()
+	defer e.templateMu.Unlock()
+
+	if _, exists := e.workflowTemplates[name]; !exists {
+		return fmt.Errorf("%w: workflow template %s", ErrNotFound, name)
+	}
+	delete(e.workflowTemplates, name)
+	return nil
+}
+
+// RunWorkflowTemplate validates the caller's parameters against the
+// template's input declarations, substitutes them into the steps, and
+// executes the resulting workflow.
+func (e *Engine) RunWorkflowTemplate(ctx context.Context, name, agentID string, params map[string]interface{}) (*WorkflowResult, error) {
+	template, err := e.GetWorkflowTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := resolveWorkflowInputs(template, params)
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]WorkflowStep, len(template.Steps))
+	for i, step := range template.Steps {
+		step.Input = substituteWorkflowInputs(step.Input, values)
+		step.CompensationInput = substituteWorkflowInputs(step.CompensationInput, values)
+		steps[i] = step
+	}
+
+	result, err := e.MultiStepWorkflow(ctx, agentID, steps)
+	if err == nil && result.Success && template.CompletionSignal != "" {
+		payload := ""
+		if len(result.Steps) > 0 {
+			payload = result.Steps[len(result.Steps)-1].Output
+		}
+		e.EmitWorkflowSignal(ctx, WorkflowSignal{
+			Name:       template.CompletionSignal,
+			Payload:    payload,
+			WorkflowID: result.WorkflowID,
+		})
+	}
+	return result, err
+}
+
+// resolveWorkflowInputs merges caller parameters with declared defaults
+// and validates them, returning each input rendered as a string ready for
+// substitution. Parameters not declared by the template are rejected.
+func resolveWorkflowInputs(template *WorkflowTemplate, params map[string]interface{}) (map[string]string, error) {
+	declared := make(map[string]bool, len(template.Inputs))
+	values := make(map[string]string, len(template.Inputs))
+
+	for i := range template.Inputs {
+		input := &template.Inputs[i]
+		declared[input.Name] = true
+
+		value, provided := params[input.Name]
+		if !provided {
+			if input.Required {
+				return nil, fmt.Errorf("workflow input %s is required", input.Name)
+			}
+			if input.Default == nil {
+				values[input.Name] = ""
+				continue
+			}
+			value = input.Default
+		}
+
+		rendered, err := renderWorkflowInput(input, value)
+		if err != nil {
+			return nil, err
+		}
+		values[input.Name] = rendered
+	}
+
+	for name := range params {
+		if !declared[name] {
+			return nil, fmt.Errorf("workflow template %s does not declare input %s", template.Name, name)
+		}
+	}
+	return values, nil
+}
+
+// renderWorkflowInput type-checks one input value and renders it as a
+// string.
+func renderWorkflowInput(input *WorkflowInput, value interface{}) (string, error) {
+	switch input.Type {
+	case WorkflowInputNumber:
+		switch number := value.(type) {
+		case float64:
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		case int:
+			return strconv.Itoa(number), nil
+		default:
+			return "", fmt.Errorf("workflow input %s must be a number", input.Name)
+		}
+	case WorkflowInputBool:
+		flag, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("workflow input %s must be a boolean", input.Name)
+		}
+		return fmt.Sprintf("%t", flag), nil
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("workflow input %s must be a string", input.Name)
+		}
+		if len(input.Enum) > 0 && !containsString(input.Enum, text) {
+			return "", fmt.Errorf("workflow input %s must be one of %v", input.Name, input.Enum)
+		}
+		if input.Pattern != "" {
+			matched, err := regexp.MatchString(input.Pattern, text)
+			if err != nil || !matched {
+				return "", fmt.Errorf("workflow input %s does not match pattern %s", input.Name, input.Pattern)
+			}
+		}
+		return text, nil
+	}
+}
+
+// substituteWorkflowInputs replaces {{inputs.<name>}} placeholders with
+// resolved values.
+func substituteWorkflowInputs(text string, values map[string]string) string {
+	for name, value := range values {
+		text = strings.ReplaceAll(text, fmt.Sprintf("{{inputs.%s}}", name), value)
+	}
+	return text
+}
@@ -0,0 +1,104 @@
+package orchestration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EchoCog/echollama/api"
+)
+
+func TestRegisterWorkflowTemplateValidation(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	if err := engine.RegisterWorkflowTemplate(&WorkflowTemplate{Name: "empty"}); err == nil {
+		t.Error("Expected an error for a template without steps")
+	}
+
+	bad := &WorkflowTemplate{
+		Name:   "bad-type",
+		Inputs: []WorkflowInput{{Name: "level", Type: "enum"}},
+		Steps:  []WorkflowStep{{Name: "noop", Type: TaskTypeCustom, Input: "x"}},
+	}
+	if err := engine.RegisterWorkflowTemplate(bad); err == nil {
+		t.Error("Expected an error for an unknown input type")
+	}
+
+	badDefault := &WorkflowTemplate{
+		Name:   "bad-default",
+		Inputs: []WorkflowInput{{Name: "count", Type: WorkflowInputNumber, Default: "three"}},
+		Steps:  []WorkflowStep{{Name: "noop", Type: TaskTypeCustom, Input: "x"}},
+	}
+	if err := engine.RegisterWorkflowTemplate(badDefault); err == nil {
+		t.Error("Expected an error for a default that fails its own type")
+	}
+}
+
+func TestRunWorkflowTemplateSubstitutesInputs(t *testing.T) {
+	engine := NewEngine(api.Client{})
+	ctx := context.Background()
+
+	agent, err := engine.CreateDefaultAgent(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	template := &WorkflowTemplate{
+		Name: "deploy",
+		Inputs: []WorkflowInput{
+			{Name: "target", Required: true, Enum: []string{"staging", "production"}},
+			{Name: "replicas", Type: WorkflowInputNumber, Default: 2},
+			{Name: "dry_run", Type: WorkflowInputBool, Default: false},
+		},
+		Steps: []WorkflowStep{
+			{Name: "rollout", Type: TaskTypeCustom, Input: "deploy {{inputs.replicas}} replicas to {{inputs.target}} (dry run: {{inputs.dry_run}})"},
+		},
+	}
+	if err := engine.RegisterWorkflowTemplate(template); err != nil {
+		t.Fatalf("RegisterWorkflowTemplate failed: %v", err)
+	}
+
+	result, err := engine.RunWorkflowTemplate(ctx, "deploy", agent.ID, map[string]interface{}{"target": "staging"})
+	if err != nil {
+		t.Fatalf("RunWorkflowTemplate failed: %v", err)
+	}
+	if !result.Success {
+		t.Fatalf("Expected workflow success, got %+v", result)
+	}
+	if result.Steps[0].Input != "deploy 2 replicas to staging (dry run: false)" {
+		t.Errorf("Unexpected substituted input: %q", result.Steps[0].Input)
+	}
+
+	if _, err := engine.RunWorkflowTemplate(ctx, "deploy", agent.ID, nil); err == nil {
+		t.Error("Expected an error when a required input is missing")
+	}
+	if _, err := engine.RunWorkflowTemplate(ctx, "deploy", agent.ID,
+		map[string]interface{}{"target": "laptop"}); err == nil {
+		t.Error("Expected an error for a value outside the enum")
+	}
+	if _, err := engine.RunWorkflowTemplate(ctx, "deploy", agent.ID,
+		map[string]interface{}{"target": "staging", "color": "green"}); err == nil {
+		t.Error("Expected an error for an undeclared input")
+	}
+}
+
+func TestWorkflowTemplateLifecycle(t *testing.T) {
+	engine := NewEngine(api.Client{})
+
+	template := &WorkflowTemplate{
+		Name:  "audit",
+		Steps: []WorkflowStep{{Name: "scan", Type: TaskTypeCustom, Input: "scan"}},
+	}
+	if err := engine.RegisterWorkflowTemplate(template); err != nil {
+		t.Fatalf("RegisterWorkflowTemplate failed: %v", err)
+	}
+
+	if listed := engine.ListWorkflowTemplates(); len(listed) != 1 || listed[0].Name != "audit" {
+		t.Errorf("Unexpected template listing: %v", listed)
+	}
+	if err := engine.DeleteWorkflowTemplate("audit"); err != nil {
+		t.Fatalf("DeleteWorkflowTemplate failed: %v", err)
+	}
+	if _, err := engine.GetWorkflowTemplate("audit"); err == nil {
+		t.Error("Expected the deleted template to be gone")
+	}
+}
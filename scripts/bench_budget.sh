@@ -0,0 +1,22 @@
+#!/bin/sh
+# Runs the Deep Tree Echo benchmarks and fails when any benchmark's mean
+# latency exceeds the budget. Override the budget (milliseconds per op) with
+# BENCH_BUDGET_MS and the sampling with BENCH_TIME (go test -benchtime).
+
+set -eu
+
+BENCH_BUDGET_MS=${BENCH_BUDGET_MS:-250}
+BENCH_TIME=${BENCH_TIME:-20x}
+
+OUTPUT=$(go test -run '^$' -bench . -benchtime "$BENCH_TIME" ./core/deeptreeecho/)
+echo "$OUTPUT"
+
+echo "$OUTPUT" | awk -v budget_ns="$((BENCH_BUDGET_MS * 1000000))" -v budget_ms="$BENCH_BUDGET_MS" '
+    /^Benchmark/ && $4 == "ns/op" {
+        if ($3 + 0 > budget_ns) {
+            printf "BUDGET EXCEEDED: %s took %.2fms/op (budget %dms)\n", $1, $3 / 1000000, budget_ms
+            failed = 1
+        }
+    }
+    END { exit failed }
+'
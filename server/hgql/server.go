@@ -93,14 +93,14 @@ func main() {
 
 	// Middleware for Deep Tree Echo processing
 	server.Router.Use(func(c *gin.Context) {
-		// Send request through Deep Tree Echo consciousness
-		server.Identity.Stream <- deeptreeecho.CognitiveEvent{
+		// Enqueue lock-free so request latency is decoupled from cognition
+		server.Identity.EnqueueEvent(deeptreeecho.CognitiveEvent{
 			Type:      "hgql_request",
 			Content:   c.Request.URL.Path,
 			Timestamp: time.Now(),
 			Impact:    0.7,
 			Source:    c.ClientIP(),
-		}
+		})
 		c.Next()
 	})
 
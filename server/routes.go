@@ -59,10 +59,10 @@ var lowVRAMThreshold uint64 = 20 * format.GibiByte
 var mode string = gin.DebugMode
 
 type Server struct {
-	addr           net.Addr
-	sched          *Scheduler
-	lowVRAM        bool
-	orchestration  *orchestration.Engine
+	addr          net.Addr
+	sched         *Scheduler
+	lowVRAM       bool
+	orchestration *orchestration.Engine
 }
 
 func init() {
@@ -406,7 +406,7 @@ func (s *Server) GenerateHandler(c *gin.Context) {
 						return
 					}
 					res.Context = tokens
-					res.Tokens = tokens  // Copy for compatibility with tests expecting .tokens field
+					res.Tokens = tokens // Copy for compatibility with tests expecting .tokens field
 				}
 			}
 
@@ -1324,6 +1324,22 @@ func (s *Server) GenerateRoutes(rc *ollama.Registry) (http.Handler, error) {
 	return r, nil
 }
 
+// guardDebugRoutes requires the OLLAMA_PPROF_TOKEN bearer token on the
+// net/http/pprof endpoints registered on the default mux. With no token
+// configured the profiling endpoints reject every request.
+func guardDebugRoutes(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/debug/") {
+			token := envconfig.PprofToken()
+			if token == "" || strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") != token {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 func Serve(ln net.Listener) error {
 	slog.SetDefault(logutil.NewLogger(os.Stderr, envconfig.LogLevel()))
 	slog.Info("server config", "env", envconfig.Values())
@@ -1392,14 +1408,10 @@ func Serve(ln net.Listener) error {
 	slog.Info(fmt.Sprintf("Listening on %s (version %s)", ln.Addr(), version.Version))
 	srvr := &http.Server{
 		// Use http.DefaultServeMux so we get net/http/pprof for
-		// free.
-		//
-		// TODO(bmizerany): Decide if we want to make this
-		// configurable so it is not exposed by default, or allow
-		// users to bind it to a different port. This was a quick
-		// and easy way to get pprof, but it may not be the best
-		// way.
-		Handler: nil,
+		// free, wrapped so the profiling endpoints require the
+		// OLLAMA_PPROF_TOKEN bearer token instead of being open to
+		// anyone who can reach the server.
+		Handler: guardDebugRoutes(http.DefaultServeMux),
 	}
 
 	// listen for a ctrl+c and stop any loaded llm
@@ -6,6 +6,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/EchoCog/echollama/core/deeptreeecho"
@@ -60,14 +61,14 @@ func main() {
 
 	// Middleware to process all requests through Deep Tree Echo
 	r.Use(func(c *gin.Context) {
-		// Send request through identity consciousness stream
-		CoreIdentity.Identity.Stream <- deeptreeecho.CognitiveEvent{
+		// Enqueue lock-free so request latency is decoupled from cognition
+		CoreIdentity.Identity.EnqueueEvent(deeptreeecho.CognitiveEvent{
 			Type:      "http_request",
 			Content:   c.Request.URL.Path,
 			Timestamp: time.Now(),
 			Impact:    0.5,
 			Source:    c.ClientIP(),
-		}
+		})
 		c.Next()
 	})
 
@@ -131,6 +132,28 @@ func main() {
 		})
 	})
 
+	// Deep Tree Echo pipeline trace endpoints
+	r.GET("/api/echo/traces", func(c *gin.Context) {
+		limit := 20
+		if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+			limit = n
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"traces": CoreIdentity.RecentTraces(limit),
+		})
+	})
+
+	r.POST("/api/echo/traces/:id/replay", func(c *gin.Context) {
+		replay, err := CoreIdentity.ReplayTrace(c.Param("id"), nil)
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"replay": replay,
+		})
+	})
+
 	// Deep Tree Echo resonate endpoint
 	r.POST("/api/echo/resonate", func(c *gin.Context) {
 		var req map[string]float64
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -34,6 +35,7 @@ type BasicResponse struct {
 type GenerateRequest struct {
 	Model  string `json:"model"`
 	Prompt string `json:"prompt"`
+	Stream *bool  `json:"stream"` // Ollama semantics: streaming unless explicitly false
 }
 
 // GenerateResponse represents the generate API response
@@ -103,14 +105,14 @@ func main() {
 
 	// Middleware to process all requests through Deep Tree Echo
 	r.Use(func(c *gin.Context) {
-		// Send request through identity consciousness stream
-		CoreIdentity.Identity.Stream <- deeptreeecho.CognitiveEvent{
+		// Enqueue lock-free so request latency is decoupled from cognition
+		CoreIdentity.Identity.EnqueueEvent(deeptreeecho.CognitiveEvent{
 			Type:      "http_request",
 			Content:   c.Request.URL.Path,
 			Timestamp: time.Now(),
 			Impact:    0.5,
 			Source:    c.ClientIP(),
-		}
+		})
 		c.Next()
 	})
 
@@ -195,27 +197,51 @@ func main() {
 			return
 		}
 
-		// Try to generate with AI if available
-		ctx := context.Background()
-		response, err := CoreIdentity.GenerateWithAI(ctx, req.Prompt)
+		ctx := c.Request.Context()
 
-		if err != nil {
-			// Fallback to Deep Tree Echo standalone
-			result, _ := CoreIdentity.Process(ctx, req.Prompt)
-			response = fmt.Sprintf("🌊 %v", result)
+		// Non-streaming clients get the complete response as one blob
+		if req.Stream != nil && !*req.Stream {
+			response, err := CoreIdentity.GenerateWithAI(ctx, req.Prompt)
+			if err != nil {
+				// Fallback to Deep Tree Echo standalone
+				result, _ := CoreIdentity.Process(ctx, req.Prompt)
+				response = fmt.Sprintf("🌊 %v", result)
+			}
+
+			c.JSON(http.StatusOK, GenerateResponse{
+				Model:    "deep-tree-echo-ai",
+				Response: response,
+				Done:     true,
+				Echo:     CoreIdentity.Identity.GetStatus(),
+			})
+			return
 		}
 
-		// Get identity status for context
-		identityStatus := CoreIdentity.Identity.GetStatus()
+		// Stream NDJSON chunks as provider tokens arrive, with the Deep
+		// Tree Echo metadata attached to the final chunk
+		tokens, err := CoreIdentity.GenerateStreamWithAI(ctx, req.Prompt)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
 
-		genResponse := GenerateResponse{
-			Model:    "deep-tree-echo-ai",
-			Response: response,
-			Done:     true,
-			Echo:     identityStatus,
+		c.Header("Content-Type", "application/x-ndjson")
+		encoder := json.NewEncoder(c.Writer)
+		for token := range tokens {
+			encoder.Encode(GenerateResponse{
+				Model:    "deep-tree-echo-ai",
+				Response: token,
+				Done:     false,
+			})
+			c.Writer.Flush()
 		}
 
-		c.JSON(http.StatusOK, genResponse)
+		encoder.Encode(GenerateResponse{
+			Model: "deep-tree-echo-ai",
+			Done:  true,
+			Echo:  CoreIdentity.Identity.GetStatus(),
+		})
+		c.Writer.Flush()
 	})
 
 	r.POST("/api/chat", func(c *gin.Context) {